@@ -48,6 +48,7 @@ type taskContext struct {
 	taskDirectory  string
 	timeout        time.Duration
 	timedOut       bool
+	resourceLimit  string
 	sync.RWMutex
 }
 
@@ -320,6 +321,8 @@ func (a *Agent) endTaskResponse(tc *taskContext, status string) *apimodels.TaskE
 		Type:        tc.getCurrentCommand().Type(),
 		TimedOut:    tc.hadTimedOut(),
 		Status:      status,
+
+		ResourceLimitExceeded: tc.getResourceLimitExceeded(),
 	}
 }
 
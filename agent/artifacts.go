@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// fetchArtifacts downloads the artifacts of other tasks that the task
+// fetches into its working directory, extracting the ones that are
+// tarballs.
+func (a *Agent) fetchArtifacts(ctx context.Context, tc *taskContext) error {
+	task := tc.taskConfig.Project.FindProjectTask(tc.taskConfig.Task.DisplayName)
+	if task == nil || len(task.FetchArtifacts) == 0 {
+		return nil
+	}
+
+	tc.logger.Execution().Info("Fetching artifacts.")
+	artifacts, err := a.comm.GetFetchArtifacts(ctx, tc.task)
+	if err != nil {
+		return errors.Wrap(err, "problem finding artifacts to fetch")
+	}
+
+	for _, artifact := range artifacts {
+		tc.logger.Execution().Infof("Fetching artifact '%s' of task '%s' on variant '%s'.",
+			artifact.Name, artifact.Task, artifact.Variant)
+		if err = fetchArtifact(ctx, artifact, tc.taskConfig.WorkDir); err != nil {
+			return errors.Wrapf(err, "problem fetching artifact '%s' of task '%s' on variant '%s'",
+				artifact.Name, artifact.Task, artifact.Variant)
+		}
+	}
+	tc.logger.Execution().Infof("Finished fetching %d artifacts.", len(artifacts))
+
+	return nil
+}
+
+// fetchArtifact downloads the artifact into the directory. A tarball is
+// extracted into the directory, and any other file is written to it under
+// the file's name in the artifact's link. The artifact is downloaded without
+// credentials; fetching private artifacts is rejected when the project is
+// validated and when the artifacts to fetch are looked up.
+func fetchArtifact(ctx context.Context, artifact apimodels.FetchedArtifact, dir string) error {
+	link, err := url.Parse(artifact.Link)
+	if err != nil {
+		return errors.Wrapf(err, "invalid link '%s'", artifact.Link)
+	}
+	fileName := path.Base(link.Path)
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return errors.Errorf("link '%s' has no file name", artifact.Link)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, artifact.Link, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "problem downloading '%s'", artifact.Link)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("problem downloading '%s': got status %d", artifact.Link, resp.StatusCode)
+	}
+
+	if strings.HasSuffix(fileName, ".tgz") || strings.HasSuffix(fileName, ".tar.gz") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return errors.Wrapf(err, "problem reading tarball '%s'", artifact.Link)
+		}
+		defer gz.Close()
+		return errors.Wrapf(util.Extract(ctx, tar.NewReader(gz), dir), "problem extracting '%s'", artifact.Link)
+	}
+
+	f, err := os.Create(filepath.Join(dir, fileName))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		grip.CatchError(f.Close())
+		return errors.Wrapf(err, "problem writing '%s'", fileName)
+	}
+	return errors.WithStack(f.Close())
+}
@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchArtifact(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tarball := &bytes.Buffer{}
+	gz := gzip.NewWriter(tarball)
+	tw := tar.NewWriter(gz)
+	contents := []byte("compiled")
+	require.NoError(tw.WriteHeader(&tar.Header{Name: "bin/app", Mode: 0755, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(contents)
+	require.NoError(err)
+	require.NoError(tw.Close())
+	require.NoError(gz.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/binaries.tgz":
+			_, _ = w.Write(tarball.Bytes())
+		case "/report.txt":
+			_, _ = w.Write([]byte("report"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "fetch-artifact")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NoError(fetchArtifact(ctx, apimodels.FetchedArtifact{Name: "binaries", Link: server.URL + "/binaries.tgz"}, dir))
+	out, err := ioutil.ReadFile(filepath.Join(dir, "bin", "app"))
+	assert.NoError(err)
+	assert.Equal(contents, out)
+
+	assert.NoError(fetchArtifact(ctx, apimodels.FetchedArtifact{Name: "report", Link: server.URL + "/report.txt"}, dir))
+	out, err = ioutil.ReadFile(filepath.Join(dir, "report.txt"))
+	assert.NoError(err)
+	assert.Equal([]byte("report"), out)
+
+	assert.Error(fetchArtifact(ctx, apimodels.FetchedArtifact{Name: "missing", Link: server.URL + "/missing.txt"}, dir))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen/command"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/subprocess"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/recovery"
 	"github.com/pkg/errors"
@@ -89,6 +90,9 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 				if err != nil {
 					tc.logger.Task().Errorf("Command failed: %v", err)
 					if isTaskCommands {
+						if limit := subprocess.GetResourceLimitExceeded(err); limit != "" {
+							tc.setResourceLimitExceeded(limit)
+						}
 						return errors.Wrap(err, "command failed")
 					}
 				}
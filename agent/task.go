@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/command"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
@@ -90,7 +91,8 @@ func (a *Agent) startTask(ctx context.Context, tc *taskContext, complete chan<-
 		complete <- evergreen.TaskFailed
 		return
 	}
-	taskConfig.Expansions.Update(*expVars)
+	taskConfig.Expansions.Update(expVars.Vars)
+	tc.logger.SetRedactions(getRedactedValues(taskConfig, expVars))
 	tc.taskConfig = taskConfig
 
 	// set up the system stats collector
@@ -129,6 +131,12 @@ func (a *Agent) startTask(ctx context.Context, tc *taskContext, complete chan<-
 	a.killProcs(tc, false)
 	a.runPreTaskCommands(innerCtx, tc)
 
+	if err = a.fetchArtifacts(innerCtx, tc); err != nil {
+		tc.logger.Execution().Errorf("Error fetching artifacts: %v", err)
+		complete <- evergreen.TaskFailed
+		return
+	}
+
 	if err = a.runTaskCommands(innerCtx, tc); err != nil {
 		complete <- evergreen.TaskFailed
 		return
@@ -136,6 +144,24 @@ func (a *Agent) startTask(ctx context.Context, tc *taskContext, complete chan<-
 	complete <- evergreen.TaskSucceeded
 }
 
+// getRedactedValues returns the values of the private project
+// variables and the distro's expansions, which must not appear in any
+// of the task's logs.
+func getRedactedValues(taskConfig *model.TaskConfig, expVars *apimodels.ExpansionVars) []string {
+	values := []string{}
+	for k, private := range expVars.PrivateVars {
+		if v, ok := expVars.Vars[k]; ok && private {
+			values = append(values, v)
+		}
+	}
+	if taskConfig.Distro != nil {
+		for _, e := range taskConfig.Distro.Expansions {
+			values = append(values, e.Value)
+		}
+	}
+	return values
+}
+
 func (a *Agent) runPreTaskCommands(ctx context.Context, tc *taskContext) {
 	var err error
 	tc.logger.Task().Info("Running pre-task commands.")
@@ -214,6 +240,20 @@ func (tc *taskContext) hadTimedOut() bool {
 	return tc.timedOut
 }
 
+func (tc *taskContext) setResourceLimitExceeded(limit string) {
+	tc.Lock()
+	defer tc.Unlock()
+
+	tc.resourceLimit = limit
+}
+
+func (tc *taskContext) getResourceLimitExceeded() string {
+	tc.RLock()
+	defer tc.RUnlock()
+
+	return tc.resourceLimit
+}
+
 // getTaskConfig fetches task configuration data required to run the task from the API server.
 func (a *Agent) getTaskConfig(ctx context.Context, tc *taskContext) (*model.TaskConfig, error) {
 	tc.logger.Execution().Info("Fetching distro configuration.")
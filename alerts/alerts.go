@@ -9,18 +9,19 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/plugin/builtin/buildbaron"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/render"
 	"github.com/mongodb/grip"
-	"github.com/mongodb/grip/logging"
 	"github.com/mongodb/grip/message"
-	"github.com/mongodb/grip/send"
 	"github.com/mongodb/grip/sometimes"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
@@ -242,28 +243,20 @@ func (qp *QueueProcessor) newSlackProvider(alertConfg model.AlertConfig) (Delive
 		return nil, errors.Errorf("slack channel [%+v] must be string [%T]", slackChan, slackChan)
 	}
 
-	opts := send.SlackOptions{
-		Channel:       channel,
-		Fields:        true,
-		AllFields:     true,
-		BasicMetadata: false,
-		Name:          "evergreen-alerts",
+	deliverer := &slackDeliverer{
+		poster:  &slackAPI{token: qp.config.Slack.Token},
+		channel: channel,
+		uiRoot:  qp.config.Ui.Url,
 	}
-
-	if err := opts.Validate(); err != nil {
-		return nil, errors.Wrap(err, "problem constructing slack options")
-	}
-
-	sender, err := send.NewSlackLogger(&opts, qp.config.Slack.Token, qp.config.LoggerConfig.Info())
-	if err != nil {
-		return nil, errors.Wrap(err, "problem constructing slack logger")
+	if _, ok := qp.config.Plugins[buildbaron.PluginName]; ok {
+		bbp, err := buildbaron.New(qp.config)
+		if err != nil {
+			return nil, errors.Wrap(err, "problem configuring build baron")
+		}
+		deliverer.buildBaron = bbp
 	}
 
-	return &slackDeliverer{
-		logger: logging.MakeGrip(sender),
-		uiRoot: qp.config.Ui.Url,
-	}, nil
-
+	return deliverer, nil
 }
 
 // getDeliverer returns the correct implementation of Deliverer according to the provider
@@ -302,8 +295,45 @@ func (qp *QueueProcessor) Deliver(req *alert.AlertRequest, ctx *AlertContext) er
 		// TODO(EVG-224) spawnhost alerts should go to spawnhost owner
 		alertConfigs = qp.superUsersConfigs
 	}
+	if len(alertConfigs) == 0 {
+		return nil
+	}
+
+	if ctx.Task != nil {
+		mute, err := alertrecord.FindActiveMute(ctx.Task.Project, ctx.Task.BuildVariant, ctx.Task.DisplayName, time.Now())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if mute != nil {
+			grip.Info(message.Fields{
+				"runner":   RunnerName,
+				"message":  "alerts for task are muted, not delivering alert",
+				"alert_id": req.Id.Hex(),
+				"task_id":  ctx.Task.Id,
+				"muted_by": mute.MutedBy,
+				"until":    mute.Until,
+			})
+			return nil
+		}
+	}
 
 	for _, alertConfig := range alertConfigs {
+		digest, err := alertDigest(alertConfig)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if digest.Batched() {
+			// the notification digest job sends the alert in a digest
+			n, err := alertNotification(*ctx, alertConfig, digest)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err = notification.InsertMany(*n); err != nil {
+				return errors.Wrap(err, "Failed to batch alert")
+			}
+			continue
+		}
+
 		deliverer, err := qp.getDeliverer(alertConfig)
 		if err != nil {
 			return errors.Wrap(err, "Failed to get email deliverer")
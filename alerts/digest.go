@@ -0,0 +1,94 @@
+package alerts
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Alert configs with a digest setting batch their alerts into a digest, like
+// subscriptions do, instead of delivering each of them. The digest setting
+// is a digest mode, and interval digests also set the number of minutes.
+const (
+	AlertDigestSetting         = "digest"
+	AlertDigestIntervalSetting = "digest_interval_minutes"
+)
+
+// alertDigest returns the digest option of the alert config.
+func alertDigest(conf model.AlertConfig) (event.Digest, error) {
+	settings := conf.GetSettingsMap()
+	digest := event.Digest{Mode: settings[AlertDigestSetting]}
+	if digest.Mode != event.DigestInterval {
+		return digest, nil
+	}
+
+	minutes, err := strconv.Atoi(settings[AlertDigestIntervalSetting])
+	if err != nil {
+		return event.Digest{}, errors.Errorf("digest interval '%s' is not a number of minutes", settings[AlertDigestIntervalSetting])
+	}
+	digest.IntervalMinutes = minutes
+	return digest, nil
+}
+
+// alertNotification returns the notification that summarizes the alert in
+// the digest of the alert config. Only email and slack alerts can be batched.
+func alertNotification(ctx AlertContext, conf model.AlertConfig, digest event.Digest) (*notification.Notification, error) {
+	uiRoot := ""
+	if ctx.Settings != nil {
+		uiRoot = ctx.Settings.Ui.Url
+	}
+
+	n := &notification.Notification{
+		ID:     bson.NewObjectId(),
+		Digest: digest,
+	}
+	if ctx.Version != nil {
+		n.Version = ctx.Version.Id
+	}
+	if ctx.Task != nil {
+		n.BuildVariant = ctx.Task.BuildVariant
+	} else if ctx.Build != nil {
+		n.BuildVariant = ctx.Build.BuildVariant
+	}
+
+	var target string
+	switch conf.Provider {
+	case EmailProvider:
+		target, _ = conf.Settings["recipient"].(string)
+		subject, _, ok := customNotification(ctx, EmailProvider, uiRoot)
+		if !ok {
+			subject = getSubject(ctx)
+		}
+		n.Subscriber.Type = event.EmailSubscriberType
+		n.Payload = &notification.EmailPayload{Subject: subject}
+
+	case SlackProvider:
+		target, _ = conf.Settings["channel"].(string)
+		summary, _, ok := customNotification(ctx, SlackProvider, uiRoot)
+		if !ok {
+			summary = getSummary(ctx)
+		}
+		if ctx.Task != nil {
+			summary = fmt.Sprintf("%s (%s/task/%s/%d)", summary, uiRoot, ctx.Task.Id, ctx.Task.Execution)
+		}
+		n.Subscriber.Type = event.SlackSubscriberType
+		n.Payload = &summary
+
+	default:
+		return nil, errors.Errorf("%s alerts can't be batched", conf.Provider)
+	}
+	if target == "" {
+		return nil, errors.Errorf("%s alert has no recipient", conf.Provider)
+	}
+	n.Subscriber.Target = &target
+
+	if err := digest.Validate(n.Subscriber.Type); err != nil {
+		return nil, errors.Wrap(err, "invalid alert digest")
+	}
+	return n, nil
+}
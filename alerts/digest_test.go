@@ -0,0 +1,84 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestAlertDigest(t *testing.T) {
+	assert := assert.New(t)
+
+	digest, err := alertDigest(model.AlertConfig{Provider: EmailProvider, Settings: bson.M{"recipient": "a@example.com"}})
+	assert.NoError(err)
+	assert.False(digest.Batched())
+
+	digest, err = alertDigest(model.AlertConfig{Provider: EmailProvider, Settings: bson.M{AlertDigestSetting: event.DigestVersion}})
+	assert.NoError(err)
+	assert.Equal(event.Digest{Mode: event.DigestVersion}, digest)
+
+	digest, err = alertDigest(model.AlertConfig{Provider: SlackProvider, Settings: bson.M{
+		AlertDigestSetting:         event.DigestInterval,
+		AlertDigestIntervalSetting: 30,
+	}})
+	assert.NoError(err)
+	assert.Equal(event.Digest{Mode: event.DigestInterval, IntervalMinutes: 30}, digest)
+
+	_, err = alertDigest(model.AlertConfig{Provider: SlackProvider, Settings: bson.M{
+		AlertDigestSetting:         event.DigestInterval,
+		AlertDigestIntervalSetting: "soon",
+	}})
+	assert.Error(err)
+}
+
+func TestAlertNotification(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx := AlertContext{
+		AlertRequest: &alert.AlertRequest{Trigger: alertrecord.TaskFailedId},
+		ProjectRef:   &model.ProjectRef{Identifier: "mci", DisplayName: ProjectName},
+		Task: &task.Task{
+			Id:           TaskId,
+			Execution:    1,
+			DisplayName:  TaskName,
+			BuildVariant: "ubuntu",
+		},
+		Build:    &build.Build{DisplayName: BuildName, BuildVariant: "ubuntu"},
+		Version:  &version.Version{Id: "v1", Revision: VersionRevision},
+		Settings: &evergreen.Settings{Ui: evergreen.UIConfig{Url: "https://evergreen.example.com"}},
+	}
+	digest := event.Digest{Mode: event.DigestVersion}
+
+	n, err := alertNotification(ctx, model.AlertConfig{Provider: EmailProvider, Settings: bson.M{"recipient": "a@example.com"}}, digest)
+	require.NoError(err)
+	assert.Equal(event.EmailSubscriberType, n.Subscriber.Type)
+	assert.Equal("a@example.com", *n.Subscriber.Target.(*string))
+	assert.Equal(digest, n.Digest)
+	assert.Equal("v1", n.Version)
+	assert.Equal("ubuntu", n.BuildVariant)
+	assert.Equal(getSubject(ctx), n.Payload.(*notification.EmailPayload).Subject)
+
+	n, err = alertNotification(ctx, model.AlertConfig{Provider: SlackProvider, Settings: bson.M{"channel": "#evergreen"}}, digest)
+	require.NoError(err)
+	assert.Equal(event.SlackSubscriberType, n.Subscriber.Type)
+	assert.Equal("#evergreen", *n.Subscriber.Target.(*string))
+	assert.Equal(getSummary(ctx)+" (https://evergreen.example.com/task/t1/1)", *n.Payload.(*string))
+
+	_, err = alertNotification(ctx, model.AlertConfig{Provider: SlackProvider, Settings: bson.M{}}, digest)
+	assert.Error(err)
+
+	_, err = alertNotification(ctx, model.AlertConfig{Provider: JiraProvider, Settings: bson.M{"project": "BF"}}, digest)
+	assert.Error(err)
+}
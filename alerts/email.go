@@ -56,12 +56,26 @@ func (es *EmailDeliverer) Deliver(alertCtx AlertContext, alertConf model.AlertCo
 	}
 
 	var err error
-	subject := getSubject(alertCtx)
-	body, err := es.getBody(alertCtx)
-	if err != nil {
-		return err
+	uiRoot := ""
+	if alertCtx.Settings != nil {
+		uiRoot = alertCtx.Settings.Ui.Url
 	}
+	subject, body, ok := customNotification(alertCtx, EmailProvider, uiRoot)
+	if !ok {
+		subject = getSubject(alertCtx)
+		body, err = es.getBody(alertCtx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return es.SendEmail(rcpt, subject, body, nil)
+}
 
+// SendEmail sends an HTML email with the subject and body to the recipient,
+// with any extra headers.
+func (es SMTPSettings) SendEmail(rcpt, subject, body string, headers map[string]string) error {
+	var err error
 	var c *smtp.Client
 	var tlsCon *tls.Conn
 	if es.UseSSL {
@@ -123,6 +137,9 @@ func (es *EmailDeliverer) Deliver(alertCtx AlertContext, alertConf model.AlertCo
 
 	// set header information
 	header := make(map[string]string)
+	for k, v := range headers {
+		header[k] = v
+	}
 	header["From"] = from.String()
 	header["To"] = rcpt
 	header["Subject"] = subject
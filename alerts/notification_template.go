@@ -0,0 +1,42 @@
+package alerts
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+// customNotification renders the project's notification template for the
+// alert's trigger and the subscriber type into a subject and a body. It
+// returns false if the project has no such template, or if the template
+// fails to render, in which case the alert is formatted as usual.
+func customNotification(alertCtx AlertContext, subscriberType, uiRoot string) (string, string, bool) {
+	if alertCtx.ProjectRef == nil || alertCtx.AlertRequest == nil {
+		return "", "", false
+	}
+	tmpl := alertCtx.ProjectRef.GetNotificationTemplate(alertCtx.AlertRequest.Trigger, subscriberType)
+	if tmpl == nil {
+		return "", "", false
+	}
+
+	subject, body, err := tmpl.Render(model.NotificationTemplateData{
+		Trigger: alertCtx.AlertRequest.Trigger,
+		UIRoot:  uiRoot,
+		Project: alertCtx.ProjectRef,
+		Task:    alertCtx.Task,
+		Build:   alertCtx.Build,
+		Version: alertCtx.Version,
+		Patch:   alertCtx.Patch,
+	})
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"runner":          RunnerName,
+			"message":         "problem rendering notification template, using the default",
+			"project":         alertCtx.ProjectRef.Identifier,
+			"trigger":         tmpl.Trigger,
+			"subscriber_type": subscriberType,
+		}))
+		return "", "", false
+	}
+	return subject, body, true
+}
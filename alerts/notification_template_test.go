@@ -0,0 +1,52 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomNotification(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := AlertContext{
+		AlertRequest: &alert.AlertRequest{Trigger: alertrecord.TaskFailedId},
+		ProjectRef: &model.ProjectRef{
+			DisplayName: ProjectName,
+			NotificationTemplates: []model.NotificationTemplate{
+				{
+					Trigger:        alertrecord.TaskFailedId,
+					SubscriberType: EmailProvider,
+					Subject:        "{{.Task.DisplayName}} broke",
+					Body:           "<a href=\"{{.UIRoot}}/task/{{.Task.Id}}\">{{.Project.DisplayName}}</a>",
+				},
+				{
+					Trigger:        alertrecord.TaskFailedId,
+					SubscriberType: SlackProvider,
+					Body:           "{{.Build.DisplayName}}",
+				},
+			},
+		},
+		Task: &task.Task{Id: TaskId, DisplayName: TaskName},
+	}
+
+	subject, body, ok := customNotification(ctx, EmailProvider, "https://evergreen.example.com")
+	assert.True(ok)
+	assert.Equal(TaskName+" broke", subject)
+	assert.Equal("<a href=\"https://evergreen.example.com/task/t1\">"+ProjectName+"</a>", body)
+
+	// the context has no build, so the default formatting is used
+	_, _, ok = customNotification(ctx, SlackProvider, "")
+	assert.False(ok)
+
+	_, _, ok = customNotification(ctx, JiraProvider, "")
+	assert.False(ok)
+
+	ctx.AlertRequest.Trigger = alertrecord.FirstVersionFailureId
+	_, _, ok = customNotification(ctx, EmailProvider, "")
+	assert.False(ok)
+}
@@ -1,30 +1,131 @@
 package alerts
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/evergreen-ci/evergreen/model"
-	"github.com/mongodb/grip"
-	"github.com/mongodb/grip/message"
+	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/pkg/errors"
 )
 
+// Slack* are the action ids of the buttons on slack task alerts. The value
+// of each button is the id of the task.
+const (
+	SlackActionRestartTask = "restart_task"
+	SlackActionViewLogs    = "view_logs"
+	SlackActionFileTicket  = "file_ticket"
+	SlackActionMuteAlerts  = "mute_alerts"
+)
+
+// slackPoster is an interface for types that can post slack messages.
+type slackPoster interface {
+	PostMessage(*thirdparty.SlackMessage) error
+}
+
+// slackAPI posts slack messages with a bot token.
+type slackAPI struct {
+	token string
+}
+
+func (s *slackAPI) PostMessage(msg *thirdparty.SlackMessage) error {
+	return thirdparty.PostSlackMessage(context.Background(), s.token, msg)
+}
+
+// buildBaronProjects is an interface for types that know which projects
+// build failure tickets can be filed for.
+type buildBaronProjects interface {
+	ProjectEnabled(string) bool
+}
+
 type slackDeliverer struct {
-	logger grip.Journaler
-	uiRoot string
+	poster  slackPoster
+	channel string
+	uiRoot  string
+
+	// buildBaron is nil if build failure tickets can't be filed.
+	buildBaron buildBaronProjects
 }
 
 func (s *slackDeliverer) Deliver(ctx AlertContext, conf model.AlertConfig) error {
-	description, err := getDescription(ctx, s.uiRoot)
-	if err != nil {
-		return errors.WithStack(err)
+	summary, description, ok := customNotification(ctx, SlackProvider, s.uiRoot)
+	if !ok {
+		var err error
+		summary = getSummary(ctx)
+		description, err = getDescription(ctx, s.uiRoot)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 	}
 
-	s.logger.Notice(message.Fields{
-		"message":     getSummary(ctx),
-		"tasks":       ctx.Task.DisplayName,
-		"variant":     ctx.Task.BuildVariant,
-		"project":     ctx.ProjectRef.Identifier,
-		"description": description,
+	return errors.Wrap(s.poster.PostMessage(s.message(ctx, summary, description)),
+		"problem delivering slack alert")
+}
+
+// message lays out the alert with buttons to act on the failed task.
+func (s *slackDeliverer) message(ctx AlertContext, summary, description string) *thirdparty.SlackMessage {
+	t := ctx.Task
+	taskURL := fmt.Sprintf("%s/task/%s/%d", s.uiRoot, t.Id, t.Execution)
+
+	logs := thirdparty.NewSlackButton("View Logs", SlackActionViewLogs, t.Id)
+	logs.URL = fmt.Sprintf("%s/task_log_raw/%s/%d", s.uiRoot, t.Id, t.Execution)
+	restart := thirdparty.NewSlackButton("Restart Task", SlackActionRestartTask, t.Id)
+	restart.Style = thirdparty.SlackButtonStylePrimary
+	actions := []interface{}{restart, logs}
+	if s.buildBaron != nil && s.buildBaron.ProjectEnabled(ctx.ProjectRef.Identifier) {
+		actions = append(actions, thirdparty.NewSlackButton("File Ticket", SlackActionFileTicket, t.Id))
+	}
+	mute := thirdparty.NewSlackButton("Mute for 24h", SlackActionMuteAlerts, t.Id)
+	mute.Style = thirdparty.SlackButtonStyleDanger
+	actions = append(actions, mute)
+
+	blocks := []thirdparty.SlackBlock{
+		{
+			Type: thirdparty.SlackBlockSection,
+			Text: &thirdparty.SlackText{
+				Type: thirdparty.SlackTextMarkdown,
+				Text: fmt.Sprintf("*<%s|%s>*", taskURL, summary),
+			},
+			Fields: []thirdparty.SlackText{
+				{Type: thirdparty.SlackTextMarkdown, Text: fmt.Sprintf("*Project*\n%s", ctx.ProjectRef.Identifier)},
+				{Type: thirdparty.SlackTextMarkdown, Text: fmt.Sprintf("*Variant*\n%s", t.BuildVariant)},
+				{Type: thirdparty.SlackTextMarkdown, Text: fmt.Sprintf("*Task*\n%s", t.DisplayName)},
+				{Type: thirdparty.SlackTextMarkdown, Text: fmt.Sprintf("*Revision*\n%s", shortRevision(t.Revision))},
+			},
+		},
+	}
+	if description != "" {
+		blocks = append(blocks, thirdparty.SlackBlock{
+			Type:     thirdparty.SlackBlockContext,
+			Elements: []interface{}{thirdparty.SlackText{Type: thirdparty.SlackTextPlain, Text: truncateSlackText(description)}},
+		})
+	}
+	blocks = append(blocks, thirdparty.SlackBlock{
+		Type:     thirdparty.SlackBlockActions,
+		BlockID:  t.Id,
+		Elements: actions,
 	})
 
-	return nil
+	return &thirdparty.SlackMessage{
+		Channel: s.channel,
+		Text:    summary,
+		Blocks:  blocks,
+	}
+}
+
+// slackMaxTextLength is the most characters slack allows in a text object.
+const slackMaxTextLength = 2000
+
+func truncateSlackText(text string) string {
+	if len(text) <= slackMaxTextLength {
+		return text
+	}
+	return text[:slackMaxTextLength-3] + "..."
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > 7 {
+		return revision[:7]
+	}
+	return revision
 }
@@ -0,0 +1,94 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSlackPoster struct {
+	messages []*thirdparty.SlackMessage
+}
+
+func (m *mockSlackPoster) PostMessage(msg *thirdparty.SlackMessage) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+type mockBuildBaron map[string]bool
+
+func (m mockBuildBaron) ProjectEnabled(project string) bool { return m[project] }
+
+func slackButtonIDs(block thirdparty.SlackBlock) []string {
+	ids := []string{}
+	for _, element := range block.Elements {
+		ids = append(ids, element.(thirdparty.SlackButton).ActionID)
+	}
+	return ids
+}
+
+func TestSlackDeliverer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx := AlertContext{
+		AlertRequest: &alert.AlertRequest{Trigger: alertrecord.TaskFailedId},
+		ProjectRef:   &model.ProjectRef{Identifier: "mci", DisplayName: ProjectName},
+		Task: &task.Task{
+			Id:           "t1",
+			Execution:    2,
+			DisplayName:  TaskName,
+			BuildVariant: "ubuntu",
+			Revision:     VersionRevision,
+			LocalTestResults: []task.TestResult{
+				{Status: evergreen.TestFailedStatus, TestFile: "jstests/core/find.js"},
+			},
+		},
+		Build:   &build.Build{DisplayName: BuildName},
+		Version: &version.Version{Revision: VersionRevision},
+		Host:    &host.Host{Id: HostId, Host: HostDNS},
+	}
+	poster := &mockSlackPoster{}
+	d := &slackDeliverer{
+		poster:  poster,
+		channel: "#evergreen",
+		uiRoot:  "https://evergreen.example.com",
+	}
+
+	require.NoError(d.Deliver(ctx, model.AlertConfig{}))
+	require.Len(poster.messages, 1)
+	msg := poster.messages[0]
+	assert.Equal("#evergreen", msg.Channel)
+	assert.Equal(getSummary(ctx), msg.Text)
+	require.Len(msg.Blocks, 3)
+	assert.Contains(msg.Blocks[0].Text.Text, "https://evergreen.example.com/task/t1/2")
+
+	actions := msg.Blocks[2]
+	assert.Equal(thirdparty.SlackBlockActions, actions.Type)
+	assert.Equal([]string{SlackActionRestartTask, SlackActionViewLogs, SlackActionMuteAlerts}, slackButtonIDs(actions))
+	for _, element := range actions.Elements {
+		assert.Equal("t1", element.(thirdparty.SlackButton).Value)
+	}
+	assert.Equal("https://evergreen.example.com/task_log_raw/t1/2", actions.Elements[1].(thirdparty.SlackButton).URL)
+
+	d.buildBaron = mockBuildBaron{"mci": true}
+	require.NoError(d.Deliver(ctx, model.AlertConfig{}))
+	require.Len(poster.messages, 2)
+	assert.Equal([]string{SlackActionRestartTask, SlackActionViewLogs, SlackActionFileTicket, SlackActionMuteAlerts},
+		slackButtonIDs(poster.messages[1].Blocks[2]))
+
+	d.buildBaron = mockBuildBaron{"other": true}
+	require.NoError(d.Deliver(ctx, model.AlertConfig{}))
+	require.Len(poster.messages, 3)
+	assert.NotContains(slackButtonIDs(poster.messages[2].Blocks[2]), SlackActionFileTicket)
+}
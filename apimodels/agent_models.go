@@ -19,6 +19,10 @@ type TaskEndDetail struct {
 	Type        string `bson:"type,omitempty" json:"type,omitempty"`
 	Description string `bson:"desc,omitempty" json:"desc,omitempty"`
 	TimedOut    bool   `bson:"timed_out,omitempty" json:"timed_out,omitempty"`
+
+	// ResourceLimitExceeded is the name of the resource limit that
+	// the failing command reached, if any.
+	ResourceLimitExceeded string `bson:"resource_limit,omitempty" json:"resource_limit,omitempty"`
 }
 
 type TaskEndDetails struct {
@@ -30,8 +34,12 @@ type GetNextTaskDetails struct {
 	TaskGroup string `json:"task_group"`
 }
 
-// ExpansionVars is a map of expansion variables for a project.
-type ExpansionVars map[string]string
+// ExpansionVars contains the expansion variables for a project, and
+// which of them are private and must be masked in the task's logs.
+type ExpansionVars struct {
+	Vars        map[string]string `json:"vars"`
+	PrivateVars map[string]bool   `json:"private_vars"`
+}
 
 // NextTaskResponse represents the response sent back when an agent asks for a next task
 type NextTaskResponse struct {
@@ -54,3 +62,12 @@ type NextTaskResponse struct {
 type EndTaskResponse struct {
 	ShouldExit bool `json:"should_exit,omitempty"`
 }
+
+// FetchedArtifact is an artifact attached by another task that a task
+// fetches before its commands run.
+type FetchedArtifact struct {
+	Task    string `json:"task"`
+	Variant string `json:"variant"`
+	Name    string `json:"name"`
+	Link    string `json:"link"`
+}
@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen"
-	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
@@ -39,8 +38,29 @@ func LoadUserManager(authConfig evergreen.AuthConfig) (UserManager, error) {
 		// the mean time.
 		grip.Warning(errors.WithStack(err))
 	}
+	if authConfig.OIDC != nil {
+		if manager != nil {
+			return nil, errors.New("Cannot have multiple forms of authentication in configuration")
+		}
+		manager, err = NewOIDCUserManager(authConfig.OIDC)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if authConfig.LDAP != nil {
+		if manager != nil {
+			return nil, errors.New("Cannot have multiple forms of authentication in configuration")
+		}
+		manager, err = NewLDAPUserManager(authConfig.LDAP)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if manager != nil {
+		// groups can only be named in user lists if the manager knows them
+		resolver, _ := manager.(GroupResolver)
+		SetGroupResolver(resolver)
 		return manager, nil
 	}
 
@@ -60,13 +80,13 @@ func setLoginToken(token string, w http.ResponseWriter) {
 }
 
 // IsSuperUser verifies that a given user has super user permissions.
-// A user has these permission if they are in the super users list or if the list is empty,
-// in which case all users are super users.
+// A user has these permission if they are in the super users list, directly or through
+// one of their groups, or if the list is empty, in which case all users are super users.
 func IsSuperUser(superUsers []string, u User) bool {
 	if u == nil || u.IsNil() {
 		return false
 	}
-	if util.StringSliceContains(superUsers, u.Username()) ||
+	if IsNamedIn(superUsers, u.Username()) ||
 		len(superUsers) == 0 {
 		return true
 	}
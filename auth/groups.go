@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+// GroupPrefix marks the entries of the super user and project admin lists
+// that name a group of users, e.g. "group:evergreen-admins", rather than a
+// single user.
+const GroupPrefix = "group:"
+
+// GroupResolver is implemented by user managers that know which groups
+// their users belong to.
+type GroupResolver interface {
+	GetUserGroups(username string) ([]string, error)
+}
+
+var (
+	groupResolverMu sync.RWMutex
+	groupResolver   GroupResolver
+)
+
+// SetGroupResolver sets the resolver used to expand the groups named in user
+// lists. LoadUserManager sets it to the loaded user manager, if that manager
+// is a GroupResolver.
+func SetGroupResolver(r GroupResolver) {
+	groupResolverMu.Lock()
+	defer groupResolverMu.Unlock()
+	groupResolver = r
+}
+
+func getGroupResolver() GroupResolver {
+	groupResolverMu.RLock()
+	defer groupResolverMu.RUnlock()
+	return groupResolver
+}
+
+// IsNamedIn returns whether the user is in the list of names, either by
+// username or through one of their groups.
+func IsNamedIn(names []string, username string) bool {
+	if util.StringSliceContains(names, username) {
+		return true
+	}
+
+	groups := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, GroupPrefix) {
+			groups = append(groups, strings.TrimPrefix(name, GroupPrefix))
+		}
+	}
+	if len(groups) == 0 {
+		return false
+	}
+	resolver := getGroupResolver()
+	if resolver == nil {
+		return false
+	}
+
+	userGroups, err := resolver.GetUserGroups(username)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "could not resolve the groups of user",
+			"user":    username,
+		}))
+		return false
+	}
+	return len(util.StringSliceIntersection(groups, userGroups)) > 0
+}
+
+// IsProjectAdmin verifies that a given user is one of the admins of a project.
+func IsProjectAdmin(admins []string, u User) bool {
+	if u == nil || u.IsNil() {
+		return false
+	}
+	return IsNamedIn(admins, u.Username())
+}
+
+// UserSubjects returns the names that the user can be referred to by: their
+// username and each of their groups, prefixed with GroupPrefix.
+func UserSubjects(u User) []string {
+	subjects := []string{u.Username()}
+	resolver := getGroupResolver()
+	if resolver == nil {
+		return subjects
+	}
+
+	groups, err := resolver.GetUserGroups(u.Username())
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "could not resolve the groups of user",
+			"user":    u.Username(),
+		}))
+		return subjects
+	}
+	for _, g := range groups {
+		subjects = append(subjects, GroupPrefix+g)
+	}
+	return subjects
+}
@@ -0,0 +1,370 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+	ldap "gopkg.in/ldap.v3"
+)
+
+// LDAPUserManager implements the UserManager with an LDAP directory.
+// CreateUserToken finds the user's entry with the configured bind account
+// and authenticates the user by binding as that entry with their password.
+// The returned token names the user and expires; it is signed with the
+// configured session secret, so any Evergreen server can check it.
+// LDAPUserManager is also a GroupResolver: the groups of a user are the
+// groups whose member attribute holds the user's entry. Users and their
+// groups are cached and looked up again once the cache expires, so that
+// changes in the directory are eventually seen. Users that are not in the
+// directory are cached too, so that unknown names don't cause a search each
+// time they are checked.
+type LDAPUserManager struct {
+	config          evergreen.LDAPConfig
+	cacheDuration   time.Duration
+	sessionDuration time.Duration
+
+	mu    sync.Mutex
+	cache map[string]ldapCachedUser
+}
+
+// ldapCachedUser is a user looked up in the directory. A missing user was
+// not found in the directory.
+type ldapCachedUser struct {
+	user      simpleUser
+	groups    []string
+	missing   bool
+	expiresAt time.Time
+}
+
+// NewLDAPUserManager initializes an LDAPUserManager.
+func NewLDAPUserManager(c *evergreen.LDAPConfig) (*LDAPUserManager, error) {
+	if c.URL == "" {
+		return nil, errors.New("no URL for config")
+	}
+	if c.UserSearchBase == "" {
+		return nil, errors.New("no user search base for config")
+	}
+	if c.SessionSecret == "" {
+		return nil, errors.New("no session secret for config")
+	}
+	config := *c
+	if config.UserAttribute == "" {
+		config.UserAttribute = "uid"
+	}
+	if config.EmailAttribute == "" {
+		config.EmailAttribute = "mail"
+	}
+	if config.DisplayNameAttribute == "" {
+		config.DisplayNameAttribute = "displayName"
+	}
+	if config.GroupMemberAttribute == "" {
+		config.GroupMemberAttribute = "member"
+	}
+	if config.GroupNameAttribute == "" {
+		config.GroupNameAttribute = "cn"
+	}
+	if config.GroupCacheMinutes <= 0 {
+		config.GroupCacheMinutes = 10
+	}
+	if config.SessionHours <= 0 {
+		config.SessionHours = 24 * 7
+	}
+	return &LDAPUserManager{
+		config:          config,
+		cacheDuration:   time.Duration(config.GroupCacheMinutes) * time.Minute,
+		sessionDuration: time.Duration(config.SessionHours) * time.Hour,
+		cache:           map[string]ldapCachedUser{},
+	}, nil
+}
+
+// GetUserByToken checks the token's signature and expiration and returns the
+// user it names.
+func (m *LDAPUserManager) GetUserByToken(ctx context.Context, token string) (User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	username, err := m.verifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	cached, err := m.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return &cached.user, nil
+}
+
+// CreateUserToken authenticates the user by binding as their entry and
+// returns a session token.
+func (m *LDAPUserManager) CreateUserToken(username, password string) (string, error) {
+	// an empty password would make an unauthenticated bind, which succeeds
+	if username == "" || password == "" {
+		return "", errors.New("must specify a username and password")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := m.connect(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if err = m.bindService(conn); err != nil {
+		return "", err
+	}
+	entry, err := m.findUser(conn, username)
+	if err != nil {
+		return "", err
+	}
+	if entry == nil {
+		return "", errors.New("invalid username or password")
+	}
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return "", errors.New("invalid username or password")
+		}
+		return "", errors.Wrapf(err, "problem authenticating user '%s'", username)
+	}
+
+	// look up groups as the bind account, which may see more than the user
+	if err = m.bindService(conn); err != nil {
+		return "", err
+	}
+	if _, err = m.cacheUser(conn, entry, username); err != nil {
+		return "", err
+	}
+
+	return m.signToken(username, time.Now().Add(m.sessionDuration)), nil
+}
+
+// GetUserGroups returns the names of the groups of the user.
+func (m *LDAPUserManager) GetUserGroups(username string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cached, err := m.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return cached.groups, nil
+}
+
+// getUser returns the cached user, looking the user up if they are not
+// cached or the cache has expired.
+func (m *LDAPUserManager) getUser(ctx context.Context, username string) (ldapCachedUser, error) {
+	m.mu.Lock()
+	cached, ok := m.cache[username]
+	m.mu.Unlock()
+	if !ok || time.Now().After(cached.expiresAt) {
+		var err error
+		cached, err = m.lookUpUser(ctx, username)
+		if err != nil {
+			return ldapCachedUser{}, err
+		}
+	}
+	if cached.missing {
+		return ldapCachedUser{}, errors.Errorf("user '%s' was not found", username)
+	}
+	return cached, nil
+}
+
+// lookUpUser finds the user in the directory and caches them, or caches that
+// they are missing.
+func (m *LDAPUserManager) lookUpUser(ctx context.Context, username string) (ldapCachedUser, error) {
+	conn, err := m.connect(ctx)
+	if err != nil {
+		return ldapCachedUser{}, err
+	}
+	defer conn.Close()
+	if err = m.bindService(conn); err != nil {
+		return ldapCachedUser{}, err
+	}
+	entry, err := m.findUser(conn, username)
+	if err != nil {
+		return ldapCachedUser{}, err
+	}
+	if entry == nil {
+		missing := ldapCachedUser{
+			missing:   true,
+			expiresAt: time.Now().Add(m.cacheDuration),
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.cache[username] = missing
+		return missing, nil
+	}
+	return m.cacheUser(conn, entry, username)
+}
+
+// cacheUser looks up the groups of the user's entry and caches the user.
+func (m *LDAPUserManager) cacheUser(conn *ldap.Conn, entry *ldap.Entry, username string) (ldapCachedUser, error) {
+	groups, err := m.findGroups(conn, entry.DN)
+	if err != nil {
+		return ldapCachedUser{}, err
+	}
+	displayName := ldapAttribute(entry, m.config.DisplayNameAttribute)
+	if displayName == "" {
+		displayName = username
+	}
+	cached := ldapCachedUser{
+		user: simpleUser{
+			UserId:       username,
+			Name:         displayName,
+			EmailAddress: ldapAttribute(entry, m.config.EmailAttribute),
+		},
+		groups:    groups,
+		expiresAt: time.Now().Add(m.cacheDuration),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache[username] = cached
+	return cached, nil
+}
+
+// connect dials the server at the ldap:// or ldaps:// URL. Requests on the
+// connection time out at the context's deadline.
+func (m *LDAPUserManager) connect(ctx context.Context) (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(m.config.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem connecting to LDAP server '%s'", m.config.URL)
+	}
+	timeout := time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	conn.SetTimeout(timeout)
+	return conn, nil
+}
+
+// bindService binds as the configured bind account, if there is one.
+func (m *LDAPUserManager) bindService(conn *ldap.Conn) error {
+	if m.config.BindDN == "" {
+		return nil
+	}
+	return errors.Wrap(conn.Bind(m.config.BindDN, m.config.BindPassword), "problem binding as the LDAP bind account")
+}
+
+// findUser returns the single entry of the user, or nil if the user is not
+// in the directory.
+func (m *LDAPUserManager) findUser(conn *ldap.Conn, username string) (*ldap.Entry, error) {
+	entries, err := m.search(conn, m.config.UserSearchBase, ldapEquals(m.config.UserAttribute, username),
+		[]string{m.config.UserAttribute, m.config.EmailAttribute, m.config.DisplayNameAttribute})
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem searching for user '%s'", username)
+	}
+	switch len(entries) {
+	case 0:
+		return nil, nil
+	case 1:
+		return entries[0], nil
+	default:
+		return nil, errors.Errorf("found %d entries for user '%s'", len(entries), username)
+	}
+}
+
+// findGroups returns the names of the groups that have the entry as a member.
+func (m *LDAPUserManager) findGroups(conn *ldap.Conn, dn string) ([]string, error) {
+	if m.config.GroupSearchBase == "" {
+		return []string{}, nil
+	}
+	entries, err := m.search(conn, m.config.GroupSearchBase, ldapEquals(m.config.GroupMemberAttribute, dn),
+		[]string{m.config.GroupNameAttribute})
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem searching for the groups of '%s'", dn)
+	}
+	groups := []string{}
+	for _, e := range entries {
+		if name := ldapAttribute(e, m.config.GroupNameAttribute); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// search returns the entries in the subtree under base that match the
+// filter, with the given attributes.
+func (m *LDAPUserManager) search(conn *ldap.Conn, base, filter string, attributes []string) ([]*ldap.Entry, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(base, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false, filter, attributes, nil))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result.Entries, nil
+}
+
+// ldapEquals returns an equality filter, with the value escaped.
+func ldapEquals(attribute, value string) string {
+	return fmt.Sprintf("(%s=%s)", attribute, ldap.EscapeFilter(value))
+}
+
+// ldapAttribute returns the first value of the entry's attribute. Attribute
+// names are not case sensitive.
+func ldapAttribute(entry *ldap.Entry, attribute string) string {
+	for _, a := range entry.Attributes {
+		if strings.EqualFold(a.Name, attribute) && len(a.Values) > 0 {
+			return a.Values[0]
+		}
+	}
+	return ""
+}
+
+// signToken returns a token for the user that expires at the given time.
+func (m *LDAPUserManager) signToken(username string, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%s.%d", base64.RawURLEncoding.EncodeToString([]byte(username)), expiresAt.Unix())
+	return payload + "." + m.tokenSignature(payload)
+}
+
+// verifyToken checks the token and returns the user it names.
+func (m *LDAPUserManager) verifyToken(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("invalid session token")
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(m.tokenSignature(payload))) {
+		return "", errors.New("invalid session token")
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", errors.New("invalid session token")
+	}
+	if time.Now().After(time.Unix(expiresAt, 0)) {
+		return "", errors.New("session token has expired")
+	}
+	username, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", errors.New("invalid session token")
+	}
+	return string(username), nil
+}
+
+func (m *LDAPUserManager) tokenSignature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(m.config.SessionSecret))
+	_, _ = mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (*LDAPUserManager) GetLoginHandler(string) func(http.ResponseWriter, *http.Request) {
+	return nil
+}
+
+func (*LDAPUserManager) GetLoginCallbackHandler() func(http.ResponseWriter, *http.Request) {
+	return nil
+}
+
+func (*LDAPUserManager) IsRedirect() bool {
+	return false
+}
@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ber "gopkg.in/asn1-ber.v1"
+	ldap "gopkg.in/ldap.v3"
+)
+
+// mockLDAPServer is an in-process LDAP server that supports simple binds and
+// searches with equality, and, or and presence filters.
+type mockLDAPServer struct {
+	listener  net.Listener
+	passwords map[string]string
+
+	mu       sync.Mutex
+	entries  []mockLDAPEntry
+	searches int
+}
+
+// mockLDAPEntry is an entry of the mock server, with its attribute names in
+// lower case.
+type mockLDAPEntry struct {
+	dn         string
+	attributes map[string][]string
+}
+
+func newMockLDAPServer(t *testing.T) *mockLDAPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &mockLDAPServer{
+		listener: listener,
+		passwords: map[string]string{
+			"cn=evergreen,dc=example,dc=com":        "service-password",
+			"uid=alice,ou=people,dc=example,dc=com": "alice-password",
+			"uid=bob,ou=people,dc=example,dc=com":   "bob-password",
+		},
+		entries: []mockLDAPEntry{
+			{dn: "uid=alice,ou=people,dc=example,dc=com", attributes: map[string][]string{
+				"uid": {"alice"}, "mail": {"alice@example.com"}, "displayname": {"Alice A"},
+			}},
+			{dn: "uid=bob,ou=people,dc=example,dc=com", attributes: map[string][]string{
+				"uid": {"bob"}, "mail": {"bob@example.com"},
+			}},
+			{dn: "cn=admins,ou=groups,dc=example,dc=com", attributes: map[string][]string{
+				"cn": {"admins"}, "member": {"uid=alice,ou=people,dc=example,dc=com"},
+			}},
+			{dn: "cn=developers,ou=groups,dc=example,dc=com", attributes: map[string][]string{
+				"cn": {"developers"}, "member": {
+					"uid=alice,ou=people,dc=example,dc=com",
+					"uid=bob,ou=people,dc=example,dc=com",
+				},
+			}},
+		},
+	}
+	go s.serve()
+	return s
+}
+
+func (s *mockLDAPServer) url() string { return "ldap://" + s.listener.Addr().String() }
+
+func (s *mockLDAPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *mockLDAPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	bound := false
+	for {
+		message, err := ber.ReadPacket(reader)
+		if err != nil || len(message.Children) < 2 {
+			return
+		}
+		id := message.Children[0].Value
+		op := message.Children[1]
+		respond := func(response *ber.Packet) {
+			envelope := ber.NewSequence("LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+			envelope.AppendChild(response)
+			_, _ = conn.Write(envelope.Bytes())
+		}
+		result := func(tag ber.Tag, code int) *ber.Packet {
+			p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+			p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "resultCode"))
+			p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+			p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+			return p
+		}
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := packetString(op.Children[1])
+			password := packetString(op.Children[2])
+			expected, ok := s.passwords[dn]
+			bound = ok && password == expected
+			if bound {
+				respond(result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess))
+			} else {
+				respond(result(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials))
+			}
+		case ldap.ApplicationSearchRequest:
+			if !bound {
+				respond(result(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights))
+				continue
+			}
+			base := strings.ToLower(packetString(op.Children[0]))
+			s.mu.Lock()
+			s.searches++
+			entries := s.entries
+			s.mu.Unlock()
+			for _, e := range entries {
+				if !strings.HasSuffix(e.dn, base) || !matchesLDAPFilter(e, op.Children[6]) {
+					continue
+				}
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "objectName"))
+				attributes := ber.NewSequence("attributes")
+				for name, values := range e.attributes {
+					attribute := ber.NewSequence("attribute")
+					attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+					set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+					for _, v := range values {
+						set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+					}
+					attribute.AppendChild(set)
+					attributes.AppendChild(attribute)
+				}
+				entry.AppendChild(attributes)
+				respond(entry)
+			}
+			respond(result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+// packetString returns the value of a primitive packet as a string.
+func packetString(p *ber.Packet) string {
+	return string(p.Data.Bytes())
+}
+
+func matchesLDAPFilter(e mockLDAPEntry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, f := range filter.Children {
+			if !matchesLDAPFilter(e, f) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, f := range filter.Children {
+			if matchesLDAPFilter(e, f) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterEqualityMatch:
+		for _, v := range e.attributes[strings.ToLower(packetString(filter.Children[0]))] {
+			if strings.EqualFold(v, packetString(filter.Children[1])) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(e.attributes[strings.ToLower(packetString(filter))]) > 0
+	}
+	return false
+}
+
+func (s *mockLDAPServer) searchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.searches
+}
+
+func TestLDAPUserManager(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server := newMockLDAPServer(t)
+	defer server.listener.Close()
+
+	config := &evergreen.LDAPConfig{
+		URL:             server.url(),
+		BindDN:          "cn=evergreen,dc=example,dc=com",
+		BindPassword:    "service-password",
+		UserSearchBase:  "ou=people,dc=example,dc=com",
+		GroupSearchBase: "ou=groups,dc=example,dc=com",
+		SessionSecret:   "secret",
+	}
+	um, err := LoadUserManager(evergreen.AuthConfig{LDAP: config})
+	require.NoError(err)
+	defer SetGroupResolver(nil)
+	assert.False(um.IsRedirect())
+	assert.Nil(um.GetLoginHandler(""))
+
+	_, err = um.CreateUserToken("alice", "wrong")
+	assert.Error(err)
+	_, err = um.CreateUserToken("alice", "")
+	assert.Error(err)
+	_, err = um.CreateUserToken("carol", "password")
+	assert.Error(err)
+
+	token, err := um.CreateUserToken("alice", "alice-password")
+	require.NoError(err)
+	ctx := context.Background()
+	user, err := um.GetUserByToken(ctx, token)
+	require.NoError(err)
+	assert.Equal("alice", user.Username())
+	assert.Equal("Alice A", user.DisplayName())
+	assert.Equal("alice@example.com", user.Email())
+
+	_, err = um.GetUserByToken(ctx, token+"x")
+	assert.Error(err)
+	ldapManager := um.(*LDAPUserManager)
+	_, err = um.GetUserByToken(ctx, ldapManager.signToken("alice", time.Now().Add(-time.Minute)))
+	assert.Error(err)
+	other, err := NewLDAPUserManager(&evergreen.LDAPConfig{URL: server.url(), UserSearchBase: "dc=example,dc=com", SessionSecret: "other"})
+	require.NoError(err)
+	_, err = um.GetUserByToken(ctx, other.signToken("alice", time.Now().Add(time.Hour)))
+	assert.Error(err)
+
+	// groups are resolved and cached
+	searches := server.searchCount()
+	assert.True(IsSuperUser([]string{"group:admins"}, user))
+	assert.True(IsProjectAdmin([]string{"someone", "group:developers"}, &simpleUser{UserId: "bob"}))
+	assert.False(IsSuperUser([]string{"group:admins"}, &simpleUser{UserId: "bob"}))
+	assert.False(IsProjectAdmin([]string{"group:nobody"}, &simpleUser{UserId: "carol"}))
+	assert.True(IsProjectAdmin([]string{"carol"}, &simpleUser{UserId: "carol"}))
+	groups, err := um.(GroupResolver).GetUserGroups("alice")
+	require.NoError(err)
+	assert.Len(groups, 2)
+
+	// alice was cached at login, bob was looked up once and carol was not found
+	assert.Equal(searches+3, server.searchCount())
+
+	// users that are not found are cached too
+	assert.False(IsProjectAdmin([]string{"group:nobody"}, &simpleUser{UserId: "carol"}))
+	_, err = um.(GroupResolver).GetUserGroups("carol")
+	assert.Error(err)
+	assert.Equal(searches+3, server.searchCount())
+
+	// once the cache expires, changes to the directory are seen
+	server.mu.Lock()
+	server.entries[2].attributes["member"] = nil
+	server.mu.Unlock()
+	assert.True(IsSuperUser([]string{"group:admins"}, user))
+	ldapManager.mu.Lock()
+	for username, cached := range ldapManager.cache {
+		cached.expiresAt = time.Now().Add(-time.Second)
+		ldapManager.cache[username] = cached
+	}
+	ldapManager.mu.Unlock()
+	assert.False(IsSuperUser([]string{"group:admins"}, user))
+}
@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// oidcLoginCookie holds the state of a login between the redirect to the
+// provider and the callback.
+const oidcLoginCookie = "evergreen-oidc-login"
+
+// OIDCUserManager implements the UserManager with a generic OpenID Connect
+// provider, such as Okta or Keycloak.
+// The provider's endpoints are read from its discovery document the first time
+// they are needed. Users log in with the authorization code flow, protected by
+// PKCE: the login handler redirects the user to the provider with an
+// unguessable state, a nonce and the challenge of a code verifier, all of
+// which are kept in a short-lived cookie. The callback handler checks the
+// state, exchanges the code and the verifier for an ID token, checks the
+// token's nonce and stores the token in the session cookie.
+// Whenever GetUserByToken is called, the token's signature is checked against
+// the provider's key set, which is cached, and the user is built from the
+// token's claims.
+type OIDCUserManager struct {
+	config evergreen.OIDCAuthConfig
+
+	mu       sync.Mutex
+	provider *oidcProvider
+	keys     *jwksCache
+}
+
+// oidcProvider holds the fields of a provider's discovery document that we
+// use.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewOIDCUserManager initializes an OIDCUserManager. The provider is not
+// contacted until a user logs in.
+func NewOIDCUserManager(c *evergreen.OIDCAuthConfig) (*OIDCUserManager, error) {
+	if c.Issuer == "" {
+		return nil, errors.New("no issuer for config")
+	}
+	if c.ClientId == "" {
+		return nil, errors.New("no client id for config")
+	}
+	config := *c
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "preferred_username"
+	}
+	if config.EmailClaim == "" {
+		config.EmailClaim = "email"
+	}
+	if config.DisplayNameClaim == "" {
+		config.DisplayNameClaim = "name"
+	}
+	return &OIDCUserManager{config: config}, nil
+}
+
+// getProvider returns the provider's discovery document and key set,
+// fetching the document if it has not been fetched yet.
+func (m *OIDCUserManager) getProvider(ctx context.Context) (*oidcProvider, *jwksCache, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.provider != nil {
+		return m.provider, m.keys, nil
+	}
+
+	provider := &oidcProvider{}
+	discoveryURL := strings.TrimSuffix(m.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, discoveryURL, provider); err != nil {
+		return nil, nil, errors.Wrap(err, "problem discovering OpenID Connect provider")
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != strings.TrimSuffix(m.config.Issuer, "/") {
+		return nil, nil, errors.Errorf("provider issuer '%s' does not match configured issuer '%s'",
+			provider.Issuer, m.config.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, nil, errors.New("provider discovery document is missing endpoints")
+	}
+
+	m.provider = provider
+	m.keys = newJWKSCache(provider.JWKSURI)
+	return m.provider, m.keys, nil
+}
+
+// GetUserByToken checks the ID token and returns the user named by its claims.
+func (m *OIDCUserManager) GetUserByToken(ctx context.Context, token string) (User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	provider, keys, err := m.getProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := verifyIDToken(ctx, keys, token, provider.Issuer, m.config.ClientId, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ID token")
+	}
+	return m.userFromClaims(claims)
+}
+
+// userFromClaims maps the claims of an ID token to a user.
+func (m *OIDCUserManager) userFromClaims(claims idTokenClaims) (User, error) {
+	username := claims.string(m.config.UsernameClaim)
+	if username == "" {
+		return nil, errors.Errorf("ID token has no '%s' claim", m.config.UsernameClaim)
+	}
+	displayName := claims.string(m.config.DisplayNameClaim)
+	if displayName == "" {
+		displayName = username
+	}
+	return &simpleUser{
+		UserId:       username,
+		Name:         displayName,
+		EmailAddress: claims.string(m.config.EmailClaim),
+	}, nil
+}
+
+// CreateUserToken is not implemented in OIDCUserManager
+func (*OIDCUserManager) CreateUserToken(string, string) (string, error) {
+	return "", errors.New("OIDCUserManager does not create tokens via username/password")
+}
+
+// GetLoginHandler returns the function that starts the authorization code
+// flow by redirecting the user to the provider.
+func (m *OIDCUserManager) GetLoginHandler(callbackUri string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider, _, err := m.getProvider(r.Context())
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "could not start OpenID Connect login",
+				"issuer":  m.config.Issuer,
+			}))
+			http.Error(w, "authentication provider is unavailable", http.StatusBadGateway)
+			return
+		}
+
+		state := util.RandomString()
+		nonce := util.RandomString()
+		// verifiers must be at least 43 characters long
+		verifier := util.RandomString() + util.RandomString()
+		redirectURI := fmt.Sprintf("%v/login/redirect/callback", callbackUri)
+		redirect := r.FormValue("redirect")
+		if redirect == "" {
+			redirect = "/"
+		}
+
+		login := url.Values{}
+		login.Set("state", state)
+		login.Set("nonce", nonce)
+		login.Set("verifier", verifier)
+		login.Set("redirect_uri", redirectURI)
+		login.Set("redirect", redirect)
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcLoginCookie,
+			Value:    login.Encode(),
+			HttpOnly: true,
+			Path:     "/",
+			MaxAge:   int((10 * time.Minute).Seconds()),
+		})
+
+		parameters := url.Values{}
+		parameters.Set("response_type", "code")
+		parameters.Set("client_id", m.config.ClientId)
+		parameters.Set("redirect_uri", redirectURI)
+		parameters.Set("scope", strings.Join(m.config.Scopes, " "))
+		parameters.Set("state", state)
+		parameters.Set("nonce", nonce)
+		parameters.Set("code_challenge", pkceChallenge(verifier))
+		parameters.Set("code_challenge_method", "S256")
+
+		separator := "?"
+		if strings.Contains(provider.AuthorizationEndpoint, "?") {
+			separator = "&"
+		}
+		http.Redirect(w, r, provider.AuthorizationEndpoint+separator+parameters.Encode(), http.StatusFound)
+	}
+}
+
+// GetLoginCallbackHandler returns the function that is called when the
+// provider redirects the user back to Evergreen.
+func (m *OIDCUserManager) GetLoginCallbackHandler() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if providerErr := r.FormValue("error"); providerErr != "" {
+			grip.Error(message.Fields{
+				"message":     "OpenID Connect provider returned an error",
+				"error":       providerErr,
+				"description": r.FormValue("error_description"),
+			})
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		cookie, err := r.Cookie(oidcLoginCookie)
+		if err != nil {
+			grip.Error("Error getting login state for OpenID Connect authentication")
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		// the login state can only be used once
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcLoginCookie,
+			HttpOnly: true,
+			Path:     "/",
+			MaxAge:   -1,
+		})
+		login, err := url.ParseQuery(cookie.Value)
+		if err != nil || login.Get("state") == "" || login.Get("state") != r.FormValue("state") {
+			grip.Error("Error unmatching states when authenticating with OpenID Connect")
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		code := r.FormValue("code")
+		if code == "" {
+			grip.Error("Error getting code from OpenID Connect provider for authentication")
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		token, err := m.exchangeCode(ctx, code, login.Get("verifier"), login.Get("redirect_uri"), login.Get("nonce"))
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "could not complete OpenID Connect login",
+				"issuer":  m.config.Issuer,
+			}))
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		setLoginToken(token, w)
+		http.Redirect(w, r, login.Get("redirect"), http.StatusFound)
+	}
+}
+
+// exchangeCode exchanges an authorization code for an ID token and checks the
+// token.
+func (m *OIDCUserManager) exchangeCode(ctx context.Context, code, verifier, redirectURI, nonce string) (string, error) {
+	provider, keys, err := m.getProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", m.config.ClientId)
+	form.Set("code_verifier", verifier)
+	req, err := http.NewRequest(http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if m.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(m.config.ClientId), url.QueryEscape(m.config.ClientSecret))
+	}
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "problem requesting token")
+	}
+	defer resp.Body.Close()
+
+	tokens := struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return "", errors.Wrapf(err, "problem reading token response with status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("token request returned status %d: %s %s", resp.StatusCode,
+			tokens.Error, tokens.ErrorDescription)
+	}
+	if tokens.IDToken == "" {
+		return "", errors.New("token response has no ID token")
+	}
+
+	claims, err := verifyIDToken(ctx, keys, tokens.IDToken, provider.Issuer, m.config.ClientId, nonce)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid ID token")
+	}
+	if _, err = m.userFromClaims(claims); err != nil {
+		return "", err
+	}
+	return tokens.IDToken, nil
+}
+
+// pkceChallenge returns the S256 challenge of a PKCE code verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (*OIDCUserManager) IsRedirect() bool {
+	return true
+}
@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssuer is an OpenID Connect provider that issues ID tokens for a single
+// authorization code.
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	code      string
+	challenge string
+	nonce     string
+	claims    map[string]interface{}
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &mockIssuer{t: t, key: key, code: "the-code"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		clientId, clientSecret, ok := r.BasicAuth()
+		if !ok || clientId != "client" || clientSecret != "secret" ||
+			r.PostForm.Get("code") != issuer.code ||
+			pkceChallenge(r.PostForm.Get("code_verifier")) != issuer.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeTestJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := map[string]interface{}{"nonce": issuer.nonce}
+		for k, v := range issuer.claims {
+			claims[k] = v
+		}
+		writeTestJSON(w, map[string]string{"id_token": issuer.sign(claims)})
+	})
+	issuer.server = httptest.NewServer(mux)
+	issuer.claims = map[string]interface{}{
+		"iss":                issuer.server.URL,
+		"aud":                "client",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"preferred_username": "octocat",
+		"email":              "octocat@example.com",
+		"name":               "Octo Cat",
+	}
+	return issuer
+}
+
+func (i *mockIssuer) sign(claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "key-1", "typ": "JWT"})
+	require.NoError(i.t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(i.t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	require.NoError(i.t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeTestJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func TestOIDCUserManagerLogin(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	um, err := LoadUserManager(evergreen.AuthConfig{OIDC: &evergreen.OIDCAuthConfig{
+		Issuer:       issuer.server.URL,
+		ClientId:     "client",
+		ClientSecret: "secret",
+	}})
+	require.NoError(err)
+	assert.True(um.IsRedirect())
+
+	// the login handler redirects to the provider
+	rec := httptest.NewRecorder()
+	um.GetLoginHandler("https://evergreen.example.com")(rec, httptest.NewRequest(http.MethodGet, "/login/redirect?redirect=/waterfall", nil))
+	require.Equal(http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(err)
+	assert.Equal(issuer.server.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+	query := location.Query()
+	assert.Equal("code", query.Get("response_type"))
+	assert.Equal("client", query.Get("client_id"))
+	assert.Equal("https://evergreen.example.com/login/redirect/callback", query.Get("redirect_uri"))
+	assert.Equal("openid profile email", query.Get("scope"))
+	assert.Equal("S256", query.Get("code_challenge_method"))
+	require.NotEmpty(query.Get("state"))
+	require.NotEmpty(query.Get("nonce"))
+	issuer.challenge = query.Get("code_challenge")
+	issuer.nonce = query.Get("nonce")
+	cookies := rec.Result().Cookies()
+	require.Len(cookies, 1)
+	loginCookie := cookies[0]
+
+	// the callback rejects a state that does not match
+	req := httptest.NewRequest(http.MethodGet, "/login/redirect/callback?code=the-code&state=other", nil)
+	req.AddCookie(loginCookie)
+	rec = httptest.NewRecorder()
+	um.GetLoginCallbackHandler()(rec, req)
+	assert.Equal("/login", rec.Header().Get("Location"))
+
+	// the callback exchanges the code for an ID token
+	req = httptest.NewRequest(http.MethodGet, "/login/redirect/callback?code=the-code&state="+query.Get("state"), nil)
+	req.AddCookie(loginCookie)
+	rec = httptest.NewRecorder()
+	um.GetLoginCallbackHandler()(rec, req)
+	require.Equal(http.StatusFound, rec.Code)
+	assert.Equal("/waterfall", rec.Header().Get("Location"))
+	var token string
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == evergreen.AuthTokenCookie {
+			token = c.Value
+		}
+	}
+	require.NotEmpty(token)
+
+	user, err := um.GetUserByToken(context.Background(), token)
+	require.NoError(err)
+	assert.Equal("octocat", user.Username())
+	assert.Equal("octocat@example.com", user.Email())
+	assert.Equal("Octo Cat", user.DisplayName())
+
+	// a token with another nonce is rejected
+	issuer.nonce = "other"
+	req = httptest.NewRequest(http.MethodGet, "/login/redirect/callback?code=the-code&state="+query.Get("state"), nil)
+	req.AddCookie(loginCookie)
+	rec = httptest.NewRecorder()
+	um.GetLoginCallbackHandler()(rec, req)
+	assert.Equal("/login", rec.Header().Get("Location"))
+}
+
+func TestOIDCUserManagerGetUserByToken(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	um, err := NewOIDCUserManager(&evergreen.OIDCAuthConfig{
+		Issuer:        issuer.server.URL,
+		ClientId:      "client",
+		UsernameClaim: "sub",
+	})
+	require.NoError(err)
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		out := map[string]interface{}{}
+		for k, v := range issuer.claims {
+			out[k] = v
+		}
+		out["sub"] = "user-1"
+		for k, v := range overrides {
+			out[k] = v
+		}
+		return out
+	}
+	ctx := context.Background()
+
+	user, err := um.GetUserByToken(ctx, issuer.sign(claims(map[string]interface{}{
+		"aud": []string{"other", "client"},
+	})))
+	require.NoError(err)
+	assert.Equal("user-1", user.Username())
+
+	for name, overrides := range map[string]map[string]interface{}{
+		"expired":      {"exp": time.Now().Add(-time.Hour).Unix()},
+		"audience":     {"aud": "other"},
+		"issuer":       {"iss": "https://other.example.com"},
+		"username":     {"sub": nil},
+		"expiration":   {"exp": nil},
+		"nested claim": {"sub": map[string]string{"id": "user-1"}},
+	} {
+		_, err = um.GetUserByToken(ctx, issuer.sign(claims(overrides)))
+		assert.Error(err, name)
+	}
+
+	// tokens signed with another key are rejected
+	otherIssuer := newMockIssuer(t)
+	defer otherIssuer.server.Close()
+	_, err = um.GetUserByToken(ctx, otherIssuer.sign(claims(nil)))
+	assert.Error(err)
+
+	_, err = um.GetUserByToken(ctx, "not a token")
+	assert.Error(err)
+}
@@ -0,0 +1,315 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // register the hashes of the signing algorithms
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// jwksRefreshInterval is how long the keys of a key set are cached.
+	jwksRefreshInterval = time.Hour
+	// jwksMinRefreshInterval is how often the key set may be fetched again
+	// when a token is signed with a key that is not cached.
+	jwksMinRefreshInterval = time.Minute
+	// idTokenLeeway is the clock skew allowed when checking token times.
+	idTokenLeeway = time.Minute
+)
+
+// jsonWebKey is a public key of a JSON web key set, as described in RFC 7517.
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyId   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey returns the crypto public key of the JSON web key.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid modulus")
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve '%s'", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid x coordinate")
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid y coordinate")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.Errorf("unsupported key type '%s'", k.KeyType)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if len(b) == 0 {
+		return nil, errors.New("value is empty")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// jwksCache caches the signing keys of a JSON web key set, by key id.
+type jwksCache struct {
+	uri string
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func newJWKSCache(uri string) *jwksCache {
+	return &jwksCache{uri: uri}
+}
+
+// get returns the key with the given id, fetching the key set if the cached
+// keys are stale or do not include the key.
+func (c *jwksCache) get(ctx context.Context, keyId string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	age := time.Since(c.fetchedAt)
+	if key, ok := c.keys[keyId]; ok && age < jwksRefreshInterval {
+		return key, nil
+	}
+	if c.keys == nil || age >= jwksMinRefreshInterval {
+		if err := c.fetch(ctx); err != nil {
+			// keep using a stale key while the provider is unreachable
+			if key, ok := c.keys[keyId]; ok {
+				return key, nil
+			}
+			return nil, err
+		}
+	}
+	if key, ok := c.keys[keyId]; ok {
+		return key, nil
+	}
+	return nil, errors.Errorf("no signing key with id '%s'", keyId)
+}
+
+// fetch replaces the cached keys with the keys of the key set.
+func (c *jwksCache) fetch(ctx context.Context) error {
+	keySet := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := getJSON(ctx, c.uri, &keySet); err != nil {
+		return errors.Wrap(err, "problem fetching key set")
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range keySet.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			// skip keys we can't use, other keys may still be valid
+			continue
+		}
+		keys[k.KeyId] = key
+	}
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	return nil
+}
+
+// idTokenClaims are the claims of an ID token.
+type idTokenClaims map[string]interface{}
+
+// string returns the value of a string claim.
+func (c idTokenClaims) string(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// time returns the value of a numeric date claim.
+func (c idTokenClaims) time(name string) (time.Time, bool) {
+	seconds, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// hasAudience returns whether the token was issued to the given client.
+func (c idTokenClaims) hasAudience(clientId string) bool {
+	switch aud := c["aud"].(type) {
+	case string:
+		return aud == clientId
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyIDToken checks the signature of an ID token with the key set and
+// returns its claims if it was issued by the issuer to the client and has not
+// expired. If nonce is not empty, the token must carry the same nonce.
+func verifyIDToken(ctx context.Context, keys *jwksCache, token, issuer, clientId, nonce string) (idTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JSON web token")
+	}
+
+	header := struct {
+		Algorithm string `json:"alg"`
+		KeyId     string `json:"kid"`
+	}{}
+	if err := decodeTokenPart(parts[0], &header); err != nil {
+		return nil, errors.Wrap(err, "invalid token header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid token signature")
+	}
+	key, err := keys.get(ctx, header.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifySignature(header.Algorithm, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := idTokenClaims{}
+	if err = decodeTokenPart(parts[1], &claims); err != nil {
+		return nil, errors.Wrap(err, "invalid token claims")
+	}
+	if strings.TrimSuffix(claims.string("iss"), "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, errors.Errorf("token was issued by '%s', not '%s'", claims.string("iss"), issuer)
+	}
+	if !claims.hasAudience(clientId) {
+		return nil, errors.Errorf("token was not issued to client '%s'", clientId)
+	}
+	expiresAt, ok := claims.time("exp")
+	if !ok {
+		return nil, errors.New("token has no expiration time")
+	}
+	if time.Now().After(expiresAt.Add(idTokenLeeway)) {
+		return nil, errors.Errorf("token expired at %s", expiresAt)
+	}
+	if nonce != "" && claims.string("nonce") != nonce {
+		return nil, errors.New("token nonce does not match")
+	}
+	return claims, nil
+}
+
+func decodeTokenPart(part string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(json.Unmarshal(data, out))
+}
+
+// verifySignature checks the signature of a token with the key for the
+// algorithm. Only asymmetric algorithms are accepted.
+func verifySignature(algorithm string, key crypto.PublicKey, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch algorithm {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return errors.Errorf("unsupported signing algorithm '%s'", algorithm)
+	}
+	h := hash.New()
+	_, _ = h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if algorithm[0] != 'R' {
+			return errors.Errorf("algorithm '%s' cannot be used with an RSA key", algorithm)
+		}
+		return errors.Wrap(rsa.VerifyPKCS1v15(k, hash, digest, signature), "invalid token signature")
+	case *ecdsa.PublicKey:
+		if algorithm[0] != 'E' {
+			return errors.Errorf("algorithm '%s' cannot be used with an EC key", algorithm)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported key type %T", key)
+	}
+}
+
+// getJSON reads the JSON response of a GET request into out.
+func getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "problem requesting '%s'", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("'%s' returned status %d", url, resp.StatusCode)
+	}
+	return errors.Wrapf(json.NewDecoder(resp.Body).Decode(out), "problem reading response of '%s'", url)
+}
@@ -0,0 +1,131 @@
+// Package blob provides a common interface for storing files in the
+// object stores that tasks can upload artifacts to.
+package blob
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// The types of stores that are supported.
+const (
+	StoreS3    = "s3"
+	StoreGCS   = "gcs"
+	StoreAzure = "azure"
+	StoreLocal = "local"
+	StoreHTTP  = "http"
+)
+
+// ValidStoreTypes lists all of the supported store types.
+var ValidStoreTypes = []string{StoreS3, StoreGCS, StoreAzure, StoreLocal, StoreHTTP}
+
+// Bucket is a location in a blob store that files can be uploaded to
+// and downloaded from. Keys are slash-separated paths relative to the
+// bucket's prefix.
+type Bucket interface {
+	// Upload writes the contents of the local file at path to key.
+	Upload(ctx context.Context, key, path string) error
+	// Download writes the contents of key to the local file at
+	// path, creating any missing parent directories.
+	Download(ctx context.Context, key, path string) error
+	// URL returns a link to the object stored at key.
+	URL(key string) string
+}
+
+// Options configure a blob store. The meaning of Bucket depends on the
+// type of the store: it is the name of the bucket for S3 and GCS, the
+// name of the container for Azure, the root directory for local
+// stores, and the base URL for HTTP stores.
+type Options struct {
+	Type   string `mapstructure:"type" yaml:"type" bson:"type" json:"type" plugin:"expand"`
+	Bucket string `mapstructure:"bucket" yaml:"bucket" bson:"bucket" json:"bucket" plugin:"expand"`
+	// Prefix is prepended to every key.
+	Prefix string `mapstructure:"prefix" yaml:"prefix,omitempty" bson:"prefix,omitempty" json:"prefix,omitempty" plugin:"expand"`
+	// Region is the region of an S3 bucket.
+	Region string `mapstructure:"region" yaml:"region,omitempty" bson:"region,omitempty" json:"region,omitempty" plugin:"expand"`
+	// Endpoint overrides the default service endpoint, to allow
+	// using S3-compatible stores. It is required for Azure stores,
+	// e.g. https://<account>.blob.core.windows.net.
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint,omitempty" bson:"endpoint,omitempty" json:"endpoint,omitempty" plugin:"expand"`
+	// Key and Secret are the access key credentials for S3 and GCS
+	// (HMAC keys), or basic auth credentials for HTTP stores.
+	Key    string `mapstructure:"key" yaml:"key,omitempty" bson:"key,omitempty" json:"key,omitempty" plugin:"expand"`
+	Secret string `mapstructure:"secret" yaml:"secret,omitempty" bson:"secret,omitempty" json:"secret,omitempty" plugin:"expand"`
+	// Token is a shared access signature for Azure stores, or a
+	// bearer token for HTTP stores.
+	Token string `mapstructure:"token" yaml:"token,omitempty" bson:"token,omitempty" json:"token,omitempty" plugin:"expand"`
+	// Permissions is the canned ACL applied to objects uploaded to
+	// S3.
+	Permissions string `mapstructure:"permissions" yaml:"permissions,omitempty" bson:"permissions,omitempty" json:"permissions,omitempty" plugin:"expand"`
+}
+
+// IsZero returns true if no store is configured.
+func (o Options) IsZero() bool {
+	return o.Type == ""
+}
+
+// Validate checks that all of the options required by the store type
+// are set. Options that contain expansions are not validated, because
+// they can only be checked once the expansions are known.
+func (o Options) Validate() error {
+	catcher := grip.NewBasicCatcher()
+
+	if util.IsExpandable(o.Type) {
+		return nil
+	}
+
+	if !util.StringSliceContains(ValidStoreTypes, o.Type) {
+		catcher.Add(errors.Errorf("invalid blob store type '%s', must be one of: %s",
+			o.Type, strings.Join(ValidStoreTypes, ", ")))
+	}
+	if o.Bucket == "" {
+		catcher.Add(errors.Errorf("blob store of type '%s' must specify a bucket", o.Type))
+	}
+	if o.Type == StoreAzure && o.Endpoint == "" {
+		catcher.Add(errors.New("azure blob store must specify an endpoint"))
+	}
+
+	return catcher.Resolve()
+}
+
+// New returns a bucket for the store described by the options.
+func New(opts Options) (Bucket, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid blob store options")
+	}
+
+	switch opts.Type {
+	case StoreS3, StoreGCS:
+		return newS3Bucket(opts)
+	case StoreAzure:
+		return newAzureBucket(opts)
+	case StoreLocal:
+		return newLocalBucket(opts), nil
+	case StoreHTTP:
+		return newHTTPBucket(opts)
+	default:
+		return nil, errors.Errorf("blob store type '%s' is not supported", opts.Type)
+	}
+}
+
+// Resolve returns the first options, in order, that configure a
+// store. Callers pass options from the most specific source (e.g. a
+// command) to the least specific (e.g. a distro).
+func Resolve(opts ...Options) Options {
+	for _, o := range opts {
+		if !o.IsZero() {
+			return o
+		}
+	}
+	return Options{}
+}
+
+// fullKey joins a key to the store's prefix.
+func (o Options) fullKey(key string) string {
+	return strings.TrimLeft(path.Join(o.Prefix, key), "/")
+}
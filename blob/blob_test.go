@@ -0,0 +1,149 @@
+package blob
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(Options{Type: StoreS3, Bucket: "bucket"}.Validate())
+	assert.NoError(Options{Type: "${store_type}"}.Validate())
+	assert.Error(Options{Type: "ftp", Bucket: "bucket"}.Validate())
+	assert.Error(Options{Type: StoreLocal}.Validate())
+	assert.Error(Options{Type: StoreAzure, Bucket: "container"}.Validate())
+	assert.NoError(Options{Type: StoreAzure, Bucket: "container", Endpoint: "https://account.blob.core.windows.net"}.Validate())
+}
+
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+
+	project := Options{Type: StoreLocal, Bucket: "/project"}
+	distro := Options{Type: StoreLocal, Bucket: "/distro"}
+	assert.Equal(project, Resolve(Options{}, project, distro))
+	assert.Equal(distro, Resolve(Options{}, Options{}, distro))
+	assert.True(Resolve().IsZero())
+}
+
+func TestLocalBucket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "blob-local-")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src.txt")
+	require.NoError(ioutil.WriteFile(src, []byte("hello"), 0644))
+
+	bucket, err := New(Options{Type: StoreLocal, Bucket: filepath.Join(dir, "store"), Prefix: "builds"})
+	require.NoError(err)
+
+	require.NoError(bucket.Upload(ctx, "a/b.txt", src))
+	data, err := ioutil.ReadFile(filepath.Join(dir, "store", "builds", "a", "b.txt"))
+	require.NoError(err)
+	assert.Equal("hello", string(data))
+	assert.Equal("file://"+filepath.ToSlash(filepath.Join(dir, "store", "builds", "a", "b.txt")), bucket.URL("a/b.txt"))
+
+	dst := filepath.Join(dir, "out", "b.txt")
+	require.NoError(bucket.Download(ctx, "a/b.txt", dst))
+	data, err = ioutil.ReadFile(dst)
+	require.NoError(err)
+	assert.Equal("hello", string(data))
+
+	assert.Error(bucket.Download(ctx, "missing", dst))
+
+	for _, key := range []string{"../../escaped.txt", "a/../../../escaped.txt", "../.."} {
+		assert.Error(bucket.Upload(ctx, key, src), key)
+		assert.Error(bucket.Download(ctx, key, dst), key)
+		assert.Empty(bucket.URL(key), key)
+	}
+	_, err = os.Stat(filepath.Join(dir, "escaped.txt"))
+	assert.True(os.IsNotExist(err))
+
+	// keys that stay inside the bucket are cleaned
+	require.NoError(bucket.Upload(ctx, "a/../c.txt", src))
+	_, err = os.Stat(filepath.Join(dir, "store", "builds", "c.txt"))
+	assert.NoError(err)
+}
+
+func TestHTTPBucket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	mu := sync.Mutex{}
+	objects := map[string]string{}
+	headers := http.Header{}
+	query := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		headers = r.Header
+		query = r.URL.RawQuery
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "blob-http-")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "src.txt")
+	require.NoError(ioutil.WriteFile(src, []byte("hello"), 0644))
+
+	t.Run("HTTP", func(t *testing.T) {
+		bucket, err := New(Options{Type: StoreHTTP, Bucket: server.URL + "/files", Token: "tok"})
+		require.NoError(err)
+
+		require.NoError(bucket.Upload(ctx, "a/b.txt", src))
+		assert.Equal("hello", objects["/files/a/b.txt"])
+		assert.Equal("Bearer tok", headers.Get("Authorization"))
+		assert.Equal(server.URL+"/files/a/b.txt", bucket.URL("a/b.txt"))
+
+		dst := filepath.Join(dir, "http.txt")
+		require.NoError(bucket.Download(ctx, "a/b.txt", dst))
+		data, err := ioutil.ReadFile(dst)
+		require.NoError(err)
+		assert.Equal("hello", string(data))
+
+		err = bucket.Download(ctx, "missing", dst)
+		require.Error(err)
+		assert.True(strings.Contains(err.Error(), "404"))
+	})
+
+	t.Run("Azure", func(t *testing.T) {
+		bucket, err := New(Options{Type: StoreAzure, Endpoint: server.URL, Bucket: "container", Prefix: "p", Token: "?sv=1&sig=secret"})
+		require.NoError(err)
+
+		require.NoError(bucket.Upload(ctx, "b.txt", src))
+		assert.Equal("hello", objects["/container/p/b.txt"])
+		assert.Equal("BlockBlob", headers.Get("x-ms-blob-type"))
+		assert.Equal("sv=1&sig=secret", query)
+		assert.NotContains(bucket.URL("b.txt"), "secret")
+	})
+}
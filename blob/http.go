@@ -0,0 +1,152 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// httpBucket stores objects with PUT requests and fetches them with GET
+// requests to a URL per object. It supports plain HTTP file servers
+// and Azure blob storage, which uses the same protocol with a shared
+// access signature in the query string.
+type httpBucket struct {
+	opts    Options
+	base    *url.URL
+	headers map[string]string
+}
+
+func newHTTPBucket(opts Options) (*httpBucket, error) {
+	base, err := url.Parse(opts.Bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid base URL '%s'", opts.Bucket)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, errors.Errorf("base URL '%s' must use http or https", opts.Bucket)
+	}
+
+	b := &httpBucket{
+		opts:    opts,
+		base:    base,
+		headers: map[string]string{},
+	}
+	if opts.Token != "" {
+		b.headers["Authorization"] = "Bearer " + opts.Token
+	}
+
+	return b, nil
+}
+
+func newAzureBucket(opts Options) (*httpBucket, error) {
+	base, err := url.Parse(strings.TrimRight(opts.Endpoint, "/") + "/" + opts.Bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid endpoint '%s'", opts.Endpoint)
+	}
+	base.RawQuery = strings.TrimPrefix(opts.Token, "?")
+
+	return &httpBucket{
+		opts: opts,
+		base: base,
+		headers: map[string]string{
+			"x-ms-blob-type": "BlockBlob",
+			"x-ms-version":   "2017-04-17",
+		},
+	}, nil
+}
+
+func (b *httpBucket) URL(key string) string {
+	u := *b.base
+	u.Path = path.Join(u.Path, b.opts.fullKey(key))
+	// the signature in an Azure URL grants access to the container,
+	// so it must not be included in links.
+	u.RawQuery = ""
+	return u.String()
+}
+
+func (b *httpBucket) requestURL(key string) string {
+	u := *b.base
+	u.Path = path.Join(u.Path, b.opts.fullKey(key))
+	return u.String()
+}
+
+func (b *httpBucket) newRequest(ctx context.Context, method, key string) (*http.Request, error) {
+	req, err := http.NewRequest(method, b.requestURL(key), nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+
+	for k, v := range b.headers {
+		req.Header.Set(k, v)
+	}
+	if b.opts.Key != "" && b.opts.Type == StoreHTTP {
+		req.SetBasicAuth(b.opts.Key, b.opts.Secret)
+	}
+
+	return req, nil
+}
+
+func (b *httpBucket) Upload(ctx context.Context, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "problem opening '%s'", path)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "problem reading '%s'", path)
+	}
+
+	req, err := b.newRequest(ctx, http.MethodPut, key)
+	if err != nil {
+		return err
+	}
+	req.Body = f
+	req.ContentLength = info.Size()
+
+	return errors.Wrapf(b.do(req, nil), "problem uploading '%s' to '%s'", path, key)
+}
+
+func (b *httpBucket) Download(ctx context.Context, key, path string) error {
+	req, err := b.newRequest(ctx, http.MethodGet, key)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrapf(b.do(req, func(resp *http.Response) error {
+		return writeFile(path, resp.Body)
+	}), "problem downloading '%s' to '%s'", key, path)
+}
+
+// do sends the request and, if it succeeds, passes the response to
+// handle.
+func (b *httpBucket) do(req *http.Request, handle func(*http.Response) error) error {
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("%s %s returned %s: %s", req.Method, req.URL.Path, resp.Status,
+			strings.TrimSpace(fmt.Sprintf("%.512s", body)))
+	}
+
+	if handle == nil {
+		return nil
+	}
+	return handle(resp)
+}
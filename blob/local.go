@@ -0,0 +1,96 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// localBucket stores objects as files in a directory on the local
+// machine or on a shared filesystem.
+type localBucket struct {
+	opts Options
+}
+
+func newLocalBucket(opts Options) *localBucket {
+	return &localBucket{opts: opts}
+}
+
+// path returns the location of the file for the key, which must be
+// inside the bucket's directory.
+func (b *localBucket) path(key string) (string, error) {
+	root := filepath.Clean(b.opts.Bucket)
+	rel := filepath.Clean(filepath.FromSlash(b.opts.fullKey(key)))
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("key '%s' is outside of the bucket", key)
+	}
+	return filepath.Join(root, rel), nil
+}
+
+func (b *localBucket) Upload(ctx context.Context, key, path string) error {
+	dst, err := b.path(key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.Wrapf(copyFile(ctx, path, dst), "problem uploading '%s' to '%s'", path, key)
+}
+
+func (b *localBucket) Download(ctx context.Context, key, path string) error {
+	src, err := b.path(key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.Wrapf(copyFile(ctx, src, path), "problem downloading '%s' to '%s'", key, path)
+}
+
+// URL returns an empty string for keys outside of the bucket.
+func (b *localBucket) URL(key string) string {
+	path, err := b.path(key)
+	if err != nil {
+		return ""
+	}
+	u := url.URL{
+		Scheme: "file",
+		Path:   filepath.ToSlash(path),
+	}
+	return u.String()
+}
+
+func copyFile(ctx context.Context, src, dst string) error {
+	if err := ctx.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return writeFile(dst, in)
+}
+
+// writeFile writes the contents of the reader to path, creating any
+// missing parent directories.
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err = io.Copy(out, r); err != nil {
+		_ = out.Close()
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(out.Close())
+}
@@ -0,0 +1,126 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+
+	// GCS buckets are accessed through the S3-compatible XML API,
+	// using HMAC keys for credentials.
+	gcsEndpoint = "https://storage.googleapis.com"
+	gcsRegion   = "auto"
+)
+
+// s3Bucket stores objects in S3, or in any store that implements the
+// S3 API, such as GCS.
+type s3Bucket struct {
+	opts    Options
+	session *session.Session
+}
+
+func newS3Bucket(opts Options) (*s3Bucket, error) {
+	config := &aws.Config{
+		Region: aws.String(defaultS3Region),
+	}
+	if opts.Type == StoreGCS {
+		config.Region = aws.String(gcsRegion)
+		config.Endpoint = aws.String(gcsEndpoint)
+	}
+	if opts.Region != "" {
+		config.Region = aws.String(opts.Region)
+	}
+	if opts.Endpoint != "" {
+		config.Endpoint = aws.String(opts.Endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	if opts.Key != "" {
+		config.Credentials = credentials.NewStaticCredentials(opts.Key, opts.Secret, "")
+	}
+
+	s, err := session.NewSession(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating session")
+	}
+
+	return &s3Bucket{
+		opts:    opts,
+		session: s,
+	}, nil
+}
+
+func (b *s3Bucket) URL(key string) string {
+	if b.opts.Endpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", strings.TrimRight(b.opts.Endpoint, "/"),
+			b.opts.Bucket, escapeKey(b.opts.fullKey(key)))
+	}
+	if b.opts.Type == StoreGCS {
+		return fmt.Sprintf("%s/%s/%s", gcsEndpoint, b.opts.Bucket, escapeKey(b.opts.fullKey(key)))
+	}
+	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", b.opts.Bucket, escapeKey(b.opts.fullKey(key)))
+}
+
+func (b *s3Bucket) Upload(ctx context.Context, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "problem opening '%s'", path)
+	}
+	defer f.Close()
+
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(b.opts.Bucket),
+		Key:    aws.String(b.opts.fullKey(key)),
+		Body:   f,
+	}
+	if b.opts.Permissions != "" {
+		input.ACL = aws.String(b.opts.Permissions)
+	}
+
+	_, err = s3manager.NewUploader(b.session).UploadWithContext(ctx, input)
+	return errors.Wrapf(err, "problem uploading '%s' to '%s'", path, key)
+}
+
+func (b *s3Bucket) Download(ctx context.Context, key, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "problem creating '%s'", path)
+	}
+
+	_, err = s3manager.NewDownloader(b.session).DownloadWithContext(ctx, f, &s3.GetObjectInput{
+		Bucket: aws.String(b.opts.Bucket),
+		Key:    aws.String(b.opts.fullKey(key)),
+	})
+	if err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "problem downloading '%s' to '%s'", key, path)
+	}
+
+	return errors.WithStack(f.Close())
+}
+
+// escapeKey escapes each segment of a key for use in a URL path.
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i := range parts {
+		parts[i] = url.PathEscape(parts[i])
+	}
+	return path.Join(parts...)
+}
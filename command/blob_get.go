@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen/blob"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// blobGet downloads a file from the blob store configured for the
+// command, the project, or the distro.
+type blobGet struct {
+	// Store overrides the blob store configured for the project or
+	// the distro.
+	Store blob.Options `mapstructure:"store" plugin:"expand"`
+
+	// RemoteFile is the key of the file to download.
+	RemoteFile string `mapstructure:"remote_file" plugin:"expand"`
+
+	// Only one of these two should be specified. local_file indicates
+	// that the file should be downloaded as-is to the specified path,
+	// and extract_to indicates that the file is a .tgz archive to be
+	// extracted to the specified directory.
+	LocalFile string `mapstructure:"local_file" plugin:"expand"`
+	ExtractTo string `mapstructure:"extract_to" plugin:"expand"`
+
+	base
+}
+
+func blobGetFactory() Command   { return &blobGet{} }
+func (c *blobGet) Name() string { return "blob.get" }
+
+func (c *blobGet) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrapf(err, "error decoding %s params", c.Name())
+	}
+
+	return errors.Wrapf(c.validate(), "error validating %s params", c.Name())
+}
+
+func (c *blobGet) validate() error {
+	if c.RemoteFile == "" {
+		return errors.New("remote_file cannot be blank")
+	}
+	if c.LocalFile != "" && c.ExtractTo != "" {
+		return errors.New("cannot specify both local_file and extract_to directory")
+	}
+	if c.LocalFile == "" && c.ExtractTo == "" {
+		return errors.New("must specify either local_file or extract_to")
+	}
+	if !c.Store.IsZero() {
+		return errors.Wrap(c.Store.Validate(), "invalid store")
+	}
+	return nil
+}
+
+func (c *blobGet) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := c.validate(); err != nil {
+		return errors.Wrap(err, "expanded params are not valid")
+	}
+
+	bucket, err := getBucket(c.Store, conf)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	target := c.LocalFile
+	if c.ExtractTo != "" {
+		tmpDir, err := ioutil.TempDir("", "blob-get")
+		if err != nil {
+			return errors.Wrap(err, "problem creating temporary directory")
+		}
+		defer func() { logger.Execution().Warning(os.RemoveAll(tmpDir)) }()
+		target = filepath.Join(tmpDir, "archive.tgz")
+	} else if !filepath.IsAbs(target) {
+		target = filepath.Join(conf.WorkDir, target)
+	}
+
+	logger.Task().Infof("Fetching %s from blob store", bucket.URL(c.RemoteFile))
+	err = withBlobRetry(ctx, logger, fmt.Sprintf("blob get of %s", c.RemoteFile), func() error {
+		return bucket.Download(ctx, c.RemoteFile, target)
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if c.ExtractTo == "" {
+		return nil
+	}
+
+	extractTo := c.ExtractTo
+	if !filepath.IsAbs(extractTo) {
+		extractTo = filepath.Join(conf.WorkDir, extractTo)
+	}
+	f, gz, tarReader, err := util.TarGzReader(target)
+	if err != nil {
+		return errors.Wrapf(err, "problem reading archive %s", c.RemoteFile)
+	}
+	defer f.Close()
+	defer gz.Close()
+
+	return errors.Wrapf(util.Extract(ctx, tarReader, extractTo), "problem extracting %s", c.RemoteFile)
+}
@@ -0,0 +1,230 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen/blob"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// blobPut uploads files to the blob store configured for the command,
+// the project, or the distro.
+type blobPut struct {
+	// Store overrides the blob store configured for the project or
+	// the distro.
+	Store blob.Options `mapstructure:"store" plugin:"expand"`
+
+	// LocalFile is the path of a single file to upload. RemoteFile
+	// is its key in the store.
+	LocalFile string `mapstructure:"local_file" plugin:"expand"`
+
+	// Include and ExcludeFiles select multiple files to upload from
+	// SourceDir. Each file is uploaded with its path relative to
+	// SourceDir appended to RemoteFile.
+	model.ArtifactInstructions `mapstructure:",squash" plugin:"expand"`
+	SourceDir                  string `mapstructure:"source_dir" plugin:"expand"`
+
+	RemoteFile string `mapstructure:"remote_file" plugin:"expand"`
+
+	// ResourceDisplayName is the name of the file in the UI. The
+	// relative path of each file is appended to it when multiple
+	// files are uploaded.
+	ResourceDisplayName string `mapstructure:"display_name" plugin:"expand"`
+
+	// Visibility determines who can see file links in the UI, in the
+	// same way as for s3.put.
+	Visibility string `mapstructure:"visibility" plugin:"expand"`
+
+	// Optional causes the command to succeed when local_file does not
+	// exist, or when no files match the include patterns.
+	Optional util.StringOrBool `mapstructure:"optional" plugin:"expand"`
+
+	skipMissing bool
+	base
+}
+
+func blobPutFactory() Command   { return &blobPut{} }
+func (c *blobPut) Name() string { return "blob.put" }
+
+func (c *blobPut) ParseParams(params map[string]interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           c,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := decoder.Decode(params); err != nil {
+		return errors.Wrapf(err, "error decoding %s params", c.Name())
+	}
+
+	return c.validate()
+}
+
+func (c *blobPut) isMulti() bool {
+	return len(c.Include) != 0
+}
+
+func (c *blobPut) validate() error {
+	catcher := grip.NewSimpleCatcher()
+
+	if c.LocalFile == "" && !c.isMulti() {
+		catcher.Add(errors.New("local_file and include cannot both be blank"))
+	}
+	if c.LocalFile != "" && c.isMulti() {
+		catcher.Add(errors.New("local_file and include cannot both be specified"))
+	}
+	if c.SourceDir != "" && !c.isMulti() {
+		catcher.Add(errors.New("source_dir can only be used with include"))
+	}
+	if c.RemoteFile == "" && !c.isMulti() {
+		catcher.Add(errors.New("remote_file cannot be blank"))
+	}
+	if !util.StringSliceContains(artifact.ValidVisibilities, c.Visibility) {
+		catcher.Add(errors.Errorf("invalid visibility setting: %v", c.Visibility))
+	}
+	if !c.Store.IsZero() {
+		catcher.Add(errors.Wrap(c.Store.Validate(), "invalid store"))
+	}
+
+	return catcher.Resolve()
+}
+
+func (c *blobPut) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := c.validate(); err != nil {
+		return errors.Wrap(err, "expanded params are not valid")
+	}
+
+	var err error
+	c.skipMissing, err = c.Optional.Bool()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	bucket, err := getBucket(c.Store, conf)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	uploads, err := c.getUploads(conf.WorkDir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(uploads) == 0 {
+		if c.skipMissing {
+			logger.Task().Info("No files to upload, skipping optional blob put")
+			return nil
+		}
+		return errors.Errorf("no files match the include patterns %v", c.Include)
+	}
+
+	files := []*artifact.File{}
+	for _, u := range uploads {
+		if ctx.Err() != nil {
+			return errors.New("blob put operation canceled")
+		}
+
+		if _, err = os.Stat(u.localPath); os.IsNotExist(err) {
+			if c.skipMissing {
+				logger.Task().Infof("Skipping optional upload of missing file %s", u.localPath)
+				continue
+			}
+			return errors.Wrapf(err, "missing file %s", u.localPath)
+		}
+
+		logger.Task().Infof("Putting %s into blob store at %s", u.localPath, bucket.URL(u.key))
+		err = withBlobRetry(ctx, logger, fmt.Sprintf("blob put of %s", u.localPath), func() error {
+			return bucket.Upload(ctx, u.key, u.localPath)
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		files = append(files, &artifact.File{
+			Name:       u.displayName,
+			Link:       bucket.URL(u.key),
+			Visibility: c.Visibility,
+		})
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+	if err = comm.AttachFiles(ctx, td, files); err != nil {
+		return errors.Wrap(err, "Attach files failed")
+	}
+	logger.Execution().Info("API attach files call succeeded")
+
+	return nil
+}
+
+type blobUpload struct {
+	localPath   string
+	key         string
+	displayName string
+}
+
+// getUploads returns the files to upload and the keys to upload them
+// to.
+func (c *blobPut) getUploads(workDir string) ([]blobUpload, error) {
+	if !c.isMulti() {
+		localPath := c.LocalFile
+		if !filepath.IsAbs(localPath) {
+			localPath = filepath.Join(workDir, localPath)
+		}
+		displayName := c.ResourceDisplayName
+		if displayName == "" {
+			displayName = filepath.Base(localPath)
+		}
+
+		return []blobUpload{{
+			localPath:   localPath,
+			key:         c.RemoteFile,
+			displayName: displayName,
+		}}, nil
+	}
+
+	sourceDir := c.SourceDir
+	if !filepath.IsAbs(sourceDir) {
+		sourceDir = filepath.Join(workDir, sourceDir)
+	}
+
+	paths, err := util.BuildArtifactFileList(sourceDir, c.Include, c.ExcludeFiles)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding files in %s", sourceDir)
+	}
+
+	uploads := make([]blobUpload, 0, len(paths))
+	for _, p := range paths {
+		displayName := p
+		if c.ResourceDisplayName != "" {
+			displayName = fmt.Sprintf("%s %s", c.ResourceDisplayName, p)
+		}
+
+		uploads = append(uploads, blobUpload{
+			localPath:   filepath.Join(sourceDir, filepath.FromSlash(p)),
+			key:         path.Join(c.RemoteFile, p),
+			displayName: displayName,
+		})
+	}
+
+	return uploads, nil
+}
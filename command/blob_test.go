@@ -0,0 +1,236 @@
+package command
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/blob"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type blobSuite struct {
+	cancel   func()
+	conf     *model.TaskConfig
+	comm     *client.Mock
+	logger   client.LoggerProducer
+	ctx      context.Context
+	storeDir string
+
+	suite.Suite
+}
+
+func TestBlobSuite(t *testing.T) {
+	suite.Run(t, new(blobSuite))
+}
+
+func (s *blobSuite) SetupTest() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	var err error
+	s.storeDir, err = ioutil.TempDir("", "blob-suite-store-")
+	s.Require().NoError(err)
+
+	s.comm = client.NewMock("http://localhost.com")
+	s.conf = &model.TaskConfig{
+		Expansions: util.NewExpansions(map[string]string{"store_dir": s.storeDir, "build": "b1"}),
+		Task:       &task.Task{Id: "mock_id", Secret: "mock_secret"},
+		Project: &model.Project{
+			BlobStore: &blob.Options{Type: blob.StoreLocal, Bucket: "${store_dir}"},
+		},
+		Distro: &distro.Distro{},
+	}
+	s.logger = s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: s.conf.Task.Id, Secret: s.conf.Task.Secret})
+
+	s.conf.WorkDir, err = ioutil.TempDir("", "blob-suite-work-")
+	s.Require().NoError(err)
+}
+
+func (s *blobSuite) TearDownTest() {
+	s.cancel()
+	s.Require().NoError(os.RemoveAll(s.conf.WorkDir))
+	s.Require().NoError(os.RemoveAll(s.storeDir))
+}
+
+func (s *blobSuite) writeFile(name, contents string) {
+	path := filepath.Join(s.conf.WorkDir, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0755))
+	s.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0644))
+}
+
+func (s *blobSuite) TestPutParseParams() {
+	cmd := blobPutFactory().(*blobPut)
+	s.Error(cmd.ParseParams(map[string]interface{}{}))
+
+	cmd = blobPutFactory().(*blobPut)
+	s.Error(cmd.ParseParams(map[string]interface{}{
+		"local_file": "a.txt",
+		"include":    []string{"*.txt"},
+	}))
+
+	cmd = blobPutFactory().(*blobPut)
+	s.Error(cmd.ParseParams(map[string]interface{}{
+		"local_file":  "a.txt",
+		"remote_file": "a.txt",
+		"store":       map[string]interface{}{"type": "ftp", "bucket": "b"},
+	}))
+
+	cmd = blobPutFactory().(*blobPut)
+	s.NoError(cmd.ParseParams(map[string]interface{}{
+		"source_dir":   "build",
+		"include":      []string{"**.txt"},
+		"excludefiles": []string{"skip*"},
+		"remote_file":  "${build}",
+		"optional":     "true",
+	}))
+	s.Equal([]string{"**.txt"}, cmd.Include)
+	s.Equal([]string{"skip*"}, cmd.ExcludeFiles)
+}
+
+func (s *blobSuite) TestPutAndGetSingleFile() {
+	s.writeFile("out.txt", "hello")
+
+	put := blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"local_file":   "out.txt",
+		"remote_file":  "${build}/out.txt",
+		"display_name": "output",
+	}))
+	s.Require().NoError(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+
+	data, err := ioutil.ReadFile(filepath.Join(s.storeDir, "b1", "out.txt"))
+	s.Require().NoError(err)
+	s.Equal("hello", string(data))
+
+	files := s.comm.AttachedFiles[s.conf.Task.Id]
+	s.Require().Len(files, 1)
+	s.Equal("output", files[0].Name)
+	s.Contains(files[0].Link, "b1/out.txt")
+
+	get := blobGetFactory().(*blobGet)
+	s.Require().NoError(get.ParseParams(map[string]interface{}{
+		"remote_file": "${build}/out.txt",
+		"local_file":  "fetched/out.txt",
+	}))
+	s.Require().NoError(get.Execute(s.ctx, s.comm, s.logger, s.conf))
+
+	data, err = ioutil.ReadFile(filepath.Join(s.conf.WorkDir, "fetched", "out.txt"))
+	s.Require().NoError(err)
+	s.Equal("hello", string(data))
+}
+
+func (s *blobSuite) TestPutMultipleFiles() {
+	s.writeFile("build/a.txt", "a")
+	s.writeFile("build/sub/b.txt", "b")
+	s.writeFile("build/sub/skip.txt", "skip")
+	s.writeFile("build/c.log", "c")
+
+	put := blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"source_dir":   "build",
+		"include":      []string{"**.txt"},
+		"excludefiles": []string{"skip*"},
+		"remote_file":  "${build}",
+	}))
+	s.Require().NoError(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+
+	for _, name := range []string{"a.txt", "sub/b.txt"} {
+		_, err := os.Stat(filepath.Join(s.storeDir, "b1", filepath.FromSlash(name)))
+		s.NoError(err, name)
+	}
+	for _, name := range []string{"sub/skip.txt", "c.log"} {
+		_, err := os.Stat(filepath.Join(s.storeDir, "b1", filepath.FromSlash(name)))
+		s.True(os.IsNotExist(err), name)
+	}
+	s.Len(s.comm.AttachedFiles[s.conf.Task.Id], 2)
+}
+
+func (s *blobSuite) TestPutOptionalMissingFile() {
+	put := blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"local_file":  "missing.txt",
+		"remote_file": "missing.txt",
+	}))
+	s.Error(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+
+	put = blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"local_file":  "missing.txt",
+		"remote_file": "missing.txt",
+		"optional":    true,
+	}))
+	s.NoError(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+	s.Empty(s.comm.AttachedFiles[s.conf.Task.Id])
+}
+
+func (s *blobSuite) TestPutOptionalMissingFileInList() {
+	s.writeFile("build/a.txt", "a")
+	s.writeFile("build/c.txt", "c")
+	// the link is listed, but the file that it points to is missing
+	s.Require().NoError(os.Symlink(filepath.Join(s.conf.WorkDir, "missing.txt"),
+		filepath.Join(s.conf.WorkDir, "build", "b.txt")))
+
+	put := blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"source_dir":  "build",
+		"include":     []string{"*.txt"},
+		"remote_file": "${build}",
+		"optional":    true,
+	}))
+	s.Require().NoError(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+
+	for _, name := range []string{"a.txt", "c.txt"} {
+		_, err := os.Stat(filepath.Join(s.storeDir, "b1", name))
+		s.NoError(err, name)
+	}
+	files := s.comm.AttachedFiles[s.conf.Task.Id]
+	s.Require().Len(files, 2)
+	s.Equal("a.txt", files[0].Name)
+	s.Equal("c.txt", files[1].Name)
+}
+
+func (s *blobSuite) TestStorePrecedence() {
+	s.writeFile("out.txt", "hello")
+
+	// without a project store, the distro's store is used
+	distroDir := filepath.Join(s.storeDir, "distro")
+	s.conf.Project.BlobStore = nil
+	s.conf.Distro.BlobStore = &blob.Options{Type: blob.StoreLocal, Bucket: distroDir}
+
+	put := blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"local_file":  "out.txt",
+		"remote_file": "out.txt",
+	}))
+	s.Require().NoError(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+	_, err := os.Stat(filepath.Join(distroDir, "out.txt"))
+	s.NoError(err)
+
+	// a store set on the command takes precedence over both
+	commandDir := filepath.Join(s.storeDir, "command")
+	put = blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"local_file":  "out.txt",
+		"remote_file": "out.txt",
+		"store":       map[string]interface{}{"type": "local", "bucket": commandDir},
+	}))
+	s.Require().NoError(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+	_, err = os.Stat(filepath.Join(commandDir, "out.txt"))
+	s.NoError(err)
+
+	// with no store configured anywhere, the command fails
+	s.conf.Distro.BlobStore = nil
+	put = blobPutFactory().(*blobPut)
+	s.Require().NoError(put.ParseParams(map[string]interface{}{
+		"local_file":  "out.txt",
+		"remote_file": "out.txt",
+	}))
+	s.Error(put.Execute(s.ctx, s.comm, s.logger, s.conf))
+}
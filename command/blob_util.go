@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/blob"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// getBucket returns the bucket for the store configured by the command
+// if there is one, and otherwise for the store configured by the
+// project or the distro, in that order.
+func getBucket(store blob.Options, conf *model.TaskConfig) (blob.Bucket, error) {
+	candidates := []blob.Options{store}
+	if conf.Project != nil && conf.Project.BlobStore != nil {
+		candidates = append(candidates, *conf.Project.BlobStore)
+	}
+	if conf.Distro != nil && conf.Distro.BlobStore != nil {
+		candidates = append(candidates, *conf.Distro.BlobStore)
+	}
+
+	opts := blob.Resolve(candidates...)
+	if opts.IsZero() {
+		return nil, errors.New("no blob store is configured for the command, project, or distro")
+	}
+
+	if err := util.ExpandValues(&opts, conf.Expansions); err != nil {
+		return nil, errors.Wrap(err, "problem expanding blob store options")
+	}
+
+	return blob.New(opts)
+}
+
+// withBlobRetry runs op until it succeeds, retrying with the same
+// backoff as the s3 commands.
+func withBlobRetry(ctx context.Context, logger client.LoggerProducer, desc string, op func() error) error {
+	backoffCounter := getS3OpBackoff()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var err error
+	for i := 1; i <= maxS3OpAttempts; i++ {
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("%s canceled", desc)
+		case <-timer.C:
+			if err = op(); err == nil {
+				return nil
+			}
+
+			logger.Execution().Errorf("problem with %s [%d of %d], retrying: %v",
+				desc, i, maxS3OpAttempts, err)
+			timer.Reset(backoffCounter.Duration())
+		}
+	}
+
+	return errors.Wrapf(err, "%s failed after %d attempts", desc, maxS3OpAttempts)
+}
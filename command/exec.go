@@ -56,6 +56,10 @@ type subprocessExec struct {
 	// allows following commands to execute even if this shell command fails.
 	ContinueOnError bool `mapstructure:"continue_on_err"`
 
+	// ResourceLimits override the task's resource limits for the
+	// process and all of its children.
+	ResourceLimits subprocess.ResourceLimits `mapstructure:",squash"`
+
 	base
 }
 
@@ -97,7 +101,7 @@ func (c *subprocessExec) ParseParams(params map[string]interface{}) error {
 		c.Env = make(map[string]string)
 	}
 
-	return nil
+	return errors.Wrap(c.ResourceLimits.Validate(), "invalid resource limits")
 }
 
 func (c *subprocessExec) doExpansions(exp *util.Expansions) error {
@@ -195,6 +199,7 @@ func (c *subprocessExec) Execute(ctx context.Context, comm client.Communicator,
 	defer closer()
 	grip.Info(proc)
 
+	c.ResourceLimits = getResourceLimits(conf, c.ResourceLimits)
 	err = errors.WithStack(c.runCommand(ctx, conf.Task.Id, proc, logger))
 
 	if ctx.Err() != nil {
@@ -213,19 +218,23 @@ func (c *subprocessExec) runCommand(ctx context.Context, taskID string, proc sub
 		logger.Execution().Info("executing command in silent mode")
 	}
 
+	group := setResourceGroup(taskID, c.ResourceLimits, proc, logger)
 	if err := proc.Start(ctx); err != nil {
-		return errors.Wrap(err, "problem starting background process")
+		return finishResourceGroup(group, errors.Wrap(err, "problem starting background process"), logger)
 	}
 	pid := proc.GetPid()
 	subprocess.TrackProcess(taskID, pid, logger.System())
 
 	if c.Background {
+		// the resource group of a background process is removed
+		// when the task's processes are cleaned up.
 		logger.Execution().Infof("started background process with pid %d", pid)
 		return nil
 	}
 	logger.Execution().Debugf("started foreground process with pid %d, waiting for completion", pid)
 
 	err := errors.Wrapf(proc.Wait(), "command with pid %s encountered error", pid)
+	err = finishResourceGroup(group, err, logger)
 
 	if c.ContinueOnError {
 		logger.Execution().Notice(err)
@@ -290,7 +290,8 @@ func (c *gitFetchProject) Execute(ctx context.Context,
 	}
 
 	//Apply patches if necessary
-	if conf.Task.Requester != evergreen.PatchVersionRequester {
+	if conf.Task.Requester != evergreen.PatchVersionRequester &&
+		conf.Task.Requester != evergreen.MergeTestRequester {
 		return nil
 	}
 
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/perfresult"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// perfSend sends structured performance results to the API server,
+// where they are compared against the results of previous versions to
+// detect regressions.
+type perfSend struct {
+	// File is the path to a JSON file containing a list of results,
+	// each with a test_name, metric, value, and optionally a unit,
+	// thread_level and lower_is_better.
+	File string `mapstructure:"file" plugin:"expand"`
+	base
+}
+
+func perfSendFactory() Command   { return &perfSend{} }
+func (c *perfSend) Name() string { return "perf.send" }
+
+func (c *perfSend) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrapf(err, "error decoding '%s' params", c.Name())
+	}
+
+	if c.File == "" {
+		return errors.New("'file' param must not be blank")
+	}
+
+	return nil
+}
+
+func (c *perfSend) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	var err error
+	c.File, err = conf.Expansions.ExpandString(c.File)
+	if err != nil {
+		return errors.Wrap(err, "error expanding file")
+	}
+
+	fileLoc := c.File
+	if !filepath.IsAbs(fileLoc) {
+		fileLoc = filepath.Join(conf.WorkDir, fileLoc)
+	}
+
+	file, err := os.Open(fileLoc)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't open perf results file '%s'", fileLoc)
+	}
+	defer file.Close()
+
+	results := []perfresult.PerfResult{}
+	if err = util.ReadJSONInto(file, &results); err != nil {
+		return errors.Wrapf(err, "couldn't read perf results file '%s'", fileLoc)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for i := range results {
+		catcher.Add(results[i].Validate())
+	}
+	if catcher.HasErrors() {
+		return errors.Wrapf(catcher.Resolve(), "invalid perf results in '%s'", fileLoc)
+	}
+
+	logger.Task().Infof("sending %d perf results", len(results))
+	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+	if err = comm.SendPerfResults(ctx, td, results); err != nil {
+		return errors.Wrap(err, "problem sending perf results")
+	}
+	logger.Task().Info("successfully sent perf results")
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type perfSendSuite struct {
+	cancel func()
+	conf   *model.TaskConfig
+	comm   *client.Mock
+	logger client.LoggerProducer
+	ctx    context.Context
+	cmd    *perfSend
+
+	suite.Suite
+}
+
+func TestPerfSendSuite(t *testing.T) {
+	suite.Run(t, new(perfSendSuite))
+}
+
+func (s *perfSendSuite) SetupTest() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.comm = client.NewMock("http://localhost.com")
+	s.conf = &model.TaskConfig{
+		Expansions: util.NewExpansions(map[string]string{"results": "perf.json"}),
+		Task:       &task.Task{Id: "mock_id", Secret: "mock_secret"},
+		Project:    &model.Project{}}
+	s.logger = s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: s.conf.Task.Id, Secret: s.conf.Task.Secret})
+	s.cmd = perfSendFactory().(*perfSend)
+
+	var err error
+	s.conf.WorkDir, err = ioutil.TempDir("", "perf-send-suite-")
+	s.Require().NoError(err)
+}
+
+func (s *perfSendSuite) TearDownTest() {
+	s.cancel()
+	s.Require().NoError(os.RemoveAll(s.conf.WorkDir))
+}
+
+func (s *perfSendSuite) writeResults(contents string) {
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.conf.WorkDir, "perf.json"), []byte(contents), 0644))
+}
+
+func (s *perfSendSuite) TestParseParamsRequiresFile() {
+	s.Error(s.cmd.ParseParams(map[string]interface{}{}))
+	s.NoError(s.cmd.ParseParams(map[string]interface{}{"file": "${results}"}))
+	s.Equal("${results}", s.cmd.File)
+}
+
+func (s *perfSendSuite) TestSendsResults() {
+	s.writeResults(`[
+		{"test_name": "insert", "metric": "ops_per_sec", "value": 1200.5, "unit": "ops/s", "thread_level": 8},
+		{"test_name": "query", "metric": "latency", "value": 3.2, "unit": "ms", "lower_is_better": true}
+	]`)
+	s.Require().NoError(s.cmd.ParseParams(map[string]interface{}{"file": "${results}"}))
+	s.Require().NoError(s.cmd.Execute(s.ctx, s.comm, s.logger, s.conf))
+
+	results := s.comm.PerfResults[s.conf.Task.Id]
+	s.Require().Len(results, 2)
+	s.Equal("insert", results[0].TestName)
+	s.Equal("ops_per_sec", results[0].Metric)
+	s.Equal(1200.5, results[0].Value)
+	s.Equal(8, results[0].ThreadLevel)
+	s.False(results[0].LowerIsBetter)
+	s.Equal("latency", results[1].Metric)
+	s.True(results[1].LowerIsBetter)
+}
+
+func (s *perfSendSuite) TestInvalidResultsAreNotSent() {
+	s.writeResults(`[{"test_name": "insert", "value": 1}]`)
+	s.Require().NoError(s.cmd.ParseParams(map[string]interface{}{"file": "perf.json"}))
+	s.Error(s.cmd.Execute(s.ctx, s.comm, s.logger, s.conf))
+	s.Empty(s.comm.PerfResults[s.conf.Task.Id])
+}
+
+func (s *perfSendSuite) TestMissingFileErrors() {
+	s.Require().NoError(s.cmd.ParseParams(map[string]interface{}{"file": "missing.json"}))
+	s.Error(s.cmd.Execute(s.ctx, s.comm, s.logger, s.conf))
+}
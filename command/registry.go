@@ -21,6 +21,8 @@ func init() {
 		"attach.results":        attachResultsFactory,
 		"attach.xunit_results":  xunitResultsFactory,
 		"attach.artifacts":      attachArtifactsFactory,
+		"blob.get":              blobGetFactory,
+		"blob.put":              blobPutFactory,
 		"expansions.fetch_vars": fetchVarsFactory,
 		"expansions.update":     updateExpansionsFactory,
 		"generate.tasks":        generateTaskFactory,
@@ -32,6 +34,7 @@ func init() {
 		"json.send":             taskDataSendFactory,
 		"keyval.inc":            keyValIncFactory,
 		"manifest.load":         manifestLoadFactory,
+		"perf.send":             perfSendFactory,
 		"s3.get":                s3GetFactory,
 		"s3.put":                s3PutFactory,
 		"s3Copy.copy":           s3CopyFactory,
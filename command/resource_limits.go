@@ -0,0 +1,63 @@
+package command
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/subprocess"
+)
+
+// getResourceLimits returns the task's resource limits, overridden by
+// the command's own limits.
+func getResourceLimits(conf *model.TaskConfig, limits subprocess.ResourceLimits) subprocess.ResourceLimits {
+	if conf.Project == nil {
+		return limits
+	}
+	if t := conf.Project.FindProjectTask(conf.Task.DisplayName); t != nil {
+		return t.ResourceLimits.Merge(limits)
+	}
+	return limits
+}
+
+// setResourceGroup creates a resource group that enforces the given limits
+// and makes the command join it before it runs, so that neither the
+// command's process nor any of its children escape the limits. It returns
+// nil if there are no limits, or if they cannot be enforced on this host,
+// in which case the command runs unconstrained.
+func setResourceGroup(taskID string, limits subprocess.ResourceLimits, proc subprocess.Command, logger client.LoggerProducer) *subprocess.ResourceGroup {
+	if limits.IsZero() {
+		return nil
+	}
+
+	groupProc, ok := proc.(subprocess.ResourceGroupCommand)
+	if !ok {
+		logger.Execution().Warning("not enforcing resource limits: the command cannot join a resource group")
+		return nil
+	}
+
+	group, err := subprocess.NewResourceGroup(taskID, limits)
+	if err != nil {
+		logger.Execution().Warningf("not enforcing resource limits: %v", err)
+		return nil
+	}
+	groupProc.SetResourceGroup(group)
+
+	logger.Execution().Debugf("enforcing resource limits %+v", limits)
+	return group
+}
+
+// finishResourceGroup removes the resource group once its process has
+// finished, and labels the process's error if the process reached one
+// of the group's limits.
+func finishResourceGroup(group *subprocess.ResourceGroup, err error, logger client.LoggerProducer) error {
+	if group == nil {
+		return err
+	}
+
+	if limit := group.Exceeded(); limit != "" {
+		logger.Task().Errorf("process exceeded resource limit '%s'", limit)
+		err = &subprocess.ResourceLimitError{Limit: limit, Err: err}
+	}
+	logger.System().Warning(group.Close())
+
+	return err
+}
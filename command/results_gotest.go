@@ -101,6 +101,8 @@ func (c *goTestResults) Execute(ctx context.Context,
 	}
 	logger.Task().Info("Finished posting logs to server")
 
+	applyQuarantine(conf, logger, &allResults)
+
 	// ship the parsed results off to the server
 	logger.Task().Info("Sending parsed results to server...")
 
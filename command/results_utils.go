@@ -44,6 +44,7 @@ func sendJSONResults(ctx context.Context, conf *model.TaskConfig,
 			results.Results[i].LogRaw = ""
 		}
 	}
+	applyQuarantine(conf, logger, results)
 	logger.Execution().Info("attaching test results")
 
 	err := comm.SendTestResults(ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}, results)
@@ -56,6 +57,17 @@ func sendJSONResults(ctx context.Context, conf *model.TaskConfig,
 	return nil
 }
 
+// applyQuarantine records the failures of tests that the project has
+// quarantined as silent failures, so that they do not fail the task.
+func applyQuarantine(conf *model.TaskConfig, logger client.LoggerProducer, results *task.LocalTestResults) {
+	if conf.ProjectRef == nil {
+		return
+	}
+	if n := conf.ProjectRef.ApplyQuarantine(conf.Task.BuildVariant, results.Results); n > 0 {
+		logger.Task().Infof("Recording %d failures of quarantined tests as silent failures", n)
+	}
+}
+
 // sendJSONLogs is responsible for sending the specified logs
 // to the API Server. If successful, it returns a log ID that can be used
 // to refer to the log object in test results.
@@ -59,6 +59,10 @@ type shellExec struct {
 	// allows following commands to execute even if this shell command fails.
 	ContinueOnError bool `mapstructure:"continue_on_err"`
 
+	// ResourceLimits override the task's resource limits for the
+	// shell and all of its children.
+	ResourceLimits subprocess.ResourceLimits `mapstructure:",squash"`
+
 	base
 }
 
@@ -76,7 +80,7 @@ func (c *shellExec) ParseParams(params map[string]interface{}) error {
 		return errors.New("cannot ignore standard out, and redirect standard error to it")
 	}
 
-	return nil
+	return errors.Wrap(c.ResourceLimits.Validate(), "invalid resource limits")
 }
 
 // Execute starts the shell with its given parameters.
@@ -141,9 +145,10 @@ func (c *shellExec) Execute(ctx context.Context,
 			c.Shell, c.Script)
 	}
 
+	group := setResourceGroup(conf.Task.Id, getResourceLimits(conf, c.ResourceLimits), localCmd, logger)
 	if err = localCmd.Start(ctx); err != nil {
 		logger.System().Debugf("error spawning shell process: %v", err)
-		return err
+		return finishResourceGroup(group, err, logger)
 	}
 
 	pid := localCmd.GetPid()
@@ -156,11 +161,14 @@ func (c *shellExec) Execute(ctx context.Context,
 	subprocess.TrackProcess(conf.Task.Id, pid, logger.System())
 
 	if c.Background {
+		// the resource group of a background process is removed
+		// when the task's processes are cleaned up.
 		logger.Execution().Debugf("running command in the background [pid=%d]", pid)
 		return nil
 	}
 
 	err = errors.Wrapf(localCmd.Wait(), "command [pid=%d] encountered problem", pid)
+	err = finishResourceGroup(group, err, logger)
 	if ctx.Err() != nil {
 		logger.System().Debug("dumping running processes before canceling work")
 		logger.System().Debug(message.CollectAllProcesses())
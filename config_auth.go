@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
@@ -39,11 +40,48 @@ type GithubAuthConfig struct {
 	Organization string   `bson:"organization" json:"organization" yaml:"organization"`
 }
 
+// OIDCAuthConfig holds settings for authenticating users with an OpenID
+// Connect provider, such as Okta or Keycloak. The provider's endpoints are
+// discovered from the Issuer. Users are identified by the claims of their ID
+// tokens; UsernameClaim, EmailClaim and DisplayNameClaim name those claims.
+type OIDCAuthConfig struct {
+	Issuer           string   `bson:"issuer" json:"issuer" yaml:"issuer"`
+	ClientId         string   `bson:"client_id" json:"client_id" yaml:"client_id"`
+	ClientSecret     string   `bson:"client_secret" json:"client_secret" yaml:"client_secret"`
+	Scopes           []string `bson:"scopes" json:"scopes" yaml:"scopes"`
+	UsernameClaim    string   `bson:"username_claim" json:"username_claim" yaml:"username_claim"`
+	EmailClaim       string   `bson:"email_claim" json:"email_claim" yaml:"email_claim"`
+	DisplayNameClaim string   `bson:"display_name_claim" json:"display_name_claim" yaml:"display_name_claim"`
+}
+
+// LDAPConfig holds settings for authenticating users against an LDAP
+// directory. Users are found under UserSearchBase by UserAttribute and
+// authenticated by binding as them; BindDN and BindPassword are used for
+// searches. Groups are found under GroupSearchBase by GroupMemberAttribute and
+// named by GroupNameAttribute. Sessions are signed with SessionSecret.
+type LDAPConfig struct {
+	URL                  string `bson:"url" json:"url" yaml:"url"`
+	BindDN               string `bson:"bind_dn" json:"bind_dn" yaml:"bind_dn"`
+	BindPassword         string `bson:"bind_password" json:"bind_password" yaml:"bind_password"`
+	UserSearchBase       string `bson:"user_search_base" json:"user_search_base" yaml:"user_search_base"`
+	UserAttribute        string `bson:"user_attribute" json:"user_attribute" yaml:"user_attribute"`
+	EmailAttribute       string `bson:"email_attribute" json:"email_attribute" yaml:"email_attribute"`
+	DisplayNameAttribute string `bson:"display_name_attribute" json:"display_name_attribute" yaml:"display_name_attribute"`
+	GroupSearchBase      string `bson:"group_search_base" json:"group_search_base" yaml:"group_search_base"`
+	GroupMemberAttribute string `bson:"group_member_attribute" json:"group_member_attribute" yaml:"group_member_attribute"`
+	GroupNameAttribute   string `bson:"group_name_attribute" json:"group_name_attribute" yaml:"group_name_attribute"`
+	GroupCacheMinutes    int    `bson:"group_cache_minutes" json:"group_cache_minutes" yaml:"group_cache_minutes"`
+	SessionSecret        string `bson:"session_secret" json:"session_secret" yaml:"session_secret"`
+	SessionHours         int    `bson:"session_hours" json:"session_hours" yaml:"session_hours"`
+}
+
 // AuthConfig has a pointer to either a CrowConfig or a NaiveAuthConfig.
 type AuthConfig struct {
 	Crowd  *CrowdConfig      `bson:"crowd" json:"crowd" yaml:"crowd"`
 	Naive  *NaiveAuthConfig  `bson:"naive" json:"naive" yaml:"naive"`
 	Github *GithubAuthConfig `bson:"github" json:"github" yaml:"github"`
+	OIDC   *OIDCAuthConfig   `bson:"oidc" json:"oidc" yaml:"oidc"`
+	LDAP   *LDAPConfig       `bson:"ldap" json:"ldap" yaml:"ldap"`
 }
 
 func (c *AuthConfig) SectionId() string { return "auth" }
@@ -63,6 +101,8 @@ func (c *AuthConfig) Set() error {
 			"crowd":  c.Crowd,
 			"naive":  c.Naive,
 			"github": c.Github,
+			"oidc":   c.OIDC,
+			"ldap":   c.LDAP,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
@@ -70,7 +110,7 @@ func (c *AuthConfig) Set() error {
 
 func (c *AuthConfig) ValidateAndDefault() error {
 	catcher := grip.NewSimpleCatcher()
-	if c.Crowd == nil && c.Naive == nil && c.Github == nil {
+	if c.Crowd == nil && c.Naive == nil && c.Github == nil && c.OIDC == nil && c.LDAP == nil {
 		catcher.Add(errors.New("You must specify one form of authentication"))
 	}
 	if c.Naive != nil {
@@ -87,5 +127,60 @@ func (c *AuthConfig) ValidateAndDefault() error {
 			catcher.Add(errors.New("Must specify either a set of users or an organization for Github Authentication"))
 		}
 	}
+	if c.OIDC != nil {
+		if c.OIDC.Issuer == "" {
+			catcher.Add(errors.New("Must specify an issuer for OpenID Connect Authentication"))
+		}
+		if c.OIDC.ClientId == "" {
+			catcher.Add(errors.New("Must specify a client id for OpenID Connect Authentication"))
+		}
+		if len(c.OIDC.Scopes) == 0 {
+			c.OIDC.Scopes = []string{"openid", "profile", "email"}
+		}
+		if !util.StringSliceContains(c.OIDC.Scopes, "openid") {
+			c.OIDC.Scopes = append([]string{"openid"}, c.OIDC.Scopes...)
+		}
+		if c.OIDC.UsernameClaim == "" {
+			c.OIDC.UsernameClaim = "preferred_username"
+		}
+		if c.OIDC.EmailClaim == "" {
+			c.OIDC.EmailClaim = "email"
+		}
+		if c.OIDC.DisplayNameClaim == "" {
+			c.OIDC.DisplayNameClaim = "name"
+		}
+	}
+	if c.LDAP != nil {
+		if c.LDAP.URL == "" {
+			catcher.Add(errors.New("Must specify a URL for LDAP Authentication"))
+		}
+		if c.LDAP.UserSearchBase == "" {
+			catcher.Add(errors.New("Must specify a user search base for LDAP Authentication"))
+		}
+		if c.LDAP.SessionSecret == "" {
+			catcher.Add(errors.New("Must specify a session secret for LDAP Authentication"))
+		}
+		if c.LDAP.UserAttribute == "" {
+			c.LDAP.UserAttribute = "uid"
+		}
+		if c.LDAP.EmailAttribute == "" {
+			c.LDAP.EmailAttribute = "mail"
+		}
+		if c.LDAP.DisplayNameAttribute == "" {
+			c.LDAP.DisplayNameAttribute = "displayName"
+		}
+		if c.LDAP.GroupMemberAttribute == "" {
+			c.LDAP.GroupMemberAttribute = "member"
+		}
+		if c.LDAP.GroupNameAttribute == "" {
+			c.LDAP.GroupNameAttribute = "cn"
+		}
+		if c.LDAP.GroupCacheMinutes <= 0 {
+			c.LDAP.GroupCacheMinutes = 10
+		}
+		if c.LDAP.SessionHours <= 0 {
+			c.LDAP.SessionHours = 24 * 7
+		}
+	}
 	return catcher.Resolve()
 }
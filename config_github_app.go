@@ -0,0 +1,84 @@
+package evergreen
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GithubAppConfig stores the credentials of the GitHub App that Evergreen
+// uses to report check runs on pull requests. The private key is the PEM
+// encoded RSA key that GitHub generates for the app.
+//
+// The app's webhook must deliver check_run events to the same route as the
+// repository webhooks, /rest/v2/hooks/github, so that users can re-run
+// builds from their check runs. Those events are validated with the
+// webhook secret, or with the repository webhooks' secret if it is empty.
+type GithubAppConfig struct {
+	AppID         int64  `yaml:"app_id" bson:"app_id" json:"app_id"`
+	PrivateKey    string `yaml:"private_key" bson:"private_key" json:"private_key"`
+	WebhookSecret string `yaml:"webhook_secret" bson:"webhook_secret" json:"webhook_secret"`
+}
+
+func (c *GithubAppConfig) SectionId() string { return "github_app" }
+
+func (c *GithubAppConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = GithubAppConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *GithubAppConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			"app_id":         c.AppID,
+			"private_key":    c.PrivateKey,
+			"webhook_secret": c.WebhookSecret,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *GithubAppConfig) ValidateAndDefault() error {
+	if c.AppID == 0 && c.PrivateKey == "" {
+		return nil
+	}
+	if c.AppID <= 0 {
+		return errors.New("github app id must be positive")
+	}
+	_, err := c.RSAPrivateKey()
+	return err
+}
+
+// Enabled returns true when the GitHub App is configured.
+func (c *GithubAppConfig) Enabled() bool {
+	return c.AppID > 0 && c.PrivateKey != ""
+}
+
+// RSAPrivateKey parses the app's PEM encoded private key.
+func (c *GithubAppConfig) RSAPrivateKey() (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(c.PrivateKey))
+	if block == nil {
+		return nil, errors.New("github app private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem parsing github app private key")
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("github app private key is not an RSA key")
+	}
+	return key, nil
+}
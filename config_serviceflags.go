@@ -20,6 +20,7 @@ type ServiceFlags struct {
 	CLIUpdatesDisabled           bool `bson:"cli_updates_disabled" json:"cli_updates_disabled"`
 	GithubStatusAPIDisabled      bool `bson:"github_status_api_disabled" json:"github_status_api_disabled"`
 	BackgroundStatsDisabled      bool `bson:"background_stats_disabled" json:"background_stats_disabled"`
+	CommitQueueDisabled          bool `bson:"commit_queue_disabled" json:"commit_queue_disabled"`
 }
 
 func (c *ServiceFlags) SectionId() string { return "service_flags" }
@@ -48,6 +49,7 @@ func (c *ServiceFlags) Set() error {
 			cliUpdatesDisabledKey:           c.CLIUpdatesDisabled,
 			githubStatusAPIDisabledKey:      c.GithubStatusAPIDisabled,
 			backgroundStatsDisabledKey:      c.BackgroundStatsDisabled,
+			commitQueueDisabledKey:          c.CommitQueueDisabled,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
@@ -12,6 +12,9 @@ type SlackConfig struct {
 	Options *send.SlackOptions `bson:"options" json:"options" yaml:"options"`
 	Token   string             `bson:"token" json:"token" yaml:"token"`
 	Level   string             `bson:"level" json:"level" yaml:"level"`
+	// SigningSecret verifies the requests that Slack sends when users
+	// click the buttons of alert messages.
+	SigningSecret string `bson:"signing_secret" json:"signing_secret" yaml:"signing_secret"`
 }
 
 func (c *SlackConfig) SectionId() string { return "slack" }
@@ -28,9 +31,10 @@ func (c *SlackConfig) Get() error {
 func (c *SlackConfig) Set() error {
 	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
 		"$set": bson.M{
-			"options": c.Options,
-			"token":   c.Token,
-			"level":   c.Level,
+			"options":        c.Options,
+			"token":          c.Token,
+			"level":          c.Level,
+			"signing_secret": c.SigningSecret,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
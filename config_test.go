@@ -1,6 +1,10 @@
 package evergreen
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"path/filepath"
 	"testing"
 
@@ -84,6 +88,34 @@ func TestGetGithubSettings(t *testing.T) {
 	})
 }
 
+func TestGithubAppConfigValidateAndDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(err)
+	pkcs1 := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	config := GithubAppConfig{}
+	assert.NoError(config.ValidateAndDefault())
+	assert.False(config.Enabled())
+
+	config = GithubAppConfig{AppID: 1234, PrivateKey: pkcs1}
+	assert.NoError(config.ValidateAndDefault())
+	assert.True(config.Enabled())
+	parsed, err := config.RSAPrivateKey()
+	assert.NoError(err)
+	assert.Equal(key.D, parsed.D)
+
+	config = GithubAppConfig{PrivateKey: pkcs1}
+	assert.Error(config.ValidateAndDefault())
+
+	config = GithubAppConfig{AppID: 1234}
+	assert.Error(config.ValidateAndDefault())
+
+	config = GithubAppConfig{AppID: 1234, PrivateKey: "not a key"}
+	assert.Error(config.ValidateAndDefault())
+}
+
 type AdminSuite struct {
 	suite.Suite
 }
@@ -253,6 +285,21 @@ func (s *AdminSuite) TestAuthConfig() {
 			Users:        []string{"ghuser"},
 			Organization: "ghorg",
 		},
+		OIDC: &OIDCAuthConfig{
+			Issuer:        "https://oidc.example.com",
+			ClientId:      "oidcclient",
+			ClientSecret:  "oidcsecret",
+			Scopes:        []string{"openid", "email"},
+			UsernameClaim: "preferred_username",
+		},
+		LDAP: &LDAPConfig{
+			URL:               "ldap://ldap.example.com",
+			BindDN:            "cn=evergreen,dc=example,dc=com",
+			UserSearchBase:    "ou=people,dc=example,dc=com",
+			GroupSearchBase:   "ou=groups,dc=example,dc=com",
+			GroupCacheMinutes: 10,
+			SessionSecret:     "secret",
+		},
 	}
 
 	err := config.Set()
@@ -381,8 +428,9 @@ func (s *AdminSuite) TestSlackConfig() {
 			Fields:    true,
 			FieldsSet: map[string]bool{},
 		},
-		Token: "token",
-		Level: "info",
+		Token:         "token",
+		Level:         "info",
+		SigningSecret: "secret",
 	}
 
 	err := config.Set()
@@ -393,6 +441,21 @@ func (s *AdminSuite) TestSlackConfig() {
 	s.Equal(config, settings.Slack)
 }
 
+func (s *AdminSuite) TestGithubAppConfig() {
+	config := GithubAppConfig{
+		AppID:         1234,
+		PrivateKey:    "key",
+		WebhookSecret: "secret",
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.GithubApp)
+}
+
 func (s *AdminSuite) TestUiConfig() {
 	config := UIConfig{
 		Url:            "url",
@@ -92,6 +92,7 @@ const (
 	DefaultTaskActivator   = ""
 	StepbackTaskActivator  = "stepback"
 	APIServerTaskActivator = "apiserver"
+	AutoRestartActivator   = "auto-restart"
 
 	RestRoutePrefix = "rest"
 	APIRoutePrefix  = "api"
@@ -102,6 +103,9 @@ const (
 
 	// Key used to store user information in request contexts
 	RequestUser requestUserContextKey = 0
+	// Key used to store the API token that authenticated a request, if
+	// one did, in request contexts
+	RequestAPIToken requestUserContextKey = 1
 
 	SetupScriptName    = "setup.sh"
 	TeardownScriptName = "teardown.sh"
@@ -170,6 +174,9 @@ const (
 	PatchVersionRequester       = "patch_request"
 	GithubPRRequester           = "github_pull_request"
 	RepotrackerVersionRequester = "gitter_request"
+	MergeTestRequester          = "merge_test"
+	PeriodicBuildRequester      = "periodic_build"
+	TriggerRequester            = "trigger_request"
 )
 
 const (
@@ -227,5 +234,6 @@ func IsSystemActivator(caller string) bool {
 }
 
 func IsPatchRequester(requester string) bool {
-	return requester == PatchVersionRequester || requester == GithubPRRequester
+	return requester == PatchVersionRequester || requester == GithubPRRequester ||
+		requester == MergeTestRequester
 }
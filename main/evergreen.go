@@ -49,12 +49,14 @@ func buildApp() *cli.App {
 
 		// Top-level commands.
 		operations.Keys(),
+		operations.APITokens(),
 		operations.Fetch(),
 		operations.Evaluate(),
 		operations.Validate(),
 		operations.List(),
 		operations.TestHistory(),
 		operations.LastGreen(),
+		operations.FlakyTests(),
 
 		// Patch creation and management commands (top-level)
 		operations.Patch(),
@@ -64,6 +66,7 @@ func buildApp() *cli.App {
 		operations.PatchRemoveModule(),
 		operations.PatchFinalize(),
 		operations.PatchCancel(),
+		operations.PatchStack(),
 	}
 
 	userHome, err := homedir.Dir()
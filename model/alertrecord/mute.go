@@ -0,0 +1,73 @@
+package alertrecord
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	MutesCollection = "alert_mutes"
+
+	// DefaultMuteDuration is how long muting a task's alerts lasts.
+	DefaultMuteDuration = 24 * time.Hour
+)
+
+// Mute silences the alerts about a task of a project's build variant until
+// it expires.
+type Mute struct {
+	Id       bson.ObjectId `bson:"_id,omitempty"`
+	Project  string        `bson:"project"`
+	Variant  string        `bson:"variant"`
+	TaskName string        `bson:"task_name"`
+	MutedBy  string        `bson:"muted_by"`
+	Until    time.Time     `bson:"until"`
+}
+
+var (
+	MuteProjectKey  = bsonutil.MustHaveTag(Mute{}, "Project")
+	MuteVariantKey  = bsonutil.MustHaveTag(Mute{}, "Variant")
+	MuteTaskNameKey = bsonutil.MustHaveTag(Mute{}, "TaskName")
+	MuteMutedByKey  = bsonutil.MustHaveTag(Mute{}, "MutedBy")
+	MuteUntilKey    = bsonutil.MustHaveTag(Mute{}, "Until")
+)
+
+// MuteTask silences the alerts about the task until the given time,
+// replacing any earlier mute of the task.
+func MuteTask(project, variant, taskName, mutedBy string, until time.Time) error {
+	_, err := db.Upsert(MutesCollection,
+		bson.M{
+			MuteProjectKey:  project,
+			MuteVariantKey:  variant,
+			MuteTaskNameKey: taskName,
+		},
+		bson.M{"$set": bson.M{
+			MuteMutedByKey: mutedBy,
+			MuteUntilKey:   until,
+		}},
+	)
+	return errors.Wrapf(err, "problem muting alerts for task '%s' on '%s' in project '%s'", taskName, variant, project)
+}
+
+// FindActiveMute returns the mute that silences the alerts about the task
+// at the given time, or nil if the task's alerts are not muted.
+func FindActiveMute(project, variant, taskName string, now time.Time) (*Mute, error) {
+	mute := &Mute{}
+	err := db.FindOneQ(MutesCollection, db.Query(bson.M{
+		MuteProjectKey:  project,
+		MuteVariantKey:  variant,
+		MuteTaskNameKey: taskName,
+		MuteUntilKey:    bson.M{"$gt": now},
+	}), mute)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding mute for task '%s' on '%s' in project '%s'", taskName, variant, project)
+	}
+	return mute, nil
+}
@@ -0,0 +1,225 @@
+package commitqueue
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Collection is the name of the commit queue collection in the database.
+	Collection = "commit_queue"
+
+	// EnqueueComment is the pull request comment that adds the pull
+	// request to its project's commit queue.
+	EnqueueComment = "evergreen merge"
+)
+
+// CommitQueue is the ordered list of pull requests that are waiting to
+// be tested and merged into a project's branch. Each item is tested in a
+// patch against the head of the branch with the changes of all items
+// ahead of it applied, and is merged once it and every item ahead of it
+// have passed.
+type CommitQueue struct {
+	ProjectID string            `bson:"_id" json:"project_id"`
+	Queue     []CommitQueueItem `bson:"queue" json:"queue"`
+}
+
+// CommitQueueItem is a pull request in a commit queue.
+type CommitQueueItem struct {
+	// Issue is the number of the pull request.
+	Issue string `bson:"issue" json:"issue"`
+
+	// Author is the Evergreen user or the GitHub login that enqueued
+	// the pull request.
+	Author      string    `bson:"author" json:"author"`
+	EnqueueTime time.Time `bson:"enqueue_time" json:"enqueue_time"`
+
+	// Version is the id of the patch that is testing the item, and is
+	// empty until the patch has been created. HeadHash is the head of
+	// the pull request that the patch tests, so that only tested
+	// changes are merged.
+	Version  string `bson:"version,omitempty" json:"version,omitempty"`
+	HeadHash string `bson:"head_hash,omitempty" json:"head_hash,omitempty"`
+}
+
+var (
+	// BSON fields for the commit queue structs
+	IDKey            = bsonutil.MustHaveTag(CommitQueue{}, "ProjectID")
+	QueueKey         = bsonutil.MustHaveTag(CommitQueue{}, "Queue")
+	IssueKey         = bsonutil.MustHaveTag(CommitQueueItem{}, "Issue")
+	VersionKey       = bsonutil.MustHaveTag(CommitQueueItem{}, "Version")
+	HeadHashKey      = bsonutil.MustHaveTag(CommitQueueItem{}, "HeadHash")
+	queueIssueKey    = bsonutil.GetDottedKeyName(QueueKey, IssueKey)
+	queueVersionKey  = bsonutil.GetDottedKeyName(QueueKey, "$", VersionKey)
+	queueHeadHashKey = bsonutil.GetDottedKeyName(QueueKey, "$", HeadHashKey)
+)
+
+// PRNumber returns the number of the item's pull request.
+func (i *CommitQueueItem) PRNumber() (int, error) {
+	num, err := strconv.Atoi(i.Issue)
+	if err != nil || num <= 0 {
+		return 0, errors.Errorf("'%s' is not a valid pull request number", i.Issue)
+	}
+	return num, nil
+}
+
+// FindOneId returns the commit queue of the project, or nil if the
+// project has no queue.
+func FindOneId(projectID string) (*CommitQueue, error) {
+	cq := &CommitQueue{}
+	err := db.FindOneQ(Collection, db.Query(bson.M{IDKey: projectID}), cq)
+	if db.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding commit queue for project '%s'", projectID)
+	}
+	return cq, nil
+}
+
+// FindAll returns every commit queue with items in it.
+func FindAll() ([]CommitQueue, error) {
+	queues := []CommitQueue{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{QueueKey: bson.M{"$ne": []CommitQueueItem{}}}), &queues)
+	return queues, errors.Wrap(err, "problem finding commit queues")
+}
+
+// FindItem returns the index of the item with the issue in the queue, or
+// -1 if the issue is not queued.
+func (q *CommitQueue) FindItem(issue string) int {
+	for i := range q.Queue {
+		if q.Queue[i].Issue == issue {
+			return i
+		}
+	}
+	return -1
+}
+
+// Enqueue adds the item to the end of the queue of the project, creating
+// the queue if it does not exist yet. It returns the position of the
+// item in the queue.
+func Enqueue(projectID string, item CommitQueueItem) (int, error) {
+	if _, err := item.PRNumber(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if item.EnqueueTime.IsZero() {
+		item.EnqueueTime = time.Now()
+	}
+	item.Version = ""
+	item.HeadHash = ""
+
+	_, err := db.Upsert(Collection,
+		bson.M{IDKey: projectID},
+		bson.M{"$setOnInsert": bson.M{QueueKey: []CommitQueueItem{}}},
+	)
+	if err != nil {
+		return 0, errors.Wrapf(err, "problem creating commit queue for project '%s'", projectID)
+	}
+
+	err = db.Update(Collection,
+		bson.M{
+			IDKey:         projectID,
+			queueIssueKey: bson.M{"$ne": item.Issue},
+		},
+		bson.M{"$push": bson.M{QueueKey: item}},
+	)
+	if db.ResultsNotFound(err) {
+		return 0, errors.Errorf("item '%s' is already in the commit queue for project '%s'", item.Issue, projectID)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "problem adding item '%s' to the commit queue", item.Issue)
+	}
+
+	cq, err := FindOneId(projectID)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if cq == nil {
+		return 0, errors.Errorf("commit queue for project '%s' not found", projectID)
+	}
+	return cq.FindItem(item.Issue), nil
+}
+
+// Remove removes the item with the issue from the queue. It returns
+// false if the issue was not queued.
+func (q *CommitQueue) Remove(issue string) (bool, error) {
+	i := q.FindItem(issue)
+	if i < 0 {
+		return false, nil
+	}
+
+	err := db.Update(Collection,
+		bson.M{IDKey: q.ProjectID},
+		bson.M{"$pull": bson.M{QueueKey: bson.M{IssueKey: issue}}},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "problem removing item '%s' from the commit queue", issue)
+	}
+
+	q.Queue = append(q.Queue[:i], q.Queue[i+1:]...)
+	return true, nil
+}
+
+// ClaimItem records the id of the patch that will test the item with the
+// issue, if no patch is testing it yet. It returns false if the item was
+// removed or another patch claimed it first, so that only one patch is
+// created for the item when several jobs advance the queue at once.
+func (q *CommitQueue) ClaimItem(issue, version string) (bool, error) {
+	i := q.FindItem(issue)
+	if i < 0 {
+		return false, errors.Errorf("item '%s' is not in the commit queue", issue)
+	}
+
+	err := db.Update(Collection,
+		bson.M{
+			IDKey: q.ProjectID,
+			QueueKey: bson.M{"$elemMatch": bson.M{
+				IssueKey:   issue,
+				VersionKey: bson.M{"$in": []interface{}{"", nil}},
+			}},
+		},
+		bson.M{"$set": bson.M{queueVersionKey: version}},
+	)
+	if db.ResultsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "problem claiming item '%s'", issue)
+	}
+
+	q.Queue[i].Version = version
+	return true, nil
+}
+
+// SetVersion records the id of the patch that is testing the item with
+// the issue, and the head of the pull request that it tests. An empty
+// version marks the item as not being tested.
+func (q *CommitQueue) SetVersion(issue, version, headHash string) error {
+	i := q.FindItem(issue)
+	if i < 0 {
+		return errors.Errorf("item '%s' is not in the commit queue", issue)
+	}
+
+	err := db.Update(Collection,
+		bson.M{
+			IDKey:         q.ProjectID,
+			queueIssueKey: issue,
+		},
+		bson.M{"$set": bson.M{
+			queueVersionKey:  version,
+			queueHeadHashKey: headHash,
+		}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "problem setting version of item '%s'", issue)
+	}
+
+	q.Queue[i].Version = version
+	q.Queue[i].HeadHash = headHash
+	return nil
+}
@@ -0,0 +1,73 @@
+package commitqueue
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	item := CommitQueueItem{Issue: "1234"}
+	num, err := item.PRNumber()
+	assert.NoError(err)
+	assert.Equal(1234, num)
+
+	for _, issue := range []string{"", "abc", "0", "-2"} {
+		item.Issue = issue
+		_, err = item.PRNumber()
+		assert.Error(err, issue)
+	}
+}
+
+func TestFindItem(t *testing.T) {
+	assert := assert.New(t)
+
+	cq := CommitQueue{
+		ProjectID: "mci",
+		Queue: []CommitQueueItem{
+			{Issue: "1"},
+			{Issue: "2"},
+		},
+	}
+	assert.Equal(0, cq.FindItem("1"))
+	assert.Equal(1, cq.FindItem("2"))
+	assert.Equal(-1, cq.FindItem("3"))
+}
+
+func TestClaimItem(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.Clear(Collection))
+
+	_, err := Enqueue("mci", CommitQueueItem{Issue: "1"})
+	require.NoError(err)
+	first, err := FindOneId("mci")
+	require.NoError(err)
+	second, err := FindOneId("mci")
+	require.NoError(err)
+
+	// only the first of two jobs that saw the unclaimed item claims it
+	claimed, err := first.ClaimItem("1", "patch1")
+	require.NoError(err)
+	assert.True(claimed)
+	assert.Equal("patch1", first.Queue[0].Version)
+	claimed, err = second.ClaimItem("1", "patch2")
+	require.NoError(err)
+	assert.False(claimed)
+
+	cq, err := FindOneId("mci")
+	require.NoError(err)
+	assert.Equal("patch1", cq.Queue[0].Version)
+
+	// releasing the item lets it be claimed again
+	require.NoError(cq.SetVersion("1", "", ""))
+	claimed, err = cq.ClaimItem("1", "patch3")
+	require.NoError(err)
+	assert.True(claimed)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/blob"
 	"github.com/evergreen-ci/evergreen/util"
 )
 
@@ -30,6 +31,11 @@ type Distro struct {
 
 	SpawnAllowed bool        `bson:"spawn_allowed" json:"spawn_allowed,omitempty" mapstructure:"spawn_allowed,omitempty"`
 	Expansions   []Expansion `bson:"expansions,omitempty" json:"expansions,omitempty" mapstructure:"expansions,omitempty"`
+
+	// BlobStore is the store used by the blob.put and blob.get
+	// commands for tasks that run on the distro, unless the project
+	// configures its own.
+	BlobStore *blob.Options `bson:"blob_store,omitempty" json:"blob_store,omitempty" mapstructure:"blob_store,omitempty"`
 }
 
 type ValidateFormat string
@@ -0,0 +1,118 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// resource type
+	ResourceTypeAudit = "AUDIT"
+
+	// event types
+	EventAuditRequest = "MUTATING_REQUEST"
+)
+
+// AuditEventData records a request by a user that changed something, through
+// either the UI or the REST API. Before and After hold the state of the
+// resource around the change, if the request's handler recorded it.
+type AuditEventData struct {
+	User         string      `bson:"user" json:"user"`
+	APIToken     string      `bson:"api_token,omitempty" json:"api_token,omitempty"`
+	Method       string      `bson:"method" json:"method"`
+	Path         string      `bson:"path" json:"path"`
+	Status       int         `bson:"status" json:"status"`
+	ResourceType string      `bson:"resource_type,omitempty" json:"resource_type,omitempty"`
+	Before       interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After        interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+var (
+	auditUserKey         = bsonutil.MustHaveTag(AuditEventData{}, "User")
+	auditMethodKey       = bsonutil.MustHaveTag(AuditEventData{}, "Method")
+	auditResourceTypeKey = bsonutil.MustHaveTag(AuditEventData{}, "ResourceType")
+)
+
+// LogAuditEvent records a request that changed the resource with the id.
+func LogAuditEvent(resourceId string, data AuditEventData) error {
+	event := EventLogEntry{
+		ResourceId:   resourceId,
+		Timestamp:    time.Now(),
+		EventType:    EventAuditRequest,
+		Data:         data,
+		ResourceType: ResourceTypeAudit,
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(&event); err != nil {
+		return errors.Wrap(err, "Error logging audit event")
+	}
+	return nil
+}
+
+type auditContextKey int
+
+const auditDataKey auditContextKey = 0
+
+// WithAuditEvent returns a context in which handlers can record the changes
+// they make to the audit event with AuditChanges.
+func WithAuditEvent(ctx context.Context, data *AuditEventData) context.Context {
+	return context.WithValue(ctx, auditDataKey, data)
+}
+
+// AuditChanges records the state of the resource changed by the request
+// before and after the change. It does nothing if the request is not being
+// audited. The states must not be modified afterwards.
+func AuditChanges(ctx context.Context, before, after interface{}) {
+	data, ok := ctx.Value(auditDataKey).(*AuditEventData)
+	if !ok || data == nil {
+		return
+	}
+	data.Before = before
+	data.After = after
+}
+
+// AuditFilter selects audit events. Empty fields match every event.
+type AuditFilter struct {
+	User         string
+	Method       string
+	ResourceType string
+	ResourceId   string
+	After        time.Time
+	Before       time.Time
+	Limit        int
+}
+
+// AuditEvents returns a query for the audit events matching the filter, most
+// recent first.
+func AuditEvents(f AuditFilter) db.Q {
+	filter := resourceTypeKeyIs(ResourceTypeAudit)
+	if f.User != "" {
+		filter[bsonutil.GetDottedKeyName(DataKey, auditUserKey)] = f.User
+	}
+	if f.Method != "" {
+		filter[bsonutil.GetDottedKeyName(DataKey, auditMethodKey)] = f.Method
+	}
+	if f.ResourceType != "" {
+		filter[bsonutil.GetDottedKeyName(DataKey, auditResourceTypeKey)] = f.ResourceType
+	}
+	if f.ResourceId != "" {
+		filter[ResourceIdKey] = f.ResourceId
+	}
+	ts := bson.M{}
+	if !f.After.IsZero() {
+		ts["$gte"] = f.After
+	}
+	if !f.Before.IsZero() {
+		ts["$lt"] = f.Before
+	}
+	if len(ts) > 0 {
+		filter[TimestampKey] = ts
+	}
+
+	return db.Query(filter).Sort([]string{"-" + TimestampKey}).Limit(f.Limit)
+}
@@ -0,0 +1,58 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	// changes to requests that aren't audited are ignored
+	AuditChanges(context.Background(), "before", "after")
+
+	data := &AuditEventData{User: "octocat"}
+	ctx := WithAuditEvent(context.Background(), data)
+	AuditChanges(ctx, map[string]int{"priority": 0}, map[string]int{"priority": 10})
+	assert.Equal(map[string]int{"priority": 0}, data.Before)
+	assert.Equal(map[string]int{"priority": 10}, data.After)
+}
+
+func TestAuditEvents(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+	require.NoError(db.Clear(AllLogCollection))
+
+	start := time.Now().Add(-time.Second)
+	require.NoError(LogAuditEvent("mci", AuditEventData{User: "octocat", Method: "POST", Path: "/project/mci", Status: 200, ResourceType: "project",
+		Before: map[string]int{"batch_time": 0}, After: map[string]int{"batch_time": 10}}))
+	require.NoError(LogAuditEvent("t1", AuditEventData{User: "hubot", Method: "PATCH", Path: "/rest/v2/tasks/t1", Status: 200, ResourceType: "task"}))
+	LogDistroAdded("d1", "octocat", nil)
+
+	events, err := Find(AllLogCollection, AuditEvents(AuditFilter{}))
+	require.NoError(err)
+	assert.Len(events, 2)
+
+	events, err = Find(AllLogCollection, AuditEvents(AuditFilter{User: "octocat", After: start}))
+	require.NoError(err)
+	require.Len(events, 1)
+	assert.Equal("mci", events[0].ResourceId)
+	data, ok := events[0].Data.(*AuditEventData)
+	require.True(ok)
+	assert.Equal("project", data.ResourceType)
+	assert.NotNil(data.After)
+
+	events, err = Find(AllLogCollection, AuditEvents(AuditFilter{ResourceType: "task", ResourceId: "t1"}))
+	require.NoError(err)
+	assert.Len(events, 1)
+
+	events, err = Find(AllLogCollection, AuditEvents(AuditFilter{Before: start}))
+	require.NoError(err)
+	assert.Len(events, 0)
+}
@@ -0,0 +1,38 @@
+package event
+
+import (
+	"time"
+
+	"github.com/mongodb/grip"
+)
+
+const (
+	// resource type
+	ResourceTypeBuild = "BUILD"
+
+	// event types
+	BuildStateChange = "STATE_CHANGE"
+)
+
+// BuildEventData implements EventData.
+type BuildEventData struct {
+	Status string `bson:"status,omitempty" json:"status,omitempty"`
+}
+
+func LogBuildEvent(buildId string, eventType string, eventData BuildEventData) {
+	event := EventLogEntry{
+		ResourceId:   buildId,
+		Timestamp:    time.Now(),
+		EventType:    eventType,
+		Data:         eventData,
+		ResourceType: ResourceTypeBuild,
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(&event); err != nil {
+		grip.Errorf("Error logging build event: %+v", err)
+	}
+}
+
+func LogBuildStateChangeEvent(buildId, status string) {
+	LogBuildEvent(buildId, BuildStateChange, BuildEventData{Status: status})
+}
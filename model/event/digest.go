@@ -0,0 +1,70 @@
+package event
+
+import (
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+)
+
+// Digest modes of a subscription.
+const (
+	// DigestImmediate sends each notification as soon as it is created.
+	// It is the mode of subscriptions without a digest.
+	DigestImmediate = "immediate"
+	// DigestVersion batches the notifications about a version until the
+	// version finishes.
+	DigestVersion = "version"
+	// DigestInterval batches notifications for a number of minutes after
+	// the first of them.
+	DigestInterval = "interval"
+
+	// MaxDigestIntervalMinutes is the longest that notifications can be
+	// batched for.
+	MaxDigestIntervalMinutes = 24 * 60
+)
+
+// DigestSubscriberTypes are the types of subscriber that notifications can
+// be batched for.
+var DigestSubscriberTypes = []string{
+	EmailSubscriberType,
+	SlackSubscriberType,
+	JIRACommentSubscriberType,
+	TeamsSubscriberType,
+	ChatWebhookSubscriberType,
+}
+
+// Digest configures whether the notifications of a subscription are sent
+// one at a time, or batched into one summary message.
+type Digest struct {
+	Mode            string `bson:"mode,omitempty" json:"mode,omitempty"`
+	IntervalMinutes int    `bson:"interval_minutes,omitempty" json:"interval_minutes,omitempty"`
+}
+
+var DigestModeKey = bsonutil.MustHaveTag(Digest{}, "Mode")
+
+// Batched returns whether notifications with the digest are batched rather
+// than sent immediately.
+func (d Digest) Batched() bool {
+	return d.Mode == DigestVersion || d.Mode == DigestInterval
+}
+
+// Validate checks that the digest has a known mode, with an interval for
+// interval digests, and that the subscriber supports digests.
+func (d Digest) Validate(subscriberType string) error {
+	switch d.Mode {
+	case "", DigestImmediate:
+		return nil
+	case DigestVersion:
+	case DigestInterval:
+		if d.IntervalMinutes <= 0 || d.IntervalMinutes > MaxDigestIntervalMinutes {
+			return errors.Errorf("digest interval must be between 1 and %d minutes", MaxDigestIntervalMinutes)
+		}
+	default:
+		return errors.Errorf("unknown digest mode '%s'", d.Mode)
+	}
+
+	if !util.StringSliceContains(DigestSubscriberTypes, subscriberType) {
+		return errors.Errorf("notifications to %s subscribers can't be batched", subscriberType)
+	}
+	return nil
+}
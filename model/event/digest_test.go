@@ -0,0 +1,25 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(Digest{}.Validate(GithubPullRequestSubscriberType))
+	assert.NoError(Digest{Mode: DigestImmediate}.Validate(JIRAIssueSubscriberType))
+	assert.NoError(Digest{Mode: DigestVersion}.Validate(EmailSubscriberType))
+	assert.NoError(Digest{Mode: DigestInterval, IntervalMinutes: 30}.Validate(TeamsSubscriberType))
+
+	assert.Error(Digest{Mode: DigestVersion}.Validate(GithubPullRequestSubscriberType))
+	assert.Error(Digest{Mode: DigestInterval}.Validate(EmailSubscriberType))
+	assert.Error(Digest{Mode: DigestInterval, IntervalMinutes: MaxDigestIntervalMinutes + 1}.Validate(EmailSubscriberType))
+	assert.Error(Digest{Mode: "weekly"}.Validate(EmailSubscriberType))
+
+	assert.False(Digest{}.Batched())
+	assert.False(Digest{Mode: DigestImmediate}.Batched())
+	assert.True(Digest{Mode: DigestVersion}.Batched())
+}
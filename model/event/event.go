@@ -21,6 +21,11 @@ type EventLogEntry struct {
 	ResourceType string        `bson:"r_type,omitempty" json:"resource_type,omitempty"`
 	ProcessedAt  time.Time     `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
 
+	// TriggersProcessedAt is when project triggers were evaluated for the
+	// event, and TriggerAttempts is how many times evaluating them failed.
+	TriggersProcessedAt time.Time `bson:"triggers_processed_at,omitempty" json:"-"`
+	TriggerAttempts     int       `bson:"trigger_attempts,omitempty" json:"-"`
+
 	Timestamp  time.Time   `bson:"ts" json:"timestamp"`
 	ResourceId string      `bson:"r_id" json:"resource_id"`
 	EventType  string      `bson:"e_type" json:"event_type"`
@@ -42,6 +47,11 @@ type unmarshalEventLogEntry struct {
 	ResourceType string        `bson:"r_type,omitempty" json:"resource_type,omitempty"`
 	ProcessedAt  time.Time     `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
 
+	// TriggersProcessedAt is when project triggers were evaluated for the
+	// event, and TriggerAttempts is how many times evaluating them failed.
+	TriggersProcessedAt time.Time `bson:"triggers_processed_at,omitempty" json:"-"`
+	TriggerAttempts     int       `bson:"trigger_attempts,omitempty" json:"-"`
+
 	Timestamp  time.Time `bson:"ts" json:"timestamp"`
 	ResourceId string    `bson:"r_id" json:"resource_id"`
 	EventType  string    `bson:"e_type" json:"event_type"`
@@ -57,6 +67,10 @@ var (
 	processedAtKey  = bsonutil.MustHaveTag(EventLogEntry{}, "ProcessedAt")
 	TypeKey         = bsonutil.MustHaveTag(EventLogEntry{}, "EventType")
 	DataKey         = bsonutil.MustHaveTag(EventLogEntry{}, "Data")
+
+	// bson fields for project trigger progress
+	triggersProcessedAtKey = bsonutil.MustHaveTag(EventLogEntry{}, "TriggersProcessedAt")
+	triggerAttemptsKey     = bsonutil.MustHaveTag(EventLogEntry{}, "TriggerAttempts")
 )
 
 const resourceTypeKey = "r_type"
@@ -5,6 +5,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -17,6 +18,22 @@ func UnprocessedEvents() bson.M {
 	}
 }
 
+// UntriggeredEvents returns a bson.M query to fetch the events of the given
+// resource types logged since the given time that project triggers have
+// not been evaluated for, and that have failed fewer than maxAttempts times
+func UntriggeredEvents(since time.Time, maxAttempts int, resourceTypes ...string) bson.M {
+	return bson.M{
+		resourceTypeKey: bson.M{"$in": resourceTypes},
+		TimestampKey:    bson.M{"$gte": since},
+		triggersProcessedAtKey: bson.M{
+			"$exists": false,
+		},
+		triggerAttemptsKey: bson.M{
+			"$not": bson.M{"$gte": maxAttempts},
+		},
+	}
+}
+
 func resourceTypeKeyIs(key string) bson.M {
 	return bson.M{
 		resourceTypeKey: key,
@@ -33,6 +50,25 @@ func Find(coll string, query db.Q) ([]EventLogEntry, error) {
 	return events, err
 }
 
+// FindByID returns the event with the id from either of the event
+// collections, or nil if there is none.
+func FindByID(id string) (*EventLogEntry, error) {
+	if !bson.IsObjectIdHex(id) {
+		return nil, nil
+	}
+	query := db.Query(bson.M{idKey: bson.ObjectIdHex(id)})
+	for _, coll := range []string{AllLogCollection, TaskLogCollection} {
+		events, err := Find(coll, query)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding event '%s'", id)
+		}
+		if len(events) > 0 {
+			return &events[0], nil
+		}
+	}
+	return nil, nil
+}
+
 // CountSystemEvents returns the total number of system metrics events
 // captured for the specified task. If taskId is "", then this will
 // return a count of all system events captured.
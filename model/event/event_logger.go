@@ -69,3 +69,49 @@ func (l *DBEventLogger) MarkProcessed(event *EventLogEntry) error {
 
 	return nil
 }
+
+// MarkTriggersProcessed records that project triggers were evaluated for
+// the event. This is tracked apart from ProcessedAt, which belongs to the
+// notification pipeline.
+func (l *DBEventLogger) MarkTriggersProcessed(event *EventLogEntry) error {
+	if !event.ID.Valid() {
+		return errors.New("event has no ID")
+	}
+	event.TriggersProcessedAt = time.Now()
+
+	err := db.Update(l.collection, bson.M{
+		idKey: event.ID,
+	}, bson.M{
+		"$set": bson.M{
+			triggersProcessedAtKey: event.TriggersProcessedAt,
+		},
+	})
+	if err != nil {
+		event.TriggersProcessedAt = time.Time{}
+		return errors.Wrap(err, "failed to update triggers process time")
+	}
+
+	return nil
+}
+
+// MarkTriggersFailed records a failed attempt to evaluate project triggers
+// for the event, so that it is retried a limited number of times.
+func (l *DBEventLogger) MarkTriggersFailed(event *EventLogEntry) error {
+	if !event.ID.Valid() {
+		return errors.New("event has no ID")
+	}
+
+	err := db.Update(l.collection, bson.M{
+		idKey: event.ID,
+	}, bson.M{
+		"$inc": bson.M{
+			triggerAttemptsKey: 1,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update trigger attempts")
+	}
+	event.TriggerAttempts++
+
+	return nil
+}
@@ -13,6 +13,10 @@ func init() {
 		EventTaskSystemInfo:   taskSystemResourceEventFactory,
 		EventTaskProcessInfo:  taskProcessResourceEventFactory,
 		ResourceTypeAdmin:     adminEventFactory,
+		ResourceTypePerf:      perfEventFactory,
+		ResourceTypeBuild:     buildEventFactory,
+		ResourceTypeVersion:   versionEventFactory,
+		ResourceTypeAudit:     auditEventFactory,
 	}
 }
 
@@ -53,7 +57,28 @@ func adminEventFactory() interface{} {
 	return &rawAdminEventData{}
 }
 
+func perfEventFactory() interface{} {
+	return &PerfEventData{}
+}
+
+func buildEventFactory() interface{} {
+	return &BuildEventData{}
+}
+
+func versionEventFactory() interface{} {
+	return &VersionEventData{}
+}
+
+func auditEventFactory() interface{} {
+	return &AuditEventData{}
+}
+
 func isSubscribable(eventType string) bool {
 	// TODO
-	return false
+	switch eventType {
+	case ResourceTypePerf:
+		return true
+	default:
+		return false
+	}
 }
@@ -342,6 +342,62 @@ func (s *eventSuite) TestFindUnprocessedEvents() {
 	})
 }
 
+func (s *eventSuite) TestUntriggeredEvents() {
+	now := time.Now()
+	data := []bson.M{
+		{
+			resourceTypeKey: ResourceTypeTask,
+			TimestampKey:    now,
+			DataKey:         bson.M{},
+			processedAtKey:  now,
+		},
+		{
+			resourceTypeKey:        ResourceTypeTask,
+			TimestampKey:           now,
+			DataKey:                bson.M{},
+			triggersProcessedAtKey: now,
+		},
+		{
+			resourceTypeKey:    ResourceTypeTask,
+			TimestampKey:       now,
+			DataKey:            bson.M{},
+			triggerAttemptsKey: 3,
+		},
+		{
+			resourceTypeKey: ResourceTypeTask,
+			TimestampKey:    now.Add(-2 * time.Hour),
+			DataKey:         bson.M{},
+		},
+		{
+			resourceTypeKey: ResourceTypeHost,
+			TimestampKey:    now,
+			DataKey:         bson.M{},
+		},
+	}
+	for i := range data {
+		s.NoError(db.Insert(AllLogCollection, data[i]))
+	}
+
+	query := db.Query(UntriggeredEvents(now.Add(-time.Hour), 3, ResourceTypeTask, ResourceTypeBuild))
+	events, err := Find(AllLogCollection, query)
+	s.NoError(err)
+	s.Require().Len(events, 1)
+	s.False(events[0].ProcessedAt.IsZero())
+
+	logger := NewDBEventLogger(AllLogCollection)
+	s.NoError(logger.MarkTriggersFailed(&events[0]))
+	s.NoError(logger.MarkTriggersFailed(&events[0]))
+	events, err = Find(AllLogCollection, query)
+	s.NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(2, events[0].TriggerAttempts)
+
+	s.NoError(logger.MarkTriggersProcessed(&events[0]))
+	events, err = Find(AllLogCollection, query)
+	s.NoError(err)
+	s.Empty(events)
+}
+
 //TODO: EVG-3061 remove this test
 func (s *eventSuite) TestTaskEventLogLegacyEvents() {
 	events := []bson.M{
@@ -32,6 +32,7 @@ const (
 	EventTaskFinished             = "HOST_TASK_FINISHED"
 	EventHostTeardown             = "HOST_TEARDOWN"
 	EventHostTerminatedExternally = "HOST_TERMINATED_EXTERNALLY"
+	EventHostDebugHold            = "HOST_DEBUG_HOLD"
 )
 
 // implements EventData
@@ -133,6 +134,10 @@ func LogProvisionFailed(hostId string, setupLogs string) {
 	LogHostEvent(hostId, EventHostProvisionFailed, HostEventData{Logs: setupLogs})
 }
 
+func LogHostDebugHold(hostId, taskId, user string) {
+	LogHostEvent(hostId, EventHostDebugHold, HostEventData{TaskId: taskId, User: user})
+}
+
 func LogHostTeardown(hostId, teardownLogs string, success bool, duration time.Duration) {
 	LogHostEvent(hostId, EventHostTeardown,
 		HostEventData{Logs: teardownLogs, Successful: success, Duration: duration})
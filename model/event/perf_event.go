@@ -0,0 +1,90 @@
+package event
+
+import (
+	"time"
+
+	"github.com/mongodb/grip"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// resource type
+	ResourceTypePerf = "PERF"
+
+	// event types
+	EventPerfRegression = "PERF_REGRESSION"
+
+	// TriggerPerfRegression is the trigger of the subscriptions that are
+	// notified of perf regressions.
+	TriggerPerfRegression = "regression"
+)
+
+// Selector types of perf regression subscriptions.
+const (
+	SelectorObject       = "object"
+	SelectorID           = "id"
+	SelectorProject      = "project"
+	SelectorBuildVariant = "build-variant"
+	SelectorTaskName     = "task-name"
+	SelectorTestName     = "test-name"
+	SelectorMetric       = "metric"
+
+	// ObjectPerf is the object selector of perf regression
+	// subscriptions.
+	ObjectPerf = "perf"
+)
+
+// PerfEventData implements EventData.
+type PerfEventData struct {
+	Project        string  `bson:"proj" json:"project"`
+	BuildVariant   string  `bson:"bv" json:"build_variant"`
+	Version        string  `bson:"v" json:"version"`
+	StartVersion   string  `bson:"start_v,omitempty" json:"start_version,omitempty"`
+	TaskName       string  `bson:"t_name" json:"task_name"`
+	TestName       string  `bson:"test" json:"test_name"`
+	Metric         string  `bson:"metric" json:"metric"`
+	ThreadLevel    int     `bson:"threads" json:"thread_level"`
+	Value          float64 `bson:"val" json:"value"`
+	BaselineMean   float64 `bson:"mean" json:"baseline_mean"`
+	BaselineStdDev float64 `bson:"std_dev" json:"baseline_std_dev"`
+	WindowMean     float64 `bson:"window_mean" json:"window_mean"`
+	PercentChange  float64 `bson:"pct" json:"percent_change"`
+}
+
+// Selectors returns the selectors that the subscriptions to the regression
+// of the task with the id must be a subset of.
+func (d *PerfEventData) Selectors(taskID string) []Selector {
+	return []Selector{
+		{Type: SelectorObject, Data: ObjectPerf},
+		{Type: SelectorID, Data: taskID},
+		{Type: SelectorProject, Data: d.Project},
+		{Type: SelectorBuildVariant, Data: d.BuildVariant},
+		{Type: SelectorTaskName, Data: d.TaskName},
+		{Type: SelectorTestName, Data: d.TestName},
+		{Type: SelectorMetric, Data: d.Metric},
+	}
+}
+
+// UnprocessedPerfEvents returns a bson.M query to fetch the perf events
+// that subscriptions have not been notified of.
+func UnprocessedPerfEvents() bson.M {
+	q := UnprocessedEvents()
+	q[resourceTypeKey] = ResourceTypePerf
+	return q
+}
+
+// LogPerfRegression records a performance regression detected in the
+// results of a task.
+func LogPerfRegression(taskId string, eventData PerfEventData) {
+	event := EventLogEntry{
+		ResourceId:   taskId,
+		Timestamp:    time.Now(),
+		EventType:    EventPerfRegression,
+		Data:         eventData,
+		ResourceType: ResourceTypePerf,
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(&event); err != nil {
+		grip.Errorf("Error logging perf event: %+v", err)
+	}
+}
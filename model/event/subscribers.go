@@ -1,7 +1,12 @@
 package event
 
 import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+
 	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -13,6 +18,8 @@ const (
 	EvergreenWebhookSubscriberType  = "evergreen-webhook"
 	EmailSubscriberType             = "email"
 	SlackSubscriberType             = "slack"
+	TeamsSubscriberType             = "teams"
+	ChatWebhookSubscriberType       = "chat-webhook"
 )
 
 //nolint: deadcode, megacheck
@@ -49,7 +56,10 @@ func (s *Subscriber) SetBSON(raw bson.Raw) error {
 	case EvergreenWebhookSubscriberType:
 		s.Target = &WebhookSubscriber{}
 
-	case JIRAIssueSubscriberType, JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType:
+	case ChatWebhookSubscriberType:
+		s.Target = &ChatWebhookSubscriber{}
+
+	case JIRAIssueSubscriberType, JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType, TeamsSubscriberType:
 		str := ""
 		s.Target = &str
 
@@ -69,6 +79,54 @@ type WebhookSubscriber struct {
 	Secret []byte `bson:"secret"`
 }
 
+// ChatWebhookSubscriber posts notifications to a chat service's incoming
+// webhook. The body of each request is rendered from Template, a text
+// template that is given the notification's payload and must produce JSON.
+// The template's "json" function quotes a value as JSON, e.g.
+// {"text": {{json .Text}}}.
+type ChatWebhookSubscriber struct {
+	URL      string            `bson:"url"`
+	Template string            `bson:"template"`
+	Headers  map[string]string `bson:"headers,omitempty"`
+}
+
+var chatWebhookTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Validate checks that the subscriber has a URL and a template that parses.
+func (s *ChatWebhookSubscriber) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if s.URL == "" {
+		catcher.Add(errors.New("chat webhook URL can't be empty"))
+	}
+	if s.Template == "" {
+		catcher.Add(errors.New("chat webhook template can't be empty"))
+	} else if _, err := template.New("chat-webhook").Funcs(chatWebhookTemplateFuncs).Parse(s.Template); err != nil {
+		catcher.Add(errors.Wrap(err, "chat webhook template is invalid"))
+	}
+	return catcher.Resolve()
+}
+
+// Render renders the body of a request to the webhook from the data.
+func (s *ChatWebhookSubscriber) Render(data interface{}) ([]byte, error) {
+	tmpl, err := template.New("chat-webhook").Funcs(chatWebhookTemplateFuncs).Parse(s.Template)
+	if err != nil {
+		return nil, errors.Wrap(err, "chat webhook template is invalid")
+	}
+	body := bytes.Buffer{}
+	if err = tmpl.Execute(&body, data); err != nil {
+		return nil, errors.Wrap(err, "can't render chat webhook template")
+	}
+	if !json.Valid(body.Bytes()) {
+		return nil, errors.New("chat webhook template did not render valid JSON")
+	}
+	return body.Bytes(), nil
+}
+
 type GithubPullRequestSubscriber struct {
 	Owner    string `bson:"owner"`
 	Repo     string `bson:"repo"`
@@ -58,3 +58,24 @@ func TestSubscribers(t *testing.T) {
 		assert.Contains(fetchedSubs, subs[i])
 	}
 }
+
+func TestChatWebhookSubscriber(t *testing.T) {
+	assert := assert.New(t)
+
+	sub := ChatWebhookSubscriber{}
+	assert.Error(sub.Validate())
+
+	sub = ChatWebhookSubscriber{URL: "https://chat.example.com/hook", Template: `{"text": {{json .}}`}
+	assert.NoError(sub.Validate())
+	body, err := sub.Render(`say "hi"`)
+	assert.Error(err)
+	assert.Nil(body)
+
+	sub.Template = `{"text": {{json .}}}`
+	body, err = sub.Render(`say "hi"`)
+	assert.NoError(err)
+	assert.Equal(`{"text": "say \"hi\""}`, string(body))
+
+	sub.Template = `{"text": {{json .}`
+	assert.Error(sub.Validate())
+}
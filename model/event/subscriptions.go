@@ -23,12 +23,14 @@ var (
 	subscriptionSelectorsKey      = bsonutil.MustHaveTag(Subscription{}, "Selectors")
 	subscriptionRegexSelectorsKey = bsonutil.MustHaveTag(Subscription{}, "RegexSelectors")
 	subscriptionSubscriberKey     = bsonutil.MustHaveTag(Subscription{}, "Subscriber")
+	subscriptionDigestKey         = bsonutil.MustHaveTag(Subscription{}, "Digest")
 
 	groupedSubscriberTypeKey       = bsonutil.MustHaveTag(GroupedSubscribers{}, "Type")
 	groupedSubscriberSubscriberKey = bsonutil.MustHaveTag(GroupedSubscribers{}, "Subscribers")
 
 	subscriberWithRegexKey               = bsonutil.MustHaveTag(SubscriberWithRegex{}, "Subscriber")
 	subscriberWithRegexRegexSelectorsKey = bsonutil.MustHaveTag(SubscriberWithRegex{}, "RegexSelectors")
+	subscriberWithRegexDigestKey         = bsonutil.MustHaveTag(SubscriberWithRegex{}, "Digest")
 )
 
 type Subscription struct {
@@ -38,6 +40,8 @@ type Subscription struct {
 	Selectors      []Selector    `bson:"selectors,omitempty"`
 	RegexSelectors []Selector    `bson:"regex_selectors,omitempty"`
 	Subscriber     Subscriber    `bson:"subscriber"`
+	// Digest batches the subscription's notifications into summaries.
+	Digest Digest `bson:"digest,omitempty"`
 }
 
 type Selector struct {
@@ -53,6 +57,7 @@ type GroupedSubscribers struct {
 type SubscriberWithRegex struct {
 	Subscriber     Subscriber `bson:"subscriber"`
 	RegexSelectors []Selector `bson:"regex_selectors"`
+	Digest         Digest     `bson:"digest"`
 }
 
 // FindSubscribers finds all subscriptions that match the given information
@@ -68,6 +73,7 @@ func FindSubscribers(subscriptionType, triggerType string, selectors []Selector)
 			"$project": bson.M{
 				subscriptionSubscriberKey:     1,
 				subscriptionRegexSelectorsKey: 1,
+				subscriptionDigestKey:         1,
 				"keep": bson.M{
 					"$and": []bson.M{
 						{
@@ -95,6 +101,7 @@ func FindSubscribers(subscriptionType, triggerType string, selectors []Selector)
 					"$push": bson.M{
 						subscriberWithRegexKey:               "$" + subscriptionSubscriberKey,
 						subscriberWithRegexRegexSelectorsKey: "$" + subscriptionRegexSelectorsKey,
+						subscriberWithRegexDigestKey:         "$" + subscriptionDigestKey,
 					},
 				},
 			},
@@ -155,6 +162,9 @@ func findSelector(selectors []Selector, selectorType string) *Selector {
 }
 
 func (s *Subscription) Upsert() error {
+	if err := s.Digest.Validate(s.Subscriber.Type); err != nil {
+		return errors.Wrap(err, "invalid digest")
+	}
 	if len(s.ID.Hex()) == 0 {
 		s.ID = bson.NewObjectId()
 	}
@@ -167,6 +177,7 @@ func (s *Subscription) Upsert() error {
 		subscriptionSelectorsKey:      s.Selectors,
 		subscriptionRegexSelectorsKey: s.RegexSelectors,
 		subscriptionSubscriberKey:     s.Subscriber,
+		subscriptionDigestKey:         s.Digest,
 	})
 	if err != nil {
 		return err
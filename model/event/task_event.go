@@ -23,6 +23,8 @@ const (
 	TaskScheduled        = "TASK_SCHEDULED"
 	TaskPriorityChanged  = "TASK_PRIORITY_CHANGED"
 	TaskJiraAlertCreated = "TASK_JIRA_ALERT_CREATED"
+	TaskStepbackCulprit  = "TASK_STEPBACK_CULPRIT"
+	TaskAutoRestarted    = "TASK_AUTO_RESTARTED"
 )
 
 // implements Data
@@ -31,6 +33,8 @@ type TaskEventData struct {
 	UserId    string `bson:"u_id,omitempty" json:"user_id,omitempty"`
 	Status    string `bson:"s,omitempty" json:"status,omitempty"`
 	JiraIssue string `bson:"jira,omitempty" json:"jira,omitempty"`
+	Revision  string `bson:"rev,omitempty" json:"revision,omitempty"`
+	Reason    string `bson:"reason,omitempty" json:"reason,omitempty"`
 
 	Timestamp time.Time `bson:"ts,omitempty" json:"timestamp,omitempty"`
 	Priority  int64     `bson:"pri,omitempty" json:"priority,omitempty"`
@@ -97,6 +101,16 @@ func LogTaskAbortRequest(taskId string, userId string) {
 		TaskEventData{UserId: userId})
 }
 
+// LogTaskStepbackCulprit logs that stepback found the task to be the first
+// failing run after the last passing run at lastPassingRevision.
+func LogTaskStepbackCulprit(taskId, lastPassingRevision string) {
+	LogTaskEvent(taskId, TaskStepbackCulprit, TaskEventData{Revision: lastPassingRevision})
+}
+
+func LogTaskAutoRestarted(taskId, reason string) {
+	LogTaskEvent(taskId, TaskAutoRestarted, TaskEventData{Reason: reason})
+}
+
 func LogTaskScheduled(taskId string, scheduledTime time.Time) {
 	LogTaskEvent(taskId, TaskScheduled,
 		TaskEventData{Timestamp: scheduledTime})
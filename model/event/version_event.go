@@ -0,0 +1,38 @@
+package event
+
+import (
+	"time"
+
+	"github.com/mongodb/grip"
+)
+
+const (
+	// resource type
+	ResourceTypeVersion = "VERSION"
+
+	// event types
+	VersionStateChange = "STATE_CHANGE"
+)
+
+// VersionEventData implements EventData.
+type VersionEventData struct {
+	Status string `bson:"status,omitempty" json:"status,omitempty"`
+}
+
+func LogVersionEvent(versionId string, eventType string, eventData VersionEventData) {
+	event := EventLogEntry{
+		ResourceId:   versionId,
+		Timestamp:    time.Now(),
+		EventType:    eventType,
+		Data:         eventData,
+		ResourceType: ResourceTypeVersion,
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(&event); err != nil {
+		grip.Errorf("Error logging version event: %+v", err)
+	}
+}
+
+func LogVersionStateChangeEvent(versionId, status string) {
+	LogVersionEvent(versionId, VersionStateChange, VersionEventData{Status: status})
+}
@@ -0,0 +1,218 @@
+package flakytest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Collection is the name of the flaky tests collection in the database.
+	Collection = "flaky_tests"
+
+	// DetectionWindow is how far back the detection job looks for
+	// finished tasks.
+	DetectionWindow = 7 * 24 * time.Hour
+)
+
+// FlakyTest is a test that has both passed and failed on the same
+// revision of a project's build variant, either in different tasks or in
+// different executions of the same task.
+type FlakyTest struct {
+	Project      string `bson:"project" json:"project"`
+	BuildVariant string `bson:"build_variant" json:"build_variant"`
+	TestFile     string `bson:"test_file" json:"test_file"`
+
+	// Revisions is the number of revisions on which the test ran, and
+	// FlakyRevisions is the number of those on which it both passed and
+	// failed. Score is their ratio, from 0 to 1.
+	Revisions      int     `bson:"revisions" json:"revisions"`
+	FlakyRevisions int     `bson:"flaky_revisions" json:"flaky_revisions"`
+	Score          float64 `bson:"score" json:"score"`
+
+	// LastFlakyRevision is the most recent revision on which the test
+	// both passed and failed.
+	LastFlakyRevision string    `bson:"last_flaky_revision" json:"last_flaky_revision"`
+	UpdatedAt         time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+var (
+	// BSON fields for the flaky test struct
+	ProjectKey      = bsonutil.MustHaveTag(FlakyTest{}, "Project")
+	BuildVariantKey = bsonutil.MustHaveTag(FlakyTest{}, "BuildVariant")
+	TestFileKey     = bsonutil.MustHaveTag(FlakyTest{}, "TestFile")
+	ScoreKey        = bsonutil.MustHaveTag(FlakyTest{}, "Score")
+)
+
+// ByProject returns a query for the flaky tests of a project, most flaky
+// first.
+func ByProject(project string) db.Q {
+	return db.Query(bson.M{ProjectKey: project}).Sort([]string{"-" + ScoreKey, BuildVariantKey, TestFileKey})
+}
+
+// Find returns all flaky tests that satisfy the query.
+func Find(query db.Q) ([]FlakyTest, error) {
+	tests := []FlakyTest{}
+	err := db.FindAllQ(Collection, query, &tests)
+	return tests, err
+}
+
+// flakinessCounts are the counts that the flakiness pipeline computes for a
+// test on a build variant.
+type flakinessCounts struct {
+	ID struct {
+		BuildVariant string `bson:"build_variant"`
+		TestFile     string `bson:"test_file"`
+	} `bson:"_id"`
+	Revisions      int `bson:"revisions"`
+	FlakyRevisions int `bson:"flaky_revisions"`
+	LastFlaky      struct {
+		Order    int    `bson:"order"`
+		Revision string `bson:"revision"`
+	} `bson:"last_flaky"`
+}
+
+// flakinessPipeline returns an aggregation on the tasks collection that
+// counts, for each test of the project's mainline tasks that finished since
+// the given time, the revisions on which it ran and on which it both passed
+// and failed. Results of every execution of a task are considered, so a
+// test that fails and then passes when its task is restarted is flaky.
+// Tests that never both passed and failed on a revision are left out.
+func flakinessPipeline(project string, since time.Time) []bson.M {
+	const (
+		resultKey = "result"
+		passedKey = "passed"
+		failedKey = "failed"
+		orderKey  = "order"
+	)
+	resultStatus := "$" + bsonutil.GetDottedKeyName(resultKey, testresult.StatusKey)
+	isFlaky := bson.M{"$and": []bson.M{
+		{"$eq": []interface{}{"$" + passedKey, true}},
+		{"$eq": []interface{}{"$" + failedKey, true}},
+	}}
+
+	return []bson.M{
+		{"$match": bson.M{
+			task.ProjectKey:    project,
+			task.StatusKey:     bson.M{"$in": task.CompletedStatuses},
+			task.FinishTimeKey: bson.M{"$gte": since},
+			task.RequesterKey: bson.M{"$in": []string{
+				evergreen.RepotrackerVersionRequester,
+				evergreen.PeriodicBuildRequester,
+				evergreen.TriggerRequester,
+			}},
+		}},
+		{"$project": bson.M{
+			task.BuildVariantKey:        1,
+			task.RevisionKey:            1,
+			task.RevisionOrderNumberKey: 1,
+		}},
+		// the unwind directly after the lookup lets the server join the
+		// results one at a time, rather than into one document per task
+		{"$lookup": bson.M{
+			"from":         testresult.Collection,
+			"localField":   task.IdKey,
+			"foreignField": testresult.TaskIDKey,
+			"as":           resultKey,
+		}},
+		{"$unwind": "$" + resultKey},
+		{"$group": bson.M{
+			"_id": bson.M{
+				BuildVariantKey: "$" + task.BuildVariantKey,
+				TestFileKey:     "$" + bsonutil.GetDottedKeyName(resultKey, testresult.TestFileKey),
+				"revision":      "$" + task.RevisionKey,
+			},
+			orderKey: bson.M{"$max": "$" + task.RevisionOrderNumberKey},
+			passedKey: bson.M{"$max": bson.M{
+				"$eq": []interface{}{resultStatus, evergreen.TestSucceededStatus},
+			}},
+			failedKey: bson.M{"$max": bson.M{"$or": []bson.M{
+				{"$eq": []interface{}{resultStatus, evergreen.TestFailedStatus}},
+				{"$eq": []interface{}{resultStatus, evergreen.TestSilentlyFailedStatus}},
+			}}},
+		}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				BuildVariantKey: "$_id." + BuildVariantKey,
+				TestFileKey:     "$_id." + TestFileKey,
+			},
+			"revisions": bson.M{"$sum": 1},
+			"flaky_revisions": bson.M{"$sum": bson.M{
+				"$cond": []interface{}{isFlaky, 1, 0},
+			}},
+			// documents compare by their first field, so this is the
+			// flaky revision with the highest order number
+			"last_flaky": bson.M{"$max": bson.M{
+				"$cond": []interface{}{isFlaky, bson.M{orderKey: "$" + orderKey, "revision": "$_id.revision"}, nil},
+			}},
+		}},
+		{"$match": bson.M{"flaky_revisions": bson.M{"$gt": 0}}},
+	}
+}
+
+// flakyTestsFromCounts scores the project's tests by their flakiness
+// counts, and sorts them most flaky first.
+func flakyTestsFromCounts(project string, counts []flakinessCounts, now time.Time) []FlakyTest {
+	flaky := make([]FlakyTest, 0, len(counts))
+	for _, c := range counts {
+		if c.Revisions == 0 || c.FlakyRevisions == 0 {
+			continue
+		}
+		flaky = append(flaky, FlakyTest{
+			Project:           project,
+			BuildVariant:      c.ID.BuildVariant,
+			TestFile:          c.ID.TestFile,
+			Revisions:         c.Revisions,
+			FlakyRevisions:    c.FlakyRevisions,
+			Score:             float64(c.FlakyRevisions) / float64(c.Revisions),
+			LastFlakyRevision: c.LastFlaky.Revision,
+			UpdatedAt:         now,
+		})
+	}
+
+	sort.Slice(flaky, func(i, j int) bool {
+		if flaky[i].Score != flaky[j].Score {
+			return flaky[i].Score > flaky[j].Score
+		}
+		if flaky[i].BuildVariant != flaky[j].BuildVariant {
+			return flaky[i].BuildVariant < flaky[j].BuildVariant
+		}
+		return flaky[i].TestFile < flaky[j].TestFile
+	})
+	return flaky
+}
+
+// UpdateForProject recomputes the flaky tests of a project from the
+// mainline tasks that finished since the given time, and replaces the
+// project's previously stored flaky tests with them. The pass and fail
+// counts are computed by the database, so only the flaky tests are loaded.
+func UpdateForProject(project string, since time.Time) ([]FlakyTest, error) {
+	counts := []flakinessCounts{}
+	if err := db.Aggregate(task.Collection, flakinessPipeline(project, since), &counts); err != nil {
+		return nil, errors.Wrapf(err, "problem computing flaky tests for project '%s'", project)
+	}
+
+	flaky := flakyTestsFromCounts(project, counts, time.Now())
+
+	if err := db.RemoveAll(Collection, bson.M{ProjectKey: project}); err != nil {
+		return nil, errors.Wrapf(err, "problem removing flaky tests for project '%s'", project)
+	}
+	if len(flaky) == 0 {
+		return flaky, nil
+	}
+	docs := make([]interface{}, 0, len(flaky))
+	for _, test := range flaky {
+		docs = append(docs, test)
+	}
+	if err := db.InsertMany(Collection, docs...); err != nil {
+		return nil, errors.Wrapf(err, "problem inserting flaky tests for project '%s'", project)
+	}
+	return flaky, nil
+}
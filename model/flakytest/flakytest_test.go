@@ -0,0 +1,122 @@
+package flakytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlakyTestsFromCounts(t *testing.T) {
+	assert := assert.New(t)
+
+	counts := make([]flakinessCounts, 3)
+	counts[0].ID.BuildVariant = "linux"
+	counts[0].ID.TestFile = "restarted"
+	counts[0].Revisions = 2
+	counts[0].FlakyRevisions = 1
+	counts[0].LastFlaky.Revision = "a"
+	counts[1].ID.BuildVariant = "linux"
+	counts[1].ID.TestFile = "across"
+	counts[1].Revisions = 1
+	counts[1].FlakyRevisions = 1
+	counts[1].LastFlaky.Revision = "b"
+	counts[2].ID.BuildVariant = "linux"
+	counts[2].ID.TestFile = "broken"
+	counts[2].Revisions = 2
+
+	now := time.Now()
+	flaky := flakyTestsFromCounts("mci", counts, now)
+	assert.Len(flaky, 2)
+
+	assert.Equal("across", flaky[0].TestFile)
+	assert.Equal("linux", flaky[0].BuildVariant)
+	assert.Equal(1.0, flaky[0].Score)
+	assert.Equal("b", flaky[0].LastFlakyRevision)
+
+	assert.Equal("restarted", flaky[1].TestFile)
+	assert.Equal("mci", flaky[1].Project)
+	assert.Equal(2, flaky[1].Revisions)
+	assert.Equal(1, flaky[1].FlakyRevisions)
+	assert.Equal(0.5, flaky[1].Score)
+	assert.Equal(now, flaky[1].UpdatedAt)
+
+	assert.Empty(flakyTestsFromCounts("mci", nil, now))
+}
+
+func TestUpdateForProject(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(task.Collection, testresult.Collection, Collection))
+	defer func() {
+		assert.NoError(db.ClearCollections(task.Collection, testresult.Collection, Collection))
+	}()
+
+	now := time.Now()
+	tasks := []task.Task{
+		{Id: "t1", Revision: "a", RevisionOrderNumber: 1, BuildVariant: "linux"},
+		{Id: "t2", Revision: "b", RevisionOrderNumber: 2, BuildVariant: "linux"},
+		{Id: "t3", Revision: "b", RevisionOrderNumber: 2, BuildVariant: "linux"},
+		{Id: "t4", Revision: "a", RevisionOrderNumber: 1, BuildVariant: "windows"},
+		// patches and old tasks are ignored
+		{Id: "t5", Revision: "b", RevisionOrderNumber: 2, BuildVariant: "linux", Requester: evergreen.PatchVersionRequester},
+		{Id: "t6", Revision: "c", RevisionOrderNumber: 3, BuildVariant: "linux", FinishTime: now.Add(-2 * DetectionWindow)},
+	}
+	for _, tsk := range tasks {
+		tsk.Project = "mci"
+		tsk.Status = evergreen.TaskFailed
+		if tsk.Requester == "" {
+			tsk.Requester = evergreen.RepotrackerVersionRequester
+		}
+		if tsk.FinishTime.IsZero() {
+			tsk.FinishTime = now
+		}
+		require.NoError(tsk.Insert())
+	}
+	require.NoError(testresult.InsertMany([]testresult.TestResult{
+		// fails and then passes when t1 is restarted
+		{TaskID: "t1", Execution: 0, TestFile: "restarted", Status: evergreen.TestFailedStatus},
+		{TaskID: "t1", Execution: 1, TestFile: "restarted", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t2", Execution: 0, TestFile: "restarted", Status: evergreen.TestSucceededStatus},
+
+		// passes in one task and fails quietly in another on revision b
+		{TaskID: "t2", Execution: 0, TestFile: "across", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t3", Execution: 0, TestFile: "across", Status: evergreen.TestSilentlyFailedStatus},
+
+		// fails consistently, which is not flaky
+		{TaskID: "t1", Execution: 0, TestFile: "broken", Status: evergreen.TestFailedStatus},
+		{TaskID: "t2", Execution: 0, TestFile: "broken", Status: evergreen.TestFailedStatus},
+		{TaskID: "t5", Execution: 0, TestFile: "broken", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t6", Execution: 0, TestFile: "broken", Status: evergreen.TestSucceededStatus},
+
+		// skipped on a different variant
+		{TaskID: "t4", Execution: 0, TestFile: "restarted", Status: evergreen.TestSkippedStatus},
+	}))
+
+	flaky, err := UpdateForProject("mci", now.Add(-DetectionWindow))
+	require.NoError(err)
+	require.Len(flaky, 2)
+
+	assert.Equal("across", flaky[0].TestFile)
+	assert.Equal("linux", flaky[0].BuildVariant)
+	assert.Equal(1, flaky[0].Revisions)
+	assert.Equal(1.0, flaky[0].Score)
+	assert.Equal("b", flaky[0].LastFlakyRevision)
+
+	assert.Equal("restarted", flaky[1].TestFile)
+	assert.Equal(2, flaky[1].Revisions)
+	assert.Equal(1, flaky[1].FlakyRevisions)
+	assert.Equal("a", flaky[1].LastFlakyRevision)
+
+	stored, err := Find(ByProject("mci"))
+	require.NoError(err)
+	assert.Len(stored, 2)
+}
@@ -54,6 +54,7 @@ var (
 	NotificationsKey           = bsonutil.MustHaveTag(Host{}, "Notifications")
 	LastCommunicationTimeKey   = bsonutil.MustHaveTag(Host{}, "LastCommunicationTime")
 	UserHostKey                = bsonutil.MustHaveTag(Host{}, "UserHost")
+	DebugTaskKey               = bsonutil.MustHaveTag(Host{}, "DebugTask")
 	ZoneKey                    = bsonutil.MustHaveTag(Host{}, "Zone")
 	ProjectKey                 = bsonutil.MustHaveTag(Host{}, "Project")
 	ProvisionOptionsKey        = bsonutil.MustHaveTag(Host{}, "ProvisionOptions")
@@ -80,6 +80,9 @@ type Host struct {
 	InstanceType string `bson:"instance_type" json:"instance_type,omitempty"`
 	// stores information on expiration notifications for spawn hosts
 	Notifications map[string]bool `bson:"notifications,omitempty" json:"notifications,omitempty"`
+	// DebugTask is the failed task that the host is being held for, so
+	// that its owner can debug the failure.
+	DebugTask string `bson:"debug_task,omitempty" json:"debug_task,omitempty"`
 
 	// incremented by task start and end stats collectors and
 	// should reflect hosts total costs. Only populated for build-hosts
@@ -498,6 +501,44 @@ func (h *Host) SetExpirationTime(expirationTime time.Time) error {
 	)
 }
 
+// HoldForDebugging takes an idle build host out of rotation and gives
+// it to the user as if it were a spawn host, so that the failure of the
+// task that last ran on it can be debugged. The host is terminated when
+// it expires, in the same way as a spawn host.
+func (h *Host) HoldForDebugging(owner, taskID string, expirationTime time.Time) error {
+	err := UpdateOne(
+		bson.M{
+			IdKey:          h.Id,
+			StartedByKey:   evergreen.User,
+			StatusKey:      evergreen.HostRunning,
+			RunningTaskKey: bson.M{"$exists": false},
+		},
+		bson.M{
+			"$set": bson.M{
+				StartedByKey:      owner,
+				UserHostKey:       true,
+				ExpirationTimeKey: expirationTime,
+				DebugTaskKey:      taskID,
+			},
+			"$unset": bson.M{
+				NotificationsKey: 1,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "problem holding host '%s' for debugging", h.Id)
+	}
+
+	h.StartedBy = owner
+	h.UserHost = true
+	h.ExpirationTime = expirationTime
+	h.DebugTask = taskID
+	h.Notifications = nil
+	event.LogHostDebugHold(h.Id, taskID, owner)
+
+	return nil
+}
+
 // SetExpirationNotification updates the notification time for a spawn host
 func (h *Host) SetExpirationNotification(thresholdKey string) error {
 	// update the in-memory host, then the database
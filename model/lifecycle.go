@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
@@ -65,11 +66,17 @@ func SetBuildActivation(buildId string, active bool, caller string) error {
 
 	// If activating a task, set the ActivatedBy field to be the caller
 	if active {
+		query := bson.M{
+			task.BuildIdKey: buildId,
+			task.StatusKey:  evergreen.TaskUndispatched,
+		}
+		// tasks that the changed files don't affect are only activated
+		// by users
+		if evergreen.IsSystemActivator(caller) {
+			query[task.PathFilteredKey] = bson.M{"$ne": true}
+		}
 		_, err = task.UpdateAll(
-			bson.M{
-				task.BuildIdKey: buildId,
-				task.StatusKey:  evergreen.TaskUndispatched,
-			},
+			query,
 			bson.M{"$set": bson.M{task.ActivatedKey: active, task.ActivatedByKey: caller}},
 		)
 	} else {
@@ -162,13 +169,18 @@ func MarkVersionCompleted(versionId string, finishTime time.Time) error {
 			status = evergreen.VersionFailed
 		}
 	}
-	return version.UpdateOne(
+	err = version.UpdateOne(
 		bson.M{version.IdKey: versionId},
 		bson.M{"$set": bson.M{
 			version.FinishTimeKey: finishTime,
 			version.StatusKey:     status,
 		}},
 	)
+	if err != nil {
+		return err
+	}
+	event.LogVersionStateChangeEvent(versionId, status)
+	return nil
 }
 
 // SetBuildPriority updates the priority field of all tasks associated with the given build id.
@@ -824,6 +836,9 @@ func createOneTask(id string, buildVarTask BuildVariantTaskUnit, project *Projec
 		Project:             project.Identifier,
 		Priority:            buildVarTask.Priority,
 	}
+	if projectTask := project.FindProjectTask(buildVarTask.Name); projectTask != nil {
+		t.DebugOnFailureMins = projectTask.DebugOnFailure
+	}
 	if buildVarTask.IsGroup {
 		t.TaskGroup = buildVarTask.GroupName
 		tg, err := GetTaskGroup(buildVarTask.GroupName, &TaskConfig{
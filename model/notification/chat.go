@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"encoding/json"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+const (
+	teamsColorSuccess = "2EB886"
+	teamsColorFailure = "E01E5A"
+	teamsColorDefault = "A0A0A0"
+)
+
+type teamsMessageCard struct {
+	Type            string               `json:"@type"`
+	Context         string               `json:"@context"`
+	Summary         string               `json:"summary"`
+	ThemeColor      string               `json:"themeColor"`
+	Title           string               `json:"title"`
+	Text            string               `json:"text"`
+	PotentialAction []teamsOpenURIAction `json:"potentialAction,omitempty"`
+}
+
+type teamsOpenURIAction struct {
+	Type    string           `json:"@type"`
+	Name    string           `json:"name"`
+	Targets []teamsURITarget `json:"targets"`
+}
+
+type teamsURITarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+// TeamsMessageCard renders the payload as the message card body of a request
+// to a Microsoft Teams incoming webhook.
+func (p *ChatPayload) TeamsMessageCard() ([]byte, error) {
+	card := teamsMessageCard{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    p.Title,
+		ThemeColor: teamsColor(p.Status),
+		Title:      p.Title,
+		Text:       p.Text,
+	}
+	if p.URL != "" {
+		card.PotentialAction = []teamsOpenURIAction{
+			{
+				Type:    "OpenUri",
+				Name:    "View in Evergreen",
+				Targets: []teamsURITarget{{OS: "default", URI: p.URL}},
+			},
+		}
+	}
+
+	body, err := json.Marshal(card)
+	return body, errors.Wrap(err, "can't marshal Teams message card")
+}
+
+func teamsColor(status string) string {
+	switch status {
+	// task and build statuses are the same as patch statuses, except for
+	// success
+	case evergreen.TaskSucceeded, evergreen.PatchSucceeded:
+		return teamsColorSuccess
+	case evergreen.TaskFailed:
+		return teamsColorFailure
+	default:
+		return teamsColorDefault
+	}
+}
+
+// ChatRequest returns the URL, headers and body of the request that sends a
+// Teams or chat webhook notification.
+func (n *Notification) ChatRequest() (string, map[string]string, []byte, error) {
+	payload, ok := n.Payload.(*ChatPayload)
+	if !ok || payload == nil {
+		return "", nil, nil, errors.Errorf("%s payload is invalid", n.Subscriber.Type)
+	}
+
+	switch n.Subscriber.Type {
+	case event.TeamsSubscriberType:
+		url, ok := n.Subscriber.Target.(*string)
+		if !ok || url == nil || *url == "" {
+			return "", nil, nil, errors.New("teams subscriber is invalid")
+		}
+		body, err := payload.TeamsMessageCard()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return *url, nil, body, nil
+
+	case event.ChatWebhookSubscriberType:
+		sub, ok := n.Subscriber.Target.(*event.ChatWebhookSubscriber)
+		if !ok || sub == nil {
+			return "", nil, nil, errors.New("chat-webhook subscriber is invalid")
+		}
+		if err := sub.Validate(); err != nil {
+			return "", nil, nil, err
+		}
+		body, err := sub.Render(payload)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return sub.URL, sub.Headers, body, nil
+
+	default:
+		return "", nil, nil, errors.Errorf("'%s' is not a chat subscriber", n.Subscriber.Type)
+	}
+}
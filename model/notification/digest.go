@@ -0,0 +1,277 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxDigestWait is the longest that a version digest waits for its version
+// to finish before it is sent anyway.
+const maxDigestWait = event.MaxDigestIntervalMinutes * time.Minute
+
+// DigestBatch is the batched notifications to one subscriber that are
+// summarized in one digest notification.
+type DigestBatch struct {
+	Subscriber event.Subscriber
+	Digest     event.Digest
+	// Version is set for version digests, whose batches each hold the
+	// notifications about one version.
+	Version       string
+	Notifications []Notification
+}
+
+// FindUndigested returns the batched notifications that have not been
+// summarized in a digest yet, oldest first.
+func FindUndigested() ([]Notification, error) {
+	notifications := []Notification{}
+	query := db.Query(bson.M{
+		bsonutil.GetDottedKeyName(digestKey, event.DigestModeKey): bson.M{
+			"$in": []string{event.DigestVersion, event.DigestInterval},
+		},
+		digestIDKey: bson.M{"$exists": false},
+		sentAtKey:   bson.M{"$exists": false},
+	}).Sort([]string{idKey})
+	err := db.FindAllQ(NotificationsCollection, query, &notifications)
+	return notifications, errors.Wrap(err, "problem finding undigested notifications")
+}
+
+// BatchDigests groups batched notifications by their subscriber and digest
+// option, and by their version for version digests.
+func BatchDigests(notifications []Notification) ([]DigestBatch, error) {
+	batches := []DigestBatch{}
+	index := map[string]int{}
+	for _, n := range notifications {
+		if !n.Digest.Batched() {
+			continue
+		}
+		subscriber, err := json.Marshal(n.Subscriber)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem batching notification '%s'", n.ID.Hex())
+		}
+		version := ""
+		if n.Digest.Mode == event.DigestVersion {
+			version = n.Version
+		}
+		key := fmt.Sprintf("%s|%s|%d|%s", subscriber, n.Digest.Mode, n.Digest.IntervalMinutes, version)
+
+		i, ok := index[key]
+		if !ok {
+			i = len(batches)
+			index[key] = i
+			batches = append(batches, DigestBatch{
+				Subscriber: n.Subscriber,
+				Digest:     n.Digest,
+				Version:    version,
+			})
+		}
+		batches[i].Notifications = append(batches[i].Notifications, n)
+	}
+	return batches, nil
+}
+
+// Ready returns whether the batch's digest should be sent. Interval digests
+// are sent once the interval has passed since their first notification.
+// Version digests are sent once their version has finished, or after a day.
+func (b *DigestBatch) Ready(now time.Time, versionFinished bool) bool {
+	if len(b.Notifications) == 0 {
+		return false
+	}
+	first := b.Notifications[0].ID.Time()
+	for _, n := range b.Notifications[1:] {
+		if n.ID.Time().Before(first) {
+			first = n.ID.Time()
+		}
+	}
+
+	switch b.Digest.Mode {
+	case event.DigestInterval:
+		return !now.Before(first.Add(time.Duration(b.Digest.IntervalMinutes) * time.Minute))
+	case event.DigestVersion:
+		return versionFinished || !now.Before(first.Add(maxDigestWait))
+	default:
+		return true
+	}
+}
+
+// Compose returns the digest notification that summarizes the batch, with
+// one line for each notification, grouped by version and build variant.
+func (b *DigestBatch) Compose() (*Notification, error) {
+	if len(b.Notifications) == 0 {
+		return nil, errors.New("can't compose a digest without notifications")
+	}
+
+	title := fmt.Sprintf("Evergreen: %d notifications", len(b.Notifications))
+	if b.Version != "" {
+		title = fmt.Sprintf("%s for version %s", title, b.Version)
+	}
+
+	versions := []string{}
+	grouped := map[string]map[string][]string{}
+	for i := range b.Notifications {
+		n := &b.Notifications[i]
+		if _, ok := grouped[n.Version]; !ok {
+			versions = append(versions, n.Version)
+			grouped[n.Version] = map[string][]string{}
+		}
+		grouped[n.Version][n.BuildVariant] = append(grouped[n.Version][n.BuildVariant], n.Summary())
+	}
+
+	text := &bytes.Buffer{}
+	for _, version := range versions {
+		if version == "" {
+			text.WriteString("Other\n")
+		} else {
+			fmt.Fprintf(text, "Version %s\n", version)
+		}
+		variants := make([]string, 0, len(grouped[version]))
+		for variant := range grouped[version] {
+			variants = append(variants, variant)
+		}
+		sort.Strings(variants)
+		for _, variant := range variants {
+			indent := "  "
+			if variant != "" {
+				fmt.Fprintf(text, "  %s\n", variant)
+				indent = "    "
+			}
+			for _, summary := range grouped[version][variant] {
+				fmt.Fprintf(text, "%s- %s\n", indent, summary)
+			}
+		}
+	}
+
+	digest := &Notification{
+		ID:         bson.NewObjectId(),
+		Subscriber: b.Subscriber,
+		Version:    b.Version,
+		IsDigest:   true,
+	}
+	switch b.Subscriber.Type {
+	case event.EmailSubscriberType:
+		headers := map[string]string{}
+		if first, ok := b.Notifications[0].Payload.(*EmailPayload); ok && first != nil {
+			for k, v := range first.Headers {
+				headers[k] = v
+			}
+		}
+		digest.Payload = &EmailPayload{
+			Headers: headers,
+			Subject: title,
+			Body:    []byte("<pre>" + html.EscapeString(text.String()) + "</pre>"),
+		}
+
+	case event.SlackSubscriberType, event.JIRACommentSubscriberType:
+		payload := title + "\n" + text.String()
+		digest.Payload = &payload
+
+	case event.TeamsSubscriberType, event.ChatWebhookSubscriberType:
+		digest.Payload = &ChatPayload{
+			Title:  title,
+			Text:   text.String(),
+			Status: b.status(),
+		}
+
+	default:
+		return nil, errors.Errorf("notifications to %s subscribers can't be batched", b.Subscriber.Type)
+	}
+
+	return digest, nil
+}
+
+// status returns the status shared by the batch's chat notifications, or
+// an empty string if they differ.
+func (b *DigestBatch) status() string {
+	status := ""
+	for i, n := range b.Notifications {
+		payload, ok := n.Payload.(*ChatPayload)
+		if !ok || payload == nil {
+			return ""
+		}
+		if i > 0 && payload.Status != status {
+			return ""
+		}
+		status = payload.Status
+	}
+	return status
+}
+
+// Summary returns a one line description of the notification, for digests.
+func (n *Notification) Summary() string {
+	summary := ""
+	switch payload := n.Payload.(type) {
+	case *EmailPayload:
+		summary = payload.Subject
+	case *ChatPayload:
+		summary = payload.Title
+		if payload.URL != "" {
+			summary = fmt.Sprintf("%s (%s)", summary, payload.URL)
+		}
+	case *GithubStatusAPIPayload:
+		summary = payload.Description
+	case *message.JiraIssue:
+		summary = payload.Summary
+	case *string:
+		summary = *payload
+	}
+
+	if i := strings.IndexByte(summary, '\n'); i >= 0 {
+		summary = summary[:i]
+	}
+	return strings.TrimSpace(summary)
+}
+
+// MarkDigested records that the notifications with the ids are summarized in
+// the digest notification with the id, so that they are neither sent nor
+// batched again. It returns the number of notifications that were not
+// already summarized in another digest.
+func MarkDigested(ids []bson.ObjectId, digestID bson.ObjectId) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	info, err := db.UpdateAll(NotificationsCollection,
+		bson.M{
+			idKey:       bson.M{"$in": ids},
+			digestIDKey: bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{digestIDKey: digestID}},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "problem marking notifications as digested")
+	}
+	return info.Updated, nil
+}
+
+// UnmarkDigested releases the notifications that were marked as summarized
+// in the digest notification with the id, so that they are batched again.
+func UnmarkDigested(digestID bson.ObjectId) error {
+	_, err := db.UpdateAll(NotificationsCollection,
+		bson.M{digestIDKey: digestID},
+		bson.M{"$unset": bson.M{digestIDKey: 1}},
+	)
+	return errors.Wrap(err, "problem unmarking digested notifications")
+}
+
+// FindUnsentDigests returns the digest notifications created before the
+// time that have not been sent.
+func FindUnsentDigests(before time.Time) ([]Notification, error) {
+	notifications := []Notification{}
+	query := db.Query(bson.M{
+		isDigestKey: true,
+		sentAtKey:   bson.M{"$exists": false},
+		idKey:       bson.M{"$lt": bson.NewObjectIdWithTime(before)},
+	}).Sort([]string{idKey})
+	err := db.FindAllQ(NotificationsCollection, query, &notifications)
+	return notifications, errors.Wrap(err, "problem finding unsent digests")
+}
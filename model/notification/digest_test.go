@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func digestNotification(subscriber event.Subscriber, digest event.Digest, version, variant, subject string, created time.Time) Notification {
+	return Notification{
+		ID:           bson.NewObjectIdWithTime(created),
+		Subscriber:   subscriber,
+		Payload:      &EmailPayload{Subject: subject, Headers: map[string]string{"To": "a@example.com"}},
+		Digest:       digest,
+		Version:      version,
+		BuildVariant: variant,
+	}
+}
+
+func TestBatchDigests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	alice, bob := "alice@example.com", "bob@example.com"
+	toAlice := event.Subscriber{Type: event.EmailSubscriberType, Target: &alice}
+	toBob := event.Subscriber{Type: event.EmailSubscriberType, Target: &bob}
+	perVersion := event.Digest{Mode: event.DigestVersion}
+	hourly := event.Digest{Mode: event.DigestInterval, IntervalMinutes: 60}
+	now := time.Now()
+
+	batches, err := BatchDigests([]Notification{
+		digestNotification(toAlice, perVersion, "v1", "linux", "compile failed", now),
+		digestNotification(toAlice, perVersion, "v1", "windows", "compile failed", now),
+		digestNotification(toAlice, perVersion, "v2", "linux", "lint failed", now),
+		digestNotification(toBob, hourly, "v1", "linux", "compile failed", now),
+		digestNotification(toBob, hourly, "v2", "linux", "lint failed", now),
+		digestNotification(toBob, event.Digest{}, "v2", "linux", "sent immediately", now),
+	})
+	require.NoError(err)
+	require.Len(batches, 3)
+	assert.Equal("v1", batches[0].Version)
+	assert.Len(batches[0].Notifications, 2)
+	assert.Equal("v2", batches[1].Version)
+	assert.Len(batches[1].Notifications, 1)
+	assert.Equal("", batches[2].Version)
+	assert.Len(batches[2].Notifications, 2)
+	assert.Equal(toBob, batches[2].Subscriber)
+}
+
+func TestDigestBatchReady(t *testing.T) {
+	assert := assert.New(t)
+
+	target := "#evergreen"
+	subscriber := event.Subscriber{Type: event.SlackSubscriberType, Target: &target}
+	now := time.Now()
+
+	batch := DigestBatch{
+		Subscriber: subscriber,
+		Digest:     event.Digest{Mode: event.DigestInterval, IntervalMinutes: 10},
+		Notifications: []Notification{
+			digestNotification(subscriber, event.Digest{}, "", "", "a", now.Add(-5*time.Minute)),
+		},
+	}
+	assert.False(batch.Ready(now, false))
+	assert.True(batch.Ready(now.Add(5*time.Minute), false))
+
+	batch.Digest = event.Digest{Mode: event.DigestVersion}
+	assert.False(batch.Ready(now, false))
+	assert.True(batch.Ready(now, true))
+	assert.True(batch.Ready(now.Add(maxDigestWait), false))
+
+	batch.Notifications = nil
+	assert.False(batch.Ready(now, true))
+}
+
+func TestDigestBatchCompose(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	alice := "alice@example.com"
+	subscriber := event.Subscriber{Type: event.EmailSubscriberType, Target: &alice}
+	digest := event.Digest{Mode: event.DigestVersion}
+	now := time.Now()
+	batch := DigestBatch{
+		Subscriber: subscriber,
+		Digest:     digest,
+		Version:    "v1",
+		Notifications: []Notification{
+			digestNotification(subscriber, digest, "v1", "windows", "compile <failed>", now),
+			digestNotification(subscriber, digest, "v1", "linux", "lint failed\nwith details", now),
+			digestNotification(subscriber, digest, "v1", "linux", "test failed", now),
+		},
+	}
+
+	n, err := batch.Compose()
+	require.NoError(err)
+	assert.True(n.ID.Valid())
+	assert.Equal(subscriber, n.Subscriber)
+	assert.False(n.Digest.Batched())
+	payload, ok := n.Payload.(*EmailPayload)
+	require.True(ok)
+	assert.Equal("Evergreen: 3 notifications for version v1", payload.Subject)
+	assert.Equal("a@example.com", payload.Headers["To"])
+	body := string(payload.Body)
+	assert.Contains(body, "compile &lt;failed&gt;")
+	assert.NotContains(body, "with details")
+	assert.True(strings.Index(body, "linux") < strings.Index(body, "windows"))
+	assert.True(strings.Index(body, "lint failed") < strings.Index(body, "test failed"))
+
+	channel := "#evergreen"
+	batch.Subscriber = event.Subscriber{Type: event.SlackSubscriberType, Target: &channel}
+	n, err = batch.Compose()
+	require.NoError(err)
+	text, ok := n.Payload.(*string)
+	require.True(ok)
+	assert.True(strings.HasPrefix(*text, "Evergreen: 3 notifications for version v1\nVersion v1\n  linux\n    - lint failed\n"))
+
+	batch.Subscriber = event.Subscriber{Type: event.GithubPullRequestSubscriberType}
+	_, err = batch.Compose()
+	assert.Error(err)
+
+	batch.Notifications = nil
+	_, err = batch.Compose()
+	assert.Error(err)
+}
+
+func TestMarkDigested(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(NotificationsCollection))
+
+	channel := "#evg"
+	ids := []bson.ObjectId{}
+	for i := 0; i < 2; i++ {
+		payload := "summary"
+		n := Notification{
+			ID:         bson.NewObjectId(),
+			Subscriber: event.Subscriber{Type: event.SlackSubscriberType, Target: &channel},
+			Payload:    &payload,
+			Digest:     event.Digest{Mode: event.DigestVersion},
+		}
+		require.NoError(InsertMany(n))
+		ids = append(ids, n.ID)
+	}
+
+	first, second := bson.NewObjectId(), bson.NewObjectId()
+	claimed, err := MarkDigested(ids[:1], first)
+	require.NoError(err)
+	assert.Equal(1, claimed)
+
+	// a digest that overlaps a claimed batch only claims the rest
+	claimed, err = MarkDigested(ids, second)
+	require.NoError(err)
+	assert.Equal(1, claimed)
+
+	require.NoError(UnmarkDigested(second))
+	pending, err := FindUndigested()
+	require.NoError(err)
+	require.Len(pending, 1)
+	assert.Equal(ids[1], pending[0].ID)
+}
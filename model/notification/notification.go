@@ -24,6 +24,9 @@ var (
 	sentAtKey     = bsonutil.MustHaveTag(Notification{}, "SentAt")
 	errorKey      = bsonutil.MustHaveTag(Notification{}, "Error")
 	payloadKey    = bsonutil.MustHaveTag(Notification{}, "Payload")
+	digestKey     = bsonutil.MustHaveTag(Notification{}, "Digest")
+	digestIDKey   = bsonutil.MustHaveTag(Notification{}, "DigestID")
+	isDigestKey   = bsonutil.MustHaveTag(Notification{}, "IsDigest")
 )
 
 type Notification struct {
@@ -31,6 +34,16 @@ type Notification struct {
 	Subscriber event.Subscriber `bson:"subscriber"`
 	Payload    interface{}      `bson:"payload"`
 
+	// Digest is the digest option of the subscription that the
+	// notification is for. Batched notifications are not sent on their
+	// own, but are summarized in a digest notification, whose id is
+	// recorded in DigestID. IsDigest is set for digest notifications.
+	Digest       event.Digest  `bson:"digest,omitempty"`
+	Version      string        `bson:"version,omitempty"`
+	BuildVariant string        `bson:"build_variant,omitempty"`
+	DigestID     bson.ObjectId `bson:"digest_id,omitempty"`
+	IsDigest     bool          `bson:"is_digest,omitempty"`
+
 	SentAt time.Time `bson:"sent_at,omitempty"`
 	Error  string    `bson:"error,omitempty"`
 }
@@ -40,6 +53,12 @@ type unmarshalNotification struct {
 	Subscriber event.Subscriber `bson:"subscriber"`
 	Payload    bson.Raw         `bson:"payload"`
 
+	Digest       event.Digest  `bson:"digest,omitempty"`
+	Version      string        `bson:"version,omitempty"`
+	BuildVariant string        `bson:"build_variant,omitempty"`
+	DigestID     bson.ObjectId `bson:"digest_id,omitempty"`
+	IsDigest     bool          `bson:"is_digest,omitempty"`
+
 	SentAt time.Time `bson:"sent_at,omitempty"`
 	Error  string    `bson:"error,omitempty"`
 }
@@ -72,6 +91,9 @@ func (n *Notification) SetBSON(raw bson.Raw) error {
 	case event.GithubPullRequestSubscriberType:
 		n.Payload = &GithubStatusAPIPayload{}
 
+	case event.TeamsSubscriberType, event.ChatWebhookSubscriberType:
+		n.Payload = &ChatPayload{}
+
 	default:
 		return errors.Errorf("unknown payload type %s", temp.Subscriber.Type)
 	}
@@ -82,6 +104,11 @@ func (n *Notification) SetBSON(raw bson.Raw) error {
 
 	n.ID = temp.ID
 	n.Subscriber = temp.Subscriber
+	n.Digest = temp.Digest
+	n.Version = temp.Version
+	n.BuildVariant = temp.BuildVariant
+	n.DigestID = temp.DigestID
+	n.IsDigest = temp.IsDigest
 	n.SentAt = temp.SentAt
 	n.Error = temp.Error
 
@@ -219,6 +246,19 @@ func (n *Notification) Composer() (message.Composer, error) {
 			"description": payload.Description,
 		}), nil
 
+	case event.TeamsSubscriberType, event.ChatWebhookSubscriberType:
+		payload, ok := n.Payload.(*ChatPayload)
+		if !ok || payload == nil {
+			return nil, errors.Errorf("%s payload is invalid", n.Subscriber.Type)
+		}
+
+		return message.ConvertToComposer(level.Notice, message.Fields{
+			"title":  payload.Title,
+			"text":   payload.Text,
+			"url":    payload.URL,
+			"status": payload.Status,
+		}), nil
+
 	default:
 		return nil, errors.Errorf("unknown type '%s'", n.Subscriber.Type)
 	}
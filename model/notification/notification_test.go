@@ -336,3 +336,56 @@ func (s *notificationSuite) TestGithubPayload() {
 	_, ok := c.Raw().(message.Fields)
 	s.True(ok)
 }
+
+func (s *notificationSuite) TestTeamsPayload() {
+	s.n.ID = bson.NewObjectId()
+	s.n.Subscriber.Type = event.TeamsSubscriberType
+	url := "https://outlook.office.com/webhook/1234"
+	s.n.Subscriber.Target = &url
+	s.n.Payload = &ChatPayload{
+		Title:  "title",
+		Text:   "text",
+		URL:    "https://example.com",
+		Status: "success",
+	}
+
+	s.NoError(InsertMany(s.n))
+
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.Require().NotNil(n)
+
+	s.Equal(s.n, *n)
+
+	c, err := n.Composer()
+	s.NoError(err)
+	s.Require().NotNil(c)
+
+	requestURL, headers, body, err := n.ChatRequest()
+	s.NoError(err)
+	s.Equal(url, requestURL)
+	s.Empty(headers)
+	s.Contains(string(body), `"themeColor":"2EB886"`)
+}
+
+func (s *notificationSuite) TestChatWebhookPayload() {
+	s.n.ID = bson.NewObjectId()
+	s.n.Subscriber.Type = event.ChatWebhookSubscriberType
+	s.n.Subscriber.Target = &event.ChatWebhookSubscriber{
+		URL:      "https://chat.example.com/hook",
+		Template: `{"msg": {{json .Title}}}`,
+	}
+	s.n.Payload = &ChatPayload{Title: "title", Text: "text"}
+
+	s.NoError(InsertMany(s.n))
+
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.Require().NotNil(n)
+
+	s.Equal(s.n, *n)
+
+	_, _, body, err := n.ChatRequest()
+	s.NoError(err)
+	s.Equal(`{"msg": "title"}`, string(body))
+}
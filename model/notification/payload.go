@@ -12,3 +12,12 @@ type GithubStatusAPIPayload struct {
 	Description string `bson:"description"`
 	URL         string `bson:"url"`
 }
+
+// ChatPayload is a message for a Microsoft Teams or generic chat webhook
+// subscriber. It is also the data given to a chat webhook's template.
+type ChatPayload struct {
+	Title  string `bson:"title" json:"title"`
+	Text   string `bson:"text" json:"text"`
+	URL    string `bson:"url,omitempty" json:"url,omitempty"`
+	Status string `bson:"status,omitempty" json:"status,omitempty"`
+}
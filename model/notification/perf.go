@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+// PerfRegressionPayload returns the payload of the notification of a perf
+// regression to a subscriber of the type.
+func PerfRegressionPayload(subscriberType string, data *event.PerfEventData, uiRoot string) (interface{}, error) {
+	title := fmt.Sprintf("Performance regression: %s %s on %s", data.TestName, data.Metric, data.BuildVariant)
+	text := fmt.Sprintf("%s (%d threads) of task %s in project %s was %g, a %.1f%% change from the baseline mean of %g.",
+		data.Metric, data.ThreadLevel, data.TaskName, data.Project, data.Value, data.PercentChange, data.BaselineMean)
+	if data.StartVersion != "" && data.StartVersion != data.Version {
+		text = fmt.Sprintf("%s The shift started in version %s.", text, data.StartVersion)
+	}
+	url := fmt.Sprintf("%s/version/%s", uiRoot, data.Version)
+
+	switch subscriberType {
+	case event.EmailSubscriberType:
+		return &EmailPayload{
+			Subject: title,
+			Body: []byte(fmt.Sprintf("<p>%s</p><p><a href=\"%s\">View version</a></p>",
+				html.EscapeString(text), html.EscapeString(url))),
+		}, nil
+
+	case event.SlackSubscriberType, event.JIRACommentSubscriberType:
+		payload := fmt.Sprintf("%s\n%s\n%s", title, text, url)
+		return &payload, nil
+
+	case event.TeamsSubscriberType, event.ChatWebhookSubscriberType:
+		return &ChatPayload{
+			Title: title,
+			Text:  text,
+			URL:   url,
+		}, nil
+
+	default:
+		return nil, errors.Errorf("perf regressions can't be sent to %s subscribers", subscriberType)
+	}
+}
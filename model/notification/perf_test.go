@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerfRegressionPayload(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	data := &event.PerfEventData{
+		Project:       "mci",
+		BuildVariant:  "ubuntu",
+		Version:       "v2",
+		StartVersion:  "v1",
+		TaskName:      "perf",
+		TestName:      "insert",
+		Metric:        "ops_per_sec",
+		ThreadLevel:   8,
+		Value:         50,
+		BaselineMean:  100,
+		PercentChange: -50,
+	}
+
+	payload, err := PerfRegressionPayload(event.EmailSubscriberType, data, "https://evergreen.example.com")
+	require.NoError(err)
+	email := payload.(*EmailPayload)
+	assert.Equal("Performance regression: insert ops_per_sec on ubuntu", email.Subject)
+	assert.Contains(string(email.Body), "https://evergreen.example.com/version/v2")
+	assert.Contains(string(email.Body), "-50.0% change")
+	assert.Contains(string(email.Body), "started in version v1")
+
+	payload, err = PerfRegressionPayload(event.SlackSubscriberType, data, "https://evergreen.example.com")
+	require.NoError(err)
+	n := Notification{Payload: payload}
+	assert.Equal("Performance regression: insert ops_per_sec on ubuntu", n.Summary())
+
+	payload, err = PerfRegressionPayload(event.TeamsSubscriberType, data, "https://evergreen.example.com")
+	require.NoError(err)
+	assert.Equal("https://evergreen.example.com/version/v2", payload.(*ChatPayload).URL)
+
+	_, err = PerfRegressionPayload(event.GithubPullRequestSubscriberType, data, "https://evergreen.example.com")
+	assert.Error(err)
+}
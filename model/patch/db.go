@@ -35,6 +35,14 @@ var (
 	ActivatedKey       = bsonutil.MustHaveTag(Patch{}, "Activated")
 	PatchedConfigKey   = bsonutil.MustHaveTag(Patch{}, "PatchedConfig")
 	githubPatchDataKey = bsonutil.MustHaveTag(Patch{}, "GithubPatchData")
+	DebugOnFailureKey  = bsonutil.MustHaveTag(Patch{}, "DebugOnFailureMins")
+	StackKey           = bsonutil.MustHaveTag(Patch{}, "Stack")
+
+	// BSON fields for the patch stack struct
+	stackIDKey         = bsonutil.MustHaveTag(PatchStack{}, "ID")
+	stackPositionKey   = bsonutil.MustHaveTag(PatchStack{}, "Position")
+	stackSequentialKey = bsonutil.MustHaveTag(PatchStack{}, "Sequential")
+	stackFinalizeKey   = bsonutil.MustHaveTag(PatchStack{}, "Finalize")
 
 	// BSON fields for the module patch struct
 	ModulePatchNameKey    = bsonutil.MustHaveTag(ModulePatch{}, "ModuleName")
@@ -45,6 +53,13 @@ var (
 	PatchSetPatchKey   = bsonutil.MustHaveTag(PatchSet{}, "Patch")
 	PatchSetSummaryKey = bsonutil.MustHaveTag(PatchSet{}, "Summary")
 
+	PatchSetCommitKey        = bsonutil.MustHaveTag(PatchSet{}, "Commit")
+	PatchSetCommitMessageKey = bsonutil.MustHaveTag(PatchSet{}, "CommitMessage")
+
+	patchesNameKey          = bsonutil.GetDottedKeyName(PatchesKey, ModulePatchNameKey)
+	patchesCommitKey        = bsonutil.GetDottedKeyName(PatchesKey, "$", ModulePatchSetKey, PatchSetCommitKey)
+	patchesCommitMessageKey = bsonutil.GetDottedKeyName(PatchesKey, "$", ModulePatchSetKey, PatchSetCommitMessageKey)
+
 	// BSON fields for the git patch summary struct
 	GitSummaryNameKey      = bsonutil.MustHaveTag(Summary{}, "Name")
 	GitSummaryAdditionsKey = bsonutil.MustHaveTag(Summary{}, "Additions")
@@ -128,6 +143,35 @@ func ByVersions(versions []string) db.Q {
 	return db.Query(bson.M{VersionKey: bson.M{"$in": versions}})
 }
 
+// ByStack produces a query that returns the patches of a stack, in order.
+func ByStack(stackID string) db.Q {
+	return db.Query(bson.M{
+		bsonutil.GetDottedKeyName(StackKey, stackIDKey): stackID,
+	}).Sort([]string{bsonutil.GetDottedKeyName(StackKey, stackPositionKey)})
+}
+
+// ByStackPosition produces a query that returns the patch at a position
+// of a stack.
+func ByStackPosition(stackID string, position int) db.Q {
+	return db.Query(bson.M{
+		bsonutil.GetDottedKeyName(StackKey, stackIDKey):       stackID,
+		bsonutil.GetDottedKeyName(StackKey, stackPositionKey): position,
+	})
+}
+
+// ByUnfinalizedSequentialStack produces a query that returns the patches
+// of sequential stacks that are waiting for the patches before them to
+// finish before they are finalized.
+func ByUnfinalizedSequentialStack() db.Q {
+	return db.Query(bson.M{
+		ActivatedKey: false,
+		VersionKey:   "",
+		bsonutil.GetDottedKeyName(StackKey, stackSequentialKey): true,
+		bsonutil.GetDottedKeyName(StackKey, stackFinalizeKey):   true,
+		bsonutil.GetDottedKeyName(StackKey, stackPositionKey):   bson.M{"$gt": 1},
+	})
+}
+
 // ExcludePatchDiff is a projection that excludes diff data, helping load times.
 var ExcludePatchDiff = bson.M{
 	bsonutil.GetDottedKeyName(PatchesKey, ModulePatchSetKey, PatchSetPatchKey): 0,
@@ -22,6 +22,9 @@ const (
 
 	// GithubAlias is a special alias to specify default variants and tasks for GitHub pull requests.
 	GithubAlias = "__github"
+
+	// CommitQueueAlias is a special alias to specify default variants and tasks for the commit queue.
+	CommitQueueAlias = "__commit_queue"
 )
 
 // githubIntent represents an intent to create a patch build as a result of a
@@ -6,6 +6,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -46,6 +47,52 @@ type Patch struct {
 	PatchedConfig   string         `bson:"patched_config"`
 	Alias           string         `bson:"alias"`
 	GithubPatchData GithubPatch    `bson:"github_patch_data,omitempty"`
+
+	// DebugOnFailureMins is the number of minutes that the host of a
+	// failed task is held for the author to debug the failure.
+	DebugOnFailureMins int `bson:"debug_on_failure_mins,omitempty"`
+
+	// CommitQueueItem is the pull request that a commit queue patch
+	// tests before it is merged.
+	CommitQueueItem string `bson:"commit_queue_item,omitempty"`
+
+	// Stack links the patch to the other patches created from the
+	// commits of the same local branch.
+	Stack *PatchStack `bson:"stack,omitempty"`
+}
+
+// PatchStack describes a patch's place in a stack of patches, one for
+// each commit of a local branch. Each patch is cumulative: it applies
+// every commit of the branch up to and including its own to the same
+// base commit.
+type PatchStack struct {
+	// ID is the id of the first patch in the stack, which is shared by
+	// all of its patches.
+	ID string `bson:"id" json:"id"`
+	// Position is the 1-based position of the patch in the stack, and
+	// Size is the number of patches in the stack.
+	Position int `bson:"position" json:"position"`
+	Size     int `bson:"size" json:"size"`
+
+	// Sequential stacks run one patch at a time, in order: each patch
+	// is finalized once the patch before it finishes, if Finalize is
+	// set. Otherwise all of the stack's patches run in parallel.
+	Sequential bool `bson:"sequential" json:"sequential"`
+	Finalize   bool `bson:"finalize" json:"finalize"`
+}
+
+// Validate returns an error if the stack's position or size is invalid.
+func (s *PatchStack) Validate() error {
+	if s.Size < 2 {
+		return errors.Errorf("a patch stack must have at least 2 patches, not %d", s.Size)
+	}
+	if s.Position < 1 || s.Position > s.Size {
+		return errors.Errorf("position %d is not in a patch stack of %d patches", s.Position, s.Size)
+	}
+	if s.Position > 1 && !IsValidId(s.ID) {
+		return errors.Errorf("'%s' is not a valid id for the first patch of the stack", s.ID)
+	}
+	return nil
 }
 
 // GithubPatch stores patch data for patches create from GitHub pull requests
@@ -73,6 +120,11 @@ type PatchSet struct {
 	Patch       string    `bson:"patch,omitempty"`
 	PatchFileId string    `bson:"patch_file_id,omitempty"`
 	Summary     []Summary `bson:"summary"`
+
+	// Commit and CommitMessage identify the local commit that the
+	// patch ends at, for patches in a stack.
+	Commit        string `bson:"commit,omitempty"`
+	CommitMessage string `bson:"commit_message,omitempty"`
 }
 
 // Summary stores summary patch information
@@ -95,6 +147,74 @@ func (p *Patch) SetDescription(desc string) error {
 	)
 }
 
+// SetDebugOnFailure sets the number of minutes that hosts are held for
+// debugging when the patch's tasks fail.
+func (p *Patch) SetDebugOnFailure(mins int) error {
+	p.DebugOnFailureMins = mins
+	return UpdateOne(
+		bson.M{IdKey: p.Id},
+		bson.M{
+			"$set": bson.M{
+				DebugOnFailureKey: mins,
+			},
+		},
+	)
+}
+
+// SetStack records the patch's place in a stack of patches, and the local
+// commit that its main patch ends at. The first patch of a stack is
+// identified by its own id.
+func (p *Patch) SetStack(stack PatchStack, commit, commitMessage string) error {
+	if stack.Position == 1 {
+		stack.ID = p.Id.Hex()
+	}
+	if err := stack.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	err := UpdateOne(
+		bson.M{
+			IdKey:          p.Id,
+			patchesNameKey: "",
+		},
+		bson.M{
+			"$set": bson.M{
+				StackKey:                stack,
+				patchesCommitKey:        commit,
+				patchesCommitMessageKey: commitMessage,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "problem setting stack of patch %s", p.Id.Hex())
+	}
+
+	p.Stack = &stack
+	for i := range p.Patches {
+		if p.Patches[i].ModuleName == "" {
+			p.Patches[i].PatchSet.Commit = commit
+			p.Patches[i].PatchSet.CommitMessage = commitMessage
+		}
+	}
+	return nil
+}
+
+// MainCommit returns the local commit that the patch's main patch ends
+// at, and its message.
+func (p *Patch) MainCommit() (string, string) {
+	for _, modulePatch := range p.Patches {
+		if modulePatch.ModuleName == "" {
+			return modulePatch.PatchSet.Commit, modulePatch.PatchSet.CommitMessage
+		}
+	}
+	return "", ""
+}
+
+// IsFinished returns true if the patch's version has finished.
+func (p *Patch) IsFinished() bool {
+	return p.Status == evergreen.PatchSucceeded || p.Status == evergreen.PatchFailed
+}
+
 // ClearPatchData removes any inline patch data stored in this patch object for patches that have
 // an associated id in gridfs, so that it can be stored properly.
 func (p *Patch) ClearPatchData() {
@@ -253,6 +373,21 @@ func (p *Patch) ConfigChanged(remotePath string) bool {
 	return false
 }
 
+// ChangedFiles returns the names of the files that the patch changes in
+// the project's own repository, excluding changes to modules.
+func (p *Patch) ChangedFiles() []string {
+	files := []string{}
+	for _, patchPart := range p.Patches {
+		if patchPart.ModuleName != "" {
+			continue
+		}
+		for _, summary := range patchPart.PatchSet.Summary {
+			files = append(files, summary.Name)
+		}
+	}
+	return files
+}
+
 // SetActivated sets the patch to activated in the db
 func (p *Patch) SetActivated(versionId string) error {
 	p.Version = versionId
@@ -328,3 +463,9 @@ func (p *Patch) RemoveModulePatch(moduleName string) error {
 func (p *Patch) IsGithubPRPatch() bool {
 	return p.GithubPatchData.PRNumber != 0
 }
+
+// IsCommitQueuePatch returns true if the patch tests a pull request in a
+// commit queue.
+func (p *Patch) IsCommitQueuePatch() bool {
+	return p.CommitQueueItem != ""
+}
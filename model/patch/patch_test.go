@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func TestConfigChanged(t *testing.T) {
@@ -32,6 +33,19 @@ func TestConfigChanged(t *testing.T) {
 	assert.False(p.ConfigChanged(remoteConfigPath))
 }
 
+func TestPatchStackValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	first := bson.NewObjectId().Hex()
+	assert.NoError((&PatchStack{Position: 1, Size: 3}).Validate())
+	assert.NoError((&PatchStack{ID: first, Position: 3, Size: 3}).Validate())
+
+	assert.Error((&PatchStack{ID: first, Position: 1, Size: 1}).Validate())
+	assert.Error((&PatchStack{ID: first, Position: 0, Size: 3}).Validate())
+	assert.Error((&PatchStack{ID: first, Position: 4, Size: 3}).Validate())
+	assert.Error((&PatchStack{Position: 2, Size: 3}).Validate())
+}
+
 type patchSuite struct {
 	suite.Suite
 	testConfig *evergreen.Settings
@@ -11,6 +11,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/subprocess"
@@ -106,9 +107,41 @@ func IncludePatchDependencies(project *Project, tvpairs []TVPair) []TVPair {
 
 // MakePatchedConfig takes in the path to a remote configuration a stringified version
 // of the current project and returns an unmarshalled version of the project
-// with the patch applied
-func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string) (
-	*Project, error) {
+// with the patch applied. The values files of matrix axes are read with load.
+func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string,
+	load AxisValuesLoader) (*Project, error) {
+	data, err := patchFile(ctx, p, remoteConfigPath, projectConfig)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	project := &Project{}
+	if err = LoadProjectIntoWithAxisValues(data, p.Project, project, load); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return project, nil
+}
+
+// PatchedAxisValuesLoader returns an AxisValuesLoader that reads files with
+// load and applies the patch to the files that it changes, so that the values
+// of matrix axes come from the patched tree. Files that the patch adds don't
+// need to exist.
+func PatchedAxisValuesLoader(ctx context.Context, p *patch.Patch, load AxisValuesLoader) AxisValuesLoader {
+	return func(path string) ([]byte, error) {
+		if !p.ConfigChanged(path) {
+			return load(path)
+		}
+		data, err := load(path)
+		if err != nil && !thirdparty.IsFileNotFound(errors.Cause(err)) {
+			return nil, err
+		}
+		return patchFile(ctx, p, path, string(data))
+	}
+}
+
+// patchFile applies the project's part of the patch to the contents of the
+// file at the path, relative to the root of the repository, and returns the
+// patched contents.
+func patchFile(ctx context.Context, p *patch.Patch, path, contents string) ([]byte, error) {
 	for _, patchPart := range p.Patches {
 		// we only need to patch the main project and not any other modules
 		if patchPart.ModuleName != "" {
@@ -141,10 +174,10 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		}
 
 		defer os.Remove(patchFilePath) //nolint: evg
-		// write project configuration
-		configFilePath, err := util.WriteToTempFile(projectConfig)
+		// write the file to patch
+		configFilePath, err := util.WriteToTempFile(contents)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not write config file")
+			return nil, errors.Wrapf(err, "could not write file '%s'", path)
 		}
 		defer os.Remove(configFilePath) //nolint: evg
 
@@ -152,10 +185,10 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		workingDirectory := filepath.Dir(patchFilePath)
 		localConfigPath := filepath.Join(
 			workingDirectory,
-			remoteConfigPath,
+			path,
 		)
 		parentDir := strings.Split(
-			remoteConfigPath,
+			path,
 			string(os.PathSeparator),
 		)[0]
 		err = os.RemoveAll(filepath.Join(workingDirectory, parentDir))
@@ -165,9 +198,9 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		if err = os.MkdirAll(filepath.Dir(localConfigPath), 0755); err != nil {
 			return nil, errors.WithStack(err)
 		}
-		// rename the temporary config file name to the remote config
-		// file path if we are patching an existing remote config
-		if len(projectConfig) > 0 {
+		// rename the temporary file name to the remote file path if we
+		// are patching an existing remote file
+		if len(contents) > 0 {
 			if err = os.Rename(configFilePath, localConfigPath); err != nil {
 				return nil, errors.Wrapf(err, "could not rename file '%v' to '%v'",
 					configFilePath, localConfigPath)
@@ -175,12 +208,12 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 			defer os.Remove(localConfigPath)
 		}
 
-		// selectively apply the patch to the config file
+		// selectively apply the patch to the file
 		patchCommandStrings := []string{
 			fmt.Sprintf("set -o xtrace"),
 			fmt.Sprintf("set -o errexit"),
 			fmt.Sprintf("git apply --whitespace=fix --include=%v < '%v'",
-				remoteConfigPath, patchFilePath),
+				path, patchFilePath),
 		}
 
 		stderr := send.MakeWriterSender(grip.GetSender(), level.Error)
@@ -203,16 +236,12 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		if err = patchCmd.Run(ctx); err != nil {
 			return nil, errors.Errorf("could not run patch command: %v", err)
 		}
-		// read in the patched config file
+		// read in the patched file
 		data, err := ioutil.ReadFile(localConfigPath)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not read patched config file")
+			return nil, errors.Wrapf(err, "could not read patched file '%s'", path)
 		}
-		project := &Project{}
-		if err = LoadProjectInto(data, p.Project, project); err != nil {
-			return nil, errors.WithStack(err)
-		}
-		return project, nil
+		return data, nil
 	}
 	return nil, errors.New("no patch on project")
 }
@@ -281,6 +310,7 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 	}
 
 	taskIds := NewPatchTaskIdTable(project, patchVersion, tasks)
+	changedFiles := p.ChangedFiles()
 	variantsProcessed := map[string]bool{}
 	for _, vt := range p.VariantsTasks {
 		if _, ok := variantsProcessed[vt.Variant]; ok {
@@ -297,11 +327,26 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
+		activated := true
+		if len(changedFiles) > 0 && project.HasPathFilters() {
+			var b *build.Build
+			b, err = build.FindOne(build.ById(buildId))
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			if b == nil {
+				return nil, errors.Errorf("build %s not found", buildId)
+			}
+			activated, err = FilterBuildTasksByChangedFiles(project, b, changedFiles)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
 		patchVersion.BuildIds = append(patchVersion.BuildIds, buildId)
 		patchVersion.BuildVariants = append(patchVersion.BuildVariants,
 			version.BuildStatus{
 				BuildVariant: vt.Variant,
-				Activated:    true,
+				Activated:    activated,
 				BuildId:      buildId,
 			},
 		)
@@ -17,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 )
@@ -51,7 +52,7 @@ func TestMakePatchedConfig(t *testing.T) {
 			}
 			projectBytes, err := ioutil.ReadFile(filepath.Join(cwd, "testdata", "project.config"))
 			So(err, ShouldBeNil)
-			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, string(projectBytes))
+			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, string(projectBytes), nil)
 			So(err, ShouldBeNil)
 			So(project, ShouldNotBeNil)
 			So(len(project.Tasks), ShouldEqual, 2)
@@ -70,7 +71,7 @@ func TestMakePatchedConfig(t *testing.T) {
 				}},
 			}
 
-			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, "")
+			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, "", nil)
 			So(err, ShouldBeNil)
 			So(project, ShouldNotBeNil)
 			So(len(project.Tasks), ShouldEqual, 1)
@@ -83,6 +84,51 @@ func TestMakePatchedConfig(t *testing.T) {
 	})
 }
 
+func TestPatchedAxisValuesLoader(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	valuesPath := filepath.Join("etc", "versions.yml")
+	p := &patch.Patch{
+		Patches: []patch.ModulePatch{{
+			Githash: "revision",
+			PatchSet: patch.PatchSet{
+				Patch: fmt.Sprintf(`diff --git a/%[1]s b/%[1]s
+--- a/%[1]s
++++ b/%[1]s
+@@ -1 +1,2 @@
+ - id: "4.0"
++- id: "4.2"
+`, valuesPath),
+				Summary: []patch.Summary{{Name: valuesPath, Additions: 1}},
+			},
+		}},
+	}
+	files := map[string]string{
+		valuesPath:  "- id: \"4.0\"\n",
+		"other.yml": "- id: \"3.6\"\n",
+	}
+	load := PatchedAxisValuesLoader(ctx, p, func(path string) ([]byte, error) {
+		contents, ok := files[path]
+		if !ok {
+			return nil, errors.Errorf("file '%s' not found", path)
+		}
+		return []byte(contents), nil
+	})
+
+	data, err := load(valuesPath)
+	assert.NoError(err)
+	assert.Equal("- id: \"4.0\"\n- id: \"4.2\"\n", string(data))
+
+	data, err = load("other.yml")
+	assert.NoError(err)
+	assert.Equal(files["other.yml"], string(data))
+
+	_, err = load("missing.yml")
+	assert.Error(err)
+}
+
 // shouldContainPair returns a blank string if its arguments resemble each other, and returns a
 // list of pretty-printed diffs between the objects if they do not match.
 func shouldContainPair(actual interface{}, expected ...interface{}) string {
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// PatchStackSummary summarizes the patches of a stack, one for each commit
+// of a local branch, and the commits that broke tasks.
+type PatchStackSummary struct {
+	StackID string            `json:"stack_id"`
+	Patches []PatchStackEntry `json:"patches"`
+	// Breaks are the tasks that failed in some patch of the stack, each
+	// with the first patch in which it failed.
+	Breaks []PatchStackBreak `json:"breaks"`
+}
+
+// PatchStackEntry is a patch of a stack.
+type PatchStackEntry struct {
+	Position      int    `json:"position"`
+	PatchID       string `json:"patch_id"`
+	Commit        string `json:"commit"`
+	CommitMessage string `json:"commit_message"`
+	Status        string `json:"status"`
+	Activated     bool   `json:"activated"`
+}
+
+// PatchStackBreak is the first patch of a stack in which a task failed.
+type PatchStackBreak struct {
+	BuildVariant  string `json:"build_variant"`
+	TaskName      string `json:"task_name"`
+	TaskID        string `json:"task_id"`
+	Position      int    `json:"position"`
+	PatchID       string `json:"patch_id"`
+	Commit        string `json:"commit"`
+	CommitMessage string `json:"commit_message"`
+}
+
+// GetPatchStackSummary returns the summary of the stack of patches whose
+// first patch has the given id.
+func GetPatchStackSummary(stackID string) (*PatchStackSummary, error) {
+	patches, err := patch.Find(patch.ByStack(stackID).Project(patch.ExcludePatchDiff))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding patches of stack %s", stackID)
+	}
+	if len(patches) == 0 {
+		return nil, nil
+	}
+
+	versions := []string{}
+	for _, p := range patches {
+		if p.Version != "" {
+			versions = append(versions, p.Version)
+		}
+	}
+	tasks := []task.Task{}
+	if len(versions) > 0 {
+		tasks, err = task.Find(db.Query(bson.M{
+			task.VersionKey: bson.M{"$in": versions},
+		}).WithFields(task.IdKey, task.VersionKey, task.BuildVariantKey, task.DisplayNameKey, task.StatusKey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding tasks of stack %s", stackID)
+		}
+	}
+
+	return summarizePatchStack(stackID, patches, tasks), nil
+}
+
+// summarizePatchStack builds the summary of a stack from its patches, in
+// order, and the tasks of their versions.
+func summarizePatchStack(stackID string, patches []patch.Patch, tasks []task.Task) *PatchStackSummary {
+	summary := &PatchStackSummary{
+		StackID: stackID,
+		Patches: make([]PatchStackEntry, 0, len(patches)),
+		Breaks:  []PatchStackBreak{},
+	}
+
+	entries := map[string]PatchStackEntry{}
+	for _, p := range patches {
+		commit, message := p.MainCommit()
+		entry := PatchStackEntry{
+			PatchID:       p.Id.Hex(),
+			Commit:        commit,
+			CommitMessage: message,
+			Status:        p.Status,
+			Activated:     p.Activated,
+		}
+		if p.Stack != nil {
+			entry.Position = p.Stack.Position
+		}
+		summary.Patches = append(summary.Patches, entry)
+		if p.Version != "" {
+			entries[p.Version] = entry
+		}
+	}
+
+	type taskKey struct {
+		variant string
+		name    string
+	}
+	breaks := map[taskKey]PatchStackBreak{}
+	for _, t := range tasks {
+		if t.Status != evergreen.TaskFailed {
+			continue
+		}
+		entry, ok := entries[t.Version]
+		if !ok {
+			continue
+		}
+		key := taskKey{variant: t.BuildVariant, name: t.DisplayName}
+		if existing, ok := breaks[key]; ok && existing.Position <= entry.Position {
+			continue
+		}
+		breaks[key] = PatchStackBreak{
+			BuildVariant:  t.BuildVariant,
+			TaskName:      t.DisplayName,
+			TaskID:        t.Id,
+			Position:      entry.Position,
+			PatchID:       entry.PatchID,
+			Commit:        entry.Commit,
+			CommitMessage: entry.CommitMessage,
+		}
+	}
+
+	for _, b := range breaks {
+		summary.Breaks = append(summary.Breaks, b)
+	}
+	sort.Slice(summary.Breaks, func(i, j int) bool {
+		if summary.Breaks[i].Position != summary.Breaks[j].Position {
+			return summary.Breaks[i].Position < summary.Breaks[j].Position
+		}
+		if summary.Breaks[i].BuildVariant != summary.Breaks[j].BuildVariant {
+			return summary.Breaks[i].BuildVariant < summary.Breaks[j].BuildVariant
+		}
+		return summary.Breaks[i].TaskName < summary.Breaks[j].TaskName
+	})
+
+	return summary
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestSummarizePatchStack(t *testing.T) {
+	assert := assert.New(t)
+
+	patches := []patch.Patch{}
+	for i, commit := range []string{"a", "b", "c"} {
+		patches = append(patches, patch.Patch{
+			Id:      bson.NewObjectId(),
+			Version: commit + "_version",
+			Status:  evergreen.PatchFailed,
+			Stack:   &patch.PatchStack{ID: "stack", Position: i + 1, Size: 3},
+			Patches: []patch.ModulePatch{
+				{PatchSet: patch.PatchSet{Commit: commit, CommitMessage: "commit " + commit}},
+			},
+		})
+	}
+	tasks := []task.Task{
+		{Id: "a1", Version: "a_version", BuildVariant: "linux", DisplayName: "compile", Status: evergreen.TaskSucceeded},
+		{Id: "b1", Version: "b_version", BuildVariant: "linux", DisplayName: "compile", Status: evergreen.TaskSucceeded},
+		{Id: "b2", Version: "b_version", BuildVariant: "linux", DisplayName: "test", Status: evergreen.TaskFailed},
+		{Id: "c1", Version: "c_version", BuildVariant: "linux", DisplayName: "compile", Status: evergreen.TaskFailed},
+		{Id: "c2", Version: "c_version", BuildVariant: "linux", DisplayName: "test", Status: evergreen.TaskFailed},
+		{Id: "other", Version: "other_version", BuildVariant: "linux", DisplayName: "lint", Status: evergreen.TaskFailed},
+	}
+
+	summary := summarizePatchStack("stack", patches, tasks)
+	assert.Equal("stack", summary.StackID)
+	assert.Len(summary.Patches, 3)
+	assert.Equal(2, summary.Patches[1].Position)
+	assert.Equal("b", summary.Patches[1].Commit)
+	assert.Equal("commit b", summary.Patches[1].CommitMessage)
+
+	if assert.Len(summary.Breaks, 2) {
+		assert.Equal("test", summary.Breaks[0].TaskName)
+		assert.Equal(2, summary.Breaks[0].Position)
+		assert.Equal("b", summary.Breaks[0].Commit)
+		assert.Equal("b2", summary.Breaks[0].TaskID)
+		assert.Equal("compile", summary.Breaks[1].TaskName)
+		assert.Equal(3, summary.Breaks[1].Position)
+		assert.Equal("c1", summary.Breaks[1].TaskID)
+	}
+}
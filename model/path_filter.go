@@ -0,0 +1,177 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+	ignore "github.com/sabhiram/go-git-ignore"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// affectedByFiles returns true if any of the files matches the paths, or
+// there are no paths, and does not match the ignore paths. An empty list
+// of files affects everything, since the changes are not known.
+func affectedByFiles(paths, ignorePaths, files []string) bool {
+	if len(files) == 0 || (len(paths) == 0 && len(ignorePaths) == 0) {
+		return true
+	}
+
+	// CompileIgnoreLines always returns a nil error.
+	var includer, ignorer *ignore.GitIgnore
+	if len(paths) > 0 {
+		includer, _ = ignore.CompileIgnoreLines(paths...)
+	}
+	if len(ignorePaths) > 0 {
+		ignorer, _ = ignore.CompileIgnoreLines(ignorePaths...)
+	}
+
+	for _, f := range files {
+		if includer != nil && !includer.MatchesPath(f) {
+			continue
+		}
+		if ignorer != nil && ignorer.MatchesPath(f) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// HasPathFilters returns true if any build variant or task of the project
+// sets paths or ignore_paths.
+func (p *Project) HasPathFilters() bool {
+	for _, bv := range p.BuildVariants {
+		if len(bv.Paths) > 0 || len(bv.IgnorePaths) > 0 {
+			return true
+		}
+	}
+	for _, t := range p.Tasks {
+		if len(t.Paths) > 0 || len(t.IgnorePaths) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// VariantAffectedByFiles returns true if the build variant should run for
+// a change to the files.
+func (p *Project) VariantAffectedByFiles(variant string, files []string) bool {
+	bv := p.FindBuildVariant(variant)
+	if bv == nil {
+		return true
+	}
+	return affectedByFiles(bv.Paths, bv.IgnorePaths, files)
+}
+
+// TaskAffectedByFiles returns true if the task should run for a change to
+// the files. Only the task's own paths are considered, not its variant's.
+func (p *Project) TaskAffectedByFiles(taskName string, files []string) bool {
+	pt := p.FindProjectTask(taskName)
+	if pt == nil {
+		return true
+	}
+	return affectedByFiles(pt.Paths, pt.IgnorePaths, files)
+}
+
+// unaffectedTaskIds returns the ids of the tasks of a variant that none of
+// the files affect. Tasks that an affected task depends on, directly or
+// indirectly, are treated as affected so that the affected task can still
+// run. A display task is affected if any of its execution tasks are.
+func unaffectedTaskIds(project *Project, variant string, tasks []task.Task, files []string) []string {
+	if !project.VariantAffectedByFiles(variant, files) {
+		ids := make([]string, 0, len(tasks))
+		for _, t := range tasks {
+			ids = append(ids, t.Id)
+		}
+		return ids
+	}
+
+	byId := map[string]*task.Task{}
+	for i := range tasks {
+		byId[tasks[i].Id] = &tasks[i]
+	}
+
+	affected := map[string]bool{}
+	toVisit := []string{}
+	for _, t := range tasks {
+		if t.DisplayOnly {
+			continue
+		}
+		if project.TaskAffectedByFiles(t.DisplayName, files) {
+			affected[t.Id] = true
+			toVisit = append(toVisit, t.Id)
+		}
+	}
+	for len(toVisit) > 0 {
+		t := byId[toVisit[0]]
+		toVisit = toVisit[1:]
+		for _, dep := range t.DependsOn {
+			if _, ok := byId[dep.TaskId]; ok && !affected[dep.TaskId] {
+				affected[dep.TaskId] = true
+				toVisit = append(toVisit, dep.TaskId)
+			}
+		}
+	}
+
+	unaffected := []string{}
+	for _, t := range tasks {
+		if t.DisplayOnly {
+			for _, et := range t.ExecutionTasks {
+				if affected[et] {
+					affected[t.Id] = true
+					break
+				}
+			}
+		}
+		if !affected[t.Id] {
+			unaffected = append(unaffected, t.Id)
+		}
+	}
+	return unaffected
+}
+
+// FilterBuildTasksByChangedFiles deactivates the tasks of the build that
+// none of the changed files affect, and marks them so that the system
+// does not activate them along with the build. If no task of the build is
+// affected, the build is deactivated too. It returns true if the build is
+// still active.
+func FilterBuildTasksByChangedFiles(project *Project, b *build.Build, files []string) (bool, error) {
+	if len(files) == 0 || !project.HasPathFilters() {
+		return b.Activated, nil
+	}
+
+	tasks, err := task.Find(task.ByBuildId(b.Id))
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding tasks for build %s", b.Id)
+	}
+
+	ids := unaffectedTaskIds(project, b.BuildVariant, tasks, files)
+	if len(ids) == 0 {
+		return b.Activated, nil
+	}
+
+	_, err = task.UpdateAll(
+		bson.M{
+			task.IdKey:     bson.M{"$in": ids},
+			task.StatusKey: evergreen.TaskUndispatched,
+		},
+		bson.M{"$set": bson.M{
+			task.ActivatedKey:    false,
+			task.PathFilteredKey: true,
+		}},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "problem deactivating tasks unaffected by changed files in build %s", b.Id)
+	}
+
+	active := b.Activated
+	if len(ids) == len(tasks) && b.Activated {
+		if err = build.UpdateActivation(b.Id, false, evergreen.DefaultTaskActivator); err != nil {
+			return false, errors.WithStack(err)
+		}
+		active = false
+	}
+
+	return active, errors.WithStack(RefreshTasksCache(b.Id))
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAffectedByFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	files := []string{"frontend/src/app.js", "frontend/README.md"}
+	assert.True(affectedByFiles(nil, nil, files))
+	assert.True(affectedByFiles([]string{"backend/"}, nil, nil))
+	assert.True(affectedByFiles([]string{"frontend/"}, nil, files))
+	assert.False(affectedByFiles([]string{"backend/"}, nil, files))
+	assert.True(affectedByFiles(nil, []string{"*.md"}, files))
+	assert.False(affectedByFiles(nil, []string{"frontend/"}, files))
+	assert.False(affectedByFiles([]string{"frontend/"}, []string{"*.md", "*.js"}, files))
+	assert.True(affectedByFiles([]string{"frontend/"}, []string{"*.md"}, files))
+}
+
+func TestPathsParsing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+- name: ui
+  paths: frontend/
+- name: unit
+  ignore_paths: ["*.md", docs/]
+- name: lint
+buildvariants:
+- name: bv
+  paths: [frontend/, backend/]
+  tasks:
+  - name: ui
+  - name: unit
+  - name: lint
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	require.NotNil(proj)
+	assert.Empty(errs)
+	require.Len(proj.BuildVariants, 1)
+	assert.Equal([]string{"frontend/", "backend/"}, proj.BuildVariants[0].Paths)
+	require.Len(proj.Tasks, 3)
+	assert.Equal([]string{"frontend/"}, proj.Tasks[0].Paths)
+	assert.Equal([]string{"*.md", "docs/"}, proj.Tasks[1].IgnorePaths)
+	assert.True(proj.HasPathFilters())
+
+	assert.True(proj.VariantAffectedByFiles("bv", []string{"backend/main.go"}))
+	assert.False(proj.VariantAffectedByFiles("bv", []string{"docs/index.md"}))
+	assert.False(proj.TaskAffectedByFiles("ui", []string{"backend/main.go"}))
+	assert.True(proj.TaskAffectedByFiles("unit", []string{"backend/main.go"}))
+	assert.False(proj.TaskAffectedByFiles("unit", []string{"backend/README.md"}))
+	assert.True(proj.TaskAffectedByFiles("lint", []string{"backend/README.md"}))
+}
+
+func TestUnaffectedTaskIds(t *testing.T) {
+	assert := assert.New(t)
+
+	project := &Project{
+		BuildVariants: BuildVariants{
+			{Name: "bv"},
+			{Name: "ui_only", Paths: []string{"frontend/"}},
+		},
+		Tasks: []ProjectTask{
+			{Name: "ui", Paths: []string{"frontend/"}},
+			{Name: "ui_deps", Paths: []string{"frontend/"}},
+			{Name: "backend", Paths: []string{"backend/"}},
+			{Name: "compile_backend_only", Paths: []string{"backend/"}},
+		},
+	}
+	tasks := []task.Task{
+		{Id: "ui_deps", DisplayName: "ui_deps"},
+		{Id: "ui", DisplayName: "ui", DependsOn: []task.Dependency{{TaskId: "ui_deps"}}},
+		{Id: "backend", DisplayName: "backend", DependsOn: []task.Dependency{{TaskId: "compile_backend"}}},
+		{Id: "compile_backend", DisplayName: "compile_backend_only"},
+		{Id: "display", DisplayName: "display", DisplayOnly: true, ExecutionTasks: []string{"backend"}},
+	}
+
+	// only the frontend changed, so the backend task, the task it depends
+	// on, and its display task don't need to run
+	ids := unaffectedTaskIds(project, "bv", tasks, []string{"frontend/app.js"})
+	assert.Equal([]string{"backend", "compile_backend", "display"}, ids)
+
+	// the backend task needs the task it depends on even though its own
+	// paths don't match
+	project.Tasks[3].Paths = []string{"nothing/"}
+	ids = unaffectedTaskIds(project, "bv", tasks, []string{"backend/main.go"})
+	assert.Equal([]string{"ui_deps", "ui"}, ids)
+
+	// every task of an unaffected variant is unaffected
+	ids = unaffectedTaskIds(project, "ui_only", tasks, []string{"backend/main.go"})
+	assert.Len(ids, len(tasks))
+}
@@ -0,0 +1,370 @@
+package perfresult
+
+import (
+	"math"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Collection is the name of the performance results collection in the database.
+	Collection = "perf_results"
+
+	// HistorySize is the number of previous mainline results that a
+	// result is compared against to detect a change point.
+	HistorySize = 20
+
+	// minHistorySize is the number of baseline results required before
+	// change points are detected.
+	minHistorySize = 5
+
+	// changePointWindow is the number of most recent results, including
+	// the new one, whose mean is compared against the baseline of the
+	// results before them.
+	changePointWindow = 3
+
+	// changePointThreshold is the number of standard errors that the
+	// mean of the window must be from the mean of the baseline for the
+	// window to start a change point.
+	changePointThreshold = 3.0
+
+	// minPercentChange is the smallest relative difference from the
+	// mean of the history that is considered a change point, which
+	// avoids reporting noise on very stable series.
+	minPercentChange = 5.0
+)
+
+// PerfResult is a single measurement of a metric for a test, reported
+// by the perf.send command.
+type PerfResult struct {
+	ID bson.ObjectId `bson:"_id,omitempty" json:"-"`
+
+	// These fields are reported by the task.
+	TestName      string  `bson:"test_name" json:"test_name"`
+	Metric        string  `bson:"metric" json:"metric"`
+	Value         float64 `bson:"value" json:"value"`
+	Unit          string  `bson:"unit,omitempty" json:"unit,omitempty"`
+	ThreadLevel   int     `bson:"thread_level" json:"thread_level,omitempty"`
+	LowerIsBetter bool    `bson:"lower_is_better,omitempty" json:"lower_is_better,omitempty"`
+
+	// These fields identify the task that reported the result, and
+	// are populated by the API server.
+	TaskID       string    `bson:"task_id" json:"task_id"`
+	Execution    int       `bson:"task_execution" json:"task_execution"`
+	TaskName     string    `bson:"task_name" json:"task_name"`
+	Project      string    `bson:"project" json:"project"`
+	BuildVariant string    `bson:"build_variant" json:"build_variant"`
+	Version      string    `bson:"version" json:"version"`
+	Revision     string    `bson:"revision" json:"revision"`
+	Order        int       `bson:"order" json:"order"`
+	Requester    string    `bson:"requester" json:"requester"`
+	CreateTime   time.Time `bson:"create_time" json:"create_time"`
+
+	// ChangePoint is set when the result differs significantly from
+	// the results of previous mainline versions.
+	ChangePoint *ChangePoint `bson:"change_point,omitempty" json:"change_point,omitempty"`
+}
+
+// ChangePoint describes how the most recent results of a series differ
+// from the baseline before them.
+type ChangePoint struct {
+	BaselineMean   float64 `bson:"baseline_mean" json:"baseline_mean"`
+	BaselineStdDev float64 `bson:"baseline_std_dev" json:"baseline_std_dev"`
+	WindowMean     float64 `bson:"window_mean" json:"window_mean"`
+	PercentChange  float64 `bson:"percent_change" json:"percent_change"`
+	HistorySize    int     `bson:"history_size" json:"history_size"`
+	// StartVersion is the version of the first result in the window,
+	// where the shift started.
+	StartVersion string    `bson:"start_version" json:"start_version"`
+	Regression   bool      `bson:"regression" json:"regression"`
+	DetectedAt   time.Time `bson:"detected_at" json:"detected_at"`
+}
+
+var (
+	// BSON fields for the perf result struct
+	IDKey             = bsonutil.MustHaveTag(PerfResult{}, "ID")
+	TestNameKey       = bsonutil.MustHaveTag(PerfResult{}, "TestName")
+	MetricKey         = bsonutil.MustHaveTag(PerfResult{}, "Metric")
+	ValueKey          = bsonutil.MustHaveTag(PerfResult{}, "Value")
+	ThreadLevelKey    = bsonutil.MustHaveTag(PerfResult{}, "ThreadLevel")
+	TaskIDKey         = bsonutil.MustHaveTag(PerfResult{}, "TaskID")
+	ExecutionKey      = bsonutil.MustHaveTag(PerfResult{}, "Execution")
+	TaskNameKey       = bsonutil.MustHaveTag(PerfResult{}, "TaskName")
+	ProjectKey        = bsonutil.MustHaveTag(PerfResult{}, "Project")
+	BuildVariantKey   = bsonutil.MustHaveTag(PerfResult{}, "BuildVariant")
+	VersionKey        = bsonutil.MustHaveTag(PerfResult{}, "Version")
+	OrderKey          = bsonutil.MustHaveTag(PerfResult{}, "Order")
+	RequesterKey      = bsonutil.MustHaveTag(PerfResult{}, "Requester")
+	CreateTimeKey     = bsonutil.MustHaveTag(PerfResult{}, "CreateTime")
+	ChangePointKey    = bsonutil.MustHaveTag(PerfResult{}, "ChangePoint")
+	RegressionKey     = bsonutil.MustHaveTag(ChangePoint{}, "Regression")
+	changePointRegKey = bsonutil.GetDottedKeyName(ChangePointKey, RegressionKey)
+
+	// historyDocKey holds the result chosen for each version of a
+	// history.
+	historyDocKey = "result"
+)
+
+// Validate checks that the fields reported by the task are set.
+func (r *PerfResult) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if r.TestName == "" {
+		catcher.Add(errors.New("test name must not be empty"))
+	}
+	if r.Metric == "" {
+		catcher.Add(errors.Errorf("metric for test '%s' must not be empty", r.TestName))
+	}
+	if math.IsNaN(r.Value) || math.IsInf(r.Value, 0) {
+		catcher.Add(errors.Errorf("value of metric '%s' for test '%s' must be a finite number", r.Metric, r.TestName))
+	}
+	if r.ThreadLevel < 0 {
+		catcher.Add(errors.Errorf("thread level for test '%s' cannot be negative", r.TestName))
+	}
+	return catcher.Resolve()
+}
+
+// ByTaskIDAndExecution returns a query for all results reported by a
+// task execution.
+func ByTaskIDAndExecution(taskID string, execution int) db.Q {
+	return db.Query(bson.M{
+		TaskIDKey:    taskID,
+		ExecutionKey: execution,
+	})
+}
+
+// ByRegressions returns a query for the regressions detected in a
+// project, optionally limited to one build variant, newest first.
+func ByRegressions(project, variant string) db.Q {
+	q := bson.M{
+		ProjectKey:        project,
+		changePointRegKey: true,
+	}
+	if variant != "" {
+		q[BuildVariantKey] = variant
+	}
+	return db.Query(q).Sort([]string{"-" + OrderKey})
+}
+
+// Find returns all perf results that satisfy the query.
+func Find(query db.Q) ([]PerfResult, error) {
+	results := []PerfResult{}
+	err := db.FindAllQ(Collection, query, &results)
+	return results, err
+}
+
+// InsertMany writes perf results to the database.
+func InsertMany(results []PerfResult) error {
+	docs := make([]interface{}, len(results))
+	catcher := grip.NewSimpleCatcher()
+	for idx := range results {
+		if results[idx].TaskID == "" {
+			catcher.Add(errors.New("cannot insert perf result with empty task ID"))
+		}
+		docs[idx] = results[idx]
+	}
+	if catcher.HasErrors() {
+		return catcher.Resolve()
+	}
+
+	return errors.WithStack(db.InsertMany(Collection, docs...))
+}
+
+// mainlineRequesters are the requesters of the versions that make up the
+// history of a project. The order of their results is the revision order
+// number, while the order of a patch's results is the patch number.
+var mainlineRequesters = []string{
+	evergreen.RepotrackerVersionRequester,
+	evergreen.TriggerRequester,
+}
+
+// FindHistory returns the most recent results for the same test,
+// metric and thread level reported by the same task in previous
+// mainline versions of the project, newest first. Results of patches
+// are compared against the mainline versions up to the patch's base
+// version. Only the latest execution of a task in each version is
+// included.
+func (r *PerfResult) FindHistory(limit int) ([]PerfResult, error) {
+	order := bson.M{"$lt": r.Order}
+	if !util.StringSliceContains(mainlineRequesters, r.Requester) {
+		base, err := version.FindOne(version.BaseVersionFromPatch(r.Project, r.Revision))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding base version of '%s'", r.Version)
+		}
+		if base == nil {
+			return []PerfResult{}, nil
+		}
+		order = bson.M{"$lte": base.RevisionOrderNumber}
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			ProjectKey:      r.Project,
+			BuildVariantKey: r.BuildVariant,
+			TaskNameKey:     r.TaskName,
+			TestNameKey:     r.TestName,
+			MetricKey:       r.Metric,
+			ThreadLevelKey:  r.ThreadLevel,
+			RequesterKey:    bson.M{"$in": mainlineRequesters},
+			OrderKey:        order,
+		}},
+		{"$sort": bson.D{
+			{Name: OrderKey, Value: -1},
+			{Name: ExecutionKey, Value: -1},
+			{Name: CreateTimeKey, Value: -1},
+		}},
+		{"$group": bson.M{
+			"_id":         "$" + VersionKey,
+			historyDocKey: bson.M{"$first": "$$ROOT"},
+		}},
+		{"$sort": bson.M{bsonutil.GetDottedKeyName(historyDocKey, OrderKey): -1}},
+		{"$limit": limit},
+	}
+
+	out := []struct {
+		Result PerfResult `bson:"result"`
+	}{}
+	if err := db.Aggregate(Collection, pipeline, &out); err != nil {
+		return nil, errors.Wrapf(err, "problem finding history of test '%s'", r.TestName)
+	}
+	history := make([]PerfResult, 0, len(out))
+	for _, doc := range out {
+		history = append(history, doc.Result)
+	}
+	return history, nil
+}
+
+// HasEarlierChangePoint returns whether an earlier execution of the
+// result's task recorded a change point for the same test, metric and
+// thread level, so that restarting a task doesn't report it again.
+func (r *PerfResult) HasEarlierChangePoint() (bool, error) {
+	if r.Execution == 0 {
+		return false, nil
+	}
+	n, err := db.Count(Collection, bson.M{
+		TaskIDKey:      r.TaskID,
+		ExecutionKey:   bson.M{"$lt": r.Execution},
+		TestNameKey:    r.TestName,
+		MetricKey:      r.Metric,
+		ThreadLevelKey: r.ThreadLevel,
+		ChangePointKey: bson.M{"$exists": true},
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding earlier change points for test '%s'", r.TestName)
+	}
+	return n > 0, nil
+}
+
+// SetChangePoint records the change point detected for the result. It
+// returns false if a change point was already recorded for the result, so
+// that concurrent detections report it once.
+func (r *PerfResult) SetChangePoint(cp *ChangePoint) (bool, error) {
+	err := db.Update(Collection, bson.M{
+		IDKey:          r.ID,
+		ChangePointKey: bson.M{"$exists": false},
+	}, bson.M{
+		"$set": bson.M{ChangePointKey: cp},
+	})
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "problem setting change point for result %s", r.ID.Hex())
+	}
+	r.ChangePoint = cp
+	return true, nil
+}
+
+// DetectChangePoint compares the window of the result and the
+// changePointWindow-1 results before it against the baseline of the
+// results before the window, and returns a change point if the means of
+// the two windows differ by more than changePointThreshold standard
+// errors and at least minPercentChange percent, and every result in the
+// window moved the same way. The baseline starts at the last change point
+// in the history, so a shift is reported once rather than for every
+// result after it. It returns nil if the result is not a change point, or
+// if there is not enough history to tell.
+func DetectChangePoint(r *PerfResult, history []PerfResult) *ChangePoint {
+	if len(history) < changePointWindow-1+minHistorySize {
+		return nil
+	}
+
+	window := make([]float64, 0, changePointWindow)
+	window = append(window, r.Value)
+	for _, h := range history[:changePointWindow-1] {
+		if h.ChangePoint != nil {
+			// the shift that this result is part of was already
+			// reported
+			return nil
+		}
+		window = append(window, h.Value)
+	}
+	start := history[changePointWindow-2]
+
+	baseline := []float64{}
+	for _, h := range history[changePointWindow-1:] {
+		baseline = append(baseline, h.Value)
+		if h.ChangePoint != nil {
+			break
+		}
+	}
+	if len(baseline) < minHistorySize {
+		return nil
+	}
+
+	baselineMean, baselineStdDev := meanAndStdDev(baseline)
+	windowMean, windowStdDev := meanAndStdDev(window)
+	diff := windowMean - baselineMean
+	stdErr := math.Sqrt(baselineStdDev*baselineStdDev/float64(len(baseline)) +
+		windowStdDev*windowStdDev/float64(len(window)))
+	if math.Abs(diff) <= changePointThreshold*stdErr {
+		return nil
+	}
+	for _, v := range window {
+		if (v-baselineMean)*diff <= 0 {
+			return nil
+		}
+	}
+
+	// a relative change is undefined if the mean is zero
+	var percentChange float64
+	if baselineMean != 0 {
+		percentChange = 100 * diff / math.Abs(baselineMean)
+		if math.Abs(percentChange) < minPercentChange {
+			return nil
+		}
+	}
+
+	return &ChangePoint{
+		BaselineMean:   baselineMean,
+		BaselineStdDev: baselineStdDev,
+		WindowMean:     windowMean,
+		PercentChange:  percentChange,
+		HistorySize:    len(baseline),
+		StartVersion:   start.Version,
+		Regression:     (diff < 0) != r.LowerIsBetter,
+		DetectedAt:     time.Now(),
+	}
+}
+
+func meanAndStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
@@ -0,0 +1,218 @@
+package perfresult
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func makeHistory(values ...float64) []PerfResult {
+	history := make([]PerfResult, len(values))
+	for i, v := range values {
+		history[i] = PerfResult{Value: v}
+	}
+	return history
+}
+
+func TestPerfResultValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&PerfResult{TestName: "t", Metric: "m", Value: 1}).Validate())
+	assert.Error((&PerfResult{Metric: "m", Value: 1}).Validate())
+	assert.Error((&PerfResult{TestName: "t", Value: 1}).Validate())
+	assert.Error((&PerfResult{TestName: "t", Metric: "m", Value: math.NaN()}).Validate())
+	assert.Error((&PerfResult{TestName: "t", Metric: "m", Value: math.Inf(1)}).Validate())
+	assert.Error((&PerfResult{TestName: "t", Metric: "m", ThreadLevel: -1}).Validate())
+}
+
+func TestDetectChangePoint(t *testing.T) {
+	assert := assert.New(t)
+	baseline := makeHistory(100, 102, 98, 101, 99, 100)
+	shifted := func(values ...float64) []PerfResult {
+		history := makeHistory(values...)
+		for i := range history {
+			history[i].Version = fmt.Sprintf("v%d", i)
+		}
+		return append(history, baseline...)
+	}
+
+	// not enough history
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 50}, shifted(50, 50)[:changePointWindow-1+minHistorySize-1]))
+
+	// within the noise
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 101}, shifted(99, 100)))
+
+	// one slow run is an outlier rather than a change point
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 50}, shifted(99, 100)))
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 50}, shifted(51, 100)))
+
+	// throughput dropped
+	cp := DetectChangePoint(&PerfResult{Value: 50}, shifted(52, 48))
+	if assert.NotNil(cp) {
+		assert.True(cp.Regression)
+		assert.Equal(100.0, cp.BaselineMean)
+		assert.Equal(50.0, cp.WindowMean)
+		assert.InDelta(-50.0, cp.PercentChange, 0.001)
+		assert.Equal(len(baseline), cp.HistorySize)
+		assert.Equal("v1", cp.StartVersion)
+	}
+
+	// throughput improved
+	cp = DetectChangePoint(&PerfResult{Value: 150}, shifted(152, 148))
+	if assert.NotNil(cp) {
+		assert.False(cp.Regression)
+	}
+
+	// latency increased
+	cp = DetectChangePoint(&PerfResult{Value: 150, LowerIsBetter: true}, shifted(152, 148))
+	if assert.NotNil(cp) {
+		assert.True(cp.Regression)
+	}
+}
+
+func TestDetectChangePointReportsShiftOnce(t *testing.T) {
+	assert := assert.New(t)
+
+	history := append(makeHistory(52, 48), makeHistory(100, 102, 98, 101, 99, 100)...)
+	cp := DetectChangePoint(&PerfResult{Value: 50}, history)
+	if !assert.NotNil(cp) {
+		return
+	}
+
+	// the following results are part of the shift that was reported
+	history = append([]PerfResult{{Value: 50, ChangePoint: cp}}, history...)
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 49}, history))
+	history = append([]PerfResult{{Value: 49}}, history...)
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 51}, history))
+
+	// the baseline starts at the change point, so the old level is not
+	// compared against until the new one is established
+	history = append([]PerfResult{{Value: 51}}, history...)
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 50}, history))
+	for _, v := range []float64{50, 49, 51, 50} {
+		history = append([]PerfResult{{Value: v}}, history...)
+	}
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 50}, history))
+	cp = DetectChangePoint(&PerfResult{Value: 20}, append(makeHistory(21, 19), history...))
+	if assert.NotNil(cp) {
+		assert.True(cp.Regression)
+		assert.InDelta(50.0, cp.BaselineMean, 1.0)
+	}
+}
+
+func TestDetectChangePointStableSeries(t *testing.T) {
+	assert := assert.New(t)
+	history := makeHistory(100, 100, 100, 100, 100, 100, 100)
+
+	// a change too small to matter in a series with no noise
+	assert.Nil(DetectChangePoint(&PerfResult{Value: 101}, append(makeHistory(101, 101), history...)))
+	assert.NotNil(DetectChangePoint(&PerfResult{Value: 110}, append(makeHistory(110, 110), history...)))
+
+	// the relative change is undefined for a zero baseline
+	cp := DetectChangePoint(&PerfResult{Value: 1}, makeHistory(1, 1, 0, 0, 0, 0, 0))
+	if assert.NotNil(cp) {
+		assert.Equal(0.0, cp.PercentChange)
+		assert.False(cp.Regression)
+	}
+}
+
+func TestFindHistory(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(Collection, version.Collection))
+
+	result := func(v string, order, execution int, requester string, value float64) PerfResult {
+		return PerfResult{
+			ID:           bson.NewObjectId(),
+			TestName:     "insert",
+			Metric:       "ops_per_sec",
+			Value:        value,
+			TaskID:       fmt.Sprintf("task_%s", v),
+			Execution:    execution,
+			TaskName:     "perf",
+			Project:      "mci",
+			BuildVariant: "linux",
+			Version:      v,
+			Revision:     fmt.Sprintf("rev_%s", v),
+			Order:        order,
+			Requester:    requester,
+		}
+	}
+	require.NoError(InsertMany([]PerfResult{
+		result("v1", 1, 0, evergreen.RepotrackerVersionRequester, 100),
+		result("v2", 2, 0, evergreen.RepotrackerVersionRequester, 50),
+		// the restarted task's result replaces the first one
+		result("v2", 2, 1, evergreen.RepotrackerVersionRequester, 101),
+		// patch numbers aren't revision orders
+		result("p1", 3, 0, evergreen.PatchVersionRequester, 10),
+		result("v4", 4, 0, evergreen.RepotrackerVersionRequester, 102),
+		result("v5", 5, 0, evergreen.RepotrackerVersionRequester, 103),
+	}))
+	require.NoError((&version.Version{
+		Id:                  "v2",
+		Identifier:          "mci",
+		Revision:            "rev_v2",
+		RevisionOrderNumber: 2,
+		Requester:           evergreen.RepotrackerVersionRequester,
+	}).Insert())
+
+	r := result("v5", 5, 0, evergreen.RepotrackerVersionRequester, 103)
+	history, err := r.FindHistory(HistorySize)
+	require.NoError(err)
+	require.Len(history, 3)
+	assert.Equal("v4", history[0].Version)
+	assert.Equal("v2", history[1].Version)
+	assert.Equal(101.0, history[1].Value)
+	assert.Equal("v1", history[2].Version)
+
+	// a patch is compared against the history up to its base version
+	p := result("p2", 7, 0, evergreen.PatchVersionRequester, 90)
+	p.Revision = "rev_v2"
+	history, err = p.FindHistory(HistorySize)
+	require.NoError(err)
+	require.Len(history, 2)
+	assert.Equal("v2", history[0].Version)
+	assert.Equal("v1", history[1].Version)
+
+	// a patch without a base version has no history
+	p.Revision = "missing"
+	history, err = p.FindHistory(HistorySize)
+	require.NoError(err)
+	assert.Empty(history)
+}
+
+func TestHasEarlierChangePoint(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.Clear(Collection))
+
+	first := PerfResult{ID: bson.NewObjectId(), TaskID: "t1", TestName: "insert", Metric: "ops_per_sec"}
+	require.NoError(InsertMany([]PerfResult{first}))
+	second := first
+	second.ID = bson.NewObjectId()
+	second.Execution = 1
+
+	reported, err := second.HasEarlierChangePoint()
+	require.NoError(err)
+	assert.False(reported)
+
+	set, err := first.SetChangePoint(&ChangePoint{Regression: true})
+	require.NoError(err)
+	require.True(set)
+	reported, err = second.HasEarlierChangePoint()
+	require.NoError(err)
+	assert.True(reported)
+	reported, err = first.HasEarlierChangePoint()
+	require.NoError(err)
+	assert.False(reported)
+}
@@ -8,11 +8,13 @@ import (
 	"strings"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/blob"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/subprocess"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/mongodb/grip"
@@ -35,6 +37,7 @@ const (
 type Project struct {
 	Enabled         bool                       `yaml:"enabled,omitempty" bson:"enabled"`
 	Stepback        bool                       `yaml:"stepback,omitempty" bson:"stepback"`
+	StepbackBisect  bool                       `yaml:"stepback_bisect,omitempty" bson:"stepback_bisect"`
 	BatchTime       int                        `yaml:"batchtime,omitempty" bson:"batch_time"`
 	Owner           string                     `yaml:"owner,omitempty" bson:"owner_name"`
 	Repo            string                     `yaml:"repo,omitempty" bson:"repo_name"`
@@ -56,6 +59,10 @@ type Project struct {
 	Tasks           []ProjectTask              `yaml:"tasks,omitempty" bson:"tasks"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 
+	// BlobStore is the default store used by the blob.put and blob.get
+	// commands, which takes precedence over the distro's store.
+	BlobStore *blob.Options `yaml:"blob_store,omitempty" bson:"blob_store,omitempty"`
+
 	// Flag that indicates a project as requiring user authentication
 	Private bool `yaml:"private,omitempty" bson:"private"`
 }
@@ -184,6 +191,13 @@ type BuildVariant struct {
 	// provided for the task
 	RunOn []string `yaml:"run_on,omitempty" bson:"run_on"`
 
+	// Paths and IgnorePaths are gitignore-style patterns matched against
+	// the files that a commit or patch changes. The variant is only
+	// activated if a changed file matches Paths, when given, and does not
+	// match IgnorePaths.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
+
 	// all of the tasks/groups to be run on the build variant, compile through tests.
 	Tasks        []BuildVariantTaskUnit `yaml:"tasks,omitempty" bson:"tasks"`
 	DisplayTasks []DisplayTask          `yaml:"display_tasks,omitempty" bson:"display_tasks,omitempty"`
@@ -232,8 +246,8 @@ type PluginCommandConf struct {
 }
 
 type ArtifactInstructions struct {
-	Include      []string `yaml:"include,omitempty" bson:"include"`
-	ExcludeFiles []string `yaml:"excludefiles,omitempty" bson:"exclude_files"`
+	Include      []string `yaml:"include,omitempty" bson:"include" mapstructure:"include" plugin:"expand"`
+	ExcludeFiles []string `yaml:"excludefiles,omitempty" bson:"exclude_files" mapstructure:"excludefiles" plugin:"expand"`
 }
 
 type YAMLCommandSet struct {
@@ -337,6 +351,34 @@ type ProjectTask struct {
 	//   3. false = overriding the project setting with false
 	Patchable *bool `yaml:"patchable,omitempty" bson:"patchable,omitempty"`
 	Stepback  *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// ResourceLimits are enforced on the processes started by each of
+	// the task's commands, unless the command overrides them.
+	ResourceLimits subprocess.ResourceLimits `yaml:",inline" bson:"resource_limits,omitempty"`
+
+	// DebugOnFailure is the number of minutes that the host is held
+	// for the patch author to debug the task when it fails.
+	DebugOnFailure int `yaml:"debug_on_failure,omitempty" bson:"debug_on_failure,omitempty"`
+
+	// Paths and IgnorePaths select the changed files that the task is
+	// activated for, in the same way as they do for build variants.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
+
+	// Artifacts are the names of the artifacts that the task attaches,
+	// which other tasks can fetch. FetchArtifacts are the artifacts of
+	// other tasks that the agent downloads, and extracts if they are
+	// tarballs, into the working directory before the task's commands run.
+	Artifacts      []string             `yaml:"artifacts,omitempty" bson:"artifacts,omitempty"`
+	FetchArtifacts []ArtifactDependency `yaml:"fetch_artifacts,omitempty" bson:"fetch_artifacts,omitempty"`
+}
+
+// ArtifactDependency is an artifact of another task that a task fetches. An
+// empty variant refers to the task's own variant.
+type ArtifactDependency struct {
+	Task    string `yaml:"task" bson:"task"`
+	Variant string `yaml:"variant,omitempty" bson:"variant,omitempty"`
+	Name    string `yaml:"name" bson:"name"`
 }
 
 // TaskIdTable is a map of [variant, task display name]->[task id].
@@ -599,6 +641,12 @@ func populateExpansions(d *distro.Distro, v *version.Version, bv *BuildVariant,
 		expansions.Put("revision_order_id", strconv.Itoa(v.RevisionOrderNumber))
 	}
 
+	if v.TriggeredBy != nil {
+		expansions.Put("upstream_project", v.TriggeredBy.UpstreamProject)
+		expansions.Put("upstream_version_id", v.TriggeredBy.UpstreamVersionID)
+		expansions.Put("upstream_revision", v.TriggeredBy.UpstreamRevision)
+	}
+
 	for _, e := range d.Expansions {
 		expansions.Put(e.Key, e.Value)
 	}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
 )
 
 // This file contains the code for matrix generation.
@@ -26,6 +29,11 @@ import (
 //  for later evaluation.
 //   5. Created variants are appended back to the project's list of buildvariants.
 //   6. During evaluateBuildVariants in project_parser.go, rules are executed.
+//
+// Axes may also read their values from a file in the project's repository
+// (`values_file`). Those values are loaded before the steps above, when the
+// project is loaded with an AxisValuesLoader; without one, the values files
+// are skipped and the axes only have the values in the project file.
 
 // matrix defines a set of variants programmatically by
 // combining a series of axis values and rules.
@@ -39,6 +47,8 @@ type matrix struct {
 	BatchTime   *int              `yaml:"batchtime"`
 	Stepback    *bool             `yaml:"stepback"`
 	RunOn       parserStringSlice `yaml:"run_on"`
+	Paths       parserStringSlice `yaml:"paths"`
+	IgnorePaths parserStringSlice `yaml:"ignore_paths"`
 	Tasks       parserBVTaskUnits `yaml:"tasks"`
 	Rules       []matrixRule      `yaml:"rules"`
 }
@@ -48,6 +58,9 @@ type matrixAxis struct {
 	Id          string      `yaml:"id"`
 	DisplayName string      `yaml:"display_name"`
 	Values      []axisValue `yaml:"values"`
+	// ValuesFile is the path, relative to the root of the repository, of a
+	// YAML file with a list of additional values for the axis.
+	ValuesFile string `yaml:"values_file"`
 }
 
 // find returns the axisValue with the given name.
@@ -268,20 +281,27 @@ func buildMatrixVariants(axes []matrixAxis, ase *axisSelectorEvaluator, matrices
 		}
 		unpruned := evaluatedSpec.allCells()
 		pruned := []parserBV{}
+		excludedBySpec := 0
 		for _, cell := range unpruned {
 			// create the variant if it isn't excluded
-			if !evaluatedExcludes.contain(cell) {
-				v, err := buildMatrixVariant(axes, cell, &matrices[i], ase)
-				if err != nil {
-					errs = append(errs, errors.Wrapf(err, "%v: error building matrix cell %v",
-						m.Id, cell))
-					continue
-				}
-				pruned = append(pruned, *v)
+			if evaluatedExcludes.contain(cell) {
+				excludedBySpec++
+				continue
+			}
+			v, err := buildMatrixVariant(axes, cell, &matrices[i], ase)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "%v: error building matrix cell %v",
+					m.Id, cell))
+				continue
+			}
+			// a nil variant means the cell was excluded by a rule
+			if v == nil {
+				continue
 			}
+			pruned = append(pruned, *v)
 		}
 		// safety check to make sure the exclude field is actually working
-		if len(m.Exclude) > 0 && len(unpruned) == len(pruned) {
+		if len(m.Exclude) > 0 && excludedBySpec == 0 {
 			errs = append(errs, errors.Errorf("%v: exclude field did not exclude anything", m.Id))
 		}
 		matrixVariants = append(matrixVariants, pruned...)
@@ -292,16 +312,18 @@ func buildMatrixVariants(axes []matrixAxis, ase *axisSelectorEvaluator, matrices
 // buildMatrixVariant does the heavy lifting of building a matrix variant based on axis information.
 // We do this by iterating over all axes and merging the axis value's settings when applicable. Expansions
 // are evaluated during this process. Rules are parsed and added to the resulting parserBV for later
-// execution.
+// execution. Returns a nil parserBV if a rule excludes the cell.
 func buildMatrixVariant(axes []matrixAxis, mv matrixValue, m *matrix, ase *axisSelectorEvaluator) (*parserBV, error) {
 	v := parserBV{
-		matrixVal:  mv,
-		matrixId:   m.Id,
-		Stepback:   m.Stepback,
-		BatchTime:  m.BatchTime,
-		Modules:    m.Modules,
-		RunOn:      m.RunOn,
-		Expansions: *util.NewExpansions(mv),
+		matrixVal:   mv,
+		matrixId:    m.Id,
+		Stepback:    m.Stepback,
+		BatchTime:   m.BatchTime,
+		Modules:     m.Modules,
+		RunOn:       m.RunOn,
+		Paths:       m.Paths,
+		IgnorePaths: m.IgnorePaths,
+		Expansions:  *util.NewExpansions(mv),
 	}
 	// we declare a separate expansion map for evaluating the display name
 	displayNameExp := util.Expansions{}
@@ -376,7 +398,18 @@ func buildMatrixVariant(axes []matrixAxis, mv matrixValue, m *matrix, ase *axisS
 		if len(errs) > 0 {
 			return nil, errors.Errorf("evaluating rules for matrix %v: %v", m.Id, errs)
 		}
-		if matchers.contain(mv) {
+		// rules with only tag selectors match on the cell's tags alone
+		matched := matchers.contain(mv) || (len(r.If) == 0 && len(r.IfTags) > 0)
+		if matched && len(r.IfTags) > 0 {
+			matched, err = v.satisfiesAnySelector(r.IfTags)
+			if err != nil {
+				return nil, errors.Wrapf(err, "evaluating tags of %s rule %d", m.Id, i)
+			}
+		}
+		if matched {
+			if r.Then.Exclude {
+				return nil, nil
+			}
 			if r.Then.Set != nil {
 				if err := v.mergeAxisValue(*r.Then.Set); err != nil {
 					return nil, errors.Wrapf(err, "evaluating %s rule %d", m.Id, i)
@@ -393,9 +426,12 @@ func buildMatrixVariant(axes []matrixAxis, mv matrixValue, m *matrix, ase *axisS
 }
 
 // matrixRule allows users to manipulate arbitrary matrix values using selectors.
+// IfTags holds tag selectors that are evaluated against the tags of the cell,
+// as built so far; when set, a cell must also satisfy one of them to match.
 type matrixRule struct {
-	If   matrixDefinitions `yaml:"if"`
-	Then ruleAction        `yaml:"then"`
+	If     matrixDefinitions `yaml:"if"`
+	IfTags parserStringSlice `yaml:"if_tags"`
+	Then   ruleAction        `yaml:"then"`
 }
 
 // ruleAction is used to define what work must be done when
@@ -404,6 +440,82 @@ type ruleAction struct {
 	Set         *axisValue        `yaml:"set"`
 	RemoveTasks parserStringSlice `yaml:"remove_tasks"`
 	AddTasks    parserBVTaskUnits `yaml:"add_tasks"`
+	Exclude     bool              `yaml:"exclude"`
+}
+
+// satisfiesAnySelector returns whether the variant's name and tags fulfill
+// any of the given selectors.
+func (pbv *parserBV) satisfiesAnySelector(selectors []string) (bool, error) {
+	for _, s := range selectors {
+		selector := ParseSelector(s)
+		if len(selector) == 0 {
+			return false, errors.New("cannot evaluate selector with no criteria")
+		}
+		satisfied := true
+		for _, sc := range selector {
+			if err := sc.Validate(); err != nil {
+				return false, errors.Errorf("criterion '%v' is invalid: %v", sc, err)
+			}
+			var ok bool
+			switch {
+			case sc.name == SelectAll:
+				ok = true
+			case sc.tagged:
+				ok = util.StringSliceContains(pbv.Tags, sc.name)
+			default:
+				ok = pbv.Name == sc.name
+			}
+			if ok == sc.negated {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// loadAxisValues appends the values read from each axis' values file to the
+// axis' values.
+func loadAxisValues(axes []matrixAxis, load AxisValuesLoader) []error {
+	var errs []error
+	for i, a := range axes {
+		if a.ValuesFile == "" {
+			continue
+		}
+		if load == nil {
+			grip.Warning(message.Fields{
+				"message":     "skipping axis values file, since files cannot be loaded here",
+				"axis":        a.Id,
+				"values_file": a.ValuesFile,
+			})
+			continue
+		}
+		data, err := load(a.ValuesFile)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "problem loading values of axis '%s' from '%s'", a.Id, a.ValuesFile))
+			continue
+		}
+		values := []axisValue{}
+		if err = yaml.Unmarshal(data, &values); err != nil {
+			errs = append(errs, errors.Wrapf(err, "problem parsing values of axis '%s' from '%s'", a.Id, a.ValuesFile))
+			continue
+		}
+		for _, v := range values {
+			if v.Id == "" {
+				errs = append(errs, errors.Errorf("axis '%s' has a value without an id in '%s'", a.Id, a.ValuesFile))
+				continue
+			}
+			if _, err = axes[i].find(v.Id); err == nil {
+				errs = append(errs, errors.Errorf("axis '%s' has duplicate value '%s'", a.Id, v.Id))
+				continue
+			}
+			axes[i].Values = append(axes[i].Values, v)
+		}
+	}
+	return errs
 }
 
 // mergeAxisValue overwrites a parserBV's fields based on settings
@@ -590,9 +702,18 @@ func expandRule(r matrixRule, exp util.Expansions) (matrixRule, error) {
 		}
 	}
 
+	if len(r.IfTags) > 0 {
+		var err error
+		newR.IfTags, err = expandStrings(r.IfTags, exp)
+		if err != nil {
+			return newR, errors.Wrap(err, "if_tags")
+		}
+	}
+
 	// r.Then.Set will be expanded when mergeAxisValue is called
 	// so we don't have to do it in this function
 	newR.Then.Set = r.Then.Set
+	newR.Then.Exclude = r.Then.Exclude
 
 	return newR, nil
 }
@@ -2,10 +2,14 @@ package model
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMatrixIntermediateParsing(t *testing.T) {
@@ -695,3 +699,145 @@ func TestRulesEvaluation(t *testing.T) {
 		})
 	})
 }
+
+func TestMatrixRulesWithTags(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+- name: compile
+axes:
+- id: os
+  values:
+  - id: windows
+    tags: ["windows"]
+    run_on: ["windows-distro"]
+  - id: ubuntu
+    tags: ["linux"]
+    run_on: ["ubuntu-distro"]
+- id: compiler
+  values:
+  - id: gcc
+    tags: ["gcc"]
+  - id: msvc
+    tags: ["msvc"]
+buildvariants:
+- matrix_name: "build"
+  matrix_spec: {os: "*", compiler: "*"}
+  display_name: "${os} ${compiler}"
+  tasks: ["compile"]
+  rules:
+  - if_tags: [".linux .msvc"]
+    then:
+      exclude: true
+  - if:
+      os: "windows"
+      compiler: "*"
+    if_tags: [".gcc"]
+    then:
+      exclude: true
+  - if_tags: [".linux"]
+    then:
+      set:
+        run_on: ["ubuntu-large"]
+        variables:
+          cc: "${compiler}"
+`
+	p, errs := projectFromYAML([]byte(yml))
+	require.Len(errs, 0)
+	require.Len(p.BuildVariants, 2)
+
+	variants := map[string]BuildVariant{}
+	for _, bv := range p.BuildVariants {
+		variants[bv.Name] = bv
+	}
+	windows, ok := variants["build__os~windows_compiler~msvc"]
+	require.True(ok)
+	assert.Equal([]string{"windows-distro"}, windows.RunOn)
+	assert.Empty(windows.Expansions["cc"])
+
+	ubuntu, ok := variants["build__os~ubuntu_compiler~gcc"]
+	require.True(ok)
+	assert.Equal([]string{"ubuntu-large"}, ubuntu.RunOn)
+	assert.Equal("gcc", ubuntu.Expansions["cc"])
+
+	yml = `
+tasks:
+- name: compile
+axes:
+- id: os
+  values:
+  - id: ubuntu
+buildvariants:
+- matrix_name: "build"
+  matrix_spec: {os: "*"}
+  tasks: ["compile"]
+  rules:
+  - if_tags: ["!!.linux"]
+    then:
+      exclude: true
+`
+	_, errs = projectFromYAML([]byte(yml))
+	assert.NotEmpty(errs)
+}
+
+func TestAxisValuesFile(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+- name: compile
+axes:
+- id: version
+  values:
+  - id: "3.6"
+  values_file: "etc/versions.yml"
+buildvariants:
+- matrix_name: "build"
+  matrix_spec: {version: "*"}
+  tasks: ["compile"]
+`
+	files := map[string]string{
+		"etc/versions.yml": `
+- id: "4.0"
+  variables:
+    branch: v4.0
+- id: "4.2"
+`,
+	}
+	load := func(path string) ([]byte, error) {
+		contents, ok := files[path]
+		if !ok {
+			return nil, errors.Errorf("file '%s' not found", path)
+		}
+		return []byte(contents), nil
+	}
+
+	p, errs := projectFromYAMLWithAxisValues([]byte(yml), load)
+	require.Len(errs, 0)
+	names := []string{}
+	for _, bv := range p.BuildVariants {
+		names = append(names, bv.Name)
+		if bv.Name == "build__version~4.0" {
+			assert.Equal("v4.0", bv.Expansions["branch"])
+		}
+	}
+	sort.Strings(names)
+	assert.Equal([]string{"build__version~3.6", "build__version~4.0", "build__version~4.2"}, names)
+
+	// without a loader, the values file is skipped
+	p, errs = projectFromYAML([]byte(yml))
+	require.Len(errs, 0)
+	require.Len(p.BuildVariants, 1)
+	assert.Equal("build__version~3.6", p.BuildVariants[0].Name)
+
+	files["etc/versions.yml"] = `[{id: "3.6"}]`
+	_, errs = projectFromYAMLWithAxisValues([]byte(yml), load)
+	assert.Len(errs, 1)
+
+	delete(files, "etc/versions.yml")
+	_, errs = projectFromYAMLWithAxisValues([]byte(yml), load)
+	assert.Len(errs, 1)
+}
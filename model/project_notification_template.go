@@ -0,0 +1,440 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"text/template"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// NotificationTemplateTriggers are the alert triggers that projects can
+// override the notifications of.
+var NotificationTemplateTriggers = []string{
+	alertrecord.TaskFailedId,
+	alertrecord.FirstVersionFailureId,
+	alertrecord.FirstVariantFailureId,
+	alertrecord.FirstTaskTypeFailureId,
+	alertrecord.TaskFailTransitionId,
+	alertrecord.LastRevisionNotFound,
+}
+
+// NotificationTemplateSubscriberTypes are the types of subscriber that
+// projects can override the notifications of.
+var NotificationTemplateSubscriberTypes = []string{
+	event.EmailSubscriberType,
+	event.SlackSubscriberType,
+}
+
+// NotificationTemplate overrides the notifications that a project sends to
+// a type of subscriber for a trigger. The subject and body are Go
+// templates, which are executed with NotificationTemplateValues. For email,
+// the subject is the email's subject and the body is its HTML body, which
+// is executed as an html/template. For Slack, the subject is the message and
+// the body is its description.
+type NotificationTemplate struct {
+	Trigger        string `bson:"trigger" json:"trigger" yaml:"trigger"`
+	SubscriberType string `bson:"subscriber_type" json:"subscriber_type" yaml:"subscriber_type"`
+	Subject        string `bson:"subject,omitempty" json:"subject,omitempty" yaml:"subject,omitempty"`
+	Body           string `bson:"body" json:"body" yaml:"body"`
+}
+
+var (
+	projectRefNotificationTemplatesKey    = bsonutil.MustHaveTag(ProjectRef{}, "NotificationTemplates")
+	notificationTemplateTriggerKey        = bsonutil.MustHaveTag(NotificationTemplate{}, "Trigger")
+	notificationTemplateSubscriberTypeKey = bsonutil.MustHaveTag(NotificationTemplate{}, "SubscriberType")
+)
+
+// NotificationTemplateData is what a notification is about. Templates are
+// not executed with it directly, but with the NotificationTemplateValues
+// made from it, so that they can't reveal secrets such as a task's secret
+// or a project's admins.
+type NotificationTemplateData struct {
+	Trigger string
+	UIRoot  string
+	Project *ProjectRef
+	Task    *task.Task
+	Build   *build.Build
+	Version *version.Version
+	Patch   *patch.Patch
+}
+
+// NotificationTemplateValues are the values that notification templates
+// are executed with:
+//
+//	.Trigger  the id of the trigger, e.g. "task_failed"
+//	.UIRoot   the URL of the Evergreen UI
+//	.Project  the project, e.g. .Project.DisplayName
+//	.Task     the task, e.g. .Task.DisplayName, .Task.Status, .Task.FailedTests
+//	.Build    the task's build, e.g. .Build.DisplayName
+//	.Version  the task's version, e.g. .Version.Revision, .Version.Author
+//	.Patch    the version's patch, e.g. .Patch.Description, or nil
+//
+// Any of Task, Build, Version and Patch may be nil, depending on what the
+// notification is about, so templates should check them with "if" or
+// "with" before using their fields.
+type NotificationTemplateValues struct {
+	Trigger string
+	UIRoot  string
+	Project *NotificationTemplateProject
+	Task    *NotificationTemplateTask
+	Build   *NotificationTemplateBuild
+	Version *NotificationTemplateVersion
+	Patch   *NotificationTemplatePatch
+}
+
+// NotificationTemplateProject is the project of a notification.
+type NotificationTemplateProject struct {
+	Identifier  string
+	DisplayName string
+	Owner       string
+	Repo        string
+	Branch      string
+}
+
+// NotificationTemplateTask is the task of a notification. FailureType is
+// the type of the command that failed, e.g. "test" or "system", and
+// FailedTests are the names of the task's failed tests.
+type NotificationTemplateTask struct {
+	Id                 string
+	DisplayName        string
+	BuildVariant       string
+	Execution          int
+	Status             string
+	Revision           string
+	Requester          string
+	TimedOut           bool
+	FailureType        string
+	FailureDescription string
+	FailedTests        []string
+	StartTime          time.Time
+	FinishTime         time.Time
+	TimeTaken          time.Duration
+	URL                string
+}
+
+// NotificationTemplateBuild is the build of a notification.
+type NotificationTemplateBuild struct {
+	Id           string
+	DisplayName  string
+	BuildVariant string
+	Status       string
+	URL          string
+}
+
+// NotificationTemplateVersion is the version of a notification.
+type NotificationTemplateVersion struct {
+	Id         string
+	Revision   string
+	Author     string
+	Message    string
+	Status     string
+	Requester  string
+	CreateTime time.Time
+	URL        string
+}
+
+// NotificationTemplatePatch is the patch of a notification.
+type NotificationTemplatePatch struct {
+	Id          string
+	PatchNumber int
+	Author      string
+	Description string
+	Status      string
+	URL         string
+}
+
+// Values returns the values that templates are executed with for the data.
+func (data *NotificationTemplateData) Values() NotificationTemplateValues {
+	values := NotificationTemplateValues{
+		Trigger: data.Trigger,
+		UIRoot:  data.UIRoot,
+	}
+	if p := data.Project; p != nil {
+		values.Project = &NotificationTemplateProject{
+			Identifier:  p.Identifier,
+			DisplayName: p.DisplayName,
+			Owner:       p.Owner,
+			Repo:        p.Repo,
+			Branch:      p.Branch,
+		}
+	}
+	if t := data.Task; t != nil {
+		failed := []string{}
+		for _, test := range t.LocalTestResults {
+			if test.Status == evergreen.TestFailedStatus {
+				failed = append(failed, test.TestFile)
+			}
+		}
+		values.Task = &NotificationTemplateTask{
+			Id:                 t.Id,
+			DisplayName:        t.DisplayName,
+			BuildVariant:       t.BuildVariant,
+			Execution:          t.Execution,
+			Status:             t.Status,
+			Revision:           t.Revision,
+			Requester:          t.Requester,
+			TimedOut:           t.Details.TimedOut,
+			FailureType:        t.Details.Type,
+			FailureDescription: t.Details.Description,
+			FailedTests:        failed,
+			StartTime:          t.StartTime,
+			FinishTime:         t.FinishTime,
+			TimeTaken:          t.TimeTaken,
+			URL:                fmt.Sprintf("%s/task/%s/%d", data.UIRoot, t.Id, t.Execution),
+		}
+	}
+	if b := data.Build; b != nil {
+		values.Build = &NotificationTemplateBuild{
+			Id:           b.Id,
+			DisplayName:  b.DisplayName,
+			BuildVariant: b.BuildVariant,
+			Status:       b.Status,
+			URL:          fmt.Sprintf("%s/build/%s", data.UIRoot, b.Id),
+		}
+	}
+	if v := data.Version; v != nil {
+		values.Version = &NotificationTemplateVersion{
+			Id:         v.Id,
+			Revision:   v.Revision,
+			Author:     v.Author,
+			Message:    v.Message,
+			Status:     v.Status,
+			Requester:  v.Requester,
+			CreateTime: v.CreateTime,
+			URL:        fmt.Sprintf("%s/version/%s", data.UIRoot, v.Id),
+		}
+	}
+	if p := data.Patch; p != nil {
+		values.Patch = &NotificationTemplatePatch{
+			Id:          p.Id.Hex(),
+			PatchNumber: p.PatchNumber,
+			Author:      p.Author,
+			Description: p.Description,
+			Status:      p.Status,
+			URL:         fmt.Sprintf("%s/patch/%s", data.UIRoot, p.Id.Hex()),
+		}
+	}
+	return values
+}
+
+// Validate checks that the template is for a known trigger and subscriber
+// type, and that its subject and body can be executed with every field of
+// the template data set.
+func (t *NotificationTemplate) Validate() error {
+	if !util.StringSliceContains(NotificationTemplateTriggers, t.Trigger) {
+		return errors.Errorf("unknown trigger '%s'", t.Trigger)
+	}
+	if !util.StringSliceContains(NotificationTemplateSubscriberTypes, t.SubscriberType) {
+		return errors.Errorf("unknown subscriber type '%s'", t.SubscriberType)
+	}
+	if t.Body == "" {
+		return errors.New("notification template must have a body")
+	}
+
+	sample := NotificationTemplateData{
+		Trigger: t.Trigger,
+		Project: &ProjectRef{},
+		Task:    &task.Task{},
+		Build:   &build.Build{},
+		Version: &version.Version{},
+		Patch:   &patch.Patch{},
+	}
+	if _, _, err := t.Render(sample); err != nil {
+		return errors.Wrap(err, "invalid notification template")
+	}
+	return nil
+}
+
+// Render executes the template's subject and body with the values of the
+// data. The body of an email is HTML, so the values in it are escaped.
+func (t *NotificationTemplate) Render(data NotificationTemplateData) (string, string, error) {
+	values := data.Values()
+	subject, err := executeNotificationTemplate("subject", t.Subject, false, values)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeNotificationTemplate("body", t.Body, t.SubscriberType == event.EmailSubscriberType, values)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+type notificationTemplate interface {
+	Execute(io.Writer, interface{}) error
+}
+
+func executeNotificationTemplate(name, text string, html bool, values NotificationTemplateValues) (string, error) {
+	var tmpl notificationTemplate
+	var err error
+	if html {
+		tmpl, err = htmltemplate.New(name).Parse(text)
+	} else {
+		tmpl, err = template.New(name).Parse(text)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "problem parsing %s", name)
+	}
+	out := &bytes.Buffer{}
+	if err = tmpl.Execute(out, values); err != nil {
+		return "", errors.Wrapf(err, "problem executing %s", name)
+	}
+	return out.String(), nil
+}
+
+// GetNotificationTemplate returns the project's template for the trigger
+// and subscriber type, or nil if the project has none.
+func (projectRef *ProjectRef) GetNotificationTemplate(trigger, subscriberType string) *NotificationTemplate {
+	for i := range projectRef.NotificationTemplates {
+		t := &projectRef.NotificationTemplates[i]
+		if t.Trigger == trigger && t.SubscriberType == subscriberType {
+			return t
+		}
+	}
+	return nil
+}
+
+// SetNotificationTemplate validates the template, and adds it to the
+// project or replaces the project's template for the same trigger and
+// subscriber type.
+func SetNotificationTemplate(projectID string, t NotificationTemplate) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	match := bson.M{
+		notificationTemplateTriggerKey:        t.Trigger,
+		notificationTemplateSubscriberTypeKey: t.SubscriberType,
+	}
+	err := db.Update(ProjectRefCollection,
+		bson.M{
+			ProjectRefIdentifierKey:            projectID,
+			projectRefNotificationTemplatesKey: bson.M{"$elemMatch": match},
+		},
+		bson.M{"$set": bson.M{bsonutil.GetDottedKeyName(projectRefNotificationTemplatesKey, "$"): t}},
+	)
+	if err == nil {
+		return nil
+	}
+	if !db.ResultsNotFound(err) {
+		return errors.Wrapf(err, "problem saving notification template for '%s' to %s", t.Trigger, t.SubscriberType)
+	}
+
+	err = db.Update(ProjectRefCollection,
+		bson.M{
+			ProjectRefIdentifierKey:            projectID,
+			projectRefNotificationTemplatesKey: bson.M{"$not": bson.M{"$elemMatch": match}},
+		},
+		bson.M{"$push": bson.M{projectRefNotificationTemplatesKey: t}},
+	)
+	if db.ResultsNotFound(err) {
+		return errors.Errorf("project '%s' does not exist", projectID)
+	}
+	return errors.Wrapf(err, "problem saving notification template for '%s' to %s", t.Trigger, t.SubscriberType)
+}
+
+// RemoveNotificationTemplate removes the project's template for the trigger
+// and subscriber type. It returns false if the project has no such
+// template.
+func (projectRef *ProjectRef) RemoveNotificationTemplate(trigger, subscriberType string) (bool, error) {
+	i := -1
+	for j, t := range projectRef.NotificationTemplates {
+		if t.Trigger == trigger && t.SubscriberType == subscriberType {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return false, nil
+	}
+
+	err := db.Update(ProjectRefCollection,
+		bson.M{ProjectRefIdentifierKey: projectRef.Identifier},
+		bson.M{"$pull": bson.M{projectRefNotificationTemplatesKey: bson.M{
+			notificationTemplateTriggerKey:        trigger,
+			notificationTemplateSubscriberTypeKey: subscriberType,
+		}}},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "problem removing notification template for '%s' to %s", trigger, subscriberType)
+	}
+
+	projectRef.NotificationTemplates = append(projectRef.NotificationTemplates[:i], projectRef.NotificationTemplates[i+1:]...)
+	return true, nil
+}
+
+// NotificationTemplateDataForEvent loads the data that notification
+// templates would be executed with for the task, build or version of the
+// event with the id. It returns nil if there is no such event.
+func NotificationTemplateDataForEvent(eventID string) (*NotificationTemplateData, error) {
+	e, err := event.FindByID(eventID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if e == nil {
+		return nil, nil
+	}
+
+	data := &NotificationTemplateData{}
+	var buildID, versionID string
+	switch e.ResourceType {
+	case event.ResourceTypeTask:
+		data.Task, err = task.FindOne(task.ById(e.ResourceId))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding task '%s'", e.ResourceId)
+		}
+		if data.Task == nil {
+			return nil, errors.Errorf("task '%s' of event '%s' does not exist", e.ResourceId, eventID)
+		}
+		buildID, versionID = data.Task.BuildId, data.Task.Version
+	case event.ResourceTypeBuild:
+		buildID = e.ResourceId
+	case event.ResourceTypeVersion:
+		versionID = e.ResourceId
+	default:
+		return nil, errors.Errorf("events of type '%s' do not have notification templates", e.ResourceType)
+	}
+
+	if buildID != "" {
+		data.Build, err = build.FindOne(build.ById(buildID))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding build '%s'", buildID)
+		}
+		if data.Build != nil {
+			versionID = data.Build.Version
+		}
+	}
+	if versionID != "" {
+		data.Version, err = version.FindOne(version.ById(versionID).WithoutFields(version.ConfigKey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding version '%s'", versionID)
+		}
+	}
+	if data.Version != nil {
+		data.Patch, err = patch.FindOne(patch.ByVersion(data.Version.Id).Project(patch.ExcludePatchDiff))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding patch of version '%s'", data.Version.Id)
+		}
+		data.Project, err = FindOneProjectRef(data.Version.Identifier)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return data, nil
+}
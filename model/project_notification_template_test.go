@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationTemplateValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpl := NotificationTemplate{
+		Trigger:        alertrecord.TaskFailedId,
+		SubscriberType: "email",
+		Subject:        "{{.Task.DisplayName}} failed on {{.Build.DisplayName}}",
+		Body:           "{{.Project.DisplayName}} @ {{.Version.Revision}}{{with .Patch}} by {{.Author}}{{end}}",
+	}
+	assert.NoError(tmpl.Validate())
+
+	tmpl.Body = "{{.Task.NoSuchField}}"
+	assert.Error(tmpl.Validate())
+	tmpl.Body = "{{if .Task}}"
+	assert.Error(tmpl.Validate())
+	tmpl.Body = ""
+	assert.Error(tmpl.Validate())
+
+	tmpl.Body = "failed"
+	tmpl.Trigger = "sometimes"
+	assert.Error(tmpl.Validate())
+	tmpl.Trigger = alertrecord.TaskFailedId
+	tmpl.SubscriberType = "pigeon"
+	assert.Error(tmpl.Validate())
+}
+
+func TestNotificationTemplateRender(t *testing.T) {
+	assert := assert.New(t)
+
+	ref := &ProjectRef{
+		Identifier:  "mci",
+		DisplayName: "Evergreen",
+		NotificationTemplates: []NotificationTemplate{
+			{
+				Trigger:        alertrecord.TaskFailedId,
+				SubscriberType: "slack",
+				Subject:        "{{.Task.DisplayName}} failed in {{.Project.DisplayName}}",
+				Body:           "{{with .Build}}{{.DisplayName}}{{else}}no build{{end}}",
+			},
+		},
+	}
+	assert.Nil(ref.GetNotificationTemplate(alertrecord.TaskFailedId, "email"))
+	tmpl := ref.GetNotificationTemplate(alertrecord.TaskFailedId, "slack")
+	assert.NotNil(tmpl)
+
+	subject, body, err := tmpl.Render(NotificationTemplateData{
+		Project: ref,
+		Task:    &task.Task{DisplayName: "compile"},
+	})
+	assert.NoError(err)
+	assert.Equal("compile failed in Evergreen", subject)
+	assert.Equal("no build", body)
+
+	// a missing task can't be rendered
+	_, _, err = tmpl.Render(NotificationTemplateData{Project: ref})
+	assert.Error(err)
+}
+
+func TestNotificationTemplateHidesSecrets(t *testing.T) {
+	assert := assert.New(t)
+
+	data := NotificationTemplateData{
+		Project: &ProjectRef{Identifier: "mci", Admins: []string{"admin"}},
+		Task:    &task.Task{Id: "t1", Secret: "secret"},
+	}
+	for _, body := range []string{"{{.Task.Secret}}", "{{.Project.Admins}}", "{{.Task.HostId}}"} {
+		tmpl := NotificationTemplate{
+			Trigger:        alertrecord.TaskFailedId,
+			SubscriberType: "slack",
+			Body:           body,
+		}
+		assert.Error(tmpl.Validate(), body)
+		_, _, err := tmpl.Render(data)
+		assert.Error(err, body)
+	}
+}
+
+func TestNotificationTemplateEscapesEmail(t *testing.T) {
+	assert := assert.New(t)
+
+	data := NotificationTemplateData{
+		Task: &task.Task{Id: "t1", DisplayName: "<script>alert(1)</script>"},
+	}
+	tmpl := NotificationTemplate{
+		Trigger:        alertrecord.TaskFailedId,
+		SubscriberType: "email",
+		Subject:        "{{.Task.DisplayName}} failed",
+		Body:           "<b>{{.Task.DisplayName}}</b>",
+	}
+	subject, body, err := tmpl.Render(data)
+	assert.NoError(err)
+	assert.Equal("<script>alert(1)</script> failed", subject)
+	assert.Equal("<b>&lt;script&gt;alert(1)&lt;/script&gt;</b>", body)
+
+	tmpl.SubscriberType = "slack"
+	_, body, err = tmpl.Render(data)
+	assert.NoError(err)
+	assert.Equal("<b><script>alert(1)</script></b>", body)
+}
@@ -2,9 +2,14 @@ package model
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"fmt"
 	"reflect"
 
+	"github.com/evergreen-ci/evergreen/blob"
+	"github.com/evergreen-ci/evergreen/subprocess"
+	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
@@ -39,6 +44,7 @@ import (
 type parserProject struct {
 	Enabled         bool                       `yaml:"enabled,omitempty"`
 	Stepback        bool                       `yaml:"stepback,omitempty"`
+	StepbackBisect  bool                       `yaml:"stepback_bisect,omitempty"`
 	BatchTime       int                        `yaml:"batchtime,omitempty"`
 	Owner           string                     `yaml:"owner,omitempty"`
 	Repo            string                     `yaml:"repo,omitempty"`
@@ -59,6 +65,7 @@ type parserProject struct {
 	TaskGroups      []parserTaskGroup          `yaml:"task_groups,omitempty"`
 	Tasks           []parserTask               `yaml:"tasks,omitempty"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty"`
+	BlobStore       *blob.Options              `yaml:"blob_store,omitempty"`
 
 	// Matrix code
 	Axes []matrixAxis `yaml:"axes,omitempty"`
@@ -88,15 +95,22 @@ func (ptg *parserTaskGroup) tags() []string { return ptg.Tags }
 
 // parserTask represents an intermediary state of task definitions.
 type parserTask struct {
-	Name            string              `yaml:"name,omitempty"`
-	Priority        int64               `yaml:"priority,omitempty"`
-	ExecTimeoutSecs int                 `yaml:"exec_timeout_secs,omitempty"`
-	DependsOn       parserDependencies  `yaml:"depends_on,omitempty"`
-	Requires        taskSelectors       `yaml:"requires,omitempty"`
-	Commands        []PluginCommandConf `yaml:"commands,omitempty"`
-	Tags            parserStringSlice   `yaml:"tags,omitempty"`
-	Patchable       *bool               `yaml:"patchable,omitempty"`
-	Stepback        *bool               `yaml:"stepback,omitempty"`
+	Name            string               `yaml:"name,omitempty"`
+	Priority        int64                `yaml:"priority,omitempty"`
+	ExecTimeoutSecs int                  `yaml:"exec_timeout_secs,omitempty"`
+	DependsOn       parserDependencies   `yaml:"depends_on,omitempty"`
+	Requires        taskSelectors        `yaml:"requires,omitempty"`
+	Commands        []PluginCommandConf  `yaml:"commands,omitempty"`
+	Tags            parserStringSlice    `yaml:"tags,omitempty"`
+	Patchable       *bool                `yaml:"patchable,omitempty"`
+	Stepback        *bool                `yaml:"stepback,omitempty"`
+	DebugOnFailure  int                  `yaml:"debug_on_failure,omitempty"`
+	Paths           parserStringSlice    `yaml:"paths,omitempty"`
+	IgnorePaths     parserStringSlice    `yaml:"ignore_paths,omitempty"`
+	Artifacts       parserStringSlice    `yaml:"artifacts,omitempty"`
+	FetchArtifacts  []ArtifactDependency `yaml:"fetch_artifacts,omitempty"`
+
+	ResourceLimits subprocess.ResourceLimits `yaml:",inline"`
 }
 
 type displayTask struct {
@@ -258,6 +272,8 @@ type parserBV struct {
 	BatchTime    *int               `yaml:"batchtime,omitempty"`
 	Stepback     *bool              `yaml:"stepback,omitempty"`
 	RunOn        parserStringSlice  `yaml:"run_on,omitempty"`
+	Paths        parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths  parserStringSlice  `yaml:"ignore_paths,omitempty"`
 	Tasks        parserBVTaskUnits  `yaml:"tasks,omitempty"`
 	DisplayTasks []displayTask      `yaml:"display_tasks,omitempty"`
 	DependsOn    parserDependencies `yaml:"depends_on,omitempty"`
@@ -391,7 +407,37 @@ func (pss *parserStringSlice) UnmarshalYAML(unmarshal func(interface{}) error) e
 // LoadProjectInto loads the raw data from the config file into project
 // and sets the project's identifier field to identifier. Tags are evaluateed.
 func LoadProjectInto(data []byte, identifier string, project *Project) error {
-	p, errs := projectFromYAML(data) // ignore warnings, for now (TODO)
+	return LoadProjectIntoWithAxisValues(data, identifier, project, nil)
+}
+
+// AxisValuesLoader returns the contents of a file in the project's repository,
+// given its path relative to the root of the repository. It is used to load
+// the values of matrix axes that read them from a file.
+type AxisValuesLoader func(path string) ([]byte, error)
+
+// GithubAxisValuesLoader returns an AxisValuesLoader that reads files from a
+// GitHub repository at the given revision.
+func GithubAxisValuesLoader(ctx context.Context, oauthToken, owner, repo, revision string) AxisValuesLoader {
+	return func(path string) ([]byte, error) {
+		file, err := thirdparty.GetGithubFile(ctx, oauthToken, owner, repo, path, revision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem fetching '%s/%s'@%s: %s", owner, repo, revision, path)
+		}
+		if file.Content == nil {
+			return nil, errors.Errorf("'%s/%s'@%s: %s is not a file", owner, repo, revision, path)
+		}
+		data, err := base64.StdEncoding.DecodeString(*file.Content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem decoding '%s/%s'@%s: %s", owner, repo, revision, path)
+		}
+		return data, nil
+	}
+}
+
+// LoadProjectIntoWithAxisValues is like LoadProjectInto, but reads the values
+// files of matrix axes with the given loader.
+func LoadProjectIntoWithAxisValues(data []byte, identifier string, project *Project, load AxisValuesLoader) error {
+	p, errs := projectFromYAMLWithAxisValues(data, load) // ignore warnings, for now (TODO)
 	if len(errs) > 0 {
 		// create a human-readable error list
 		buf := bytes.Buffer{}
@@ -414,10 +460,19 @@ func LoadProjectInto(data []byte, identifier string, project *Project) error {
 // projectFromYAML reads and evaluates project YAML, returning a project and warnings and
 // errors encountered during parsing or evaluation.
 func projectFromYAML(yml []byte) (*Project, []error) {
+	return projectFromYAMLWithAxisValues(yml, nil)
+}
+
+// projectFromYAMLWithAxisValues is like projectFromYAML, but first loads the
+// values of matrix axes that read them from files.
+func projectFromYAMLWithAxisValues(yml []byte, load AxisValuesLoader) (*Project, []error) {
 	intermediateProject, errs := createIntermediateProject(yml)
 	if len(errs) > 0 {
 		return nil, errs
 	}
+	if errs = loadAxisValues(intermediateProject.Axes, load); len(errs) > 0 {
+		return nil, errs
+	}
 	p, errs := translateProject(intermediateProject)
 	return p, errs
 }
@@ -443,6 +498,7 @@ func translateProject(pp *parserProject) (*Project, []error) {
 	proj := &Project{
 		Enabled:         pp.Enabled,
 		Stepback:        pp.Stepback,
+		StepbackBisect:  pp.StepbackBisect,
 		BatchTime:       pp.BatchTime,
 		Owner:           pp.Owner,
 		Repo:            pp.Repo,
@@ -460,6 +516,7 @@ func translateProject(pp *parserProject) (*Project, []error) {
 		Modules:         pp.Modules,
 		Functions:       pp.Functions,
 		ExecTimeoutSecs: pp.ExecTimeoutSecs,
+		BlobStore:       pp.BlobStore,
 	}
 	tse := NewParserTaskSelectorEvaluator(pp.Tasks)
 	tgse := newTaskGroupSelectorEvaluator(pp.TaskGroups)
@@ -506,6 +563,12 @@ func evaluateTaskUnits(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, v
 			Tags:            pt.Tags,
 			Patchable:       pt.Patchable,
 			Stepback:        pt.Stepback,
+			ResourceLimits:  pt.ResourceLimits,
+			DebugOnFailure:  pt.DebugOnFailure,
+			Paths:           pt.Paths,
+			IgnorePaths:     pt.IgnorePaths,
+			Artifacts:       pt.Artifacts,
+			FetchArtifacts:  pt.FetchArtifacts,
 		}
 		t.DependsOn, errs = evaluateDependsOn(tse.tagEval, tgse, vse, pt.DependsOn)
 		evalErrs = append(evalErrs, errs...)
@@ -561,6 +624,8 @@ func evaluateBuildVariants(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluato
 			Stepback:    pbv.Stepback,
 			RunOn:       pbv.RunOn,
 			Tags:        pbv.Tags,
+			Paths:       pbv.Paths,
+			IgnorePaths: pbv.IgnorePaths,
 		}
 		bv.Tasks, errs = evaluateBVTasks(tse, tgse, vse, pbv)
 		// evaluate any rules passed in during matrix construction
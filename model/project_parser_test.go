@@ -9,6 +9,7 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ShouldContainResembling tests whether a slice contains an element that DeepEquals
@@ -1115,3 +1116,111 @@ buildvariants:
 	assert.Equal("task_3", proj.BuildVariants[2].Tasks[0].Requires[0].Name)
 	assert.Equal("task_3", proj.BuildVariants[2].Tasks[1].Requires[0].Name)
 }
+
+func TestTaskResourceLimitsParsing(t *testing.T) {
+	assert := assert.New(t)
+
+	yml := `
+tasks:
+- name: limited
+  max_memory_mb: 512
+  cpu_shares: 1024
+  max_pids: 100
+- name: unlimited
+buildvariants:
+- name: "bv"
+  tasks:
+  - name: limited
+  - name: unlimited
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	assert.NotNil(proj)
+	assert.Empty(errs)
+	assert.Len(proj.Tasks, 2)
+	assert.Equal(512, proj.Tasks[0].ResourceLimits.MaxMemoryMB)
+	assert.Equal(1024, proj.Tasks[0].ResourceLimits.CPUShares)
+	assert.Equal(100, proj.Tasks[0].ResourceLimits.MaxPIDs)
+	assert.True(proj.Tasks[1].ResourceLimits.IsZero())
+}
+
+func TestBlobStoreParsing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+blob_store:
+  type: azure
+  endpoint: https://account.blob.core.windows.net
+  bucket: artifacts
+  prefix: ${project}
+  token: ${azure_sas}
+tasks:
+- name: t1
+buildvariants:
+- name: "bv"
+  tasks:
+  - name: t1
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	require.NotNil(proj)
+	assert.Empty(errs)
+	require.NotNil(proj.BlobStore)
+	assert.Equal("azure", proj.BlobStore.Type)
+	assert.Equal("https://account.blob.core.windows.net", proj.BlobStore.Endpoint)
+	assert.Equal("artifacts", proj.BlobStore.Bucket)
+	assert.Equal("${project}", proj.BlobStore.Prefix)
+	assert.Equal("${azure_sas}", proj.BlobStore.Token)
+}
+
+func TestDebugOnFailureParsing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+- name: t1
+  debug_on_failure: 30
+- name: t2
+buildvariants:
+- name: "bv"
+  tasks:
+  - name: t1
+  - name: t2
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	require.NotNil(proj)
+	assert.Empty(errs)
+	require.Len(proj.Tasks, 2)
+	assert.Equal(30, proj.Tasks[0].DebugOnFailure)
+	assert.Equal(0, proj.Tasks[1].DebugOnFailure)
+}
+
+func TestFetchArtifactsParsing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+- name: compile
+  artifacts: binaries
+- name: test
+  depends_on:
+  - name: compile
+    variant: linux
+  fetch_artifacts:
+  - task: compile
+    variant: linux
+    name: binaries
+buildvariants:
+- name: linux
+  tasks:
+  - name: compile
+  - name: test
+`
+	proj, errs := projectFromYAML([]byte(yml))
+	require.NotNil(proj)
+	assert.Empty(errs)
+	require.Len(proj.Tasks, 2)
+	assert.Equal([]string{"binaries"}, proj.Tasks[0].Artifacts)
+	assert.Equal([]ArtifactDependency{{Task: "compile", Variant: "linux", Name: "binaries"}}, proj.Tasks[1].FetchArtifacts)
+}
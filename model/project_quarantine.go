@@ -0,0 +1,110 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// QuarantinedTest is a test of a project whose failures are recorded as
+// silent failures, so that they do not fail the test's task.
+type QuarantinedTest struct {
+	TestFile string `bson:"test_file" json:"test_file" yaml:"test_file"`
+	// BuildVariant limits the quarantine to one build variant. An empty
+	// build variant quarantines the test on every variant.
+	BuildVariant string `bson:"build_variant" json:"build_variant" yaml:"build_variant"`
+
+	User   string    `bson:"user" json:"user" yaml:"user"`
+	Reason string    `bson:"reason,omitempty" json:"reason,omitempty" yaml:"reason,omitempty"`
+	Time   time.Time `bson:"time" json:"time" yaml:"time"`
+}
+
+var (
+	quarantinedTestTestFileKey     = bsonutil.MustHaveTag(QuarantinedTest{}, "TestFile")
+	quarantinedTestBuildVariantKey = bsonutil.MustHaveTag(QuarantinedTest{}, "BuildVariant")
+)
+
+// IsQuarantined returns true if the test is quarantined on the build
+// variant.
+func (projectRef *ProjectRef) IsQuarantined(testFile, buildVariant string) bool {
+	for _, q := range projectRef.QuarantinedTests {
+		if q.TestFile == testFile && (q.BuildVariant == "" || q.BuildVariant == buildVariant) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyQuarantine changes the failures of the results of quarantined
+// tests on the build variant into silent failures. It returns the number
+// of results that it changed.
+func (projectRef *ProjectRef) ApplyQuarantine(buildVariant string, results []task.TestResult) int {
+	quarantined := 0
+	for i := range results {
+		if results[i].Status == evergreen.TestFailedStatus && projectRef.IsQuarantined(results[i].TestFile, buildVariant) {
+			results[i].Status = evergreen.TestSilentlyFailedStatus
+			quarantined++
+		}
+	}
+	return quarantined
+}
+
+// AddQuarantinedTest quarantines a test of the project. It returns an
+// error if the test is already quarantined on the same build variant.
+func AddQuarantinedTest(projectID string, test QuarantinedTest) error {
+	if test.TestFile == "" {
+		return errors.New("quarantined test must have a test file")
+	}
+	if test.Time.IsZero() {
+		test.Time = time.Now()
+	}
+
+	err := db.Update(ProjectRefCollection,
+		bson.M{
+			ProjectRefIdentifierKey: projectID,
+			projectRefQuarantinedTestsKey: bson.M{"$not": bson.M{"$elemMatch": bson.M{
+				quarantinedTestTestFileKey:     test.TestFile,
+				quarantinedTestBuildVariantKey: test.BuildVariant,
+			}}},
+		},
+		bson.M{"$push": bson.M{projectRefQuarantinedTestsKey: test}},
+	)
+	if db.ResultsNotFound(err) {
+		return errors.Errorf("test '%s' is already quarantined in project '%s', or the project does not exist", test.TestFile, projectID)
+	}
+	return errors.Wrapf(err, "problem quarantining test '%s'", test.TestFile)
+}
+
+// RemoveQuarantinedTest removes the quarantine of a test of the project on
+// the build variant. It returns false if the test was not quarantined.
+func (projectRef *ProjectRef) RemoveQuarantinedTest(testFile, buildVariant string) (bool, error) {
+	i := -1
+	for j, q := range projectRef.QuarantinedTests {
+		if q.TestFile == testFile && q.BuildVariant == buildVariant {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return false, nil
+	}
+
+	err := db.Update(ProjectRefCollection,
+		bson.M{ProjectRefIdentifierKey: projectRef.Identifier},
+		bson.M{"$pull": bson.M{projectRefQuarantinedTestsKey: bson.M{
+			quarantinedTestTestFileKey:     testFile,
+			quarantinedTestBuildVariantKey: buildVariant,
+		}}},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "problem removing quarantine of test '%s'", testFile)
+	}
+
+	projectRef.QuarantinedTests = append(projectRef.QuarantinedTests[:i], projectRef.QuarantinedTests[i+1:]...)
+	return true, nil
+}
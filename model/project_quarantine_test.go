@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyQuarantine(t *testing.T) {
+	assert := assert.New(t)
+
+	ref := &ProjectRef{
+		Identifier: "mci",
+		QuarantinedTests: []QuarantinedTest{
+			{TestFile: "everywhere"},
+			{TestFile: "linux_only", BuildVariant: "linux"},
+		},
+	}
+	assert.True(ref.IsQuarantined("everywhere", "windows"))
+	assert.True(ref.IsQuarantined("linux_only", "linux"))
+	assert.False(ref.IsQuarantined("linux_only", "windows"))
+	assert.False(ref.IsQuarantined("other", "linux"))
+
+	results := []task.TestResult{
+		{TestFile: "everywhere", Status: evergreen.TestFailedStatus},
+		{TestFile: "everywhere", Status: evergreen.TestSucceededStatus},
+		{TestFile: "linux_only", Status: evergreen.TestFailedStatus},
+		{TestFile: "other", Status: evergreen.TestFailedStatus},
+	}
+	assert.Equal(1, ref.ApplyQuarantine("windows", results))
+	assert.Equal(evergreen.TestSilentlyFailedStatus, results[0].Status)
+	assert.Equal(evergreen.TestSucceededStatus, results[1].Status)
+	assert.Equal(evergreen.TestFailedStatus, results[2].Status)
+	assert.Equal(evergreen.TestFailedStatus, results[3].Status)
+
+	assert.Equal(1, ref.ApplyQuarantine("linux", results))
+	assert.Equal(evergreen.TestSilentlyFailedStatus, results[2].Status)
+	assert.Equal(evergreen.TestFailedStatus, results[3].Status)
+}
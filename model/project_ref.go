@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -34,6 +37,32 @@ type ProjectRef struct {
 
 	PRTestingEnabled bool `bson:"pr_testing_enabled" json:"pr_testing_enabled" yaml:"pr_testing_enabled"`
 
+	// CommitQueue configures testing pull requests before they are
+	// merged into the project's branch.
+	CommitQueue CommitQueueParams `bson:"commit_queue" json:"commit_queue" yaml:"commit_queue"`
+
+	// PeriodicBuilds are builds of the head of the project's branch that
+	// run on a cron schedule, independent of commits.
+	PeriodicBuilds []PeriodicBuildDefinition `bson:"periodic_builds,omitempty" json:"periodic_builds,omitempty" yaml:"periodic_builds,omitempty"`
+
+	// TaskRetry configures automatically restarting tasks that fail
+	// because of system or setup failures.
+	TaskRetry TaskRetryParams `bson:"task_retry" json:"task_retry" yaml:"task_retry"`
+
+	// Triggers create versions of the project when tasks, builds, or
+	// versions of upstream projects finish.
+	Triggers []TriggerDefinition `bson:"triggers,omitempty" json:"triggers,omitempty" yaml:"triggers,omitempty"`
+
+	// QuarantinedTests are tests whose failures do not fail their tasks.
+	// They are not saved by Upsert, and are changed only with
+	// AddQuarantinedTest and RemoveQuarantinedTest.
+	QuarantinedTests []QuarantinedTest `bson:"quarantined_tests,omitempty" json:"quarantined_tests,omitempty" yaml:"quarantined_tests,omitempty"`
+
+	// NotificationTemplates override the text of the project's alerts.
+	// They are not saved by Upsert, and are changed only with
+	// SetNotificationTemplate and RemoveNotificationTemplate.
+	NotificationTemplates []NotificationTemplate `bson:"notification_templates,omitempty" json:"notification_templates,omitempty" yaml:"notification_templates,omitempty"`
+
 	//Tracked determines whether or not the project is discoverable in the UI
 	Tracked bool `bson:"tracked" json:"tracked"`
 
@@ -49,6 +78,110 @@ type ProjectRef struct {
 	RepotrackerError *RepositoryErrorDetails `bson:"repotracker_error" json:"repotracker_error"`
 }
 
+// CommitQueueParams configures the commit queue of a project.
+type CommitQueueParams struct {
+	Enabled bool `bson:"enabled" json:"enabled" yaml:"enabled"`
+
+	// MergeMethod is the GitHub merge method used to merge pull
+	// requests that pass: merge, squash, or rebase.
+	MergeMethod string `bson:"merge_method" json:"merge_method" yaml:"merge_method"`
+
+	// PatchAlias is the project alias that selects the variants and
+	// tasks to run before merging. It defaults to
+	// patch.CommitQueueAlias.
+	PatchAlias string `bson:"patch_alias" json:"patch_alias" yaml:"patch_alias"`
+}
+
+// TaskRetryParams configures automatically restarting tasks whose
+// failures are more likely caused by their hosts than by their code.
+type TaskRetryParams struct {
+	// MaxRetries is the most times a task is restarted automatically.
+	// Restarts never exceed evergreen.MaxTaskExecution.
+	MaxRetries int `bson:"max_retries" json:"max_retries" yaml:"max_retries"`
+
+	// SystemFailures and SetupFailures select the kinds of failure that
+	// are retried.
+	SystemFailures bool `bson:"system_failures" json:"system_failures" yaml:"system_failures"`
+	SetupFailures  bool `bson:"setup_failures" json:"setup_failures" yaml:"setup_failures"`
+}
+
+// PeriodicBuildDefinition is a build of a project that runs on a cron
+// schedule. The tasks to run are selected by a project alias or by a set
+// of build variants, all of whose tasks run.
+type PeriodicBuildDefinition struct {
+	ID            string   `bson:"id" json:"id" yaml:"id"`
+	Cron          string   `bson:"cron" json:"cron" yaml:"cron"`
+	Alias         string   `bson:"alias,omitempty" json:"alias,omitempty" yaml:"alias,omitempty"`
+	BuildVariants []string `bson:"build_variants,omitempty" json:"build_variants,omitempty" yaml:"build_variants,omitempty"`
+	Message       string   `bson:"message,omitempty" json:"message,omitempty" yaml:"message,omitempty"`
+
+	// LastScheduled is the latest time in the schedule that a build was
+	// queued for. It is set with SetPeriodicBuildLastScheduled.
+	LastScheduled time.Time `bson:"last_scheduled,omitempty" json:"-" yaml:"-"`
+}
+
+// Validate returns an error if the definition has no id, an invalid cron
+// expression, or nothing to run.
+func (d *PeriodicBuildDefinition) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if d.ID == "" {
+		catcher.Add(errors.New("periodic build must have an id"))
+	}
+	if _, err := util.ParseCron(d.Cron); err != nil {
+		catcher.Add(errors.Wrapf(err, "periodic build '%s' has an invalid cron expression", d.ID))
+	}
+	if d.Alias == "" && len(d.BuildVariants) == 0 {
+		catcher.Add(errors.Errorf("periodic build '%s' must have an alias or build variants", d.ID))
+	}
+	if d.Alias != "" && len(d.BuildVariants) > 0 {
+		catcher.Add(errors.Errorf("periodic build '%s' cannot have both an alias and build variants", d.ID))
+	}
+	return catcher.Resolve()
+}
+
+// GetPeriodicBuild returns the periodic build definition with the id, or
+// nil if there is none.
+func (projectRef *ProjectRef) GetPeriodicBuild(id string) *PeriodicBuildDefinition {
+	for i := range projectRef.PeriodicBuilds {
+		if projectRef.PeriodicBuilds[i].ID == id {
+			return &projectRef.PeriodicBuilds[i]
+		}
+	}
+	return nil
+}
+
+// SetPeriodicBuildLastScheduled records the latest time in the schedule of
+// the project's periodic build with the id that a build was queued for.
+func (projectRef *ProjectRef) SetPeriodicBuildLastScheduled(id string, t time.Time) error {
+	err := db.Update(ProjectRefCollection,
+		bson.M{
+			ProjectRefIdentifierKey: projectRef.Identifier,
+			bsonutil.GetDottedKeyName(projectRefPeriodicBuildsKey, periodicBuildIDKey): id,
+		},
+		bson.M{"$set": bson.M{
+			bsonutil.GetDottedKeyName(projectRefPeriodicBuildsKey, "$", periodicBuildLastScheduledKey): t,
+		}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "problem setting the last scheduled time of periodic build '%s' for project %s", id, projectRef.Identifier)
+	}
+	if definition := projectRef.GetPeriodicBuild(id); definition != nil {
+		definition.LastScheduled = t
+	}
+	return nil
+}
+
+// GetTrigger returns the trigger definition with the id, or nil if there
+// is none.
+func (projectRef *ProjectRef) GetTrigger(id string) *TriggerDefinition {
+	for i := range projectRef.Triggers {
+		if projectRef.Triggers[i].ID == id {
+			return &projectRef.Triggers[i]
+		}
+	}
+	return nil
+}
+
 // RepositoryErrorDetails indicates whether or not there is an invalid revision and if there is one,
 // what the guessed merge base revision is.
 type RepositoryErrorDetails struct {
@@ -97,6 +230,15 @@ var (
 	ProjectRefAdminsKey             = bsonutil.MustHaveTag(ProjectRef{}, "Admins")
 	projectRefTracksPushEventsKey   = bsonutil.MustHaveTag(ProjectRef{}, "TracksPushEvents")
 	projectRefPRTestingEnabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PRTestingEnabled")
+	projectRefCommitQueueKey        = bsonutil.MustHaveTag(ProjectRef{}, "CommitQueue")
+	commitQueueEnabledKey           = bsonutil.MustHaveTag(CommitQueueParams{}, "Enabled")
+	projectRefPeriodicBuildsKey     = bsonutil.MustHaveTag(ProjectRef{}, "PeriodicBuilds")
+	periodicBuildIDKey              = bsonutil.MustHaveTag(PeriodicBuildDefinition{}, "ID")
+	periodicBuildLastScheduledKey   = bsonutil.MustHaveTag(PeriodicBuildDefinition{}, "LastScheduled")
+	projectRefTriggersKey           = bsonutil.MustHaveTag(ProjectRef{}, "Triggers")
+	projectRefTaskRetryKey          = bsonutil.MustHaveTag(ProjectRef{}, "TaskRetry")
+	triggerDefinitionProjectKey     = bsonutil.MustHaveTag(TriggerDefinition{}, "Project")
+	projectRefQuarantinedTestsKey   = bsonutil.MustHaveTag(ProjectRef{}, "QuarantinedTests")
 )
 
 const (
@@ -234,6 +376,88 @@ func FindOneProjectRefByRepoAndBranchWithPRTesting(owner, repo, branch string) (
 	return &projectRefs[target], nil
 }
 
+// FindOneProjectRefWithCommitQueueByRepoAndBranch finds the enabled
+// ProjectRef with matching repo/branch that has the commit queue
+// enabled, or nil if there is none.
+func FindOneProjectRefWithCommitQueueByRepoAndBranch(owner, repo, branch string) (*ProjectRef, error) {
+	projectRef := &ProjectRef{}
+	err := db.FindOne(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefOwnerKey:   owner,
+			ProjectRefRepoKey:    repo,
+			ProjectRefBranchKey:  branch,
+			ProjectRefEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefCommitQueueKey, commitQueueEnabledKey): true,
+		},
+		db.NoProjection,
+		db.NoSort,
+		projectRef,
+	)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return projectRef, errors.Wrapf(err, "Could not fetch project ref for repo '%s/%s' with branch '%s'",
+		owner, repo, branch)
+}
+
+// FindProjectRefsWithCommitQueueEnabled returns the enabled project refs
+// that have the commit queue enabled.
+func FindProjectRefsWithCommitQueueEnabled() ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefCommitQueueKey, commitQueueEnabledKey): true,
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
+// FindProjectRefsWithPeriodicBuilds returns all enabled project refs
+// that define periodic builds.
+func FindProjectRefsWithPeriodicBuilds() ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey:        true,
+			projectRefPeriodicBuildsKey: bson.M{"$exists": true, "$ne": []interface{}{}},
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
+// FindDownstreamProjectRefs returns all enabled project refs with a
+// trigger on the upstream project.
+func FindDownstreamProjectRefs(upstreamProject string) ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefTriggersKey, triggerDefinitionProjectKey): upstreamProject,
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
 // FindProjectRefs returns limit refs starting at project identifier key
 // in the sortDir direction
 func FindProjectRefs(key string, limit int, sortDir int, isAuthenticated bool) ([]ProjectRef, error) {
@@ -306,6 +530,10 @@ func (projectRef *ProjectRef) Upsert() error {
 				ProjectRefAdminsKey:             projectRef.Admins,
 				projectRefTracksPushEventsKey:   projectRef.TracksPushEvents,
 				projectRefPRTestingEnabledKey:   projectRef.PRTestingEnabled,
+				projectRefCommitQueueKey:        projectRef.CommitQueue,
+				projectRefPeriodicBuildsKey:     projectRef.PeriodicBuilds,
+				projectRefTriggersKey:           projectRef.Triggers,
+				projectRefTaskRetryKey:          projectRef.TaskRetry,
 			},
 		},
 	)
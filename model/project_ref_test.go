@@ -3,6 +3,7 @@ package model
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
@@ -211,3 +212,56 @@ func TestFindOneProjectRefByRepoAndBranchWithPRTesting(t *testing.T) {
 	assert.Contains(err.Error(), "found 2 project refs, when 1 was expected")
 	require.Nil(projectRef)
 }
+
+func TestPeriodicBuildDefinitionValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&PeriodicBuildDefinition{ID: "nightly", Cron: "0 4 * * *", Alias: "nightly"}).Validate())
+	assert.NoError((&PeriodicBuildDefinition{ID: "weekly", Cron: "@weekly", BuildVariants: []string{"ubuntu"}}).Validate())
+
+	assert.Error((&PeriodicBuildDefinition{Cron: "0 4 * * *", Alias: "nightly"}).Validate())
+	assert.Error((&PeriodicBuildDefinition{ID: "nightly", Cron: "0 25 * * *", Alias: "nightly"}).Validate())
+	assert.Error((&PeriodicBuildDefinition{ID: "nightly", Cron: "0 4 * * *"}).Validate())
+	assert.Error((&PeriodicBuildDefinition{ID: "nightly", Cron: "0 4 * * *", Alias: "nightly",
+		BuildVariants: []string{"ubuntu"}}).Validate())
+}
+
+func TestGetPeriodicBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	ref := &ProjectRef{
+		PeriodicBuilds: []PeriodicBuildDefinition{
+			{ID: "nightly", Cron: "0 4 * * *", Alias: "nightly"},
+			{ID: "weekly", Cron: "@weekly", BuildVariants: []string{"ubuntu"}},
+		},
+	}
+	definition := ref.GetPeriodicBuild("weekly")
+	assert.NotNil(definition)
+	assert.Equal("@weekly", definition.Cron)
+	assert.Nil(ref.GetPeriodicBuild("monthly"))
+}
+
+func TestSetPeriodicBuildLastScheduled(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(ProjectRefCollection))
+
+	ref := &ProjectRef{
+		Identifier: "mci",
+		PeriodicBuilds: []PeriodicBuildDefinition{
+			{ID: "nightly", Cron: "0 4 * * *", Alias: "nightly"},
+			{ID: "weekly", Cron: "@weekly", BuildVariants: []string{"ubuntu"}},
+		},
+	}
+	require.NoError(ref.Insert())
+
+	scheduled := time.Date(2018, time.January, 15, 4, 0, 0, 0, time.UTC)
+	require.NoError(ref.SetPeriodicBuildLastScheduled("weekly", scheduled))
+	assert.Equal(scheduled, ref.GetPeriodicBuild("weekly").LastScheduled)
+
+	dbRef, err := FindOneProjectRef("mci")
+	require.NoError(err)
+	require.NotNil(dbRef)
+	assert.True(dbRef.GetPeriodicBuild("nightly").LastScheduled.IsZero())
+	assert.True(scheduled.Equal(dbRef.GetPeriodicBuild("weekly").LastScheduled))
+}
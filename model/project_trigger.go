@@ -0,0 +1,262 @@
+package model
+
+import (
+	"regexp"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	TriggerLevelTask    = "task"
+	TriggerLevelBuild   = "build"
+	TriggerLevelVersion = "version"
+
+	// maxTriggerChainLength is the most projects that a chain of
+	// triggers can pass through before it stops
+	maxTriggerChainLength = 10
+)
+
+// TriggerDefinition creates a version of the project when a task, build,
+// or version of an upstream project finishes with a given status. The
+// tasks to run are selected by a project alias or by a set of build
+// variants, all of whose tasks run.
+type TriggerDefinition struct {
+	ID string `bson:"id" json:"id" yaml:"id"`
+
+	// Project is the identifier of the upstream project.
+	Project string `bson:"project" json:"project" yaml:"project"`
+	// Level is the kind of upstream item: task, build, or version.
+	Level string `bson:"level" json:"level" yaml:"level"`
+	// Status is the status that the upstream item must finish with. An
+	// empty status matches any finished item.
+	Status string `bson:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty"`
+	// BuildVariantRegex and TaskRegex limit the upstream builds and tasks
+	// that match for the build and task levels.
+	BuildVariantRegex string `bson:"variant_regex,omitempty" json:"variant_regex,omitempty" yaml:"variant_regex,omitempty"`
+	TaskRegex         string `bson:"task_regex,omitempty" json:"task_regex,omitempty" yaml:"task_regex,omitempty"`
+
+	Alias         string   `bson:"alias,omitempty" json:"alias,omitempty" yaml:"alias,omitempty"`
+	BuildVariants []string `bson:"build_variants,omitempty" json:"build_variants,omitempty" yaml:"build_variants,omitempty"`
+	Message       string   `bson:"message,omitempty" json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Validate returns an error if the definition is missing an id, upstream
+// project, or something to run, or has an invalid level, status, or regex.
+func (d *TriggerDefinition) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if d.ID == "" {
+		catcher.Add(errors.New("trigger must have an id"))
+	}
+	if d.Project == "" {
+		catcher.Add(errors.Errorf("trigger '%s' must have an upstream project", d.ID))
+	}
+	switch d.Level {
+	case TriggerLevelTask, TriggerLevelBuild, TriggerLevelVersion:
+	default:
+		catcher.Add(errors.Errorf("trigger '%s' level '%s' must be one of task, build, or version", d.ID, d.Level))
+	}
+	switch d.Status {
+	case "", evergreen.TaskSucceeded, evergreen.TaskFailed:
+	default:
+		catcher.Add(errors.Errorf("trigger '%s' status '%s' must be success, failed, or empty", d.ID, d.Status))
+	}
+	if _, err := regexp.Compile(d.BuildVariantRegex); err != nil {
+		catcher.Add(errors.Wrapf(err, "trigger '%s' has an invalid variant regex", d.ID))
+	}
+	if _, err := regexp.Compile(d.TaskRegex); err != nil {
+		catcher.Add(errors.Wrapf(err, "trigger '%s' has an invalid task regex", d.ID))
+	}
+	if d.Alias == "" && len(d.BuildVariants) == 0 {
+		catcher.Add(errors.Errorf("trigger '%s' must have an alias or build variants", d.ID))
+	}
+	if d.Alias != "" && len(d.BuildVariants) > 0 {
+		catcher.Add(errors.Errorf("trigger '%s' cannot have both an alias and build variants", d.ID))
+	}
+	return catcher.Resolve()
+}
+
+// UpstreamEvent is a task, build, or version of an upstream project that
+// has finished.
+type UpstreamEvent struct {
+	EventID      string `bson:"event_id" json:"event_id"`
+	Level        string `bson:"level" json:"level"`
+	Status       string `bson:"status" json:"status"`
+	Project      string `bson:"project" json:"project"`
+	VersionID    string `bson:"version_id" json:"version_id"`
+	Revision     string `bson:"revision" json:"revision"`
+	BuildVariant string `bson:"build_variant,omitempty" json:"build_variant,omitempty"`
+	TaskName     string `bson:"task_name,omitempty" json:"task_name,omitempty"`
+
+	// Chain is the list of projects upstream of the event's project.
+	Chain []string `bson:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// NewUpstreamEvent returns the upstream event for an event log entry, or
+// nil if the entry is not for a finished task, build, or version that can
+// trigger downstream projects. Only mainline versions trigger; patches do
+// not.
+func NewUpstreamEvent(e *event.EventLogEntry) (*UpstreamEvent, error) {
+	u := &UpstreamEvent{EventID: e.ID.Hex()}
+	var requester string
+
+	switch data := e.Data.(type) {
+	case *event.TaskEventData:
+		if e.EventType != event.TaskFinished {
+			return nil, nil
+		}
+		t, err := task.FindOneNoMerge(task.ById(e.ResourceId))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding task %s", e.ResourceId)
+		}
+		if t == nil {
+			return nil, errors.Errorf("task %s not found", e.ResourceId)
+		}
+		u.Level = TriggerLevelTask
+		u.Status = data.Status
+		u.Project = t.Project
+		u.VersionID = t.Version
+		u.Revision = t.Revision
+		u.BuildVariant = t.BuildVariant
+		u.TaskName = t.DisplayName
+		requester = t.Requester
+
+	case *event.BuildEventData:
+		if e.EventType != event.BuildStateChange {
+			return nil, nil
+		}
+		b, err := build.FindOne(build.ById(e.ResourceId))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding build %s", e.ResourceId)
+		}
+		if b == nil {
+			return nil, errors.Errorf("build %s not found", e.ResourceId)
+		}
+		u.Level = TriggerLevelBuild
+		u.Status = data.Status
+		u.Project = b.Project
+		u.VersionID = b.Version
+		u.Revision = b.Revision
+		u.BuildVariant = b.BuildVariant
+		requester = b.Requester
+
+	case *event.VersionEventData:
+		if e.EventType != event.VersionStateChange {
+			return nil, nil
+		}
+		u.Level = TriggerLevelVersion
+		u.Status = data.Status
+		u.VersionID = e.ResourceId
+
+	default:
+		return nil, nil
+	}
+
+	switch u.Status {
+	case evergreen.TaskSucceeded, evergreen.TaskFailed:
+	default:
+		return nil, nil
+	}
+
+	v, err := version.FindOne(version.ById(u.VersionID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding version %s", u.VersionID)
+	}
+	if v == nil {
+		return nil, errors.Errorf("version %s not found", u.VersionID)
+	}
+	if u.Level == TriggerLevelVersion {
+		u.Project = v.Identifier
+		u.Revision = v.Revision
+		requester = v.Requester
+	}
+	if v.TriggeredBy != nil {
+		u.Chain = v.TriggeredBy.Chain
+	}
+
+	if evergreen.IsPatchRequester(requester) {
+		return nil, nil
+	}
+	return u, nil
+}
+
+// Matches returns true if the trigger definition applies to the upstream
+// event.
+func (d *TriggerDefinition) Matches(u *UpstreamEvent) bool {
+	if d.Project != u.Project || d.Level != u.Level {
+		return false
+	}
+	if d.Status != "" && d.Status != u.Status {
+		return false
+	}
+	if d.BuildVariantRegex != "" && u.Level != TriggerLevelVersion {
+		if matched, err := regexp.MatchString(d.BuildVariantRegex, u.BuildVariant); err != nil || !matched {
+			return false
+		}
+	}
+	if d.TaskRegex != "" && u.Level == TriggerLevelTask {
+		if matched, err := regexp.MatchString(d.TaskRegex, u.TaskName); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// TriggerInfo returns the trigger information to store on the version that
+// the trigger definition creates for the upstream event.
+func (u *UpstreamEvent) TriggerInfo(triggerID string) *version.TriggerInfo {
+	chain := make([]string, 0, len(u.Chain)+1)
+	chain = append(chain, u.Chain...)
+	chain = append(chain, u.Project)
+	return &version.TriggerInfo{
+		TriggerID:         triggerID,
+		UpstreamProject:   u.Project,
+		UpstreamVersionID: u.VersionID,
+		UpstreamRevision:  u.Revision,
+		Chain:             chain,
+	}
+}
+
+// DownstreamTrigger is a trigger definition of a downstream project.
+type DownstreamTrigger struct {
+	ProjectID  string
+	Definition TriggerDefinition
+}
+
+// FindDownstreamTriggers returns the trigger definitions of enabled
+// projects that the upstream event matches. Projects already in the
+// event's chain of upstream projects are skipped, so that triggers cannot
+// loop.
+func FindDownstreamTriggers(u *UpstreamEvent) ([]DownstreamTrigger, error) {
+	if len(u.Chain)+1 >= maxTriggerChainLength {
+		grip.Warningf("not triggering downstream of project %s: the chain of triggers is too long (%v)", u.Project, u.Chain)
+		return nil, nil
+	}
+
+	refs, err := FindDownstreamProjectRefs(u.Project)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding projects downstream of %s", u.Project)
+	}
+
+	triggers := []DownstreamTrigger{}
+	for _, ref := range refs {
+		if ref.Identifier == u.Project || util.StringSliceContains(u.Chain, ref.Identifier) {
+			continue
+		}
+		for _, definition := range ref.Triggers {
+			if definition.Matches(u) {
+				triggers = append(triggers, DownstreamTrigger{
+					ProjectID:  ref.Identifier,
+					Definition: definition,
+				})
+			}
+		}
+	}
+	return triggers, nil
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerDefinitionValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&TriggerDefinition{ID: "lib", Project: "lib", Level: TriggerLevelVersion,
+		Status: evergreen.VersionSucceeded, Alias: "downstream"}).Validate())
+	assert.NoError((&TriggerDefinition{ID: "lib", Project: "lib", Level: TriggerLevelTask,
+		TaskRegex: "^compile$", BuildVariants: []string{"ubuntu"}}).Validate())
+
+	assert.Error((&TriggerDefinition{Project: "lib", Level: TriggerLevelVersion, Alias: "downstream"}).Validate())
+	assert.Error((&TriggerDefinition{ID: "lib", Level: TriggerLevelVersion, Alias: "downstream"}).Validate())
+	assert.Error((&TriggerDefinition{ID: "lib", Project: "lib", Level: "patch", Alias: "downstream"}).Validate())
+	assert.Error((&TriggerDefinition{ID: "lib", Project: "lib", Level: TriggerLevelVersion,
+		Status: evergreen.TaskStarted, Alias: "downstream"}).Validate())
+	assert.Error((&TriggerDefinition{ID: "lib", Project: "lib", Level: TriggerLevelTask,
+		TaskRegex: "(", Alias: "downstream"}).Validate())
+	assert.Error((&TriggerDefinition{ID: "lib", Project: "lib", Level: TriggerLevelVersion}).Validate())
+}
+
+func TestTriggerDefinitionMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	taskEvent := &UpstreamEvent{
+		Level:        TriggerLevelTask,
+		Status:       evergreen.TaskSucceeded,
+		Project:      "lib",
+		BuildVariant: "ubuntu",
+		TaskName:     "compile",
+	}
+
+	definition := &TriggerDefinition{Project: "lib", Level: TriggerLevelTask}
+	assert.True(definition.Matches(taskEvent))
+
+	definition.Status = evergreen.TaskSucceeded
+	definition.BuildVariantRegex = "^ubu"
+	definition.TaskRegex = "^compile$"
+	assert.True(definition.Matches(taskEvent))
+
+	definition.Status = evergreen.TaskFailed
+	assert.False(definition.Matches(taskEvent))
+	definition.Status = ""
+
+	definition.TaskRegex = "^test"
+	assert.False(definition.Matches(taskEvent))
+	definition.TaskRegex = ""
+
+	definition.BuildVariantRegex = "windows"
+	assert.False(definition.Matches(taskEvent))
+	definition.BuildVariantRegex = ""
+
+	definition.Project = "app"
+	assert.False(definition.Matches(taskEvent))
+	definition.Project = "lib"
+
+	definition.Level = TriggerLevelVersion
+	assert.False(definition.Matches(taskEvent))
+
+	// variant and task regexes don't apply to versions
+	versionEvent := &UpstreamEvent{Level: TriggerLevelVersion, Status: evergreen.VersionFailed, Project: "lib"}
+	definition.BuildVariantRegex = "windows"
+	definition.TaskRegex = "^test"
+	assert.True(definition.Matches(versionEvent))
+}
+
+func TestUpstreamEventTriggerInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	u := &UpstreamEvent{
+		Project:   "app",
+		VersionID: "app_v1",
+		Revision:  "abcdef",
+		Chain:     []string{"lib"},
+	}
+	info := u.TriggerInfo("app-trigger")
+	assert.Equal("app-trigger", info.TriggerID)
+	assert.Equal("app", info.UpstreamProject)
+	assert.Equal("app_v1", info.UpstreamVersionID)
+	assert.Equal("abcdef", info.UpstreamRevision)
+	assert.Equal([]string{"lib", "app"}, info.Chain)
+	assert.Equal([]string{"lib"}, u.Chain)
+}
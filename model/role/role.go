@@ -0,0 +1,257 @@
+package role
+
+import (
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Collection is the name of the roles collection in the database.
+	Collection = "roles"
+	// AssignmentsCollection is the name of the role assignments collection
+	// in the database.
+	AssignmentsCollection = "role_assignments"
+)
+
+// Resource types that roles are assigned on.
+const (
+	ProjectResource = "project"
+	DistroResource  = "distro"
+)
+
+// AllResources matches every resource of a type in an assignment.
+const AllResources = "*"
+
+// Permissions that roles grant. TaskRestart also covers aborting,
+// scheduling, and changing the priority of tasks, and of the builds,
+// versions, and patches that contain them.
+const (
+	ProjectView   = "project:view"
+	ProjectEdit   = "project:edit"
+	TaskRestart   = "task:restart"
+	HostTerminate = "host:terminate"
+	DistroEdit    = "distro:edit"
+)
+
+// permissionResources maps each permission to the type of resource it is
+// granted on. Tasks are granted on their project, and hosts on their distro.
+var permissionResources = map[string]string{
+	ProjectView:   ProjectResource,
+	ProjectEdit:   ProjectResource,
+	TaskRestart:   ProjectResource,
+	HostTerminate: DistroResource,
+	DistroEdit:    DistroResource,
+}
+
+// PublicPermissions are granted to every logged in user on projects that
+// are not private. On private projects they must be granted by a role.
+var PublicPermissions = []string{ProjectView, TaskRestart}
+
+// ResourceType returns the type of resource that the permission is granted
+// on, or an empty string for an unknown permission.
+func ResourceType(permission string) string {
+	return permissionResources[permission]
+}
+
+// Role is a named set of permissions.
+type Role struct {
+	Id          string   `bson:"_id" json:"id"`
+	Name        string   `bson:"name" json:"name"`
+	Permissions []string `bson:"permissions" json:"permissions"`
+}
+
+// Assignment grants the permissions of a role to a subject, which is either
+// a username or a group prefixed with auth.GroupPrefix, on a resource. A
+// resource id of AllResources grants the role on every resource of the type.
+type Assignment struct {
+	Id           string `bson:"_id" json:"id"`
+	Role         string `bson:"role" json:"role"`
+	Subject      string `bson:"subject" json:"subject"`
+	ResourceType string `bson:"resource_type" json:"resource_type"`
+	ResourceId   string `bson:"resource_id" json:"resource_id"`
+}
+
+var (
+	// BSON fields for the role struct
+	IdKey          = bsonutil.MustHaveTag(Role{}, "Id")
+	NameKey        = bsonutil.MustHaveTag(Role{}, "Name")
+	PermissionsKey = bsonutil.MustHaveTag(Role{}, "Permissions")
+
+	// BSON fields for the assignment struct
+	AssignmentIdKey           = bsonutil.MustHaveTag(Assignment{}, "Id")
+	AssignmentRoleKey         = bsonutil.MustHaveTag(Assignment{}, "Role")
+	AssignmentSubjectKey      = bsonutil.MustHaveTag(Assignment{}, "Subject")
+	AssignmentResourceTypeKey = bsonutil.MustHaveTag(Assignment{}, "ResourceType")
+	AssignmentResourceIdKey   = bsonutil.MustHaveTag(Assignment{}, "ResourceId")
+)
+
+// Validate checks that the role has an id and only known permissions.
+func (r *Role) Validate() error {
+	if r.Id == "" {
+		return errors.New("role must have an id")
+	}
+	for _, p := range r.Permissions {
+		if ResourceType(p) == "" {
+			return errors.Errorf("unknown permission '%s'", p)
+		}
+	}
+	return nil
+}
+
+// Upsert inserts the role or replaces the role with the same id.
+func (r *Role) Upsert() error {
+	_, err := db.Upsert(Collection, bson.M{IdKey: r.Id}, r)
+	return errors.Wrapf(err, "problem saving role '%s'", r.Id)
+}
+
+// Validate checks that the assignment names a role, a subject and a
+// resource of a known type.
+func (a *Assignment) Validate() error {
+	if a.Role == "" {
+		return errors.New("assignment must have a role")
+	}
+	if a.Subject == "" {
+		return errors.New("assignment must have a subject")
+	}
+	if a.ResourceType != ProjectResource && a.ResourceType != DistroResource {
+		return errors.Errorf("unknown resource type '%s'", a.ResourceType)
+	}
+	if a.ResourceId == "" {
+		return errors.New("assignment must have a resource id")
+	}
+	return nil
+}
+
+// Insert adds the assignment, giving it an id if it has none.
+func (a *Assignment) Insert() error {
+	if a.Id == "" {
+		a.Id = bson.NewObjectId().Hex()
+	}
+	return errors.Wrap(db.Insert(AssignmentsCollection, a), "problem inserting role assignment")
+}
+
+// FindAll returns all roles.
+func FindAll() ([]Role, error) {
+	roles := []Role{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{}).Sort([]string{IdKey}), &roles)
+	return roles, errors.Wrap(err, "problem finding roles")
+}
+
+// FindOne returns the role with the id, or nil if there is none.
+func FindOne(id string) (*Role, error) {
+	r := &Role{}
+	err := db.FindOneQ(Collection, db.Query(bson.M{IdKey: id}), r)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding role '%s'", id)
+	}
+	return r, nil
+}
+
+// Remove removes the role and its assignments. It returns false if there is
+// no such role.
+func Remove(id string) (bool, error) {
+	err := db.Remove(Collection, bson.M{IdKey: id})
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "problem removing role '%s'", id)
+	}
+	err = db.RemoveAll(AssignmentsCollection, bson.M{AssignmentRoleKey: id})
+	return true, errors.Wrapf(err, "problem removing the assignments of role '%s'", id)
+}
+
+// FindAssignments returns the assignments that satisfy the query.
+func FindAssignments(query db.Q) ([]Assignment, error) {
+	assignments := []Assignment{}
+	err := db.FindAllQ(AssignmentsCollection, query, &assignments)
+	return assignments, errors.Wrap(err, "problem finding role assignments")
+}
+
+// AssignmentsBySubject returns a query for the assignments of a subject, or
+// for all assignments if the subject is empty.
+func AssignmentsBySubject(subject string) db.Q {
+	filter := bson.M{}
+	if subject != "" {
+		filter[AssignmentSubjectKey] = subject
+	}
+	return db.Query(filter).Sort([]string{AssignmentSubjectKey, AssignmentResourceTypeKey, AssignmentResourceIdKey})
+}
+
+// AssignmentsForResource returns a query for the assignments to any of the
+// subjects on the resource, including those on all resources of its type.
+func AssignmentsForResource(subjects []string, resourceType, resourceId string) db.Q {
+	return db.Query(bson.M{
+		AssignmentSubjectKey:      bson.M{"$in": subjects},
+		AssignmentResourceTypeKey: resourceType,
+		AssignmentResourceIdKey:   bson.M{"$in": []string{resourceId, AllResources}},
+	})
+}
+
+// RemoveAssignment removes the assignment with the id. It returns false if
+// there is no such assignment.
+func RemoveAssignment(id string) (bool, error) {
+	err := db.Remove(AssignmentsCollection, bson.M{AssignmentIdKey: id})
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, errors.Wrapf(err, "problem removing role assignment '%s'", id)
+}
+
+// HasPermission returns whether any of the assignments to the subjects
+// grants the permission on the resource, given the roles they assign.
+func HasPermission(roles []Role, assignments []Assignment, subjects []string, resourceType, resourceId, permission string) bool {
+	if ResourceType(permission) != resourceType {
+		return false
+	}
+	granted := map[string]bool{}
+	for _, r := range roles {
+		granted[r.Id] = util.StringSliceContains(r.Permissions, permission)
+	}
+	for _, a := range assignments {
+		if !granted[a.Role] || a.ResourceType != resourceType {
+			continue
+		}
+		if a.ResourceId != resourceId && a.ResourceId != AllResources {
+			continue
+		}
+		if util.StringSliceContains(subjects, a.Subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserHasPermission returns whether a role assigned to the user, or to one
+// of their groups, grants the permission on the resource.
+func UserHasPermission(u auth.User, resourceType, resourceId, permission string) (bool, error) {
+	if u == nil || u.IsNil() {
+		return false, nil
+	}
+	subjects := auth.UserSubjects(u)
+	assignments, err := FindAssignments(AssignmentsForResource(subjects, resourceType, resourceId))
+	if err != nil {
+		return false, err
+	}
+	if len(assignments) == 0 {
+		return false, nil
+	}
+
+	roleIds := make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		roleIds = append(roleIds, a.Role)
+	}
+	roles := []Role{}
+	if err = db.FindAllQ(Collection, db.Query(bson.M{IdKey: bson.M{"$in": roleIds}}), &roles); err != nil {
+		return false, errors.Wrap(err, "problem finding roles")
+	}
+	return HasPermission(roles, assignments, subjects, resourceType, resourceId, permission), nil
+}
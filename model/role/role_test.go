@@ -0,0 +1,54 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Role{Id: "contractor", Permissions: []string{ProjectView, TaskRestart}}
+	assert.NoError(r.Validate())
+	r.Permissions = append(r.Permissions, "project:delete")
+	assert.Error(r.Validate())
+	assert.Error((&Role{}).Validate())
+
+	a := Assignment{Role: "contractor", Subject: "group:contractors", ResourceType: ProjectResource, ResourceId: "mci"}
+	assert.NoError(a.Validate())
+	a.ResourceType = "host"
+	assert.Error(a.Validate())
+	assert.Error((&Assignment{Role: "contractor", Subject: "octocat", ResourceType: DistroResource}).Validate())
+	assert.Error((&Assignment{Subject: "octocat", ResourceType: DistroResource, ResourceId: AllResources}).Validate())
+}
+
+func TestHasPermission(t *testing.T) {
+	assert := assert.New(t)
+
+	roles := []Role{
+		{Id: "contractor", Permissions: []string{ProjectView, TaskRestart}},
+		{Id: "maintainer", Permissions: []string{ProjectView, ProjectEdit, TaskRestart}},
+		{Id: "operator", Permissions: []string{HostTerminate, DistroEdit}},
+	}
+	assignments := []Assignment{
+		{Role: "contractor", Subject: "group:contractors", ResourceType: ProjectResource, ResourceId: "mci"},
+		{Role: "maintainer", Subject: "octocat", ResourceType: ProjectResource, ResourceId: AllResources},
+		{Role: "operator", Subject: "octocat", ResourceType: DistroResource, ResourceId: "ubuntu1604"},
+		{Role: "deleted", Subject: "hubot", ResourceType: ProjectResource, ResourceId: "mci"},
+	}
+	contractor := []string{"hubot", "group:contractors"}
+	maintainer := []string{"octocat"}
+
+	assert.True(HasPermission(roles, assignments, contractor, ProjectResource, "mci", TaskRestart))
+	assert.False(HasPermission(roles, assignments, contractor, ProjectResource, "mci", ProjectEdit))
+	assert.False(HasPermission(roles, assignments, contractor, ProjectResource, "other", TaskRestart))
+
+	assert.True(HasPermission(roles, assignments, maintainer, ProjectResource, "other", ProjectEdit))
+	assert.True(HasPermission(roles, assignments, maintainer, DistroResource, "ubuntu1604", HostTerminate))
+	assert.False(HasPermission(roles, assignments, maintainer, DistroResource, "windows", HostTerminate))
+
+	// permissions are only granted on their type of resource
+	assert.False(HasPermission(roles, assignments, maintainer, DistroResource, "ubuntu1604", ProjectEdit))
+	assert.False(HasPermission(roles, assignments, maintainer, ProjectResource, "ubuntu1604", DistroEdit))
+}
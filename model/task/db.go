@@ -50,6 +50,9 @@ var (
 	StatusKey              = bsonutil.MustHaveTag(Task{}, "Status")
 	DetailsKey             = bsonutil.MustHaveTag(Task{}, "Details")
 	AbortedKey             = bsonutil.MustHaveTag(Task{}, "Aborted")
+	StepbackCulpritKey     = bsonutil.MustHaveTag(Task{}, "StepbackCulprit")
+	AutoRestartsKey        = bsonutil.MustHaveTag(Task{}, "AutoRestarts")
+	PathFilteredKey        = bsonutil.MustHaveTag(Task{}, "PathFiltered")
 	TimeTakenKey           = bsonutil.MustHaveTag(Task{}, "TimeTaken")
 	ExpectedDurationKey    = bsonutil.MustHaveTag(Task{}, "ExpectedDuration")
 	PriorityKey            = bsonutil.MustHaveTag(Task{}, "Priority")
@@ -302,6 +305,23 @@ func ByBeforeRevisionWithStatusesAndRequester(revisionOrder int, statuses []stri
 	}).Sort([]string{"-" + RevisionOrderNumberKey})
 }
 
+// ByAfterRevisionWithStatusesAndRequester returns the tasks after the
+// revision with one of the statuses, earliest revision first.
+func ByAfterRevisionWithStatusesAndRequester(revisionOrder int, statuses []string, buildVariant, displayName, project, requester string) db.Q {
+	return db.Query(bson.M{
+		BuildVariantKey: buildVariant,
+		DisplayNameKey:  displayName,
+		RequesterKey:    requester,
+		RevisionOrderNumberKey: bson.M{
+			"$gt": revisionOrder,
+		},
+		StatusKey: bson.M{
+			"$in": statuses,
+		},
+		ProjectKey: project,
+	}).Sort([]string{RevisionOrderNumberKey})
+}
+
 // ByTimeRun returns all tasks that are running in between two given times.
 func ByTimeRun(startTime, endTime time.Time) db.Q {
 	return db.Query(
@@ -53,6 +53,10 @@ type Task struct {
 	TaskGroup         string `bson:"task_group" json:"task_group"`
 	TaskGroupMaxHosts int    `bson:"task_group_max_hosts,omitempty" json:"task_group_max_hosts,omitempty"`
 
+	// DebugOnFailureMins is the number of minutes that the host is held
+	// for debugging after the task fails.
+	DebugOnFailureMins int `bson:"debug_on_failure_mins,omitempty" json:"debug_on_failure_mins,omitempty"`
+
 	// only relevant if the task is runnin.  the time of the last heartbeat
 	// sent back by the agent
 	LastHeartbeat time.Time `bson:"last_heartbeat"`
@@ -94,6 +98,20 @@ type Task struct {
 	Details apimodels.TaskEndDetail `bson:"details" json:"task_end_details"`
 	Aborted bool                    `bson:"abort,omitempty" json:"abort"`
 
+	// StepbackCulprit is set when bisecting stepback has found that this
+	// is the first failing run of the task after its last passing run.
+	StepbackCulprit bool `bson:"stepback_culprit,omitempty" json:"stepback_culprit,omitempty"`
+
+	// AutoRestarts is the number of times the task has been restarted
+	// automatically after failing because of a system or setup failure or
+	// because its host died.
+	AutoRestarts int `bson:"auto_restarts,omitempty" json:"auto_restarts,omitempty"`
+
+	// PathFiltered is set when none of the files changed by the task's
+	// commit or patch match the task's or its variant's paths, so the
+	// task is not activated along with its build.
+	PathFiltered bool `bson:"path_filtered,omitempty" json:"path_filtered,omitempty"`
+
 	// TimeTaken is how long the task took to execute.  meaningless if the task is not finished
 	TimeTaken time.Duration `bson:"time_taken" json:"time_taken"`
 
@@ -436,6 +454,53 @@ func (t *Task) SetDistro(distroID string) error {
 		})
 }
 
+// SetStepbackCulprit records that the task is the first failing run of
+// the task after its last passing run.
+func (t *Task) SetStepbackCulprit() error {
+	t.StepbackCulprit = true
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				StepbackCulpritKey: true,
+			},
+		})
+}
+
+// IncAutoRestarts records that the task has been restarted automatically.
+func (t *Task) IncAutoRestarts() error {
+	t.AutoRestarts++
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$inc": bson.M{
+				AutoRestartsKey: 1,
+			},
+		})
+}
+
+// ClearAutoRestarts resets the number of times the task has been restarted
+// automatically, as when it is restarted by a user.
+func (t *Task) ClearAutoRestarts() error {
+	if t.AutoRestarts == 0 {
+		return nil
+	}
+	t.AutoRestarts = 0
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$unset": bson.M{
+				AutoRestartsKey: "",
+			},
+		})
+}
+
 // MarkAsUndispatched marks that the task has been undispatched from a
 // particular host. Unsets the running task field on the host and the
 // host id field on the task
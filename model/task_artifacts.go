@@ -0,0 +1,72 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// FindArtifactsToFetch returns the links of the artifacts that the task
+// fetches from other tasks of its version, as listed in the fetch_artifacts
+// of its definition in the project. It returns an error if any of the
+// artifacts has not been attached or is private.
+func FindArtifactsToFetch(t *task.Task, project *Project) ([]apimodels.FetchedArtifact, error) {
+	pt := project.FindProjectTask(t.DisplayName)
+	if pt == nil {
+		return nil, errors.Errorf("task '%s' is not defined in project '%s'", t.DisplayName, project.Identifier)
+	}
+
+	artifacts := []apimodels.FetchedArtifact{}
+	for _, dep := range pt.FetchArtifacts {
+		variant := dep.Variant
+		if variant == "" {
+			variant = t.BuildVariant
+		}
+
+		producer, err := task.FindOne(task.ByVersionsForNameAndVariant([]string{t.Version}, dep.Task, variant))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding task '%s' on variant '%s'", dep.Task, variant)
+		}
+		if producer == nil {
+			return nil, errors.Errorf("task '%s' on variant '%s' is not in version '%s'", dep.Task, variant, t.Version)
+		}
+
+		entry, err := artifact.FindOne(artifact.ByTaskIdAndExecution(producer.Id, producer.Execution))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding artifacts of task '%s'", producer.Id)
+		}
+		file := findArtifactFile(entry, dep.Name)
+		if file == nil || file.Link == "" {
+			return nil, errors.Errorf("task '%s' on variant '%s' did not attach artifact '%s'", dep.Task, variant, dep.Name)
+		}
+		// the agent downloads artifacts without credentials
+		if file.Visibility == artifact.Private {
+			return nil, errors.Errorf("artifact '%s' of task '%s' on variant '%s' is private and can't be fetched",
+				dep.Name, dep.Task, variant)
+		}
+
+		artifacts = append(artifacts, apimodels.FetchedArtifact{
+			Task:    dep.Task,
+			Variant: variant,
+			Name:    dep.Name,
+			Link:    file.Link,
+		})
+	}
+
+	return artifacts, nil
+}
+
+// findArtifactFile returns the entry's file with the given name, or nil if
+// there is none.
+func findArtifactFile(entry *artifact.Entry, name string) *artifact.File {
+	if entry == nil {
+		return nil
+	}
+	for i := range entry.Files {
+		if entry.Files[i].Name == name {
+			return &entry.Files[i]
+		}
+	}
+	return nil
+}
@@ -20,6 +20,10 @@ import (
 type StatusChanges struct {
 	PatchNewStatus string
 	BuildNewStatus string
+
+	// TaskAutoRestarted is set when the task was restarted under its
+	// project's retry policy instead of finishing.
+	TaskAutoRestarted bool
 }
 
 func SetActiveState(taskId string, caller string, active bool) error {
@@ -136,6 +140,14 @@ func resetTask(taskId, caller string) error {
 		return errors.WithStack(err)
 	}
 
+	// a restart that isn't automatic gives the task all of its project's
+	// retries again
+	if caller != evergreen.AutoRestartActivator {
+		if err = t.ClearAutoRestarts(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
 	if err = t.ActivateTask(caller); err != nil {
 		return errors.WithStack(err)
 	}
@@ -186,13 +198,33 @@ func TryResetTask(taskId, user, origin string, detail *apimodels.TaskEndDetail)
 		}
 	}
 
+	// a task whose agent stopped sending heartbeats failed because its host
+	// died. If its project retries system failures, the restart counts
+	// toward the project's retries, and the task fails once they run out.
+	caller := user
+	if detail != nil && detail.Description == task.AgentHeartbeat {
+		var policy *TaskRetryParams
+		policy, err = getHostDeathRetryPolicy(t)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if policy != nil {
+			if t.AutoRestarts >= policy.MaxRetries {
+				grip.Debugf("Task '%s' has no auto restarts left, marking as failed", t.Id)
+				updates := StatusChanges{}
+				return errors.WithStack(MarkEnd(t, origin, time.Now(), detail, false, &updates))
+			}
+			caller = evergreen.AutoRestartActivator
+		}
+	}
+
 	if detail != nil {
 		if err = t.MarkEnd(time.Now(), detail); err != nil {
 			return errors.Wrap(err, "Error marking task as ended")
 		}
 	}
 
-	if err = resetTask(t.Id, user); err != nil {
+	if err = resetTask(t.Id, caller); err != nil {
 		return err
 	}
 	if origin == evergreen.UIPackage || origin == evergreen.RESTV2Package {
@@ -201,6 +233,13 @@ func TryResetTask(taskId, user, origin string, detail *apimodels.TaskEndDetail)
 		event.LogTaskRestarted(t.Id, origin)
 	}
 
+	if caller == evergreen.AutoRestartActivator {
+		if err = t.IncAutoRestarts(); err != nil {
+			return errors.Wrapf(err, "problem recording auto restart of task %s", t.Id)
+		}
+		event.LogTaskAutoRestarted(t.Id, "host died")
+	}
+
 	if t.DisplayOnly {
 		return t.UpdateDisplayTask()
 	}
@@ -359,6 +398,114 @@ func doStepback(t *task.Task) error {
 	return errors.WithStack(ActivatePreviousTask(t.Id, evergreen.StepbackTaskActivator))
 }
 
+// getStepbackBisect returns true if failures of the task's project should
+// be stepped back by bisecting the inactive tasks between the last passing
+// and first failing runs, rather than one task at a time.
+func getStepbackBisect(t *task.Task) (bool, error) {
+	project, err := FindProjectFromTask(t)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return project.StepbackBisect, nil
+}
+
+// doBisectStepback activates the task halfway between the last passing and
+// the first failing runs of the task, if none of the tasks between them
+// are already activated. When no tasks are left between them, the first
+// failing run is recorded as the culprit.
+func doBisectStepback(t *task.Task) error {
+	if t.DisplayOnly {
+		execTasks, err := task.Find(task.ByIds(t.ExecutionTasks))
+		if err != nil {
+			return errors.Wrapf(err, "error finding tasks for stepback of %s", t.Id)
+		}
+		catcher := grip.NewSimpleCatcher()
+		for _, et := range execTasks {
+			catcher.Add(doBisectStepback(&et))
+		}
+		if catcher.HasErrors() {
+			return catcher.Resolve()
+		}
+	}
+
+	var lastPassing *task.Task
+	var err error
+	switch t.Status {
+	case evergreen.TaskSucceeded:
+		// only tasks activated by stepback narrow the range
+		if t.ActivatedBy != evergreen.StepbackTaskActivator {
+			return nil
+		}
+		lastPassing = t
+	case evergreen.TaskFailed:
+		lastPassing, err = t.PreviousCompletedTask(t.Project, []string{evergreen.TaskSucceeded})
+		if err != nil {
+			return errors.Wrap(err, "Error locating previous successful task")
+		}
+	}
+	if lastPassing == nil {
+		return nil
+	}
+
+	firstFailing, err := task.FindOneNoMerge(task.ByAfterRevisionWithStatusesAndRequester(lastPassing.RevisionOrderNumber,
+		[]string{evergreen.TaskFailed}, t.BuildVariant, t.DisplayName, t.Project, t.Requester))
+	if err != nil {
+		return errors.Wrap(err, "Error locating first failing task")
+	}
+	if firstFailing == nil || firstFailing.StepbackCulprit {
+		return nil
+	}
+
+	between, err := task.Find(task.ByIntermediateRevisions(lastPassing.RevisionOrderNumber, firstFailing.RevisionOrderNumber,
+		t.BuildVariant, t.DisplayName, t.Project, t.Requester).Sort([]string{task.RevisionOrderNumberKey}))
+	if err != nil {
+		return errors.Wrap(err, "Error finding tasks to step back")
+	}
+
+	next, found := nextBisectStepbackTask(between)
+	if found {
+		if err = firstFailing.SetStepbackCulprit(); err != nil {
+			return errors.Wrapf(err, "problem recording %s as the stepback culprit", firstFailing.Id)
+		}
+		event.LogTaskStepbackCulprit(firstFailing.Id, lastPassing.Revision)
+		grip.Info(message.Fields{
+			"message":       "stepback found first failing task",
+			"task":          firstFailing.Id,
+			"revision":      firstFailing.Revision,
+			"last_passing":  lastPassing.Id,
+			"project":       t.Project,
+			"build_variant": t.BuildVariant,
+		})
+		return nil
+	}
+	if next == nil {
+		return nil
+	}
+
+	return errors.WithStack(SetActiveState(next.Id, evergreen.StepbackTaskActivator, true))
+}
+
+// nextBisectStepbackTask returns the task at the midpoint of the tasks
+// between the last passing and first failing runs that have not run.
+// It returns nil if one of them is already activated, and true if none
+// of them are left to run. Blacklisted tasks are skipped.
+func nextBisectStepbackTask(between []task.Task) (*task.Task, bool) {
+	candidates := []task.Task{}
+	for _, t := range between {
+		if t.Priority < 0 || task.IsFinished(t) {
+			continue
+		}
+		if t.Activated {
+			return nil, false
+		}
+		candidates = append(candidates, t)
+	}
+	if len(candidates) == 0 {
+		return nil, true
+	}
+	return &candidates[len(candidates)/2], false
+}
+
 // MarkEnd updates the task as being finished, performs a stepback if necessary, and updates the build status
 func MarkEnd(t *task.Task, caller string, finishTime time.Time, detail *apimodels.TaskEndDetail,
 	deactivatePrevious bool, updates *StatusChanges) error {
@@ -396,8 +543,21 @@ func MarkEnd(t *task.Task, caller string, finishTime time.Time, detail *apimodel
 		}
 	}
 
-	// no need to activate/deactivate other task if this is a patch request's task
-	if evergreen.IsPatchRequester(t.Requester) {
+	// a failure caused by the host rather than the code doesn't count
+	// toward stepback, so restart the task instead
+	reason, err := getAutoRestartReason(t, detail)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if reason != "" {
+		updates.TaskAutoRestarted = true
+		return errors.WithStack(autoRestartTask(t, reason))
+	}
+
+	// no need to activate/deactivate other task if this is a patch request's,
+	// periodic build's, or triggered version's task
+	if evergreen.IsPatchRequester(t.Requester) || t.Requester == evergreen.PeriodicBuildRequester ||
+		t.Requester == evergreen.TriggerRequester {
 		return errors.Wrap(UpdateBuildAndVersionStatusForTask(t.Id, updates),
 			"Error updating build status (1)")
 	}
@@ -418,6 +578,71 @@ func MarkEnd(t *task.Task, caller string, finishTime time.Time, detail *apimodel
 	return nil
 }
 
+// getAutoRestartReason returns why the task should be restarted under its
+// project's retry policy, or an empty string if it should not be.
+func getAutoRestartReason(t *task.Task, detail *apimodels.TaskEndDetail) (string, error) {
+	if detail.Status != evergreen.TaskFailed || t.IsPartOfDisplay() || t.Execution >= evergreen.MaxTaskExecution {
+		return "", nil
+	}
+
+	ref, err := FindOneProjectRef(t.Project)
+	if err != nil {
+		return "", errors.Wrapf(err, "problem finding project ref for task %s", t.Id)
+	}
+	if ref == nil {
+		return "", nil
+	}
+	return autoRestartReason(ref.TaskRetry, t, detail), nil
+}
+
+// getHostDeathRetryPolicy returns the retry policy of the task's project if
+// the policy restarts tasks whose hosts die, which are system failures, or nil
+// if it doesn't.
+func getHostDeathRetryPolicy(t *task.Task) (*TaskRetryParams, error) {
+	ref, err := FindOneProjectRef(t.Project)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding project ref for task %s", t.Id)
+	}
+	if ref == nil || !ref.TaskRetry.SystemFailures || ref.TaskRetry.MaxRetries <= 0 {
+		return nil, nil
+	}
+	return &ref.TaskRetry, nil
+}
+
+func autoRestartReason(policy TaskRetryParams, t *task.Task, detail *apimodels.TaskEndDetail) string {
+	if t.AutoRestarts >= policy.MaxRetries {
+		return ""
+	}
+	switch {
+	case detail.Type == SystemCommandType && policy.SystemFailures:
+		return "system failure"
+	case detail.Type == SetupCommandType && policy.SetupFailures:
+		return "setup failure"
+	default:
+		return ""
+	}
+}
+
+// autoRestartTask restarts a task that failed because of its host, and
+// records the reason in the task's event log.
+func autoRestartTask(t *task.Task, reason string) error {
+	if err := t.IncAutoRestarts(); err != nil {
+		return errors.Wrapf(err, "problem recording auto restart of task %s", t.Id)
+	}
+	if err := resetTask(t.Id, evergreen.AutoRestartActivator); err != nil {
+		return errors.Wrapf(err, "problem auto restarting task %s", t.Id)
+	}
+	event.LogTaskAutoRestarted(t.Id, reason)
+	grip.Info(message.Fields{
+		"message":       "automatically restarted task",
+		"task":          t.Id,
+		"project":       t.Project,
+		"reason":        reason,
+		"auto_restarts": t.AutoRestarts,
+	})
+	return nil
+}
+
 func evalStepback(t *task.Task, caller, status string, deactivatePrevious bool) error {
 	if status == evergreen.TaskFailed {
 		var shouldStepBack bool
@@ -426,19 +651,44 @@ func evalStepback(t *task.Task, caller, status string, deactivatePrevious bool)
 			return errors.WithStack(err)
 		}
 		if shouldStepBack {
-			if err = doStepback(t); err != nil {
+			var bisect bool
+			bisect, err = getStepbackBisect(t)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if bisect {
+				err = doBisectStepback(t)
+			} else {
+				err = doStepback(t)
+			}
+			if err != nil {
 				return errors.Wrap(err, "Error during step back")
 			}
 		} else {
 			grip.Debugln("Not stepping backwards on task failure:", t.Id)
 		}
 
-	} else if deactivatePrevious && status == evergreen.TaskSucceeded {
+	} else if status == evergreen.TaskSucceeded {
+		// a passing task that stepback activated narrows the range
+		// that a bisecting stepback searches
+		if t.ActivatedBy == evergreen.StepbackTaskActivator {
+			bisect, err := getStepbackBisect(t)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if bisect {
+				if err = doBisectStepback(t); err != nil {
+					return errors.Wrap(err, "Error during step back")
+				}
+			}
+		}
+
 		// if the task was successful, ignore running previous
 		// activated tasks for this buildvariant
-
-		if err := DeactivatePreviousTasks(t.Id, caller); err != nil {
-			return errors.Wrap(err, "Error deactivating previous task")
+		if deactivatePrevious {
+			if err := DeactivatePreviousTasks(t.Id, caller); err != nil {
+				return errors.Wrap(err, "Error deactivating previous task")
+			}
 		}
 	}
 
@@ -569,6 +819,7 @@ func UpdateBuildAndVersionStatusForTask(taskId string, updates *StatusChanges) e
 			}
 			updates.BuildNewStatus = evergreen.BuildFailed
 		}
+		event.LogBuildStateChangeEvent(b.Id, updates.BuildNewStatus)
 
 		if evergreen.IsPatchRequester(b.Requester) {
 			if err = TryMarkPatchBuildFinished(b, finishTime, updates); err != nil {
@@ -9,12 +9,15 @@ import (
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
 )
 
 var (
@@ -1621,3 +1624,309 @@ func TestStepback(t *testing.T) {
 	assert.NoError(err)
 	assert.True(dbTask.Activated)
 }
+
+func TestBisectStepback(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(task.Collection, task.OldCollection, build.Collection, event.AllLogCollection))
+
+	b := &build.Build{
+		Id:     "build",
+		Status: evergreen.BuildStarted,
+	}
+	// the task passed at revision 1 and failed at revision 7, and the runs in
+	// between haven't been activated
+	for i := 1; i <= 7; i++ {
+		tsk := &task.Task{
+			Id:                  fmt.Sprintf("t%d", i),
+			DistroId:            "test",
+			DisplayName:         "task",
+			BuildId:             b.Id,
+			BuildVariant:        "bv",
+			Project:             "sample",
+			Requester:           evergreen.RepotrackerVersionRequester,
+			Revision:            fmt.Sprintf("r%d", i),
+			RevisionOrderNumber: i,
+			Status:              evergreen.TaskInactive,
+		}
+		switch i {
+		case 1:
+			tsk.Activated = true
+			tsk.Status = evergreen.TaskSucceeded
+		case 7:
+			tsk.Activated = true
+			tsk.Status = evergreen.TaskFailed
+		}
+		require.NoError(tsk.Insert())
+		b.Tasks = append(b.Tasks, build.TaskCache{Id: tsk.Id, Activated: tsk.Activated, Status: tsk.Status})
+	}
+	require.NoError(b.Insert())
+
+	finish := func(id, status string) *task.Task {
+		require.NoError(task.UpdateOne(bson.M{task.IdKey: id}, bson.M{"$set": bson.M{task.StatusKey: status}}))
+		dbTask, err := task.FindOneNoMerge(task.ById(id))
+		require.NoError(err)
+		require.NotNil(dbTask)
+		return dbTask
+	}
+	isActivatedByStepback := func(id string) bool {
+		dbTask, err := task.FindOneNoMerge(task.ById(id))
+		require.NoError(err)
+		require.NotNil(dbTask)
+		return dbTask.Activated && dbTask.ActivatedBy == evergreen.StepbackTaskActivator
+	}
+	culpritEvents := func(id string) int {
+		events, err := event.Find(event.AllLogCollection, event.TaskEventsInOrder(id))
+		require.NoError(err)
+		count := 0
+		for _, e := range events {
+			if e.EventType == event.TaskStepbackCulprit {
+				count++
+				data, ok := e.Data.(*event.TaskEventData)
+				assert.True(ok)
+				assert.Equal("r4", data.Revision)
+			}
+		}
+		return count
+	}
+
+	// the failure activates the midpoint of revisions 2 to 6
+	first := finish("t7", evergreen.TaskFailed)
+	assert.NoError(doBisectStepback(first))
+	assert.True(isActivatedByStepback("t4"))
+	for _, id := range []string{"t2", "t3", "t5", "t6"} {
+		assert.False(isActivatedByStepback(id))
+	}
+
+	// nothing more is activated while the midpoint runs
+	assert.NoError(doBisectStepback(first))
+	assert.False(isActivatedByStepback("t3"))
+	assert.False(isActivatedByStepback("t5"))
+
+	// a pass at the midpoint activates the midpoint of revisions 5 and 6
+	assert.NoError(doBisectStepback(finish("t4", evergreen.TaskSucceeded)))
+	assert.True(isActivatedByStepback("t6"))
+	assert.False(isActivatedByStepback("t5"))
+
+	// a failure at the midpoint activates the midpoint of what's left before it
+	assert.NoError(doBisectStepback(finish("t6", evergreen.TaskFailed)))
+	assert.True(isActivatedByStepback("t5"))
+	assert.False(isActivatedByStepback("t2"))
+	assert.False(isActivatedByStepback("t3"))
+
+	// once nothing is left between the last pass and the first failure, the
+	// first failure is recorded as the culprit
+	assert.NoError(doBisectStepback(finish("t5", evergreen.TaskFailed)))
+	culprit, err := task.FindOneNoMerge(task.ById("t5"))
+	require.NoError(err)
+	assert.True(culprit.StepbackCulprit)
+	assert.Equal(1, culpritEvents("t5"))
+	for _, id := range []string{"t6", "t7"} {
+		dbTask, err := task.FindOneNoMerge(task.ById(id))
+		require.NoError(err)
+		assert.False(dbTask.StepbackCulprit)
+	}
+
+	// the culprit is only recorded once
+	assert.NoError(doBisectStepback(culprit))
+	assert.Equal(1, culpritEvents("t5"))
+}
+
+func TestNextBisectStepbackTask(t *testing.T) {
+	assert := assert.New(t)
+
+	// nothing left between the last pass and first failure
+	next, found := nextBisectStepbackTask(nil)
+	assert.True(found)
+	assert.Nil(next)
+
+	between := []task.Task{
+		{Id: "t1", RevisionOrderNumber: 1, Status: evergreen.TaskUndispatched},
+		{Id: "t2", RevisionOrderNumber: 2, Status: evergreen.TaskUndispatched},
+		{Id: "t3", RevisionOrderNumber: 3, Status: evergreen.TaskUndispatched, Priority: -1},
+		{Id: "t4", RevisionOrderNumber: 4, Status: evergreen.TaskUndispatched},
+		{Id: "t5", RevisionOrderNumber: 5, Status: evergreen.TaskUndispatched},
+	}
+	next, found = nextBisectStepbackTask(between)
+	assert.False(found)
+	assert.NotNil(next)
+	assert.Equal("t4", next.Id)
+
+	// a step of the bisection is already running
+	between[1].Activated = true
+	next, found = nextBisectStepbackTask(between)
+	assert.False(found)
+	assert.Nil(next)
+
+	// only blacklisted tasks are left
+	next, found = nextBisectStepbackTask(between[2:3])
+	assert.True(found)
+	assert.Nil(next)
+}
+
+func TestAutoRestartReason(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := TaskRetryParams{MaxRetries: 2, SystemFailures: true}
+	systemFailure := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType}
+	setupFailure := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SetupCommandType}
+	testFailure := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: TestCommandType}
+
+	assert.Equal("system failure", autoRestartReason(policy, &task.Task{}, systemFailure))
+	assert.Equal("system failure", autoRestartReason(policy, &task.Task{AutoRestarts: 1}, systemFailure))
+	assert.Empty(autoRestartReason(policy, &task.Task{AutoRestarts: 2}, systemFailure))
+	assert.Empty(autoRestartReason(policy, &task.Task{}, setupFailure))
+	assert.Empty(autoRestartReason(policy, &task.Task{}, testFailure))
+
+	policy.SetupFailures = true
+	assert.Equal("setup failure", autoRestartReason(policy, &task.Task{}, setupFailure))
+
+	// retries are off by default
+	assert.Empty(autoRestartReason(TaskRetryParams{SystemFailures: true}, &task.Task{}, systemFailure))
+}
+
+func TestMarkEndAutoRestart(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(db.ClearCollections(task.Collection, task.OldCollection, build.Collection, version.Collection,
+		ProjectRefCollection, event.AllLogCollection))
+
+	ref := &ProjectRef{
+		Identifier: "sample",
+		TaskRetry:  TaskRetryParams{MaxRetries: 1, SystemFailures: true},
+	}
+	assert.NoError(ref.Insert())
+	b := &build.Build{
+		Id:      "buildtest",
+		Status:  evergreen.BuildStarted,
+		Version: "abc",
+		Tasks:   []build.TaskCache{{Id: "testone", Status: evergreen.TaskStarted, Activated: true}},
+	}
+	assert.NoError(b.Insert())
+	v := &version.Version{
+		Id:     b.Version,
+		Status: evergreen.VersionStarted,
+	}
+	assert.NoError(v.Insert())
+	testTask := &task.Task{
+		Id:          "testone",
+		DisplayName: "testName",
+		Activated:   true,
+		BuildId:     b.Id,
+		Version:     v.Id,
+		Project:     ref.Identifier,
+		Status:      evergreen.TaskStarted,
+	}
+	assert.NoError(testTask.Insert())
+
+	updates := StatusChanges{}
+	detail := &apimodels.TaskEndDetail{
+		Status: evergreen.TaskFailed,
+		Type:   SystemCommandType,
+	}
+	assert.NoError(MarkEnd(testTask, "test", time.Now(), detail, false, &updates))
+	assert.True(updates.TaskAutoRestarted)
+	assert.Empty(updates.BuildNewStatus)
+
+	// the task is restarted
+	dbTask, err := task.FindOne(task.ById(testTask.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+	assert.Equal(1, dbTask.AutoRestarts)
+
+	// the restart is logged
+	events, err := event.Find(event.AllLogCollection, event.TaskEventsInOrder(testTask.Id))
+	assert.NoError(err)
+	found := false
+	for _, e := range events {
+		if e.EventType == event.TaskAutoRestarted {
+			found = true
+			data, ok := e.Data.(*event.TaskEventData)
+			assert.True(ok)
+			assert.Equal("system failure", data.Reason)
+		}
+	}
+	assert.True(found)
+
+	// the build and version haven't failed
+	dbBuild, err := build.FindOne(build.ById(b.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.BuildStarted, dbBuild.Status)
+	dbVersion, err := version.FindOne(version.ById(v.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.VersionStarted, dbVersion.Status)
+
+	// once its retries run out, the task fails
+	assert.NoError(dbTask.MarkStart(time.Now()))
+	updates = StatusChanges{}
+	assert.NoError(MarkEnd(dbTask, "test", time.Now(), detail, false, &updates))
+	assert.False(updates.TaskAutoRestarted)
+	dbTask, err = task.FindOne(task.ById(testTask.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.TaskFailed, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+}
+
+func TestTryResetTaskAutoRestarts(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(db.ClearCollections(task.Collection, task.OldCollection, build.Collection, version.Collection,
+		ProjectRefCollection, event.AllLogCollection))
+
+	ref := &ProjectRef{
+		Identifier: "sample",
+		TaskRetry:  TaskRetryParams{MaxRetries: 1, SystemFailures: true},
+	}
+	assert.NoError(ref.Insert())
+	b := &build.Build{
+		Id:      "buildtest",
+		Status:  evergreen.BuildStarted,
+		Version: "abc",
+		Tasks:   []build.TaskCache{{Id: "testone", Status: evergreen.TaskStarted, Activated: true}},
+	}
+	assert.NoError(b.Insert())
+	v := &version.Version{
+		Id:     b.Version,
+		Status: evergreen.VersionStarted,
+	}
+	assert.NoError(v.Insert())
+	testTask := &task.Task{
+		Id:        "testone",
+		Activated: true,
+		BuildId:   b.Id,
+		Version:   v.Id,
+		Project:   ref.Identifier,
+		Status:    evergreen.TaskStarted,
+	}
+	assert.NoError(testTask.Insert())
+	hostDied := func() *apimodels.TaskEndDetail {
+		return &apimodels.TaskEndDetail{
+			Description: task.AgentHeartbeat,
+			TimedOut:    true,
+			Status:      evergreen.TaskFailed,
+		}
+	}
+
+	// the host dying counts as an automatic restart
+	assert.NoError(TryResetTask(testTask.Id, "", "monitor", hostDied()))
+	dbTask, err := task.FindOne(task.ById(testTask.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+	assert.Equal(1, dbTask.AutoRestarts)
+
+	// once the retries run out, the task fails when its host dies
+	assert.NoError(dbTask.MarkStart(time.Now()))
+	assert.NoError(TryResetTask(testTask.Id, "", "monitor", hostDied()))
+	dbTask, err = task.FindOne(task.ById(testTask.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.TaskFailed, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+
+	// restarting the task by hand gives it its retries back
+	assert.NoError(TryResetTask(testTask.Id, "user", evergreen.UIPackage, nil))
+	dbTask, err = task.FindOne(task.ById(testTask.Id))
+	assert.NoError(err)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(2, dbTask.Execution)
+	assert.Equal(0, dbTask.AutoRestarts)
+}
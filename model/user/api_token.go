@@ -0,0 +1,239 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Scopes limit what an API token can be used for.
+const (
+	// APITokenScopeReadOnly tokens can only make requests that read.
+	APITokenScopeReadOnly = "read_only"
+	// APITokenScopePatchSubmit tokens can also submit and modify patches.
+	APITokenScopePatchSubmit = "patch_submit"
+	// APITokenScopeFull tokens have the rights of their user.
+	APITokenScopeFull = "full"
+
+	// APITokenPrefix starts every API token, which tells them apart from
+	// the legacy API keys.
+	APITokenPrefix = "evgt_"
+
+	// apiTokenLastUsedResolution is how often the last use of a token is
+	// recorded.
+	apiTokenLastUsedResolution = time.Minute
+)
+
+// APIToken is a named API token of a user. Only a hash of the token is
+// stored, so the token itself is only known when it's created.
+type APIToken struct {
+	Name  string `bson:"name" json:"name"`
+	Hash  string `bson:"hash" json:"-"`
+	Scope string `bson:"scope" json:"scope"`
+	// Projects limits the token to requests on these projects, if set.
+	Projects   []string  `bson:"projects,omitempty" json:"projects,omitempty"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+	ExpiresAt  time.Time `bson:"expires_at" json:"expires_at"`
+	LastUsedAt time.Time `bson:"last_used_at,omitempty" json:"last_used_at"`
+}
+
+var (
+	APITokenNameKey       = bsonutil.MustHaveTag(APIToken{}, "Name")
+	APITokenHashKey       = bsonutil.MustHaveTag(APIToken{}, "Hash")
+	APITokenLastUsedAtKey = bsonutil.MustHaveTag(APIToken{}, "LastUsedAt")
+)
+
+// ValidAPITokenScope returns whether the scope is one of the API token
+// scopes.
+func ValidAPITokenScope(scope string) bool {
+	return util.StringSliceContains([]string{APITokenScopeReadOnly, APITokenScopePatchSubmit, APITokenScopeFull}, scope)
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Expired returns whether the token has expired.
+func (t *APIToken) Expired() bool {
+	return !time.Now().Before(t.ExpiresAt)
+}
+
+// AllowsRequest returns whether the token's scope allows a request with the
+// method to the path. Patch submit tokens may make any request to a patch
+// route, under either the legacy or the REST API.
+func (t *APIToken) AllowsRequest(method, path string) bool {
+	switch t.Scope {
+	case APITokenScopeFull:
+		return true
+	case APITokenScopePatchSubmit:
+		if strings.Contains(path, "/patches/") || strings.HasSuffix(path, "/patches") {
+			return true
+		}
+	}
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// AllowsProject returns whether the token may be used on the project.
+func (t *APIToken) AllowsProject(project string) bool {
+	return len(t.Projects) == 0 || util.StringSliceContains(t.Projects, project)
+}
+
+// ProjectLimited returns whether the token is limited to some projects.
+func (t *APIToken) ProjectLimited() bool {
+	return len(t.Projects) != 0
+}
+
+// AllowsProjects returns whether the token may be used on every project that
+// a token limited to the projects may be used on. A token limited to
+// projects only allows tokens limited to a subset of them.
+func (t *APIToken) AllowsProjects(projects []string) bool {
+	if !t.ProjectLimited() {
+		return true
+	}
+	if len(projects) == 0 {
+		return false
+	}
+	for _, project := range projects {
+		if !t.AllowsProject(project) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetAPIToken returns the user's token with the name, or nil if there is
+// none.
+func (u *DBUser) GetAPIToken(name string) *APIToken {
+	for i := range u.APITokens {
+		if u.APITokens[i].Name == name {
+			return &u.APITokens[i]
+		}
+	}
+	return nil
+}
+
+// CheckAPIKey checks a key given to authenticate as the user, which is
+// either the user's legacy API key or one of their API tokens. It returns
+// the token, or nil for the legacy key.
+func (u *DBUser) CheckAPIKey(key string) (*APIToken, error) {
+	if !strings.HasPrefix(key, APITokenPrefix) {
+		if u.APIKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(u.APIKey)) != 1 {
+			return nil, errors.New("invalid API key")
+		}
+		return nil, nil
+	}
+
+	hash := hashAPIToken(key)
+	for i := range u.APITokens {
+		token := &u.APITokens[i]
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(token.Hash)) != 1 {
+			continue
+		}
+		if token.Expired() {
+			return nil, errors.Errorf("API token '%s' has expired", token.Name)
+		}
+		return token, nil
+	}
+	return nil, errors.New("invalid API token")
+}
+
+// NewAPIToken generates the secret of a new token, and returns the token,
+// with the hash of the secret, and the secret.
+func NewAPIToken(token APIToken) (APIToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return APIToken{}, "", errors.Wrap(err, "problem generating API token")
+	}
+	secret := APITokenPrefix + hex.EncodeToString(b)
+	token.Hash = hashAPIToken(secret)
+	token.CreatedAt = time.Now()
+	token.LastUsedAt = time.Time{}
+	return token, secret, nil
+}
+
+// AddAPIToken adds a new token, which must have a name that is not already
+// used by the user's tokens, and returns the secret that authenticates with
+// it.
+func (u *DBUser) AddAPIToken(token APIToken) (string, error) {
+	token, secret, err := NewAPIToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	userWithoutToken := bson.M{
+		IdKey: u.Id,
+		bsonutil.GetDottedKeyName(APITokensKey, APITokenNameKey): bson.M{"$ne": token.Name},
+	}
+	update := bson.M{
+		"$push": bson.M{APITokensKey: token},
+	}
+	err = UpdateOne(userWithoutToken, update)
+	if err == mgo.ErrNotFound {
+		return "", errors.Errorf("API token '%s' already exists", token.Name)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "problem adding API token '%s'", token.Name)
+	}
+
+	u.APITokens = append(u.APITokens, token)
+	return secret, nil
+}
+
+// DeleteAPIToken revokes the user's token with the name.
+func (u *DBUser) DeleteAPIToken(name string) error {
+	selector := bson.M{
+		IdKey: u.Id,
+		bsonutil.GetDottedKeyName(APITokensKey, APITokenNameKey): name,
+	}
+	update := bson.M{
+		"$pull": bson.M{APITokensKey: bson.M{APITokenNameKey: name}},
+	}
+	err := UpdateOne(selector, update)
+	if err == mgo.ErrNotFound {
+		return errors.Errorf("API token '%s' does not exist", name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "problem deleting API token '%s'", name)
+	}
+
+	tokens := []APIToken{}
+	for _, t := range u.APITokens {
+		if t.Name != name {
+			tokens = append(tokens, t)
+		}
+	}
+	u.APITokens = tokens
+	return nil
+}
+
+// MarkAPITokenUsed records that the user's token with the name was used.
+// Uses are recorded at most once a minute.
+func (u *DBUser) MarkAPITokenUsed(name string) error {
+	now := time.Now()
+	selector := bson.M{
+		IdKey: u.Id,
+		APITokensKey: bson.M{"$elemMatch": bson.M{
+			APITokenNameKey:       name,
+			APITokenLastUsedAtKey: bson.M{"$not": bson.M{"$gt": now.Add(-apiTokenLastUsedResolution)}},
+		}},
+	}
+	update := bson.M{
+		"$set": bson.M{bsonutil.GetDottedKeyName(APITokensKey, "$", APITokenLastUsedAtKey): now},
+	}
+	err := UpdateOne(selector, update)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return errors.Wrapf(err, "problem recording use of API token '%s'", name)
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAPIKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	valid, secret, err := NewAPIToken(APIToken{Name: "ci", Scope: APITokenScopeReadOnly, ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(err)
+	assert.True(len(secret) > len(APITokenPrefix))
+	assert.NotEqual(secret, valid.Hash)
+	expired, expiredSecret, err := NewAPIToken(APIToken{Name: "old", Scope: APITokenScopeFull, ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(err)
+
+	u := &DBUser{Id: "octocat", APIKey: "legacy", APITokens: []APIToken{valid, expired}}
+
+	token, err := u.CheckAPIKey("legacy")
+	assert.NoError(err)
+	assert.Nil(token)
+
+	token, err = u.CheckAPIKey(secret)
+	assert.NoError(err)
+	require.NotNil(token)
+	assert.Equal("ci", token.Name)
+
+	_, err = u.CheckAPIKey(expiredSecret)
+	assert.Error(err)
+	_, err = u.CheckAPIKey(APITokenPrefix + "unknown")
+	assert.Error(err)
+	_, err = u.CheckAPIKey("wrong")
+	assert.Error(err)
+
+	// users without a legacy key can't authenticate with an empty one
+	_, err = (&DBUser{Id: "bot"}).CheckAPIKey("")
+	assert.Error(err)
+}
+
+func TestAPITokenAllows(t *testing.T) {
+	assert := assert.New(t)
+
+	readOnly := APIToken{Scope: APITokenScopeReadOnly}
+	assert.True(readOnly.AllowsRequest(http.MethodGet, "/rest/v2/tasks/t1"))
+	assert.False(readOnly.AllowsRequest(http.MethodPost, "/rest/v2/tasks/t1/restart"))
+	assert.False(readOnly.AllowsRequest(http.MethodPut, "/api/patches/"))
+
+	patchSubmit := APIToken{Scope: APITokenScopePatchSubmit}
+	assert.True(patchSubmit.AllowsRequest(http.MethodPut, "/api/patches/"))
+	assert.True(patchSubmit.AllowsRequest(http.MethodPost, "/rest/v2/patches/p1/abort"))
+	assert.False(patchSubmit.AllowsRequest(http.MethodPost, "/rest/v2/tasks/t1/restart"))
+
+	full := APIToken{Scope: APITokenScopeFull}
+	assert.True(full.AllowsRequest(http.MethodDelete, "/rest/v2/keys/k1"))
+
+	assert.True(full.AllowsProject("mci"))
+	limited := APIToken{Scope: APITokenScopeFull, Projects: []string{"mci"}}
+	assert.True(limited.AllowsProject("mci"))
+	assert.False(limited.AllowsProject("other"))
+
+	assert.False(full.ProjectLimited())
+	assert.True(full.AllowsProjects(nil))
+	assert.True(limited.ProjectLimited())
+	assert.True(limited.AllowsProjects([]string{"mci"}))
+	assert.False(limited.AllowsProjects([]string{"mci", "other"}))
+	assert.False(limited.AllowsProjects(nil))
+}
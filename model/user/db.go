@@ -23,6 +23,7 @@ var (
 	SettingsKey     = bsonutil.MustHaveTag(DBUser{}, "Settings")
 	APIKeyKey       = bsonutil.MustHaveTag(DBUser{}, "APIKey")
 	PubKeysKey      = bsonutil.MustHaveTag(DBUser{}, "PubKeys")
+	APITokensKey    = bsonutil.MustHaveTag(DBUser{}, "APITokens")
 )
 
 var (
@@ -38,8 +39,13 @@ var (
 )
 
 var (
-	SettingsTZKey             = bsonutil.MustHaveTag(UserSettings{}, "Timezone")
-	userSettingsGithubUserKey = bsonutil.MustHaveTag(UserSettings{}, "GithubUser")
+	SettingsTZKey              = bsonutil.MustHaveTag(UserSettings{}, "Timezone")
+	userSettingsGithubUserKey  = bsonutil.MustHaveTag(UserSettings{}, "GithubUser")
+	userSettingsSlackMemberKey = bsonutil.MustHaveTag(UserSettings{}, "SlackMember")
+)
+
+var (
+	slackMemberIDKey = bsonutil.MustHaveTag(SlackMember{}, "ID")
 )
 
 func FindByGithubUID(uid int) (*DBUser, error) {
@@ -57,6 +63,25 @@ func FindByGithubUID(uid int) (*DBUser, error) {
 	return &u, nil
 }
 
+// FindBySlackMemberID returns the user linked to the Slack account with the
+// member ID, or nil if there is no such user.
+func FindBySlackMemberID(id string) (*DBUser, error) {
+	users, err := Find(db.Query(bson.M{
+		bsonutil.GetDottedKeyName(SettingsKey, userSettingsSlackMemberKey, slackMemberIDKey): id,
+	}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch user by slack member id")
+	}
+	switch len(users) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &users[0], nil
+	default:
+		return nil, errors.Errorf("slack member '%s' is linked to %d users", id, len(users))
+	}
+}
+
 func ById(userId string) db.Q {
 	return db.Query(bson.M{IdKey: userId})
 }
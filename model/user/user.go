@@ -21,6 +21,7 @@ type DBUser struct {
 	CreatedAt    time.Time    `bson:"created_at"`
 	Settings     UserSettings `bson:"settings"`
 	APIKey       string       `bson:"apikey"`
+	APITokens    []APIToken   `bson:"api_tokens,omitempty"`
 }
 
 type GithubUser struct {
@@ -34,10 +35,18 @@ type PubKey struct {
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 }
 
+// SlackMember is the Slack account linked to a user. It is found by the
+// user's email address, so that no one else can link it.
+type SlackMember struct {
+	ID   string `bson:"id,omitempty" json:"id,omitempty"`
+	Name string `bson:"name,omitempty" json:"name,omitempty"`
+}
+
 type UserSettings struct {
-	Timezone     string     `json:"timezone" bson:"timezone"`
-	NewWaterfall bool       `json:"new_waterfall" bson:"new_waterfall"`
-	GithubUser   GithubUser `json:"github_user" bson:"github_user,omitempty"`
+	Timezone     string      `json:"timezone" bson:"timezone"`
+	NewWaterfall bool        `json:"new_waterfall" bson:"new_waterfall"`
+	GithubUser   GithubUser  `json:"github_user" bson:"github_user,omitempty"`
+	SlackMember  SlackMember `json:"slack_member" bson:"slack_member,omitempty"`
 }
 
 func (u *DBUser) Username() string {
@@ -123,6 +132,32 @@ func (u *DBUser) DeletePublicKey(keyName string) error {
 	return nil
 }
 
+// LinkSlackMember links the Slack account to the user. A Slack account can
+// only be linked to one user.
+func (u *DBUser) LinkSlackMember(member SlackMember) error {
+	if member.ID == "" {
+		return errors.New("slack member ID is empty")
+	}
+	others, err := Count(db.Query(bson.M{
+		IdKey: bson.M{"$ne": u.Id},
+		bsonutil.GetDottedKeyName(SettingsKey, userSettingsSlackMemberKey, slackMemberIDKey): member.ID,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "problem checking for other users linked to the slack account")
+	}
+	if others != 0 {
+		return errors.Errorf("slack account '%s' is linked to another user", member.Name)
+	}
+
+	if err = UpdateOne(bson.M{IdKey: u.Id}, bson.M{
+		"$set": bson.M{bsonutil.GetDottedKeyName(SettingsKey, userSettingsSlackMemberKey): member},
+	}); err != nil {
+		return errors.Wrap(err, "problem linking slack account")
+	}
+	u.Settings.SlackMember = member
+	return nil
+}
+
 func (u *DBUser) PublicKeys() []PubKey {
 	return u.PubKeys
 }
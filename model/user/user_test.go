@@ -137,3 +137,23 @@ func (s *UserTestSuite) TestFindByGithubUID() {
 	s.NoError(err)
 	s.Nil(u)
 }
+
+func (s *UserTestSuite) TestLinkSlackMember() {
+	u, err := FindBySlackMemberID("U123")
+	s.NoError(err)
+	s.Nil(u)
+
+	member := SlackMember{ID: "U123", Name: "octocat"}
+	s.NoError(s.users[0].LinkSlackMember(member))
+	s.Equal(member, s.users[0].Settings.SlackMember)
+	// linking it again is a no-op
+	s.NoError(s.users[0].LinkSlackMember(member))
+
+	u, err = FindBySlackMemberID("U123")
+	s.NoError(err)
+	s.Require().NotNil(u)
+	s.Equal("Test1", u.Id)
+
+	s.Error(s.users[1].LinkSlackMember(member))
+	s.Error(s.users[1].LinkSlackMember(SlackMember{}))
+}
@@ -49,6 +49,22 @@ type Version struct {
 	// AuthorID is an optional reference to the Evergreen user that authored
 	// this comment, if they can be identified
 	AuthorID string `bson:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// TriggeredBy is set on versions that an upstream project's trigger
+	// created
+	TriggeredBy *TriggerInfo `bson:"triggered_by,omitempty" json:"triggered_by,omitempty"`
+}
+
+// TriggerInfo describes the upstream version that triggered a version.
+type TriggerInfo struct {
+	TriggerID         string `bson:"trigger_id" json:"trigger_id"`
+	UpstreamProject   string `bson:"upstream_project" json:"upstream_project"`
+	UpstreamVersionID string `bson:"upstream_version_id" json:"upstream_version_id"`
+	UpstreamRevision  string `bson:"upstream_revision" json:"upstream_revision"`
+
+	// Chain is the list of projects upstream of this version, which keeps
+	// triggers from looping back to a project in the chain
+	Chain []string `bson:"chain,omitempty" json:"chain,omitempty"`
 }
 
 func (self *Version) UpdateBuildVariants() error {
@@ -0,0 +1,190 @@
+package operations
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func APITokens() cli.Command {
+	return cli.Command{
+		Name:    "api-tokens",
+		Aliases: []string{"api-token", "tokens"},
+		Usage:   "manage your API tokens with the evergreen service",
+		Subcommands: []cli.Command{
+			apiTokensCreate(),
+			apiTokensList(),
+			apiTokensRevoke(),
+		},
+	}
+}
+
+func apiTokensCreate() cli.Command {
+	const (
+		tokenNameFlagName  = "name"
+		tokenScopeFlagName = "scope"
+		tokenDaysFlagName  = "days"
+	)
+
+	return cli.Command{
+		Name:  "create",
+		Usage: "create an API token, which is only shown once",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  tokenNameFlagName,
+				Usage: "specify the name of the token",
+			},
+			cli.StringFlag{
+				Name:  tokenScopeFlagName,
+				Usage: "specify what the token can do: read_only, patch_submit or full",
+				Value: "read_only",
+			},
+			cli.StringSliceFlag{
+				Name:  joinFlagNames(projectFlagName, "p"),
+				Usage: "limit the token to a project (may be specified multiple times)",
+			},
+			cli.IntFlag{
+				Name:  tokenDaysFlagName,
+				Usage: "specify the number of days until the token expires",
+				Value: 90,
+			},
+		},
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireClientConfig,
+			func(c *cli.Context) error {
+				if c.String(tokenNameFlagName) == "" && c.NArg() == 1 {
+					return c.Set(tokenNameFlagName, c.Args().Get(0))
+				}
+				return nil
+			},
+			requireStringFlag(tokenNameFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			token, err := client.CreateAPIToken(ctx, c.String(tokenNameFlagName), c.String(tokenScopeFlagName),
+				c.StringSlice(projectFlagName), c.Int(tokenDaysFlagName))
+			if err != nil {
+				return errors.Wrap(err, "problem creating API token")
+			}
+
+			grip.Infof("Created API token '%s', which expires %s.", model.FromAPIString(token.Name), formatAPITokenTime(token.ExpiresAt))
+			grip.Info("Store it now, as it can't be shown again:")
+			grip.Info(model.FromAPIString(token.Token))
+
+			return nil
+		},
+	}
+}
+
+func apiTokensList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list all API tokens of the current user",
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			tokens, err := client.GetAPITokens(ctx)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching API tokens")
+			}
+
+			if len(tokens) == 0 {
+				grip.Info("No API tokens found")
+				return nil
+			}
+			grip.Info("API tokens:")
+			for _, token := range tokens {
+				projects := "all"
+				if len(token.Projects) > 0 {
+					projects = strings.Join(token.Projects, ", ")
+				}
+				grip.Infof("Name: '%s', Scope: %s, Projects: %s, Expires: %s, Last used: %s",
+					model.FromAPIString(token.Name), model.FromAPIString(token.Scope), projects,
+					formatAPITokenTime(token.ExpiresAt), formatAPITokenTime(token.LastUsedAt))
+			}
+
+			return nil
+		},
+	}
+}
+
+func apiTokensRevoke() cli.Command {
+	return cli.Command{
+		Name:    "revoke",
+		Aliases: []string{"delete"},
+		Usage:   "revoke an API token",
+		Before: mergeBeforeFuncs(
+			requireClientConfig,
+			setPlainLogger,
+			func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return errors.New("must specify only one token to revoke at a time")
+				}
+
+				if c.Args().Get(0) == "" {
+					return errors.New("api-tokens revoke requires a token name")
+				}
+				return nil
+			}),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			tokenName := c.Args().Get(0)
+
+			if err := client.DeleteAPIToken(ctx, tokenName); err != nil {
+				return errors.Wrap(err, "problem revoking API token")
+			}
+
+			grip.Infof("Successfully revoked API token: '%s'\n", tokenName)
+
+			return nil
+		},
+	}
+}
+
+func formatAPITokenTime(t model.APITime) string {
+	if time.Time(t).IsZero() {
+		return "never"
+	}
+	return time.Time(t).Format("2006-01-02 15:04")
+}
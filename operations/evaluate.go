@@ -3,6 +3,7 @@ package operations
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/pkg/errors"
@@ -12,8 +13,10 @@ import (
 
 func Evaluate() cli.Command {
 	const (
-		taskFlagName     = "tasks"
-		variantsFlagName = "variants"
+		taskFlagName         = "tasks"
+		variantsFlagName     = "variants"
+		variantNamesFlagName = "variant-names"
+		rootFlagName         = "root"
 	)
 
 	return cli.Command{
@@ -27,12 +30,22 @@ func Evaluate() cli.Command {
 			cli.BoolFlag{
 				Name:  variantsFlagName,
 				Usage: "only show variant definitions",
+			},
+			cli.BoolFlag{
+				Name:  variantNamesFlagName,
+				Usage: "only show the names of the expanded variants, one per line",
+			},
+			cli.StringFlag{
+				Name:  rootFlagName,
+				Usage: "the root of the repository, from which the values files of matrix axes are read",
+				Value: ".",
 			}),
 		Before: requirePathFlag,
 		Action: func(c *cli.Context) error {
 			path := c.String(pathFlagName)
 			showTasks := c.Bool(taskFlagName)
 			showVariants := c.Bool(variantsFlagName)
+			root := c.String(rootFlagName)
 
 			configBytes, err := ioutil.ReadFile(path)
 			if err != nil {
@@ -40,11 +53,20 @@ func Evaluate() cli.Command {
 			}
 
 			p := &model.Project{}
-			err = model.LoadProjectInto(configBytes, "", p)
+			err = model.LoadProjectIntoWithAxisValues(configBytes, "", p, func(valuesPath string) ([]byte, error) {
+				return ioutil.ReadFile(filepath.Join(root, valuesPath))
+			})
 			if err != nil {
 				return errors.Wrap(err, "error loading project")
 			}
 
+			if c.Bool(variantNamesFlagName) {
+				for _, bv := range p.BuildVariants {
+					fmt.Println(bv.Name)
+				}
+				return nil
+			}
+
 			var out interface{}
 			if showTasks || showVariants {
 				tmp := struct {
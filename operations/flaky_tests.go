@@ -0,0 +1,204 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const (
+	flakyTestFileFlagName    = "test"
+	flakyTestVariantFlagName = "variant"
+	flakyTestReasonFlagName  = "reason"
+)
+
+func FlakyTests() cli.Command {
+	return cli.Command{
+		Name:  "flaky-tests",
+		Usage: "list the flaky tests of a project and manage its quarantined tests",
+		Subcommands: []cli.Command{
+			flakyTestsList(),
+			flakyTestsQuarantined(),
+			flakyTestsQuarantine(),
+			flakyTestsUnquarantine(),
+		},
+	}
+}
+
+func addQuarantinedTestFlags(flags ...cli.Flag) []cli.Flag {
+	return addProjectFlag(append(flags,
+		cli.StringFlag{
+			Name:  joinFlagNames(flakyTestFileFlagName, "t"),
+			Usage: "the name of the test file",
+		},
+		cli.StringFlag{
+			Name:  joinFlagNames(flakyTestVariantFlagName, "v"),
+			Usage: "the build variant of the test, or none for every variant",
+		})...)
+}
+
+func flakyTestsList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list the flaky tests of a project, most flaky first",
+		Flags:  addProjectFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig, requireStringFlag(projectFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			tests, err := client.GetFlakyTests(ctx, project)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching flaky tests")
+			}
+			if len(tests) == 0 {
+				grip.Infof("No flaky tests found for project '%s'", project)
+				return nil
+			}
+
+			w := new(tabwriter.Writer)
+			w.Init(os.Stdout, 0, 8, 1, '\t', 0)
+			fmt.Fprintln(w, "Score\tFlaky\tVariant\tTest\tLast Flaky Revision")
+			for _, t := range tests {
+				fmt.Fprintf(w, "%.2f\t%d/%d\t%s\t%s\t%s\n", t.Score, t.FlakyRevisions, t.Revisions,
+					model.FromAPIString(t.BuildVariant), model.FromAPIString(t.TestFile),
+					model.FromAPIString(t.LastFlakyRevision))
+			}
+			return errors.WithStack(w.Flush())
+		},
+	}
+}
+
+func flakyTestsQuarantined() cli.Command {
+	return cli.Command{
+		Name:   "quarantined",
+		Usage:  "list the quarantined tests of a project",
+		Flags:  addProjectFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig, requireStringFlag(projectFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			tests, err := client.GetQuarantinedTests(ctx, project)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching quarantined tests")
+			}
+			if len(tests) == 0 {
+				grip.Infof("No quarantined tests found for project '%s'", project)
+				return nil
+			}
+
+			w := new(tabwriter.Writer)
+			w.Init(os.Stdout, 0, 8, 1, '\t', 0)
+			fmt.Fprintln(w, "Variant\tTest\tUser\tReason")
+			for _, t := range tests {
+				variant := model.FromAPIString(t.BuildVariant)
+				if variant == "" {
+					variant = "(all)"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", variant, model.FromAPIString(t.TestFile),
+					model.FromAPIString(t.User), model.FromAPIString(t.Reason))
+			}
+			return errors.WithStack(w.Flush())
+		},
+	}
+}
+
+func flakyTestsQuarantine() cli.Command {
+	return cli.Command{
+		Name:  "quarantine",
+		Usage: "quarantine a test so that its failures do not fail its task",
+		Flags: addQuarantinedTestFlags(cli.StringFlag{
+			Name:  joinFlagNames(flakyTestReasonFlagName, "r"),
+			Usage: "why the test is quarantined",
+		}),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig,
+			requireStringFlag(projectFlagName), requireStringFlag(flakyTestFileFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+			testFile := c.String(flakyTestFileFlagName)
+			variant := c.String(flakyTestVariantFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.QuarantineTest(ctx, project, testFile, variant, c.String(flakyTestReasonFlagName)); err != nil {
+				return err
+			}
+
+			grip.Infof("Quarantined test '%s' in project '%s'", testFile, project)
+			return nil
+		},
+	}
+}
+
+func flakyTestsUnquarantine() cli.Command {
+	return cli.Command{
+		Name:  "unquarantine",
+		Usage: "remove the quarantine of a test",
+		Flags: addQuarantinedTestFlags(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig,
+			requireStringFlag(projectFlagName), requireStringFlag(flakyTestFileFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+			testFile := c.String(flakyTestFileFlagName)
+			variant := c.String(flakyTestVariantFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.UnquarantineTest(ctx, project, testFile, variant); err != nil {
+				return err
+			}
+
+			grip.Infof("Removed the quarantine of test '%s' in project '%s'", testFile, project)
+			return nil
+		},
+	}
+}
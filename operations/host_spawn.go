@@ -2,6 +2,7 @@ package operations
 
 import (
 	"context"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
@@ -130,6 +131,11 @@ func hostlist() cli.Command {
 func printHosts(hosts []*model.APIHost) error {
 	for _, h := range hosts {
 		grip.Infof("ID: %s; Distro: %s; Status: %s; Host name: %s; User: %s", h.Id, h.Distro.Id, h.Status, h.HostURL, h.User)
+		if model.FromAPIString(h.DebugTask) != "" {
+			grip.Infof("\tHeld for debugging task %s until %s; connect with: ssh %s@%s",
+				model.FromAPIString(h.DebugTask), time.Time(h.ExpirationTime).Format(time.RFC1123),
+				model.FromAPIString(h.User), model.FromAPIString(h.HostURL))
+		}
 	}
 	return nil
 }
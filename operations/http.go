@@ -365,6 +365,12 @@ func (ac *legacyClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, e
 		Tasks       []string `json:"tasks"`
 		Finalize    bool     `json:"finalize"`
 		Alias       string   `json:"alias"`
+
+		DebugOnFailure int `json:"debug_on_failure,omitempty"`
+
+		Stack         *patch.PatchStack `json:"stack,omitempty"`
+		Commit        string            `json:"commit,omitempty"`
+		CommitMessage string            `json:"commit_message,omitempty"`
 	}{
 		incomingPatch.description,
 		incomingPatch.projectId,
@@ -374,6 +380,10 @@ func (ac *legacyClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, e
 		incomingPatch.tasks,
 		incomingPatch.finalize,
 		incomingPatch.alias,
+		incomingPatch.debugOnFailure,
+		incomingPatch.stack,
+		incomingPatch.commit,
+		incomingPatch.commitMessage,
 	}
 
 	rPipe, wPipe := io.Pipe()
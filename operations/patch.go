@@ -13,6 +13,9 @@ const (
 	patchFinalizeFlagName    = "finalize"
 	patchVerboseFlagName     = "verbose"
 	patchAliasFlagName       = "alias"
+	patchDebugFlagName       = "debug-on-failure"
+	patchPerCommitFlagName   = "per-commit"
+	patchSequentialFlagName  = "sequential"
 )
 
 func getPatchFlags(flags ...cli.Flag) []cli.Flag {
@@ -32,6 +35,10 @@ func getPatchFlags(flags ...cli.Flag) []cli.Flag {
 		cli.BoolFlag{
 			Name:  patchVerboseFlagName,
 			Usage: "show patch summary",
+		},
+		cli.IntFlag{
+			Name:  patchDebugFlagName,
+			Usage: "hold the hosts of failed tasks for this many minutes to debug them",
 		}))
 }
 
@@ -41,20 +48,34 @@ func Patch() cli.Command {
 		Before:  setPlainLogger,
 		Aliases: []string{"create-patch", "submit-patch"},
 		Usage:   "submit a new patch to evergreen",
-		Flags:   getPatchFlags(),
+		Flags: getPatchFlags(
+			cli.BoolFlag{
+				Name:  patchPerCommitFlagName,
+				Usage: "submit a stack of patches, one for each commit of the local branch",
+			},
+			cli.BoolFlag{
+				Name:  patchSequentialFlagName,
+				Usage: "with --per-commit, run each patch of the stack after the one before it finishes",
+			}),
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			args := c.Args()
 			params := &patchParams{
-				Project:     c.String(projectFlagName),
-				Variants:    c.StringSlice(variantsFlagName),
-				Tasks:       c.StringSlice(tasksFlagName),
-				SkipConfirm: c.Bool(yesFlagName),
-				Description: c.String(patchDescriptionFlagName),
-				Finalize:    c.Bool(patchFinalizeFlagName),
-				ShowSummary: c.Bool(patchVerboseFlagName),
-				Large:       c.Bool(largeFlagName),
-				Alias:       c.String(patchAliasFlagName),
+				Project:        c.String(projectFlagName),
+				Variants:       c.StringSlice(variantsFlagName),
+				Tasks:          c.StringSlice(tasksFlagName),
+				SkipConfirm:    c.Bool(yesFlagName),
+				Description:    c.String(patchDescriptionFlagName),
+				Finalize:       c.Bool(patchFinalizeFlagName),
+				ShowSummary:    c.Bool(patchVerboseFlagName),
+				Large:          c.Bool(largeFlagName),
+				Alias:          c.String(patchAliasFlagName),
+				DebugOnFailure: c.Int(patchDebugFlagName),
+				PerCommit:      c.Bool(patchPerCommitFlagName),
+				Sequential:     c.Bool(patchSequentialFlagName),
+			}
+			if params.Sequential && !params.PerCommit {
+				return errors.Errorf("--%s requires --%s", patchSequentialFlagName, patchPerCommitFlagName)
 			}
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -77,6 +98,15 @@ func Patch() cli.Command {
 				return err
 			}
 
+			if params.PerCommit {
+				commits, err := loadGitCommits(ref.Branch, args...)
+				if err != nil {
+					return err
+				}
+
+				return params.createPatchStack(ac, conf, commits)
+			}
+
 			diffData, err := loadGitData(ref.Branch, args...)
 			if err != nil {
 				return err
@@ -110,14 +140,15 @@ func PatchFile() cli.Command {
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			params := &patchParams{
-				Project:     c.String(projectFlagName),
-				Variants:    c.StringSlice(variantsFlagName),
-				Tasks:       c.StringSlice(tasksFlagName),
-				SkipConfirm: c.Bool(yesFlagName),
-				Description: c.String(patchDescriptionFlagName),
-				Finalize:    c.Bool(patchFinalizeFlagName),
-				ShowSummary: c.Bool(patchVerboseFlagName),
-				Large:       c.Bool(largeFlagName),
+				Project:        c.String(projectFlagName),
+				Variants:       c.StringSlice(variantsFlagName),
+				Tasks:          c.StringSlice(tasksFlagName),
+				SkipConfirm:    c.Bool(yesFlagName),
+				Description:    c.String(patchDescriptionFlagName),
+				Finalize:       c.Bool(patchFinalizeFlagName),
+				ShowSummary:    c.Bool(patchVerboseFlagName),
+				Large:          c.Bool(largeFlagName),
+				DebugOnFailure: c.Int(patchDebugFlagName),
 			}
 			diffPath := c.String(diffPathFlagName)
 			base := c.String(baseFlagName)
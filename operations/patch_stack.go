@@ -0,0 +1,70 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func PatchStack() cli.Command {
+	return cli.Command{
+		Name:   "patch-stack",
+		Usage:  "show the patches of a stack and the first commit that broke each task",
+		Flags:  addPatchIDFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig, requirePatchIDFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			patchID := c.String(patchIDFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			stack, err := client.GetPatchStack(ctx, patchID)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching patch stack")
+			}
+
+			w := new(tabwriter.Writer)
+			w.Init(os.Stdout, 0, 8, 1, '\t', 0)
+			fmt.Fprintln(w, "Position\tPatch\tStatus\tCommit\tMessage")
+			for _, p := range stack.Patches {
+				fmt.Fprintf(w, "%d/%d\t%s\t%s\t%s\t%s\n", p.Position, len(stack.Patches),
+					model.FromAPIString(p.PatchId), model.FromAPIString(p.Status),
+					shortCommit(model.FromAPIString(p.Commit)), model.FromAPIString(p.CommitMessage))
+			}
+			fmt.Fprintln(w)
+
+			if len(stack.Breaks) == 0 {
+				fmt.Fprintln(w, "No task failed in the stack.")
+				return errors.WithStack(w.Flush())
+			}
+			fmt.Fprintln(w, "Variant\tTask\tFirst Broken By\tMessage")
+			for _, b := range stack.Breaks {
+				fmt.Fprintf(w, "%s\t%s\t%d/%d %s\t%s\n", model.FromAPIString(b.BuildVariant),
+					model.FromAPIString(b.TaskName), b.Position, len(stack.Patches),
+					shortCommit(model.FromAPIString(b.Commit)), model.FromAPIString(b.CommitMessage))
+			}
+			return errors.WithStack(w.Flush())
+		},
+	}
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 10 {
+		return commit[:10]
+	}
+	return commit
+}
@@ -44,6 +44,14 @@ type localDiff struct {
 	base         string
 }
 
+// localCommit is a commit of the local branch, with the cumulative diff of
+// the branch from its merge base up to and including the commit.
+type localCommit struct {
+	commit  string
+	message string
+	diff    *localDiff
+}
+
 type patchParams struct {
 	Project     string
 	Variants    []string
@@ -54,6 +62,15 @@ type patchParams struct {
 	Finalize    bool
 	Large       bool
 	ShowSummary bool
+
+	// DebugOnFailure is the number of minutes to hold the hosts of
+	// failed tasks for debugging.
+	DebugOnFailure int
+
+	// PerCommit submits a stack of patches, one for each commit of the
+	// local branch, and Sequential runs them one at a time, in order.
+	PerCommit  bool
+	Sequential bool
 }
 
 type patchSubmission struct {
@@ -65,6 +82,12 @@ type patchSubmission struct {
 	variants    string
 	tasks       []string
 	finalize    bool
+
+	debugOnFailure int
+
+	stack         *patch.PatchStack
+	commit        string
+	commitMessage string
 }
 
 func (p *patchParams) createPatch(ac *legacyClient, conf *ClientSettings, diffData *localDiff) error {
@@ -96,6 +119,8 @@ func (p *patchParams) createPatch(ac *legacyClient, conf *ClientSettings, diffDa
 		tasks:       p.Tasks,
 		finalize:    p.Finalize,
 		alias:       p.Alias,
+
+		debugOnFailure: p.DebugOnFailure,
 	}
 
 	newPatch, err := ac.PutPatch(patchSub)
@@ -112,6 +137,93 @@ func (p *patchParams) createPatch(ac *legacyClient, conf *ClientSettings, diffDa
 	return nil
 }
 
+// createPatchStack submits a stack of patches, one for each of the commits,
+// in order. Each patch links to the first patch of the stack. When the stack
+// is sequential only the first patch is finalized now, and each of the rest
+// is finalized once the patch before it finishes.
+func (p *patchParams) createPatchStack(ac *legacyClient, conf *ClientSettings, commits []localCommit) error {
+	if len(commits) == 0 {
+		return errors.New("the local branch has no commits to submit")
+	}
+	if len(commits) == 1 {
+		return p.createPatch(ac, conf, commits[0].diff)
+	}
+	for _, c := range commits {
+		if err := validatePatchSize(c.diff, p.Large); err != nil {
+			return errors.Wrapf(err, "patch for commit %s is too large", c.commit)
+		}
+	}
+
+	if !p.SkipConfirm {
+		last := commits[len(commits)-1].diff
+		grip.Info(last.patchSummary)
+		for i, c := range commits {
+			grip.Infof("%d/%d: %s %s", i+1, len(commits), c.commit, c.message)
+		}
+		if !confirm(fmt.Sprintf("This will submit %d patches, one for each commit. Continue? (y/n):", len(commits)), true) {
+			return nil
+		}
+	}
+
+	variantsStr := strings.Join(p.Variants, ",")
+	var first *patch.Patch
+	for i, c := range commits {
+		stack := &patch.PatchStack{
+			Position:   i + 1,
+			Size:       len(commits),
+			Sequential: p.Sequential,
+			Finalize:   p.Finalize,
+		}
+		finalize := p.Finalize
+		if first != nil {
+			stack.ID = first.Id.Hex()
+			if p.Sequential {
+				finalize = false
+			}
+		}
+
+		description := fmt.Sprintf("(%d/%d): %s", i+1, len(commits), c.message)
+		if p.Description != "" {
+			description = fmt.Sprintf("%s %s", p.Description, description)
+		}
+
+		patchSub := patchSubmission{
+			projectId:   p.Project,
+			patchData:   c.diff.fullPatch,
+			description: description,
+			base:        c.diff.base,
+			variants:    variantsStr,
+			tasks:       p.Tasks,
+			finalize:    finalize,
+			alias:       p.Alias,
+
+			debugOnFailure: p.DebugOnFailure,
+
+			stack:         stack,
+			commit:        c.commit,
+			commitMessage: c.message,
+		}
+
+		newPatch, err := ac.PutPatch(patchSub)
+		if err != nil {
+			return errors.Wrapf(err, "problem submitting patch %d/%d for commit %s", i+1, len(commits), c.commit)
+		}
+		if first == nil {
+			first = newPatch
+		}
+
+		patchDisp, err := getPatchDisplay(newPatch, p.ShowSummary, conf.UIServerHost)
+		if err != nil {
+			return err
+		}
+		grip.Info(patchDisp)
+	}
+
+	grip.Infof("Patch stack of %d patches successfully created.", len(commits))
+	grip.Infof("Run `evergreen patch-stack --patch %s` to see which commits broke tasks.", first.Id.Hex())
+	return nil
+}
+
 // Performs validation for patch or patch-file
 func (p *patchParams) validatePatchCommand(ctx context.Context, conf *ClientSettings, ac *legacyClient, comm client.Communicator) (ref *model.ProjectRef, err error) {
 	if p.Project == "" {
@@ -311,6 +423,47 @@ func loadGitData(branch string, extraArgs ...string) (*localDiff, error) {
 	return &localDiff{patch, stat, log, mergeBase}, nil
 }
 
+// loadGitCommits inspects the current git working directory and returns each
+// commit of the branch since its merge base, oldest first, with the cumulative
+// patch of the branch up to that commit. The branch and extra arguments are
+// used as in loadGitData.
+func loadGitCommits(branch string, extraArgs ...string) ([]localCommit, error) {
+	mergeBase, err := gitMergeBase(branch+"@{upstream}", "HEAD")
+	if err != nil {
+		return nil, errors.Errorf("Error getting merge base: %v", err)
+	}
+	revList, err := gitCmd("rev-list", "", "--reverse", fmt.Sprintf("%s..HEAD", mergeBase))
+	if err != nil {
+		return nil, errors.Errorf("Error listing commits: %v", err)
+	}
+
+	if !util.StringSliceContains(extraArgs, "--binary") {
+		extraArgs = append(extraArgs, "--binary")
+	}
+
+	commits := []localCommit{}
+	for _, commit := range strings.Fields(revList) {
+		message, err := gitCmd("log", "", "-1", "--format=%s", commit)
+		if err != nil {
+			return nil, errors.Errorf("git log: %v", err)
+		}
+		stat, err := gitDiff(mergeBase, commit, "--stat")
+		if err != nil {
+			return nil, errors.Errorf("Error getting diff summary: %v", err)
+		}
+		fullPatch, err := gitDiff(mergeBase, append([]string{commit}, extraArgs...)...)
+		if err != nil {
+			return nil, errors.Errorf("Error getting patch: %v", err)
+		}
+		commits = append(commits, localCommit{
+			commit:  commit,
+			message: strings.TrimSpace(message),
+			diff:    &localDiff{fullPatch, stat, "", mergeBase},
+		})
+	}
+	return commits, nil
+}
+
 // gitMergeBase runs "git merge-base <branch1> <branch2>" and returns the
 // resulting githash as string
 func gitMergeBase(branch1, branch2 string) (string, error) {
@@ -216,6 +216,7 @@ func getHandlerAPI(settings *evergreen.Settings, queue amboy.Queue, router *mux.
 	n := negroni.New()
 	n.Use(service.NewRecoveryLogger())
 	n.Use(negroni.HandlerFunc(service.UserMiddleware(as.UserManager)))
+	n.Use(service.NewAuditLogger(router))
 	n.UseHandler(router)
 	return n, nil
 }
@@ -268,6 +269,7 @@ func getHandlerUI(settings *evergreen.Settings, queue amboy.Queue, router *mux.R
 	n.Use(negroni.NewStatic(http.Dir(webHome)))
 	n.Use(service.NewRecoveryLogger())
 	n.Use(negroni.HandlerFunc(service.UserMiddleware(uis.UserManager)))
+	n.Use(service.NewAuditLogger(router))
 	n.UseHandler(router)
 
 	return n, nil
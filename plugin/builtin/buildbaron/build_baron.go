@@ -103,6 +103,29 @@ func (bbp *BuildBaronPlugin) Configure(conf map[string]interface{}) error {
 	return nil
 }
 
+// New returns a build baron plugin configured from the plugin settings, for
+// filing tickets outside of the UI.
+func New(settings *evergreen.Settings) (*BuildBaronPlugin, error) {
+	conf, ok := settings.Plugins[PluginName]
+	if !ok {
+		return nil, fmt.Errorf("%s plugin is not configured", PluginName)
+	}
+	bbp := &BuildBaronPlugin{}
+	if err := bbp.Configure(conf); err != nil {
+		return nil, err
+	}
+	return bbp, nil
+}
+
+// ProjectEnabled returns whether build baron is configured for the project.
+func (bbp *BuildBaronPlugin) ProjectEnabled(projectId string) bool {
+	if bbp.opts == nil {
+		return false
+	}
+	_, ok := bbp.opts.Projects[projectId]
+	return ok
+}
+
 func (bbp *BuildBaronPlugin) GetPanelConfig() (*plugin.PanelConfig, error) {
 	return &plugin.PanelConfig{
 		Panels: []plugin.UIPanel{
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -15,6 +16,7 @@ import (
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -75,72 +77,83 @@ func (bbp *BuildBaronPlugin) fileTicket(w http.ResponseWriter, r *http.Request)
 		util.WriteJSON(w, http.StatusNotFound, fmt.Sprintf("task not found for id %v", input.TaskId))
 		return
 	}
+
+	result, err := bbp.FileTicket(t, input.TestIds, u.Id)
+	if err != nil {
+		grip.Error(err)
+		util.WriteJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, result)
+}
+
+// FileTicket creates a JIRA build failure ticket for the task with the
+// given failed tests, assigned to and reported by the user. If no tests are
+// given, all of the task's failed tests are included.
+func (bbp *BuildBaronPlugin) FileTicket(t *task.Task, testIds []string, userId string) (*thirdparty.JiraCreateTicketResponse, error) {
+	bbProj, ok := bbp.opts.Projects[t.Project]
+	if !ok {
+		return nil, errors.Errorf("build baron is not configured for project '%s'", t.Project)
+	}
+
 	var h *host.Host
+	var err error
 	if !t.DisplayOnly {
 		// Find the host the task ran on
 		h, err = host.FindOne(host.ById(t.HostId))
 		if err != nil {
-			util.WriteJSON(w, http.StatusInternalServerError, err.Error())
-			return
+			return nil, errors.Wrapf(err, "error finding host for task '%s'", t.Id)
 		}
 		if h == nil {
-			util.WriteJSON(w, http.StatusInternalServerError, fmt.Sprintf("host not found for task id %v with host id: %v", input.TaskId, t.HostId))
-			return
+			return nil, errors.Errorf("host not found for task id %v with host id: %v", t.Id, t.HostId)
 		}
 	}
 
 	// build a list of all failed tests to include
-	testIds := map[string]bool{}
-	for _, testId := range input.TestIds {
-		testIds[testId] = true
+	includeTests := map[string]bool{}
+	for _, testId := range testIds {
+		includeTests[testId] = true
 	}
 	tests := []jiraTestFailure{}
 	for _, test := range t.LocalTestResults {
-		if testIds[test.TestFile] {
-			tests = append(tests, jiraTestFailure{
-				Name:       cleanTestName(test.TestFile),
-				URL:        test.URL,
-				HistoryURL: historyURL(t, cleanTestName(test.TestFile)),
-			})
+		if len(testIds) == 0 && test.Status != evergreen.TestFailedStatus {
+			continue
 		}
+		if len(testIds) != 0 && !includeTests[test.TestFile] {
+			continue
+		}
+		tests = append(tests, jiraTestFailure{
+			Name:       cleanTestName(test.TestFile),
+			URL:        test.URL,
+			HistoryURL: historyURL(t, cleanTestName(test.TestFile)),
+		})
 	}
 
 	//lay out the JIRA API request
 	request := map[string]interface{}{}
-	request["project"] = map[string]string{"key": bbp.opts.Projects[t.Project].TicketCreateProject}
+	request["project"] = map[string]string{"key": bbProj.TicketCreateProject}
 	request["summary"] = getSummary(t.DisplayName, tests)
 	request[FailingTasksField] = []string{t.DisplayName}
 	request[FailingVariantField] = []string{t.BuildVariant}
 	request[EvergreenProjectField] = []string{t.Project}
 	request[FailingRevisionField] = []string{t.Revision}
 	request["issuetype"] = map[string]string{"name": "Build Failure"}
-	request["assignee"] = map[string]string{"name": u.Id}
-	request["reporter"] = map[string]string{"name": u.Id}
-	request["description"], err = getDescription(t, h, u.Id, tests)
-
+	request["assignee"] = map[string]string{"name": userId}
+	request["reporter"] = map[string]string{"name": userId}
+	request["description"], err = getDescription(t, h, userId, tests)
 	if err != nil {
-		util.WriteJSON(
-			w, http.StatusBadRequest, fmt.Sprintf("error creating description: %v", err))
-		return
+		return nil, errors.Wrap(err, "error creating description")
 	}
 
-	grip.Infoln("Creating JIRA ticket for user", u.Id)
+	grip.Infoln("Creating JIRA ticket for user", userId)
 
-	jiraHandler := thirdparty.NewJiraHandler(
-		bbp.opts.Host,
-		bbp.opts.Username,
-		bbp.opts.Password,
-	)
-	result, err := jiraHandler.CreateTicket(request)
+	result, err := bbp.jiraHandler.CreateTicket(request)
 	if err != nil {
-		msg := fmt.Sprintf("error creating JIRA ticket: %v", err)
-		grip.Error(msg)
-		util.WriteJSON(w, http.StatusBadRequest, msg)
-		return
+		return nil, errors.Wrap(err, "error creating JIRA ticket")
 	}
 	event.LogJiraIssueCreated(t.Id, result.Key)
 	grip.Infof("Ticket %s successfully created", result.Key)
-	util.WriteJSON(w, http.StatusOK, result)
+	return result, nil
 }
 
 func cleanTestName(path string) string {
@@ -113,7 +113,8 @@ mciModule.controller('AdminSettingsController', ['$scope','$window', 'mciAdminRe
     repotracker_push_event_disabled: "repotracker_push_event",
     cli_updates_disabled: "cli_updates",
     github_status_api_disabled: "github_status_api",
-    background_stats_disabled: "background stats"
+    background_stats_disabled: "background stats",
+    commit_queue_disabled: "commit queue"
   }
 
   timestamp = function(ts) {
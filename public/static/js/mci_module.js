@@ -300,6 +300,9 @@ var mciModule = angular.module('MCI', [
       return 'success';
     } else if (task.status == 'failed') {
       if ('task_end_details' in task) {
+        if (task.task_end_details.resource_limit) {
+          return 'exceeded ' + task.task_end_details.resource_limit;
+        }
         if ('timed_out' in task.task_end_details) {
           if (task.task_end_details.timed_out && 'desc' in task.task_end_details && task.task_end_details.desc == 'heartbeat') {
             return 'system unresponsive';
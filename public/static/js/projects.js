@@ -113,6 +113,32 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
   }
 
 
+  // addPeriodicBuild adds an empty periodic build definition to the
+  // settingsFormData's list of periodic builds
+  $scope.addPeriodicBuild = function(){
+    $scope.settingsFormData.periodic_builds.push({id: "", cron: "", alias: "", build_variants_temp: "", message: ""});
+    $scope.isDirty = true;
+  }
+
+  // removePeriodicBuild removes the periodic build located at index
+  $scope.removePeriodicBuild = function(index){
+    $scope.settingsFormData.periodic_builds.splice(index, 1);
+    $scope.isDirty = true;
+  }
+
+  // addTrigger adds an empty trigger definition to the settingsFormData's
+  // list of triggers
+  $scope.addTrigger = function(){
+    $scope.settingsFormData.triggers.push({id: "", project: "", level: "version", status: "success", variant_regex: "", task_regex: "", alias: "", build_variants_temp: "", message: ""});
+    $scope.isDirty = true;
+  }
+
+  // removeTrigger removes the trigger located at index
+  $scope.removeTrigger = function(index){
+    $scope.settingsFormData.triggers.splice(index, 1);
+    $scope.isDirty = true;
+  }
+
   $scope.addProject = function() {
     $scope.modalOpen = false;
     $('#admin-modal').modal('hide');
@@ -221,6 +247,16 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
           setup_github_hook: $scope.githubHookID != 0,
           tracks_push_events: data.ProjectRef.tracks_push_events || false,
           pr_testing_enabled: data.ProjectRef.pr_testing_enabled || false,
+          commit_queue: data.ProjectRef.commit_queue || {enabled: false, merge_method: "squash", patch_alias: ""},
+          periodic_builds: _.map(data.ProjectRef.periodic_builds || [], function(definition) {
+            definition.build_variants_temp = (definition.build_variants || []).join(',');
+            return definition;
+          }),
+          task_retry: data.ProjectRef.task_retry || {max_retries: 0, system_failures: false, setup_failures: false},
+          triggers: _.map(data.ProjectRef.triggers || [], function(definition) {
+            definition.build_variants_temp = (definition.build_variants || []).join(',');
+            return definition;
+          }),
           force_repotracker_run: false,
           delete_aliases: []
         };
@@ -291,6 +327,11 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
     if ($scope.admin_name) {
       $scope.addAdmin();
     }
+    _.each($scope.settingsFormData.periodic_builds.concat($scope.settingsFormData.triggers), function(definition) {
+      definition.build_variants = _.compact(_.map(definition.build_variants_temp.split(','), function(name) {
+        return name.trim();
+      }));
+    });
     $http.post('/project/' + $scope.settingsFormData.identifier, $scope.settingsFormData).then(
       function(resp) {
         var data = resp.data;
@@ -45,6 +45,7 @@ mciModule.controller('SettingsCtrl', ['$scope', '$http', '$window', 'notificatio
   $scope.user_tz = $window.user_tz;
   $scope.new_tz = $scope.user_tz || "America/New_York";
   $scope.github_user = $window.github_user
+  $scope.slack_member = $window.slack_member;
   $scope.new_waterfall = $window.new_waterfall;
   $scope.userConf = $window.userConf;
   $scope.binaries = $window.binaries;
@@ -64,13 +65,23 @@ mciModule.controller('SettingsCtrl', ['$scope', '$http', '$window', 'notificatio
       });
   }
 
+  $scope.linkSlack = function(){
+    $http.post('/settings/slack').then(
+      function(resp) {
+        $scope.slack_member = resp.data;
+      },
+      function(resp) {
+        notifier.pushNotification("Failed to link Slack account: " + resp.data.error,'errorHeader');
+      });
+  }
+
   $scope.updateUserSettings = function(new_tz, new_waterfall) {
     data = {
         timezone: new_tz,
         new_waterfall: new_waterfall,
         github_user: {
             last_known_as: $scope.github_user,
-        }
+        },
     };
     $http.put('/settings/', data).then(
       function(resp) {
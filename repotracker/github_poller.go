@@ -77,7 +77,8 @@ func (gRepoPoller *GithubRepositoryPoller) GetRemoteConfig(ctx context.Context,
 	}
 
 	projectConfig = &model.Project{}
-	err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, projectConfig)
+	err = model.LoadProjectIntoWithAxisValues(projectFileBytes, projectRef.Identifier, projectConfig,
+		model.GithubAxisValuesLoader(ctx, gRepoPoller.OauthToken, projectRef.Owner, projectRef.Repo, projectFileRevision))
 	if err != nil {
 		return nil, thirdparty.YAMLFormatError{err.Error()}
 	}
@@ -0,0 +1,151 @@
+package repotracker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// headVersionOptions describe a version that is created at the head of a
+// project's branch rather than for a new commit.
+type headVersionOptions struct {
+	// name and suffix make the version's id unique, along with the
+	// project and revision
+	name   string
+	suffix string
+
+	requester   string
+	message     string
+	triggeredBy *version.TriggerInfo
+
+	// alias or buildVariants select the tasks to run
+	alias         string
+	buildVariants []string
+}
+
+// createHeadVersion creates and activates a version at the head of the
+// project's branch that runs the variants and tasks that the options
+// select.
+func (repoTracker *RepoTracker) createHeadVersion(ctx context.Context, opts headVersionOptions) (*version.Version, error) {
+	ref := repoTracker.ProjectRef
+
+	revisions, err := repoTracker.GetRecentRevisions(1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem getting the head revision of project %s", ref.Identifier)
+	}
+	if len(revisions) == 0 {
+		return nil, errors.Errorf("project %s has no revisions", ref.Identifier)
+	}
+	revision := revisions[0]
+
+	project, err := repoTracker.GetProjectConfig(ctx, revision.Revision)
+	if err != nil {
+		if projectErr, ok := err.(projectConfigError); !ok || len(projectErr.Errors) > 0 {
+			return nil, errors.Wrapf(err, "problem getting the project config at revision %s", revision.Revision)
+		}
+	}
+
+	v, err := NewVersionFromRevision(ref, revision)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	now := time.Now()
+	v.Id = util.CleanName(fmt.Sprintf("%s_%s_%s_%s", ref.String(), opts.name, revision.Revision, opts.suffix))
+	v.Requester = opts.requester
+	v.CreateTime = now
+	v.TriggeredBy = opts.triggeredBy
+	if opts.message != "" {
+		v.Message = opts.message
+	}
+
+	projectYamlBytes, err := yaml.Marshal(project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshaling config")
+	}
+	v.Config = string(projectYamlBytes)
+
+	tasks, err := headVersionTasks(project, opts.alias, opts.buildVariants)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if len(tasks.ExecTasks) == 0 {
+		return nil, errors.Errorf("'%s' selects no tasks in project %s", opts.name, ref.Identifier)
+	}
+
+	taskIds := model.NewPatchTaskIdTable(project, v, tasks)
+	for _, bv := range project.BuildVariants {
+		taskNames := tasks.ExecTasks.TaskNames(bv.Name)
+		if len(taskNames) == 0 {
+			continue
+		}
+		displayNames := tasks.DisplayTasks.TaskNames(bv.Name)
+
+		var buildId string
+		buildId, err = model.CreateBuildFromVersion(project, v, taskIds, bv.Name, true, taskNames, displayNames)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		v.BuildIds = append(v.BuildIds, buildId)
+		v.BuildVariants = append(v.BuildVariants, version.BuildStatus{
+			BuildVariant: bv.Name,
+			Activated:    true,
+			ActivateAt:   now,
+			BuildId:      buildId,
+		})
+	}
+
+	if err = v.Insert(); err != nil {
+		return nil, errors.Wrapf(err, "problem inserting version %s", v.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":   "created version at branch head",
+		"runner":    RunnerName,
+		"project":   ref.Identifier,
+		"requester": opts.requester,
+		"name":      opts.name,
+		"revision":  revision.Revision,
+		"version":   v.Id,
+	})
+
+	return v, nil
+}
+
+// headVersionTasks returns the variants and tasks that the alias or the
+// build variants select, along with their dependencies. All tasks of the
+// build variants are selected.
+func headVersionTasks(project *model.Project, alias string, buildVariants []string) (model.TaskVariantPairs, error) {
+	tasks := model.TaskVariantPairs{}
+	if alias != "" {
+		pairs, displayPairs, err := project.BuildProjectTVPairsWithAlias(alias)
+		if err != nil {
+			return tasks, errors.Wrapf(err, "problem getting tasks for alias '%s'", alias)
+		}
+		tasks.ExecTasks = pairs
+		tasks.DisplayTasks = displayPairs
+	} else {
+		for _, name := range buildVariants {
+			bv := project.FindBuildVariant(name)
+			if bv == nil {
+				return tasks, errors.Errorf("build variant '%s' is not in the project", name)
+			}
+			for _, t := range bv.Tasks {
+				tasks.ExecTasks = append(tasks.ExecTasks, model.TVPair{Variant: bv.Name, TaskName: t.Name})
+			}
+			for _, dt := range bv.DisplayTasks {
+				tasks.DisplayTasks = append(tasks.DisplayTasks, model.TVPair{Variant: bv.Name, TaskName: dt.Name})
+			}
+		}
+	}
+
+	tasks.ExecTasks = model.IncludePatchDependencies(project, tasks.ExecTasks)
+	return tasks, nil
+}
@@ -0,0 +1,25 @@
+package repotracker
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+)
+
+// CreatePeriodicVersion creates and activates a version at the head of the
+// project's branch that runs the variants and tasks of the periodic build
+// definition. It returns the new version.
+func (repoTracker *RepoTracker) CreatePeriodicVersion(ctx context.Context, definition *model.PeriodicBuildDefinition) (*version.Version, error) {
+	return repoTracker.createHeadVersion(ctx, headVersionOptions{
+		name:          definition.ID,
+		suffix:        strconv.FormatInt(time.Now().Unix(), 10),
+		requester:     evergreen.PeriodicBuildRequester,
+		message:       definition.Message,
+		alias:         definition.Alias,
+		buildVariants: definition.BuildVariants,
+	})
+}
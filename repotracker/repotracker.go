@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/notify"
@@ -347,11 +348,13 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 		}
 		v.Config = string(projectYamlBytes)
 
-		// "Ignore" a version if all changes are to ignored files
-		if len(project.Ignore) > 0 {
-			filenames, err := repoTracker.GetChangedFiles(ctx, revision)
+		// "Ignore" a version if all changes are to ignored files, and
+		// leave variants and tasks whose paths don't match inactive
+		var filenames []string
+		if len(project.Ignore) > 0 || project.HasPathFilters() {
+			filenames, err = repoTracker.GetChangedFiles(ctx, revision)
 			if err != nil {
-				return nil, errors.Wrap(err, "error checking GitHub for ignored files")
+				return nil, errors.Wrap(err, "error checking GitHub for changed files")
 			}
 			if project.IgnoresAllFiles(filenames) {
 				v.Ignored = true
@@ -359,7 +362,7 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 		}
 
 		// We rebind newestVersion each iteration, so the last binding will be the newest version
-		err = errors.Wrapf(createVersionItems(v, ref, project),
+		err = errors.Wrapf(createVersionItems(v, ref, project, filenames),
 			"Error creating version items for %s in project %s",
 			v.Id, ref.Identifier)
 		if err != nil {
@@ -506,8 +509,9 @@ func NewVersionFromRevision(ref *model.ProjectRef, rev model.Revision) (*version
 }
 
 // createVersionItems populates and stores all the tasks and builds for a version according to
-// the given project config.
-func createVersionItems(v *version.Version, ref *model.ProjectRef, project *model.Project) error {
+// the given project config. Builds and tasks that none of the changed files affect are
+// created but never activated by the system.
+func createVersionItems(v *version.Version, ref *model.ProjectRef, project *model.Project, changedFiles []string) error {
 	// generate all task Ids so that we can easily reference them for dependencies
 	taskIds := model.NewTaskIdTable(project, v)
 
@@ -522,6 +526,38 @@ func createVersionItems(v *version.Version, ref *model.ProjectRef, project *mode
 			return errors.WithStack(err)
 		}
 
+		if !project.VariantAffectedByFiles(buildvariant.Name, changedFiles) {
+			grip.Info(message.Fields{
+				"message": "not activating build unaffected by changed files",
+				"name":    buildvariant.Name,
+				"project": ref.Identifier,
+				"version": v.Id,
+				"runner":  RunnerName,
+			})
+
+			// a zero activation time keeps the build from being activated
+			v.BuildIds = append(v.BuildIds, buildId)
+			v.BuildVariants = append(v.BuildVariants, version.BuildStatus{
+				BuildVariant: buildvariant.Name,
+				Activated:    false,
+				BuildId:      buildId,
+			})
+			continue
+		}
+
+		if len(changedFiles) > 0 && project.HasPathFilters() {
+			b, err := build.FindOne(build.ById(buildId))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if b == nil {
+				return errors.Errorf("build %s not found", buildId)
+			}
+			if _, err = model.FilterBuildTasksByChangedFiles(project, b, changedFiles); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
 		lastActivated, err := version.FindOne(version.ByLastVariantActivation(ref.Identifier, buildvariant.Name))
 		if err != nil {
 			grip.Error(message.WrapError(err, message.Fields{
@@ -0,0 +1,39 @@
+package repotracker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+)
+
+// CreateDownstreamVersion creates and activates a version at the head of
+// the project's branch that runs the variants and tasks of the trigger
+// definition, in response to the upstream event. It returns the new
+// version.
+func (repoTracker *RepoTracker) CreateDownstreamVersion(ctx context.Context, definition *model.TriggerDefinition, upstream *model.UpstreamEvent) (*version.Version, error) {
+	message := definition.Message
+	if message == "" {
+		item := "version"
+		switch upstream.Level {
+		case model.TriggerLevelTask:
+			item = fmt.Sprintf("task '%s' on '%s'", upstream.TaskName, upstream.BuildVariant)
+		case model.TriggerLevelBuild:
+			item = fmt.Sprintf("build '%s'", upstream.BuildVariant)
+		}
+		message = fmt.Sprintf("Triggered by %s of project %s finishing with status '%s' (version %s at revision %s)",
+			item, upstream.Project, upstream.Status, upstream.VersionID, upstream.Revision)
+	}
+
+	return repoTracker.createHeadVersion(ctx, headVersionOptions{
+		name:          definition.ID,
+		suffix:        upstream.EventID,
+		requester:     evergreen.TriggerRequester,
+		message:       message,
+		triggeredBy:   upstream.TriggerInfo(definition.ID),
+		alias:         definition.Alias,
+		buildVariants: definition.BuildVariants,
+	})
+}
@@ -136,22 +136,24 @@ func (c *communicatorImpl) LastMessageAt() time.Time {
 // GetLogProducer
 func (c *communicatorImpl) GetLoggerProducer(ctx context.Context, taskData TaskData) LoggerProducer {
 	local := grip.GetSender()
+	redactor := &logRedactor{}
 
 	exec := newLogSender(ctx, c, apimodels.AgentLogPrefix, taskData)
 	grip.CatchWarning(exec.SetFormatter(send.MakeDefaultFormatter()))
-	exec = send.NewConfiguredMultiSender(local, exec)
+	exec = newRedactingSender(send.NewConfiguredMultiSender(local, exec), redactor)
 
 	task := newTimeoutLogSender(ctx, c, apimodels.TaskLogPrefix, taskData)
 	grip.CatchWarning(task.SetFormatter(send.MakeDefaultFormatter()))
-	task = send.NewConfiguredMultiSender(local, task)
+	task = newRedactingSender(send.NewConfiguredMultiSender(local, task), redactor)
 
 	system := newLogSender(ctx, c, apimodels.SystemLogPrefix, taskData)
 	grip.CatchWarning(system.SetFormatter(send.MakeDefaultFormatter()))
-	system = send.NewConfiguredMultiSender(local, system)
+	system = newRedactingSender(send.NewConfiguredMultiSender(local, system), redactor)
 
 	return &logHarness{
 		execution: logging.MakeGrip(exec),
 		task:      logging.MakeGrip(task),
 		system:    logging.MakeGrip(system),
+		redactor:  redactor,
 	}
 }
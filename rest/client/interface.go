@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/perfresult"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
@@ -81,9 +82,11 @@ type Communicator interface {
 	// The following operations use the legacy API server and are
 	// used by task commands.
 	SendTestResults(context.Context, TaskData, *task.LocalTestResults) error
+	SendPerfResults(context.Context, TaskData, []perfresult.PerfResult) error
 	SendTestLog(context.Context, TaskData, *model.TestLog) (string, error)
 	GetTaskPatch(context.Context, TaskData) (*patchmodel.Patch, error)
 	GetPatchFile(context.Context, TaskData, string) (string, error)
+	GetFetchArtifacts(context.Context, TaskData) ([]apimodels.FetchedArtifact, error)
 
 	// The following operations are used by
 	AttachFiles(context.Context, TaskData, []*artifact.File) error
@@ -146,9 +149,25 @@ type Communicator interface {
 	// Delete a key with specified name from the current authenticated user
 	DeletePublicKey(context.Context, string) error
 
+	// API token methods of the current authenticated user
+	//
+	GetAPITokens(context.Context) ([]restmodel.APIUserToken, error)
+	CreateAPIToken(context.Context, string, string, []string, int) (*restmodel.APIUserToken, error)
+	DeleteAPIToken(context.Context, string) error
+
 	// List variant/task aliases
 	ListAliases(context.Context, string) ([]model.ProjectAlias, error)
 
 	// GetClientConfig fetches the ClientConfig for the evergreen server
 	GetClientConfig(context.Context) (*evergreen.ClientConfig, error)
+
+	// Flaky and quarantined test methods
+	//
+	GetFlakyTests(context.Context, string) ([]restmodel.APIFlakyTest, error)
+	GetQuarantinedTests(context.Context, string) ([]restmodel.APIQuarantinedTest, error)
+	QuarantineTest(context.Context, string, string, string, string) error
+	UnquarantineTest(context.Context, string, string, string) error
+
+	// GetPatchStack fetches the summary of the stack of a patch
+	GetPatchStack(context.Context, string) (*restmodel.APIPatchStack, error)
 }
@@ -30,6 +30,11 @@ type LoggerProducer interface {
 	TaskWriter(level.Priority) io.WriteCloser
 	SystemWriter(level.Priority) io.WriteCloser
 
+	// SetRedactions sets the secret values that are masked in all
+	// subsequent output to every logging channel, replacing any
+	// previously set values.
+	SetRedactions([]string)
+
 	// Close releases all resources by calling Close on all underlying senders.
 	Close() error
 }
@@ -44,6 +49,7 @@ type logHarness struct {
 	execution grip.Journaler
 	task      grip.Journaler
 	system    grip.Journaler
+	redactor  *logRedactor
 	mu        sync.Mutex
 	writers   []io.WriteCloser
 }
@@ -52,6 +58,8 @@ func (l *logHarness) Execution() grip.Journaler { return l.execution }
 func (l *logHarness) Task() grip.Journaler      { return l.task }
 func (l *logHarness) System() grip.Journaler    { return l.system }
 
+func (l *logHarness) SetRedactions(values []string) { l.redactor.setValues(values) }
+
 func (l *logHarness) TaskWriter(p level.Priority) io.WriteCloser {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -92,9 +100,10 @@ func (l *logHarness) Close() error {
 // Single Channel LoggerProducer
 
 type singleChannelLogHarness struct {
-	logger  grip.Journaler
-	mu      sync.Mutex
-	writers []io.WriteCloser
+	logger   grip.Journaler
+	redactor *logRedactor
+	mu       sync.Mutex
+	writers  []io.WriteCloser
 }
 
 // NewSingleChannelLogHarnness returns a log implementation that uses
@@ -107,8 +116,10 @@ type singleChannelLogHarness struct {
 func NewSingleChannelLogHarness(name string, sender send.Sender) LoggerProducer {
 	sender.SetName(name)
 
+	redactor := &logRedactor{}
 	l := &singleChannelLogHarness{
-		logger: logging.MakeGrip(sender),
+		logger:   logging.MakeGrip(newRedactingSender(sender, redactor)),
+		redactor: redactor,
 	}
 
 	return l
@@ -118,6 +129,10 @@ func (l *singleChannelLogHarness) Execution() grip.Journaler { return l.logger }
 func (l *singleChannelLogHarness) Task() grip.Journaler      { return l.logger }
 func (l *singleChannelLogHarness) System() grip.Journaler    { return l.logger }
 
+func (l *singleChannelLogHarness) SetRedactions(values []string) {
+	l.redactor.setValues(values)
+}
+
 func (l *singleChannelLogHarness) TaskWriter(p level.Priority) io.WriteCloser {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -17,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/perfresult"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/util"
@@ -344,6 +345,29 @@ func (c *communicatorImpl) GetTaskPatch(ctx context.Context, taskData TaskData)
 	return &patch, nil
 }
 
+// GetFetchArtifacts returns the links of the artifacts of other tasks that
+// the task fetches before its commands run.
+func (c *communicatorImpl) GetFetchArtifacts(ctx context.Context, taskData TaskData) ([]apimodels.FetchedArtifact, error) {
+	info := requestInfo{
+		method:   get,
+		taskData: &taskData,
+		version:  apiVersion1,
+	}
+	info.setTaskPathSuffix("fetch_artifacts")
+	resp, err := c.retryRequest(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get artifacts to fetch for %s", taskData.ID)
+	}
+	defer resp.Body.Close()
+
+	artifacts := []apimodels.FetchedArtifact{}
+	if err = util.ReadJSONInto(resp.Body, &artifacts); err != nil {
+		return nil, errors.Wrapf(err, "problem parsing artifacts to fetch for %s", taskData.ID)
+	}
+
+	return artifacts, nil
+}
+
 // GetPatchFiles is used by the git.get_project plugin and fetches
 // patches from the database, used in patch builds.
 func (c *communicatorImpl) GetPatchFile(ctx context.Context, taskData TaskData, patchFileID string) (string, error) {
@@ -418,6 +442,25 @@ func (c *communicatorImpl) SendTestResults(ctx context.Context, taskData TaskDat
 	return nil
 }
 
+// SendPerfResults posts a task's performance results.
+func (c *communicatorImpl) SendPerfResults(ctx context.Context, taskData TaskData, results []perfresult.PerfResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	info := requestInfo{
+		method:   post,
+		taskData: &taskData,
+		version:  apiVersion1,
+	}
+	info.setTaskPathSuffix("perf_results")
+	resp, err := c.retryRequest(ctx, info, results)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post perf results for task %s", taskData.ID)
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
 // AttachFiles attaches task files.
 func (c *communicatorImpl) AttachFiles(ctx context.Context, taskData TaskData, taskFiles []*artifact.File) error {
 	if len(taskFiles) == 0 {
@@ -15,6 +15,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/perfresult"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -53,7 +54,9 @@ type Mock struct {
 	HeartbeatShouldErr     bool
 	TaskExecution          int
 
-	AttachedFiles map[string][]*artifact.File
+	AttachedFiles    map[string][]*artifact.File
+	PerfResults      map[string][]perfresult.PerfResult
+	FetchedArtifacts []apimodels.FetchedArtifact
 
 	// metrics collection
 	ProcInfo map[string][]*message.ProcessInfo
@@ -85,6 +88,7 @@ func NewMock(serverURL string) *Mock {
 		ProcInfo:      make(map[string][]*message.ProcessInfo),
 		SysInfo:       make(map[string]*message.SystemInfo),
 		AttachedFiles: make(map[string][]*artifact.File),
+		PerfResults:   make(map[string][]perfresult.PerfResult),
 		serverURL:     serverURL,
 	}
 }
@@ -211,8 +215,11 @@ func (c *Mock) Heartbeat(ctx context.Context, td TaskData) (bool, error) {
 // FetchExpansionVars returns a mock ExpansionVars.
 func (c *Mock) FetchExpansionVars(ctx context.Context, td TaskData) (*apimodels.ExpansionVars, error) {
 	return &apimodels.ExpansionVars{
-		"shellexec_fn": c.ShellExecFilename,
-		"timeout_fn":   c.TimeoutFilename,
+		Vars: map[string]string{
+			"shellexec_fn": c.ShellExecFilename,
+			"timeout_fn":   c.TimeoutFilename,
+		},
+		PrivateVars: map[string]bool{},
 	}, nil
 }
 
@@ -371,6 +378,16 @@ func (c *Mock) SendTestResults(ctx context.Context, td TaskData, results *task.L
 	return nil
 }
 
+// SendPerfResults records the performance results sent for a task.
+func (c *Mock) SendPerfResults(ctx context.Context, td TaskData, results []perfresult.PerfResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.PerfResults[td.ID] = append(c.PerfResults[td.ID], results...)
+
+	return nil
+}
+
 // SendFiles attaches task files.
 func (c *Mock) AttachFiles(ctx context.Context, td TaskData, taskFiles []*artifact.File) error {
 	c.mu.Lock()
@@ -382,6 +399,14 @@ func (c *Mock) AttachFiles(ctx context.Context, td TaskData, taskFiles []*artifa
 	return nil
 }
 
+// GetFetchArtifacts returns the mock's fetched artifacts.
+func (c *Mock) GetFetchArtifacts(ctx context.Context, td TaskData) ([]apimodels.FetchedArtifact, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.FetchedArtifacts, nil
+}
+
 // SendTestLog posts a test log for a communicator's task. Is a
 // noop if the test Log is nil.
 func (c *Mock) SendTestLog(ctx context.Context, td TaskData, log *serviceModel.TestLog) (string, error) {
@@ -481,10 +506,42 @@ func (c *Mock) DeletePublicKey(ctx context.Context, keyName string) error {
 	return errors.New("(c *Mock) DeletePublicKey not implemented")
 }
 
+func (c *Mock) GetAPITokens(ctx context.Context) ([]model.APIUserToken, error) {
+	return nil, errors.New("(c *Mock) GetAPITokens not implemented")
+}
+
+func (c *Mock) CreateAPIToken(ctx context.Context, name, scope string, projects []string, expiresInDays int) (*model.APIUserToken, error) {
+	return nil, errors.New("(c *Mock) CreateAPIToken not implemented")
+}
+
+func (c *Mock) DeleteAPIToken(ctx context.Context, name string) error {
+	return errors.New("(c *Mock) DeleteAPIToken not implemented")
+}
+
 func (c *Mock) ListAliases(ctx context.Context, keyName string) ([]serviceModel.ProjectAlias, error) {
 	return nil, errors.New("(c *Mock) ListAliases not implemented")
 }
 
+func (c *Mock) GetFlakyTests(ctx context.Context, project string) ([]model.APIFlakyTest, error) {
+	return nil, errors.New("(c *Mock) GetFlakyTests not implemented")
+}
+
+func (c *Mock) GetQuarantinedTests(ctx context.Context, project string) ([]model.APIQuarantinedTest, error) {
+	return nil, errors.New("(c *Mock) GetQuarantinedTests not implemented")
+}
+
+func (c *Mock) QuarantineTest(ctx context.Context, project, testFile, buildVariant, reason string) error {
+	return errors.New("(c *Mock) QuarantineTest not implemented")
+}
+
+func (c *Mock) UnquarantineTest(ctx context.Context, project, testFile, buildVariant string) error {
+	return errors.New("(c *Mock) UnquarantineTest not implemented")
+}
+
+func (c *Mock) GetPatchStack(ctx context.Context, patchID string) (*model.APIPatchStack, error) {
+	return nil, errors.New("(c *Mock) GetPatchStack not implemented")
+}
+
 func (c *Mock) GetClientConfig(ctx context.Context) (*evergreen.ClientConfig, error) {
 	return &evergreen.ClientConfig{
 		ClientBinaries: []evergreen.ClientBinary{
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+)
+
+// RedactedValue replaces secret values in all log output produced by
+// the agent.
+const RedactedValue = "<REDACTED>"
+
+// minRedactedLineLength is the length of the shortest line of a
+// multi-line value that is redacted on its own. Shorter lines are too
+// likely to match unrelated output.
+const minRedactedLineLength = 8
+
+// logRedactor holds the set of secret values that must never appear
+// in log output. It is shared between all of the senders of a
+// LoggerProducer so that the values can be set once the task's
+// configuration is known, after the loggers have been created.
+type logRedactor struct {
+	mu       sync.RWMutex
+	replacer *strings.Replacer
+}
+
+// setValues replaces the set of values to redact. Values that span
+// multiple lines are also redacted line-by-line, because writers
+// split their output on newlines before it is sent, except for lines
+// that aren't secret on their own.
+func (r *logRedactor) setValues(values []string) {
+	seen := map[string]bool{}
+	secrets := []string{}
+	add := func(v string) {
+		if strings.TrimSpace(v) == "" || seen[v] {
+			return
+		}
+		seen[v] = true
+		secrets = append(secrets, v)
+	}
+
+	for _, v := range values {
+		add(v)
+		if strings.Contains(v, "\n") {
+			for _, line := range strings.Split(v, "\n") {
+				if line = strings.TrimSpace(line); isSecretLine(line) {
+					add(line)
+				}
+			}
+		}
+	}
+
+	// the replacer prefers the earliest pair when several values
+	// match at the same position, so longer values must come first
+	// to avoid leaking the remainder of a value that contains
+	// another.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+
+	var replacer *strings.Replacer
+	if len(secrets) > 0 {
+		pairs := make([]string, 0, 2*len(secrets))
+		for _, s := range secrets {
+			pairs = append(pairs, s, RedactedValue)
+		}
+		replacer = strings.NewReplacer(pairs...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replacer = replacer
+}
+
+// isSecretLine returns false for lines of a multi-line value that are
+// short, contain only punctuation (e.g. the braces of a JSON document),
+// or are the armor of a PEM block.
+func isSecretLine(line string) bool {
+	if len(line) < minRedactedLineLength {
+		return false
+	}
+	if strings.HasPrefix(line, "-----BEGIN ") || strings.HasPrefix(line, "-----END ") {
+		return false
+	}
+	return strings.IndexFunc(line, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
+
+func (r *logRedactor) redact(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.replacer == nil {
+		return s
+	}
+
+	return r.replacer.Replace(s)
+}
+
+// redactingSender wraps another sender and masks all redacted values
+// in a message before passing it along.
+type redactingSender struct {
+	redactor *logRedactor
+	send.Sender
+}
+
+func newRedactingSender(sender send.Sender, redactor *logRedactor) send.Sender {
+	return &redactingSender{
+		redactor: redactor,
+		Sender:   sender,
+	}
+}
+
+func (s *redactingSender) Send(m message.Composer) {
+	if !m.Loggable() {
+		s.Sender.Send(m)
+		return
+	}
+
+	original := m.String()
+	redacted := s.redactor.redact(original)
+	if redacted == original {
+		s.Sender.Send(m)
+		return
+	}
+
+	s.Sender.Send(message.NewDefaultMessage(m.Priority(), redacted))
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRedactor(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &logRedactor{}
+	assert.Equal("nothing to hide", r.redact("nothing to hide"))
+
+	r.setValues([]string{"hunter2", "", "  ", "hunter22"})
+	assert.Equal("password is <REDACTED>", r.redact("password is hunter2"))
+	assert.Equal("password is <REDACTED>!", r.redact("password is hunter22!"))
+	assert.Equal("a <REDACTED> b <REDACTED>", r.redact("a hunter2 b hunter2"))
+	assert.Equal("nothing to hide", r.redact("nothing to hide"))
+
+	r.setValues([]string{"-----BEGIN KEY-----\nabc123def\n-----END KEY-----\n"})
+	assert.Equal("<REDACTED>", r.redact("abc123def"))
+	assert.Equal("-----END KEY-----", r.redact("-----END KEY-----"))
+	assert.Equal("hunter2", r.redact("hunter2"))
+
+	r.setValues([]string{"{\n  \"token\": \"abc123def\",\n  \"id\": 1\n},\n[\n],\n"})
+	assert.Equal("<REDACTED>", r.redact(`"token": "abc123def",`))
+	assert.Equal("{", r.redact("{"))
+	assert.Equal("},", r.redact("},"))
+	assert.Equal("[", r.redact("["))
+	assert.Equal(`"id": 1`, r.redact(`"id": 1`))
+}
+
+func TestLoggerProducerRedactsAllChannels(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sender, err := send.NewInternalLogger("test", send.LevelInfo{Default: level.Info, Threshold: level.Info})
+	require.NoError(err)
+	logger := NewSingleChannelLogHarness("test", sender)
+	logger.SetRedactions([]string{"s3cr3t-value"})
+
+	logger.Task().Info("task s3cr3t-value")
+	logger.Execution().Info(message.Fields{"key": "s3cr3t-value"})
+	logger.System().Infof("system %s", "s3cr3t-value")
+
+	// values split across writes are buffered into a single line
+	// before they are sent.
+	w := logger.TaskWriter(level.Info)
+	_, err = w.Write([]byte("echo s3cr"))
+	require.NoError(err)
+	_, err = w.Write([]byte("3t-value" + strings.Repeat(" padding", 20) + "\n"))
+	require.NoError(err)
+	require.NoError(w.Close())
+
+	count := 0
+	for sender.HasMessage() {
+		m := sender.GetMessage()
+		if !m.Message.Loggable() {
+			continue
+		}
+		count++
+		assert.NotContains(m.Rendered, "s3cr")
+		assert.Contains(m.Rendered, RedactedValue, fmt.Sprintf("message %d", count))
+	}
+	assert.Equal(4, count)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -485,6 +486,95 @@ func (c *communicatorImpl) DeletePublicKey(ctx context.Context, keyName string)
 	return nil
 }
 
+func (c *communicatorImpl) GetAPITokens(ctx context.Context) ([]model.APIUserToken, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    "api_tokens",
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem fetching API tokens and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem fetching API tokens")
+	}
+
+	// the server returns a single object rather than a list when there is
+	// exactly one token
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading JSON")
+	}
+	tokens := []model.APIUserToken{}
+	if err = json.Unmarshal(bytes, &tokens); err != nil {
+		token := model.APIUserToken{}
+		if err = json.Unmarshal(bytes, &token); err != nil {
+			return nil, errors.Wrap(err, "error parsing API tokens")
+		}
+		tokens = []model.APIUserToken{token}
+	}
+	return tokens, nil
+}
+
+func (c *communicatorImpl) CreateAPIToken(ctx context.Context, name, scope string, projects []string, expiresInDays int) (*model.APIUserToken, error) {
+	info := requestInfo{
+		method:  post,
+		version: apiVersion2,
+		path:    "api_tokens",
+	}
+	body := model.APIUserToken{
+		Name:          model.ToAPIString(name),
+		Scope:         model.ToAPIString(scope),
+		Projects:      projects,
+		ExpiresInDays: expiresInDays,
+	}
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem creating API token and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem creating API token")
+	}
+
+	token := &model.APIUserToken{}
+	if err = util.ReadJSONInto(resp.Body, token); err != nil {
+		return nil, errors.Wrap(err, "error parsing API token")
+	}
+	return token, nil
+}
+
+func (c *communicatorImpl) DeleteAPIToken(ctx context.Context, name string) error {
+	info := requestInfo{
+		method:  delete,
+		version: apiVersion2,
+		path:    "api_tokens/" + name,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem revoking API token and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem revoking API token")
+	}
+	return nil
+}
+
 func (c *communicatorImpl) ListAliases(ctx context.Context, project string) ([]serviceModel.ProjectAlias, error) {
 	path := fmt.Sprintf("alias/%s", project)
 	info := requestInfo{
@@ -551,3 +641,151 @@ func (c *communicatorImpl) GetClientConfig(ctx context.Context) (*evergreen.Clie
 
 	return &config, nil
 }
+
+func (c *communicatorImpl) GetFlakyTests(ctx context.Context, project string) ([]model.APIFlakyTest, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/flaky_tests", project),
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem querying api server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem fetching flaky tests and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem fetching flaky tests")
+	}
+
+	// the server returns a single object rather than a list when there is
+	// exactly one flaky test
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading JSON")
+	}
+	tests := []model.APIFlakyTest{}
+	if err = json.Unmarshal(bytes, &tests); err != nil {
+		test := model.APIFlakyTest{}
+		if err = json.Unmarshal(bytes, &test); err != nil {
+			return nil, errors.Wrap(err, "error parsing flaky tests")
+		}
+		tests = []model.APIFlakyTest{test}
+	}
+	return tests, nil
+}
+
+func (c *communicatorImpl) GetQuarantinedTests(ctx context.Context, project string) ([]model.APIQuarantinedTest, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/quarantined_tests", project),
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem querying api server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem fetching quarantined tests and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem fetching quarantined tests")
+	}
+
+	// the server returns a single object rather than a list when there is
+	// exactly one quarantined test
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading JSON")
+	}
+	tests := []model.APIQuarantinedTest{}
+	if err = json.Unmarshal(bytes, &tests); err != nil {
+		test := model.APIQuarantinedTest{}
+		if err = json.Unmarshal(bytes, &test); err != nil {
+			return nil, errors.Wrap(err, "error parsing quarantined tests")
+		}
+		tests = []model.APIQuarantinedTest{test}
+	}
+	return tests, nil
+}
+
+func (c *communicatorImpl) QuarantineTest(ctx context.Context, project, testFile, buildVariant, reason string) error {
+	info := requestInfo{
+		method:  post,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/quarantined_tests", project),
+	}
+	body := map[string]string{
+		"test_file":     testFile,
+		"build_variant": buildVariant,
+		"reason":        reason,
+	}
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem quarantining test and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem quarantining test")
+	}
+	return nil
+}
+
+func (c *communicatorImpl) UnquarantineTest(ctx context.Context, project, testFile, buildVariant string) error {
+	query := url.Values{}
+	query.Set("test_file", testFile)
+	query.Set("build_variant", buildVariant)
+	info := requestInfo{
+		method:  delete,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/quarantined_tests?%s", project, query.Encode()),
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrap(err, "problem reaching evergreen API server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem removing quarantine and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem removing quarantine")
+	}
+	return nil
+}
+
+func (c *communicatorImpl) GetPatchStack(ctx context.Context, patchID string) (*model.APIPatchStack, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("patches/%s/stack", patchID),
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem querying api server")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem fetching patch stack and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem fetching patch stack")
+	}
+
+	stack := &model.APIPatchStack{}
+	if err = util.ReadJSONInto(resp.Body, stack); err != nil {
+		return nil, errors.Wrap(err, "error parsing patch stack")
+	}
+	return stack, nil
+}
@@ -195,9 +195,16 @@ func (ac *DBAdminConnector) GetAdminEventLog(before time.Time, n int) ([]restMod
 	return out, catcher.Resolve()
 }
 
+// FindAuditEvents returns the audit events matching the filter, most recent
+// first.
+func (ac *DBAdminConnector) FindAuditEvents(filter event.AuditFilter) ([]event.EventLogEntry, error) {
+	return event.Find(event.AllLogCollection, event.AuditEvents(filter))
+}
+
 type MockAdminConnector struct {
 	mu           sync.RWMutex
 	MockSettings *evergreen.Settings
+	AuditEvents  []event.EventLogEntry
 }
 
 // GetEvergreenSettings retrieves the admin settings document from the mock connector
@@ -270,3 +277,29 @@ func (ac *MockAdminConnector) RevertConfigTo(guid string, user string) error {
 func (ac *MockAdminConnector) GetAdminEventLog(before time.Time, n int) ([]restModel.APIAdminEvent, error) {
 	return nil, nil
 }
+
+func (ac *MockAdminConnector) FindAuditEvents(filter event.AuditFilter) ([]event.EventLogEntry, error) {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	events := []event.EventLogEntry{}
+	for _, e := range ac.AuditEvents {
+		data, ok := e.Data.(*event.AuditEventData)
+		if !ok {
+			continue
+		}
+		if (filter.User != "" && data.User != filter.User) ||
+			(filter.Method != "" && data.Method != filter.Method) ||
+			(filter.ResourceType != "" && data.ResourceType != filter.ResourceType) ||
+			(filter.ResourceId != "" && e.ResourceId != filter.ResourceId) ||
+			(!filter.After.IsZero() && e.Timestamp.Before(filter.After)) ||
+			(!filter.Before.IsZero() && !e.Timestamp.Before(filter.Before)) {
+			continue
+		}
+		events = append(events, e)
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+	}
+	return events, nil
+}
@@ -63,6 +63,7 @@ type MockBuildConnector struct {
 	CachedBuilds         []build.Build
 	CachedProjects       map[string]*model.ProjectRef
 	CachedAborted        map[string]string
+	CachedRestarted      map[string]string
 	FailOnChangePriority bool
 	FailOnAbort          bool
 	FailOnRestart        bool
@@ -120,10 +121,14 @@ func (bc *MockBuildConnector) SetBuildActivated(buildId string, user string, act
 	return nil
 }
 
-// RestartBuild does absolutely nothing
+// RestartBuild records the user that restarted the build in
+// CachedRestarted, if it is set.
 func (bc *MockBuildConnector) RestartBuild(buildId string, user string) error {
 	if bc.FailOnRestart {
 		return errors.New("manufactured error")
 	}
+	if bc.CachedRestarted != nil {
+		bc.CachedRestarted[buildId] = user
+	}
 	return nil
 }
@@ -0,0 +1,250 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+// DBCommitQueueConnector is a struct that implements the commit queue
+// related methods from the Connector through interactions with the
+// backing database.
+type DBCommitQueueConnector struct{}
+
+// GetCommitQueue returns the commit queue of the project, or an empty
+// queue if nothing has been enqueued for the project yet.
+func (cc *DBCommitQueueConnector) GetCommitQueue(projectID string) (*commitqueue.CommitQueue, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	cq, err := commitqueue.FindOneId(ref.Identifier)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if cq == nil {
+		cq = &commitqueue.CommitQueue{ProjectID: ref.Identifier, Queue: []commitqueue.CommitQueueItem{}}
+	}
+	return cq, nil
+}
+
+// EnqueueItem adds the pull request to the end of the commit queue of the
+// project, after checking that the pull request is open and targets the
+// project's branch. It returns the position of the pull request in the queue.
+func (cc *DBCommitQueueConnector) EnqueueItem(ctx context.Context, projectID, issue, author string) (int, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return 0, err
+	}
+	if !ref.CommitQueue.Enabled {
+		return 0, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("commit queue is not enabled for project '%s'", ref.Identifier),
+		}
+	}
+
+	prNum, err := strconv.Atoi(issue)
+	if err != nil || prNum <= 0 {
+		return 0, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("'%s' is not a valid pull request number", issue),
+		}
+	}
+
+	token, err := evergreen.GetEnvironment().Settings().GetGithubOauthToken()
+	if err != nil {
+		return 0, errors.Wrap(err, "can't get github oauth token")
+	}
+	pr, err := thirdparty.GetGithubPullRequest(ctx, token, ref.Owner, ref.Repo, prNum)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't get pull request %d", prNum)
+	}
+	if pr.GetState() != "open" {
+		return 0, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("pull request %d is not open", prNum),
+		}
+	}
+	if pr.GetBase().GetRef() != ref.Branch {
+		return 0, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message: fmt.Sprintf("pull request %d targets branch '%s', not the project's branch '%s'",
+				prNum, pr.GetBase().GetRef(), ref.Branch),
+		}
+	}
+
+	position, err := commitqueue.Enqueue(ref.Identifier, commitqueue.CommitQueueItem{
+		Issue:  issue,
+		Author: author,
+	})
+	if err != nil {
+		return 0, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return position, nil
+}
+
+// CommitQueueRemoveItem removes the pull request from the commit queue of
+// the project, aborting the patch that is testing it. It returns false if
+// the pull request was not queued.
+func (cc *DBCommitQueueConnector) CommitQueueRemoveItem(projectID, issue, user string) (bool, error) {
+	cq, err := cc.GetCommitQueue(projectID)
+	if err != nil {
+		return false, err
+	}
+
+	i := cq.FindItem(issue)
+	if i < 0 {
+		return false, nil
+	}
+	version := cq.Queue[i].Version
+
+	removed, err := cq.Remove(issue)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	if version != "" {
+		p, err := patch.FindOne(patch.ByVersion(version))
+		if err != nil {
+			return removed, errors.Wrapf(err, "problem finding patch for version '%s'", version)
+		}
+		if p != nil {
+			if err = model.CancelPatch(p, user); err != nil {
+				return removed, errors.Wrapf(err, "problem aborting patch '%s'", p.Id.Hex())
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// EnqueueFromComment adds the pull request of an issue comment event to
+// its project's commit queue, if the comment asks for it and was written
+// by a member of the organization that is allowed to create patches.
+func (cc *DBCommitQueueConnector) EnqueueFromComment(ctx context.Context, event *github.IssueCommentEvent) error {
+	if event.GetAction() != "created" || !event.GetIssue().IsPullRequest() {
+		return nil
+	}
+	if strings.TrimSpace(event.GetComment().GetBody()) != commitqueue.EnqueueComment {
+		return nil
+	}
+
+	owner := event.GetRepo().GetOwner().GetLogin()
+	repo := event.GetRepo().GetName()
+	prNum := event.GetIssue().GetNumber()
+	login := event.GetComment().GetUser().GetLogin()
+	if owner == "" || repo == "" || prNum == 0 || login == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "issue comment event is missing its repository, pull request, or author",
+		}
+	}
+
+	settings := evergreen.GetEnvironment().Settings()
+	if settings.GithubPRCreatorOrg == "" {
+		return errors.New("commit queue requires a Github org to authenticate against")
+	}
+	token, err := settings.GetGithubOauthToken()
+	if err != nil {
+		return errors.Wrap(err, "can't get github oauth token")
+	}
+
+	isMember, err := thirdparty.GithubUserInOrganization(ctx, token, settings.GithubPRCreatorOrg, login)
+	if err != nil {
+		return errors.Wrapf(err, "can't check whether '%s' is a member of '%s'", login, settings.GithubPRCreatorOrg)
+	}
+	if !isMember {
+		return &rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("'%s' is not allowed to enqueue pull requests", login),
+		}
+	}
+
+	pr, err := thirdparty.GetGithubPullRequest(ctx, token, owner, repo, prNum)
+	if err != nil {
+		return errors.Wrapf(err, "can't get pull request %d", prNum)
+	}
+	branch := pr.GetBase().GetRef()
+
+	ref, err := model.FindOneProjectRefWithCommitQueueByRepoAndBranch(owner, repo, branch)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if ref == nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("no project for '%s/%s' branch '%s' has the commit queue enabled", owner, repo, branch),
+		}
+	}
+
+	_, err = cc.EnqueueItem(ctx, ref.Identifier, strconv.Itoa(prNum), login)
+	return err
+}
+
+func findProjectRef(projectID string) (*model.ProjectRef, error) {
+	ref, err := model.FindOneProjectRef(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding project '%s'", projectID)
+	}
+	if ref == nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project with id '%s' not found", projectID),
+		}
+	}
+	return ref, nil
+}
+
+// MockCommitQueueConnector is a struct that implements mock versions of
+// the commit queue related methods for testing.
+type MockCommitQueueConnector struct {
+	Queue map[string][]commitqueue.CommitQueueItem
+}
+
+func (mc *MockCommitQueueConnector) GetCommitQueue(projectID string) (*commitqueue.CommitQueue, error) {
+	if mc.Queue == nil {
+		mc.Queue = map[string][]commitqueue.CommitQueueItem{}
+	}
+	return &commitqueue.CommitQueue{ProjectID: projectID, Queue: mc.Queue[projectID]}, nil
+}
+
+func (mc *MockCommitQueueConnector) EnqueueItem(ctx context.Context, projectID, issue, author string) (int, error) {
+	cq, _ := mc.GetCommitQueue(projectID)
+	if cq.FindItem(issue) >= 0 {
+		return 0, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("item '%s' is already in the commit queue", issue),
+		}
+	}
+	mc.Queue[projectID] = append(mc.Queue[projectID], commitqueue.CommitQueueItem{Issue: issue, Author: author})
+	return len(mc.Queue[projectID]) - 1, nil
+}
+
+func (mc *MockCommitQueueConnector) CommitQueueRemoveItem(projectID, issue, user string) (bool, error) {
+	cq, _ := mc.GetCommitQueue(projectID)
+	i := cq.FindItem(issue)
+	if i < 0 {
+		return false, nil
+	}
+	mc.Queue[projectID] = append(mc.Queue[projectID][:i], mc.Queue[projectID][i+1:]...)
+	return true, nil
+}
+
+func (mc *MockCommitQueueConnector) EnqueueFromComment(ctx context.Context, event *github.IssueCommentEvent) error {
+	return nil
+}
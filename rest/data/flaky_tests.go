@@ -0,0 +1,111 @@
+package data
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/pkg/errors"
+)
+
+// DBFlakyTestConnector is a struct that implements the flaky and
+// quarantined test related methods from the Connector through interactions
+// with the backing database.
+type DBFlakyTestConnector struct{}
+
+// FindFlakyTests returns the flaky tests of the project, most flaky first.
+func (fc *DBFlakyTestConnector) FindFlakyTests(projectID string) ([]flakytest.FlakyTest, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := flakytest.Find(flakytest.ByProject(ref.Identifier))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding flaky tests for project '%s'", projectID)
+	}
+	return tests, nil
+}
+
+// FindQuarantinedTests returns the quarantined tests of the project.
+func (fc *DBFlakyTestConnector) FindQuarantinedTests(projectID string) ([]model.QuarantinedTest, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if ref.QuarantinedTests == nil {
+		return []model.QuarantinedTest{}, nil
+	}
+	return ref.QuarantinedTests, nil
+}
+
+// AddQuarantinedTest quarantines a test of the project.
+func (fc *DBFlakyTestConnector) AddQuarantinedTest(projectID string, test model.QuarantinedTest) error {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return err
+	}
+	for _, q := range ref.QuarantinedTests {
+		if q.TestFile == test.TestFile && q.BuildVariant == test.BuildVariant {
+			return &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("test '%s' is already quarantined", test.TestFile),
+			}
+		}
+	}
+	return errors.WithStack(model.AddQuarantinedTest(ref.Identifier, test))
+}
+
+// RemoveQuarantinedTest removes the quarantine of a test of the project on
+// the build variant.
+func (fc *DBFlakyTestConnector) RemoveQuarantinedTest(projectID, testFile, buildVariant string) (bool, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return false, err
+	}
+	return ref.RemoveQuarantinedTest(testFile, buildVariant)
+}
+
+// MockFlakyTestConnector is a struct that implements mock versions of the
+// flaky and quarantined test related methods for testing.
+type MockFlakyTestConnector struct {
+	FlakyTests       map[string][]flakytest.FlakyTest
+	QuarantinedTests map[string][]model.QuarantinedTest
+}
+
+func (mc *MockFlakyTestConnector) FindFlakyTests(projectID string) ([]flakytest.FlakyTest, error) {
+	return mc.FlakyTests[projectID], nil
+}
+
+func (mc *MockFlakyTestConnector) FindQuarantinedTests(projectID string) ([]model.QuarantinedTest, error) {
+	return mc.QuarantinedTests[projectID], nil
+}
+
+func (mc *MockFlakyTestConnector) AddQuarantinedTest(projectID string, test model.QuarantinedTest) error {
+	if mc.QuarantinedTests == nil {
+		mc.QuarantinedTests = map[string][]model.QuarantinedTest{}
+	}
+	for _, q := range mc.QuarantinedTests[projectID] {
+		if q.TestFile == test.TestFile && q.BuildVariant == test.BuildVariant {
+			return &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("test '%s' is already quarantined", test.TestFile),
+			}
+		}
+	}
+	mc.QuarantinedTests[projectID] = append(mc.QuarantinedTests[projectID], test)
+	return nil
+}
+
+func (mc *MockFlakyTestConnector) RemoveQuarantinedTest(projectID, testFile, buildVariant string) (bool, error) {
+	tests := mc.QuarantinedTests[projectID]
+	for i, q := range tests {
+		if q.TestFile == testFile && q.BuildVariant == buildVariant {
+			mc.QuarantinedTests[projectID] = append(tests[:i], tests[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -27,6 +27,10 @@ type DBConnector struct {
 	RepoTrackerConnector
 	CLIUpdateConnector
 	GenerateConnector
+	DBCommitQueueConnector
+	DBFlakyTestConnector
+	DBNotificationTemplateConnector
+	DBRoleConnector
 }
 
 func (ctx *DBConnector) GetSuperUsers() []string   { return ctx.superUsers }
@@ -59,6 +63,10 @@ type MockConnector struct {
 	MockRepoTrackerConnector
 	MockCLIUpdateConnector
 	MockGenerateConnector
+	MockCommitQueueConnector
+	MockFlakyTestConnector
+	MockNotificationTemplateConnector
+	MockRoleConnector
 }
 
 func (ctx *MockConnector) GetSuperUsers() []string   { return ctx.superUsers }
@@ -9,9 +9,13 @@ import (
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/model/user"
@@ -131,6 +135,9 @@ type Connector interface {
 
 	// FindPatchById fetches the patch corresponding to the input patch ID.
 	FindPatchById(string) (*patch.Patch, error)
+	// FindPatchStack returns the summary of the stack that the patch with
+	// the input ID belongs to.
+	FindPatchStack(string) (*model.PatchStackSummary, error)
 
 	// AbortVersion aborts all tasks of a version given its ID.
 	AbortVersion(string) error
@@ -160,6 +167,9 @@ type Connector interface {
 	RestartFailedTasks(amboy.Queue, model.RestartTaskOptions) (*restModel.RestartTasksResponse, error)
 	RevertConfigTo(string, string) error
 	GetAdminEventLog(time.Time, int) ([]restModel.APIAdminEvent, error)
+	// FindAuditEvents returns the audit events matching the filter, most
+	// recent first.
+	FindAuditEvents(event.AuditFilter) ([]event.EventLogEntry, error)
 
 	FindCostTaskByProject(string, string, time.Time, time.Time, int, int) ([]task.Task, error)
 
@@ -171,6 +181,15 @@ type Connector interface {
 	AddPublicKey(*user.DBUser, string, string) error
 	DeletePublicKey(*user.DBUser, string) error
 
+	// AddAPIToken adds an API token to the user and returns the secret
+	// that authenticates with it.
+	AddAPIToken(*user.DBUser, user.APIToken) (string, error)
+	// DeleteAPIToken revokes the API token of the user with the name.
+	DeleteAPIToken(*user.DBUser, string) error
+	// MarkAPITokenUsed records that the API token of the user with the
+	// name was used.
+	MarkAPITokenUsed(*user.DBUser, string) error
+
 	AddPatchIntent(patch.Intent, amboy.Queue) error
 
 	SetHostStatus(*host.Host, string, string) error
@@ -191,4 +210,63 @@ type Connector interface {
 
 	// GenerateTasks parses JSON files for `generate.tasks` and creates the new builds and tasks.
 	GenerateTasks(string, []json.RawMessage) error
+
+	// GetCommitQueue returns the commit queue of a project.
+	GetCommitQueue(string) (*commitqueue.CommitQueue, error)
+	// EnqueueItem adds a pull request to the commit queue of a project and
+	// returns its position in the queue.
+	EnqueueItem(context.Context, string, string, string) (int, error)
+	// CommitQueueRemoveItem removes a pull request from the commit queue of
+	// a project and aborts the patch testing it.
+	CommitQueueRemoveItem(string, string, string) (bool, error)
+	// EnqueueFromComment adds the pull request of a Github issue comment
+	// event to its project's commit queue.
+	EnqueueFromComment(context.Context, *github.IssueCommentEvent) error
+
+	// FindFlakyTests returns the flaky tests of a project, most flaky first.
+	FindFlakyTests(string) ([]flakytest.FlakyTest, error)
+	// FindQuarantinedTests returns the quarantined tests of a project.
+	FindQuarantinedTests(string) ([]model.QuarantinedTest, error)
+	// AddQuarantinedTest quarantines a test of a project.
+	AddQuarantinedTest(string, model.QuarantinedTest) error
+	// RemoveQuarantinedTest removes the quarantine of a test of a project
+	// on a build variant. It returns false if the test was not quarantined.
+	RemoveQuarantinedTest(string, string, string) (bool, error)
+
+	// FindNotificationTemplates returns the notification templates of a
+	// project.
+	FindNotificationTemplates(string) ([]model.NotificationTemplate, error)
+	// SetNotificationTemplate validates a notification template and saves
+	// it to a project, replacing the project's template for the same
+	// trigger and subscriber type.
+	SetNotificationTemplate(string, model.NotificationTemplate) error
+	// RemoveNotificationTemplate removes a project's notification template
+	// for a trigger and subscriber type. It returns false if the project
+	// has no such template.
+	RemoveNotificationTemplate(string, string, string) (bool, error)
+	// PreviewNotificationTemplate renders a notification template for the
+	// event with the id, which must be about the project, and returns the
+	// subject and body.
+	PreviewNotificationTemplate(string, string, model.NotificationTemplate) (string, string, error)
+
+	// FindRoles returns all roles.
+	FindRoles() ([]role.Role, error)
+	// UpsertRole creates a role or replaces the role with the same id.
+	UpsertRole(role.Role) error
+	// DeleteRole removes a role and its assignments. It returns false if
+	// there is no such role.
+	DeleteRole(string) (bool, error)
+	// FindRoleAssignments returns the role assignments of a user or group,
+	// or all role assignments if it is empty.
+	FindRoleAssignments(string) ([]role.Assignment, error)
+	// AddRoleAssignment assigns an existing role, setting the id of the
+	// assignment.
+	AddRoleAssignment(*role.Assignment) error
+	// DeleteRoleAssignment removes a role assignment. It returns false if
+	// there is no such assignment.
+	DeleteRoleAssignment(string) (bool, error)
+	// UserHasPermission returns whether a role assigned to the user, or to
+	// one of their groups, grants the permission on the resource of the
+	// type with the id.
+	UserHasPermission(auth.User, string, string, string) (bool, error)
 }
@@ -0,0 +1,189 @@
+package data
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/pkg/errors"
+)
+
+// DBNotificationTemplateConnector is a struct that implements the
+// notification template related methods from the Connector through
+// interactions with the backing database.
+type DBNotificationTemplateConnector struct{}
+
+// FindNotificationTemplates returns the notification templates of the
+// project.
+func (nc *DBNotificationTemplateConnector) FindNotificationTemplates(projectID string) ([]model.NotificationTemplate, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if ref.NotificationTemplates == nil {
+		return []model.NotificationTemplate{}, nil
+	}
+	return ref.NotificationTemplates, nil
+}
+
+// SetNotificationTemplate validates the template and saves it to the
+// project.
+func (nc *DBNotificationTemplateConnector) SetNotificationTemplate(projectID string, t model.NotificationTemplate) error {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return err
+	}
+	if err = t.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return errors.WithStack(model.SetNotificationTemplate(ref.Identifier, t))
+}
+
+// RemoveNotificationTemplate removes the project's notification template for
+// the trigger and subscriber type.
+func (nc *DBNotificationTemplateConnector) RemoveNotificationTemplate(projectID, trigger, subscriberType string) (bool, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return false, err
+	}
+	return ref.RemoveNotificationTemplate(trigger, subscriberType)
+}
+
+// PreviewNotificationTemplate renders the template for the event with the
+// id. If the template has no body, the project's saved template for its
+// trigger and subscriber type is rendered instead.
+func (nc *DBNotificationTemplateConnector) PreviewNotificationTemplate(projectID, eventID string, t model.NotificationTemplate) (string, string, error) {
+	ref, err := findProjectRef(projectID)
+	if err != nil {
+		return "", "", err
+	}
+	if t.Body == "" {
+		saved := ref.GetNotificationTemplate(t.Trigger, t.SubscriberType)
+		if saved == nil {
+			return "", "", &rest.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("project '%s' has no notification template for '%s' to %s", projectID, t.Trigger, t.SubscriberType),
+			}
+		}
+		t = *saved
+	}
+	if err = t.Validate(); err != nil {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	data, err := model.NotificationTemplateDataForEvent(eventID)
+	if err != nil {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	if data == nil || data.Project == nil || data.Project.Identifier != ref.Identifier {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("event '%s' of project '%s' not found", eventID, projectID),
+		}
+	}
+	data.Trigger = t.Trigger
+	data.UIRoot = evergreen.GetEnvironment().Settings().Ui.Url
+
+	subject, body, err := t.Render(*data)
+	if err != nil {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return subject, body, nil
+}
+
+// MockNotificationTemplateConnector is a struct that implements mock
+// versions of the notification template related methods for testing.
+type MockNotificationTemplateConnector struct {
+	NotificationTemplates map[string][]model.NotificationTemplate
+	// TemplateData is the data that templates are rendered with for
+	// each event id.
+	TemplateData map[string]model.NotificationTemplateData
+}
+
+func (mc *MockNotificationTemplateConnector) FindNotificationTemplates(projectID string) ([]model.NotificationTemplate, error) {
+	return mc.NotificationTemplates[projectID], nil
+}
+
+func (mc *MockNotificationTemplateConnector) SetNotificationTemplate(projectID string, t model.NotificationTemplate) error {
+	if err := t.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	if mc.NotificationTemplates == nil {
+		mc.NotificationTemplates = map[string][]model.NotificationTemplate{}
+	}
+	templates := mc.NotificationTemplates[projectID]
+	for i := range templates {
+		if templates[i].Trigger == t.Trigger && templates[i].SubscriberType == t.SubscriberType {
+			templates[i] = t
+			return nil
+		}
+	}
+	mc.NotificationTemplates[projectID] = append(templates, t)
+	return nil
+}
+
+func (mc *MockNotificationTemplateConnector) RemoveNotificationTemplate(projectID, trigger, subscriberType string) (bool, error) {
+	templates := mc.NotificationTemplates[projectID]
+	for i, t := range templates {
+		if t.Trigger == trigger && t.SubscriberType == subscriberType {
+			mc.NotificationTemplates[projectID] = append(templates[:i], templates[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (mc *MockNotificationTemplateConnector) PreviewNotificationTemplate(projectID, eventID string, t model.NotificationTemplate) (string, string, error) {
+	if t.Body == "" {
+		ref := model.ProjectRef{NotificationTemplates: mc.NotificationTemplates[projectID]}
+		saved := ref.GetNotificationTemplate(t.Trigger, t.SubscriberType)
+		if saved == nil {
+			return "", "", &rest.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("project '%s' has no notification template for '%s' to %s", projectID, t.Trigger, t.SubscriberType),
+			}
+		}
+		t = *saved
+	}
+	if err := t.Validate(); err != nil {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	data, ok := mc.TemplateData[eventID]
+	if !ok || data.Project == nil || data.Project.Identifier != projectID {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("event '%s' of project '%s' not found", eventID, projectID),
+		}
+	}
+	data.Trigger = t.Trigger
+
+	subject, body, err := t.Render(data)
+	if err != nil {
+		return "", "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return subject, body, nil
+}
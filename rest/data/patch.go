@@ -44,6 +44,32 @@ func (pc *DBPatchConnector) FindPatchById(patchId string) (*patch.Patch, error)
 	return p, nil
 }
 
+// FindPatchStack finds the patch matching patchId and summarizes the stack
+// of patches that it belongs to.
+func (pc *DBPatchConnector) FindPatchStack(patchId string) (*model.PatchStackSummary, error) {
+	p, err := pc.FindPatchById(patchId)
+	if err != nil {
+		return nil, err
+	}
+	if p.Stack == nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("patch with id %s is not part of a stack", patchId),
+		}
+	}
+	summary, err := model.GetPatchStackSummary(p.Stack.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem summarizing stack of patch %s", patchId)
+	}
+	if summary == nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("stack %s not found", p.Stack.ID),
+		}
+	}
+	return summary, nil
+}
+
 // AbortPatch uses the service level CancelPatch method to abort a single patch
 // with matching Id.
 func (pc *DBPatchConnector) AbortPatch(patchId string, user string) error {
@@ -110,9 +136,10 @@ func (p *DBPatchConnector) AbortPatchesFromPullRequest(event *github.PullRequest
 // MockPatchConnector is a struct that implements the Patch related methods
 // from the Connector through interactions with he backing database.
 type MockPatchConnector struct {
-	CachedPatches  []patch.Patch
-	CachedAborted  map[string]string
-	CachedPriority map[string]int64
+	CachedPatches     []patch.Patch
+	CachedAborted     map[string]string
+	CachedPriority    map[string]int64
+	CachedPatchStacks map[string]model.PatchStackSummary
 }
 
 // FindPatchesByProject queries the cached patches splice for the matching patches.
@@ -159,6 +186,29 @@ func (pc *MockPatchConnector) FindPatchById(patchId string) (*patch.Patch, error
 	}
 }
 
+// FindPatchStack finds the matching patch in CachedPatches and returns the
+// summary of its stack from CachedPatchStacks.
+func (pc *MockPatchConnector) FindPatchStack(patchId string) (*model.PatchStackSummary, error) {
+	p, err := pc.FindPatchById(patchId)
+	if err != nil {
+		return nil, err
+	}
+	if p.Stack == nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("patch with id %s is not part of a stack", patchId),
+		}
+	}
+	summary, ok := pc.CachedPatchStacks[p.Stack.ID]
+	if !ok {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("stack %s not found", p.Stack.ID),
+		}
+	}
+	return &summary, nil
+}
+
 // AbortPatch sets the value of patchId in CachedAborted to user.
 func (pc *MockPatchConnector) AbortPatch(patchId string, user string) error {
 	var foundPatch *patch.Patch
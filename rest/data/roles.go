@@ -0,0 +1,169 @@
+package data
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/rest"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DBRoleConnector is a struct that implements the role related methods
+// from the Connector through interactions with the backing database.
+type DBRoleConnector struct{}
+
+// FindRoles returns all roles.
+func (rc *DBRoleConnector) FindRoles() ([]role.Role, error) {
+	return role.FindAll()
+}
+
+// UpsertRole creates the role or replaces the role with the same id.
+func (rc *DBRoleConnector) UpsertRole(r role.Role) error {
+	if err := r.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return r.Upsert()
+}
+
+// DeleteRole removes the role and its assignments.
+func (rc *DBRoleConnector) DeleteRole(id string) (bool, error) {
+	return role.Remove(id)
+}
+
+// FindRoleAssignments returns the role assignments of the subject, or all
+// role assignments if the subject is empty.
+func (rc *DBRoleConnector) FindRoleAssignments(subject string) ([]role.Assignment, error) {
+	return role.FindAssignments(role.AssignmentsBySubject(subject))
+}
+
+// AddRoleAssignment assigns an existing role.
+func (rc *DBRoleConnector) AddRoleAssignment(a *role.Assignment) error {
+	if err := a.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	r, err := role.FindOne(a.Role)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("role '%s' does not exist", a.Role),
+		}
+	}
+	return a.Insert()
+}
+
+// DeleteRoleAssignment removes a role assignment.
+func (rc *DBRoleConnector) DeleteRoleAssignment(id string) (bool, error) {
+	return role.RemoveAssignment(id)
+}
+
+// UserHasPermission returns whether a role assigned to the user grants the
+// permission on the resource.
+func (rc *DBRoleConnector) UserHasPermission(u auth.User, resourceType, resourceId, permission string) (bool, error) {
+	return role.UserHasPermission(u, resourceType, resourceId, permission)
+}
+
+// MockRoleConnector is a struct that implements mock versions of the role
+// related methods for testing.
+type MockRoleConnector struct {
+	Roles       []role.Role
+	Assignments []role.Assignment
+}
+
+func (mc *MockRoleConnector) FindRoles() ([]role.Role, error) {
+	return mc.Roles, nil
+}
+
+func (mc *MockRoleConnector) UpsertRole(r role.Role) error {
+	if err := r.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	for i := range mc.Roles {
+		if mc.Roles[i].Id == r.Id {
+			mc.Roles[i] = r
+			return nil
+		}
+	}
+	mc.Roles = append(mc.Roles, r)
+	return nil
+}
+
+func (mc *MockRoleConnector) DeleteRole(id string) (bool, error) {
+	for i := range mc.Roles {
+		if mc.Roles[i].Id != id {
+			continue
+		}
+		mc.Roles = append(mc.Roles[:i], mc.Roles[i+1:]...)
+		assignments := []role.Assignment{}
+		for _, a := range mc.Assignments {
+			if a.Role != id {
+				assignments = append(assignments, a)
+			}
+		}
+		mc.Assignments = assignments
+		return true, nil
+	}
+	return false, nil
+}
+
+func (mc *MockRoleConnector) FindRoleAssignments(subject string) ([]role.Assignment, error) {
+	assignments := []role.Assignment{}
+	for _, a := range mc.Assignments {
+		if subject == "" || a.Subject == subject {
+			assignments = append(assignments, a)
+		}
+	}
+	return assignments, nil
+}
+
+func (mc *MockRoleConnector) AddRoleAssignment(a *role.Assignment) error {
+	if err := a.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	for _, r := range mc.Roles {
+		if r.Id == a.Role {
+			if a.Id == "" {
+				a.Id = bson.NewObjectId().Hex()
+			}
+			mc.Assignments = append(mc.Assignments, *a)
+			return nil
+		}
+	}
+	return &rest.APIError{
+		StatusCode: http.StatusBadRequest,
+		Message:    fmt.Sprintf("role '%s' does not exist", a.Role),
+	}
+}
+
+func (mc *MockRoleConnector) DeleteRoleAssignment(id string) (bool, error) {
+	for i := range mc.Assignments {
+		if mc.Assignments[i].Id == id {
+			mc.Assignments = append(mc.Assignments[:i], mc.Assignments[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (mc *MockRoleConnector) UserHasPermission(u auth.User, resourceType, resourceId, permission string) (bool, error) {
+	if u == nil || u.IsNil() {
+		return false, nil
+	}
+	return role.HasPermission(mc.Roles, mc.Assignments, auth.UserSubjects(u), resourceType, resourceId, permission), nil
+}
@@ -31,6 +31,21 @@ func (u *DBUserConnector) DeletePublicKey(user *user.DBUser, keyName string) err
 	return user.DeletePublicKey(keyName)
 }
 
+// AddAPIToken adds an API token to the user and returns its secret.
+func (u *DBUserConnector) AddAPIToken(user *user.DBUser, token user.APIToken) (string, error) {
+	return user.AddAPIToken(token)
+}
+
+// DeleteAPIToken revokes an API token of the user.
+func (u *DBUserConnector) DeleteAPIToken(user *user.DBUser, name string) error {
+	return user.DeleteAPIToken(name)
+}
+
+// MarkAPITokenUsed records the use of an API token of the user.
+func (u *DBUserConnector) MarkAPITokenUsed(user *user.DBUser, name string) error {
+	return user.MarkAPITokenUsed(name)
+}
+
 // MockUserConnector stores a cached set of users that are queried against by the
 // implementations of the UserConnector interface's functions.
 type MockUserConnector struct {
@@ -86,3 +101,45 @@ func (muc *MockUserConnector) DeletePublicKey(u *user.DBUser, keyName string) er
 	cu.PubKeys = newKeys
 	return nil
 }
+
+func (muc *MockUserConnector) AddAPIToken(dbuser *user.DBUser, token user.APIToken) (string, error) {
+	u, ok := muc.CachedUsers[dbuser.Id]
+	if !ok {
+		return "", errors.Errorf("User '%s' doesn't exist", dbuser.Id)
+	}
+	if u.GetAPIToken(token.Name) != nil {
+		return "", errors.Errorf("API token '%s' already exists", token.Name)
+	}
+
+	token, secret, err := user.NewAPIToken(token)
+	if err != nil {
+		return "", err
+	}
+	u.APITokens = append(u.APITokens, token)
+	return secret, nil
+}
+
+func (muc *MockUserConnector) DeleteAPIToken(dbuser *user.DBUser, name string) error {
+	u, ok := muc.CachedUsers[dbuser.Id]
+	if !ok {
+		return errors.Errorf("User '%s' doesn't exist", dbuser.Id)
+	}
+	for i, t := range u.APITokens {
+		if t.Name == name {
+			u.APITokens = append(u.APITokens[:i], u.APITokens[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("API token '%s' does not exist", name)
+}
+
+func (muc *MockUserConnector) MarkAPITokenUsed(dbuser *user.DBUser, name string) error {
+	u, ok := muc.CachedUsers[dbuser.Id]
+	if !ok {
+		return errors.Errorf("User '%s' doesn't exist", dbuser.Id)
+	}
+	if t := u.GetAPIToken(name); t != nil {
+		t.LastUsedAt = time.Now()
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ func NewConfigModel() *APIAdminSettings {
 		AuthConfig:   &APIAuthConfig{},
 		Credentials:  map[string]string{},
 		Expansions:   map[string]string{},
+		GithubApp:    &APIGithubAppConfig{},
 		HostInit:     &APIHostInitConfig{},
 		Jira:         &APIJiraConfig{},
 		Keys:         map[string]string{},
@@ -48,6 +49,7 @@ type APIAdminSettings struct {
 	ConfigDir          APIString                         `json:"configdir,omitempty"`
 	Credentials        map[string]string                 `json:"credentials,omitempty"`
 	Expansions         map[string]string                 `json:"expansions,omitempty"`
+	GithubApp          *APIGithubAppConfig               `json:"github_app,omitempty"`
 	GithubPRCreatorOrg APIString                         `json:"github_pr_creator_org,omitempty"`
 	HostInit           *APIHostInitConfig                `json:"hostinit,omitempty"`
 	IsNonProd          *bool                             `json:"isnonprod,omitempty"`
@@ -335,6 +337,8 @@ type APIAuthConfig struct {
 	Crowd  *APICrowdConfig      `json:"crowd"`
 	Naive  *APINaiveAuthConfig  `json:"naive"`
 	Github *APIGithubAuthConfig `json:"github"`
+	OIDC   *APIOIDCAuthConfig   `json:"oidc"`
+	LDAP   *APILDAPConfig       `json:"ldap"`
 }
 
 func (a *APIAuthConfig) BuildFromService(h interface{}) error {
@@ -358,6 +362,18 @@ func (a *APIAuthConfig) BuildFromService(h interface{}) error {
 				return err
 			}
 		}
+		if v.OIDC != nil {
+			a.OIDC = &APIOIDCAuthConfig{}
+			if err := a.OIDC.BuildFromService(v.OIDC); err != nil {
+				return err
+			}
+		}
+		if v.LDAP != nil {
+			a.LDAP = &APILDAPConfig{}
+			if err := a.LDAP.BuildFromService(v.LDAP); err != nil {
+				return err
+			}
+		}
 	default:
 		return errors.Errorf("%T is not a supported type", h)
 	}
@@ -368,6 +384,8 @@ func (a *APIAuthConfig) ToService() (interface{}, error) {
 	var crowd *evergreen.CrowdConfig
 	var naive *evergreen.NaiveAuthConfig
 	var github *evergreen.GithubAuthConfig
+	var oidc *evergreen.OIDCAuthConfig
+	var ldap *evergreen.LDAPConfig
 	i, err := a.Crowd.ToService()
 	if err != nil {
 		return nil, err
@@ -389,10 +407,26 @@ func (a *APIAuthConfig) ToService() (interface{}, error) {
 	if i != nil {
 		github = i.(*evergreen.GithubAuthConfig)
 	}
+	i, err = a.OIDC.ToService()
+	if err != nil {
+		return nil, err
+	}
+	if i != nil {
+		oidc = i.(*evergreen.OIDCAuthConfig)
+	}
+	i, err = a.LDAP.ToService()
+	if err != nil {
+		return nil, err
+	}
+	if i != nil {
+		ldap = i.(*evergreen.LDAPConfig)
+	}
 	return evergreen.AuthConfig{
 		Crowd:  crowd,
 		Naive:  naive,
 		Github: github,
+		OIDC:   oidc,
+		LDAP:   ldap,
 	}, nil
 }
 
@@ -542,12 +576,149 @@ func (a *APIGithubAuthConfig) ToService() (interface{}, error) {
 	return &config, nil
 }
 
+type APIOIDCAuthConfig struct {
+	Issuer           APIString   `json:"issuer"`
+	ClientId         APIString   `json:"client_id"`
+	ClientSecret     APIString   `json:"client_secret"`
+	Scopes           []APIString `json:"scopes"`
+	UsernameClaim    APIString   `json:"username_claim"`
+	EmailClaim       APIString   `json:"email_claim"`
+	DisplayNameClaim APIString   `json:"display_name_claim"`
+}
+
+func (a *APIOIDCAuthConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *evergreen.OIDCAuthConfig:
+		if v == nil {
+			return nil
+		}
+		a.Issuer = ToAPIString(v.Issuer)
+		a.ClientId = ToAPIString(v.ClientId)
+		a.ClientSecret = ToAPIString(v.ClientSecret)
+		a.UsernameClaim = ToAPIString(v.UsernameClaim)
+		a.EmailClaim = ToAPIString(v.EmailClaim)
+		a.DisplayNameClaim = ToAPIString(v.DisplayNameClaim)
+		for _, s := range v.Scopes {
+			a.Scopes = append(a.Scopes, ToAPIString(s))
+		}
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIOIDCAuthConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	config := evergreen.OIDCAuthConfig{
+		Issuer:           FromAPIString(a.Issuer),
+		ClientId:         FromAPIString(a.ClientId),
+		ClientSecret:     FromAPIString(a.ClientSecret),
+		UsernameClaim:    FromAPIString(a.UsernameClaim),
+		EmailClaim:       FromAPIString(a.EmailClaim),
+		DisplayNameClaim: FromAPIString(a.DisplayNameClaim),
+	}
+	for _, s := range a.Scopes {
+		config.Scopes = append(config.Scopes, FromAPIString(s))
+	}
+	return &config, nil
+}
+
+type APILDAPConfig struct {
+	URL                  APIString `json:"url"`
+	BindDN               APIString `json:"bind_dn"`
+	BindPassword         APIString `json:"bind_password"`
+	UserSearchBase       APIString `json:"user_search_base"`
+	UserAttribute        APIString `json:"user_attribute"`
+	EmailAttribute       APIString `json:"email_attribute"`
+	DisplayNameAttribute APIString `json:"display_name_attribute"`
+	GroupSearchBase      APIString `json:"group_search_base"`
+	GroupMemberAttribute APIString `json:"group_member_attribute"`
+	GroupNameAttribute   APIString `json:"group_name_attribute"`
+	GroupCacheMinutes    int       `json:"group_cache_minutes"`
+	SessionSecret        APIString `json:"session_secret"`
+	SessionHours         int       `json:"session_hours"`
+}
+
+func (a *APILDAPConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *evergreen.LDAPConfig:
+		if v == nil {
+			return nil
+		}
+		a.URL = ToAPIString(v.URL)
+		a.BindDN = ToAPIString(v.BindDN)
+		a.BindPassword = ToAPIString(v.BindPassword)
+		a.UserSearchBase = ToAPIString(v.UserSearchBase)
+		a.UserAttribute = ToAPIString(v.UserAttribute)
+		a.EmailAttribute = ToAPIString(v.EmailAttribute)
+		a.DisplayNameAttribute = ToAPIString(v.DisplayNameAttribute)
+		a.GroupSearchBase = ToAPIString(v.GroupSearchBase)
+		a.GroupMemberAttribute = ToAPIString(v.GroupMemberAttribute)
+		a.GroupNameAttribute = ToAPIString(v.GroupNameAttribute)
+		a.GroupCacheMinutes = v.GroupCacheMinutes
+		a.SessionSecret = ToAPIString(v.SessionSecret)
+		a.SessionHours = v.SessionHours
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APILDAPConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return &evergreen.LDAPConfig{
+		URL:                  FromAPIString(a.URL),
+		BindDN:               FromAPIString(a.BindDN),
+		BindPassword:         FromAPIString(a.BindPassword),
+		UserSearchBase:       FromAPIString(a.UserSearchBase),
+		UserAttribute:        FromAPIString(a.UserAttribute),
+		EmailAttribute:       FromAPIString(a.EmailAttribute),
+		DisplayNameAttribute: FromAPIString(a.DisplayNameAttribute),
+		GroupSearchBase:      FromAPIString(a.GroupSearchBase),
+		GroupMemberAttribute: FromAPIString(a.GroupMemberAttribute),
+		GroupNameAttribute:   FromAPIString(a.GroupNameAttribute),
+		GroupCacheMinutes:    a.GroupCacheMinutes,
+		SessionSecret:        FromAPIString(a.SessionSecret),
+		SessionHours:         a.SessionHours,
+	}, nil
+}
+
 // APIBanner is a public structure representing the banner part of the admin settings
 type APIBanner struct {
 	Text  APIString `json:"banner"`
 	Theme APIString `json:"theme"`
 }
 
+type APIGithubAppConfig struct {
+	AppID         int64     `json:"app_id"`
+	PrivateKey    APIString `json:"private_key"`
+	WebhookSecret APIString `json:"webhook_secret"`
+}
+
+func (a *APIGithubAppConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.GithubAppConfig:
+		a.AppID = v.AppID
+		a.PrivateKey = ToAPIString(v.PrivateKey)
+		a.WebhookSecret = ToAPIString(v.WebhookSecret)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIGithubAppConfig) ToService() (interface{}, error) {
+	return evergreen.GithubAppConfig{
+		AppID:         a.AppID,
+		PrivateKey:    FromAPIString(a.PrivateKey),
+		WebhookSecret: FromAPIString(a.WebhookSecret),
+	}, nil
+}
+
 type APIHostInitConfig struct {
 	SSHTimeoutSeconds int64 `json:"ssh_timeout_secs"`
 }
@@ -980,12 +1151,14 @@ type APIServiceFlags struct {
 	CLIUpdatesDisabled           bool `json:"cli_updates_disabled"`
 	GithubStatusAPIDisabled      bool `bson:"github_status_api_disabled" json:"github_status_api_disabled"`
 	BackgroundStatsDisabled      bool `bson:"background_stats_disabled" json:"background_stats_disabled"`
+	CommitQueueDisabled          bool `bson:"commit_queue_disabled" json:"commit_queue_disabled"`
 }
 
 type APISlackConfig struct {
-	Options *APISlackOptions `json:"options"`
-	Token   APIString        `json:"token"`
-	Level   APIString        `json:"level"`
+	Options       *APISlackOptions `json:"options"`
+	Token         APIString        `json:"token"`
+	Level         APIString        `json:"level"`
+	SigningSecret APIString        `json:"signing_secret"`
 }
 
 func (a *APISlackConfig) BuildFromService(h interface{}) error {
@@ -993,6 +1166,7 @@ func (a *APISlackConfig) BuildFromService(h interface{}) error {
 	case evergreen.SlackConfig:
 		a.Token = ToAPIString(v.Token)
 		a.Level = ToAPIString(v.Level)
+		a.SigningSecret = ToAPIString(v.SigningSecret)
 		if v.Options != nil {
 			a.Options = &APISlackOptions{}
 			if err := a.Options.BuildFromService(*v.Options); err != nil { //nolint: vet
@@ -1012,9 +1186,10 @@ func (a *APISlackConfig) ToService() (interface{}, error) {
 	}
 	options := i.(send.SlackOptions) //nolint: vet
 	return evergreen.SlackConfig{
-		Token:   FromAPIString(a.Token),
-		Level:   FromAPIString(a.Level),
-		Options: &options,
+		Token:         FromAPIString(a.Token),
+		Level:         FromAPIString(a.Level),
+		SigningSecret: FromAPIString(a.SigningSecret),
+		Options:       &options,
 	}, nil
 }
 
@@ -1165,6 +1340,7 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.CLIUpdatesDisabled = v.CLIUpdatesDisabled
 		as.GithubStatusAPIDisabled = v.GithubStatusAPIDisabled
 		as.BackgroundStatsDisabled = v.BackgroundStatsDisabled
+		as.CommitQueueDisabled = v.CommitQueueDisabled
 	default:
 		return errors.Errorf("%T is not a supported service flags type", h)
 	}
@@ -1186,6 +1362,7 @@ func (as *APIServiceFlags) ToService() (interface{}, error) {
 		CLIUpdatesDisabled:           as.CLIUpdatesDisabled,
 		GithubStatusAPIDisabled:      as.GithubStatusAPIDisabled,
 		BackgroundStatsDisabled:      as.BackgroundStatsDisabled,
+		CommitQueueDisabled:          as.CommitQueueDisabled,
 	}, nil
 }
 
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+// APIAuditEvent is a request by a user that changed something.
+type APIAuditEvent struct {
+	Timestamp    time.Time   `json:"ts"`
+	User         string      `json:"user"`
+	APIToken     string      `json:"api_token,omitempty"`
+	Method       string      `json:"method"`
+	Path         string      `json:"path"`
+	Status       int         `json:"status"`
+	ResourceType string      `json:"resource_type,omitempty"`
+	ResourceId   string      `json:"resource_id,omitempty"`
+	Before       interface{} `json:"before,omitempty"`
+	After        interface{} `json:"after,omitempty"`
+}
+
+func (e *APIAuditEvent) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case event.EventLogEntry:
+		data, ok := v.Data.(*event.AuditEventData)
+		if !ok {
+			return errors.New("unable to convert event type to audit event")
+		}
+		e.Timestamp = v.Timestamp
+		e.ResourceId = v.ResourceId
+		e.User = data.User
+		e.APIToken = data.APIToken
+		e.Method = data.Method
+		e.Path = data.Path
+		e.Status = data.Status
+		e.ResourceType = data.ResourceType
+		e.Before = data.Before
+		e.After = data.After
+	default:
+		return fmt.Errorf("%T is not the correct event type", h)
+	}
+
+	return nil
+}
+
+func (e *APIAuditEvent) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APIAuditEvent")
+}
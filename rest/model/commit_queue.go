@@ -0,0 +1,97 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/pkg/errors"
+)
+
+// APICommitQueue is the model to be returned by the API whenever a
+// project's commit queue is fetched.
+type APICommitQueue struct {
+	ProjectID APIString            `json:"project_id"`
+	Queue     []APICommitQueueItem `json:"queue"`
+}
+
+// APICommitQueueItem is a pull request in a commit queue.
+type APICommitQueueItem struct {
+	Issue       APIString `json:"issue"`
+	Author      APIString `json:"author"`
+	EnqueueTime APITime   `json:"enqueue_time"`
+	Version     APIString `json:"version"`
+}
+
+// BuildFromService converts from a service level commit queue to an
+// APICommitQueue.
+func (cq *APICommitQueue) BuildFromService(h interface{}) error {
+	var queue *commitqueue.CommitQueue
+	switch v := h.(type) {
+	case commitqueue.CommitQueue:
+		queue = &v
+	case *commitqueue.CommitQueue:
+		queue = v
+	default:
+		return errors.Errorf("incorrect type when converting commit queue type")
+	}
+
+	cq.ProjectID = ToAPIString(queue.ProjectID)
+	cq.Queue = make([]APICommitQueueItem, 0, len(queue.Queue))
+	for _, item := range queue.Queue {
+		apiItem := APICommitQueueItem{}
+		if err := apiItem.BuildFromService(item); err != nil {
+			return errors.WithStack(err)
+		}
+		cq.Queue = append(cq.Queue, apiItem)
+	}
+	return nil
+}
+
+// ToService is not implemented for APICommitQueue.
+func (cq *APICommitQueue) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APICommitQueue")
+}
+
+// BuildFromService converts from a service level commit queue item to an
+// APICommitQueueItem.
+func (item *APICommitQueueItem) BuildFromService(h interface{}) error {
+	v, ok := h.(commitqueue.CommitQueueItem)
+	if !ok {
+		return errors.Errorf("incorrect type when converting commit queue item type")
+	}
+
+	item.Issue = ToAPIString(v.Issue)
+	item.Author = ToAPIString(v.Author)
+	item.EnqueueTime = NewTime(v.EnqueueTime)
+	item.Version = ToAPIString(v.Version)
+	return nil
+}
+
+// ToService returns a service layer commit queue item using the data
+// from the APICommitQueueItem.
+func (item *APICommitQueueItem) ToService() (interface{}, error) {
+	return commitqueue.CommitQueueItem{
+		Issue:  FromAPIString(item.Issue),
+		Author: FromAPIString(item.Author),
+	}, nil
+}
+
+// APICommitQueuePosition is the position of a pull request in a commit
+// queue after it has been enqueued.
+type APICommitQueuePosition struct {
+	Position int `json:"position"`
+}
+
+// BuildFromService converts from a queue position to an
+// APICommitQueuePosition.
+func (pos *APICommitQueuePosition) BuildFromService(h interface{}) error {
+	v, ok := h.(int)
+	if !ok {
+		return errors.Errorf("incorrect type when converting commit queue position type")
+	}
+	pos.Position = v
+	return nil
+}
+
+// ToService is not implemented for APICommitQueuePosition.
+func (pos *APICommitQueuePosition) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APICommitQueuePosition")
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/pkg/errors"
+)
+
+// APIFlakyTest is the model to be returned by the API whenever a
+// project's flaky tests are fetched.
+type APIFlakyTest struct {
+	BuildVariant      APIString `json:"build_variant"`
+	TestFile          APIString `json:"test_file"`
+	Revisions         int       `json:"revisions"`
+	FlakyRevisions    int       `json:"flaky_revisions"`
+	Score             float64   `json:"score"`
+	LastFlakyRevision APIString `json:"last_flaky_revision"`
+	UpdatedAt         APITime   `json:"updated_at"`
+}
+
+// BuildFromService converts from a service level flaky test to an
+// APIFlakyTest.
+func (t *APIFlakyTest) BuildFromService(h interface{}) error {
+	v, ok := h.(flakytest.FlakyTest)
+	if !ok {
+		return errors.Errorf("incorrect type when converting flaky test type")
+	}
+
+	t.BuildVariant = ToAPIString(v.BuildVariant)
+	t.TestFile = ToAPIString(v.TestFile)
+	t.Revisions = v.Revisions
+	t.FlakyRevisions = v.FlakyRevisions
+	t.Score = v.Score
+	t.LastFlakyRevision = ToAPIString(v.LastFlakyRevision)
+	t.UpdatedAt = NewTime(v.UpdatedAt)
+	return nil
+}
+
+// ToService is not implemented for APIFlakyTest.
+func (t *APIFlakyTest) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APIFlakyTest")
+}
+
+// APIQuarantinedTest is a quarantined test of a project. An empty build
+// variant quarantines the test on every variant.
+type APIQuarantinedTest struct {
+	TestFile     APIString `json:"test_file"`
+	BuildVariant APIString `json:"build_variant"`
+	User         APIString `json:"user"`
+	Reason       APIString `json:"reason"`
+	Time         APITime   `json:"time"`
+}
+
+// BuildFromService converts from a service level quarantined test to an
+// APIQuarantinedTest.
+func (t *APIQuarantinedTest) BuildFromService(h interface{}) error {
+	v, ok := h.(model.QuarantinedTest)
+	if !ok {
+		return errors.Errorf("incorrect type when converting quarantined test type")
+	}
+
+	t.TestFile = ToAPIString(v.TestFile)
+	t.BuildVariant = ToAPIString(v.BuildVariant)
+	t.User = ToAPIString(v.User)
+	t.Reason = ToAPIString(v.Reason)
+	t.Time = NewTime(v.Time)
+	return nil
+}
+
+// ToService returns a service layer quarantined test using the data from
+// the APIQuarantinedTest.
+func (t *APIQuarantinedTest) ToService() (interface{}, error) {
+	return model.QuarantinedTest{
+		TestFile:     FromAPIString(t.TestFile),
+		BuildVariant: FromAPIString(t.BuildVariant),
+		User:         FromAPIString(t.User),
+		Reason:       FromAPIString(t.Reason),
+		Time:         time.Time(t.Time),
+	}, nil
+}
@@ -19,6 +19,11 @@ type APIHost struct {
 	Status      APIString  `json:"status"`
 	RunningTask taskInfo   `json:"running_task"`
 	UserHost    bool       `json:"user_host"`
+
+	// DebugTask is set when the host is held for debugging the task
+	// that failed on it, until ExpirationTime.
+	DebugTask      APIString `json:"debug_task"`
+	ExpirationTime APITime   `json:"expiration_time"`
 }
 
 // HostPostRequest is a struct that holds the format of a POST request to /hosts
@@ -86,6 +91,8 @@ func (apiHost *APIHost) buildFromHostStruct(h interface{}) error {
 	apiHost.User = ToAPIString(v.User)
 	apiHost.Status = ToAPIString(v.Status)
 	apiHost.UserHost = v.UserHost
+	apiHost.DebugTask = ToAPIString(v.DebugTask)
+	apiHost.ExpirationTime = NewTime(v.ExpirationTime)
 
 	di := DistroInfo{
 		Id:       ToAPIString(v.Distro.Id),
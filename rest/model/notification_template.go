@@ -0,0 +1,59 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+)
+
+// APINotificationTemplate is the model to be returned by the API whenever
+// a project's notification templates are fetched.
+type APINotificationTemplate struct {
+	Trigger        APIString `json:"trigger"`
+	SubscriberType APIString `json:"subscriber_type"`
+	Subject        APIString `json:"subject"`
+	Body           APIString `json:"body"`
+}
+
+// BuildFromService converts from a service level notification template to
+// an APINotificationTemplate.
+func (t *APINotificationTemplate) BuildFromService(h interface{}) error {
+	v, ok := h.(model.NotificationTemplate)
+	if !ok {
+		return errors.Errorf("incorrect type when converting notification template type")
+	}
+
+	t.Trigger = ToAPIString(v.Trigger)
+	t.SubscriberType = ToAPIString(v.SubscriberType)
+	t.Subject = ToAPIString(v.Subject)
+	t.Body = ToAPIString(v.Body)
+	return nil
+}
+
+// ToService returns a service layer notification template using the data
+// from the APINotificationTemplate.
+func (t *APINotificationTemplate) ToService() (interface{}, error) {
+	return model.NotificationTemplate{
+		Trigger:        FromAPIString(t.Trigger),
+		SubscriberType: FromAPIString(t.SubscriberType),
+		Subject:        FromAPIString(t.Subject),
+		Body:           FromAPIString(t.Body),
+	}, nil
+}
+
+// APINotificationPreview is a notification template rendered for an
+// event.
+type APINotificationPreview struct {
+	EventID APIString `json:"event_id"`
+	Subject APIString `json:"subject"`
+	Body    APIString `json:"body"`
+}
+
+// BuildFromService is not implemented for APINotificationPreview
+func (p *APINotificationPreview) BuildFromService(h interface{}) error {
+	return errors.Errorf("BuildFromService() is not implemented for APINotificationPreview")
+}
+
+// ToService is not implemented for APINotificationPreview
+func (p *APINotificationPreview) ToService() (interface{}, error) {
+	return nil, errors.Errorf("ToService() is not implemented for APINotificationPreview")
+}
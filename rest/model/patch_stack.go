@@ -0,0 +1,82 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+)
+
+// APIPatchStack is the model to be returned by the API whenever the stack
+// of a patch is fetched.
+type APIPatchStack struct {
+	StackId APIString            `json:"stack_id"`
+	Patches []APIPatchStackEntry `json:"patches"`
+	Breaks  []APIPatchStackBreak `json:"breaks"`
+}
+
+// APIPatchStackEntry is a patch of a stack, one for each commit of a local
+// branch.
+type APIPatchStackEntry struct {
+	Position      int       `json:"position"`
+	PatchId       APIString `json:"patch_id"`
+	Commit        APIString `json:"commit"`
+	CommitMessage APIString `json:"commit_message"`
+	Status        APIString `json:"status"`
+	Activated     bool      `json:"activated"`
+}
+
+// APIPatchStackBreak is a task that failed in a stack, with the first
+// patch of the stack in which it failed.
+type APIPatchStackBreak struct {
+	BuildVariant  APIString `json:"build_variant"`
+	TaskName      APIString `json:"task_name"`
+	TaskId        APIString `json:"task_id"`
+	Position      int       `json:"position"`
+	PatchId       APIString `json:"patch_id"`
+	Commit        APIString `json:"commit"`
+	CommitMessage APIString `json:"commit_message"`
+}
+
+// BuildFromService converts from a service level patch stack summary to an
+// APIPatchStack.
+func (s *APIPatchStack) BuildFromService(h interface{}) error {
+	var v *model.PatchStackSummary
+	switch summary := h.(type) {
+	case model.PatchStackSummary:
+		v = &summary
+	case *model.PatchStackSummary:
+		v = summary
+	default:
+		return errors.Errorf("incorrect type when converting patch stack type")
+	}
+
+	s.StackId = ToAPIString(v.StackID)
+	s.Patches = make([]APIPatchStackEntry, 0, len(v.Patches))
+	for _, p := range v.Patches {
+		s.Patches = append(s.Patches, APIPatchStackEntry{
+			Position:      p.Position,
+			PatchId:       ToAPIString(p.PatchID),
+			Commit:        ToAPIString(p.Commit),
+			CommitMessage: ToAPIString(p.CommitMessage),
+			Status:        ToAPIString(p.Status),
+			Activated:     p.Activated,
+		})
+	}
+	s.Breaks = make([]APIPatchStackBreak, 0, len(v.Breaks))
+	for _, b := range v.Breaks {
+		s.Breaks = append(s.Breaks, APIPatchStackBreak{
+			BuildVariant:  ToAPIString(b.BuildVariant),
+			TaskName:      ToAPIString(b.TaskName),
+			TaskId:        ToAPIString(b.TaskID),
+			Position:      b.Position,
+			PatchId:       ToAPIString(b.PatchID),
+			Commit:        ToAPIString(b.Commit),
+			CommitMessage: ToAPIString(b.CommitMessage),
+		})
+	}
+	return nil
+}
+
+// ToService is not implemented for APIPatchStack.
+func (s *APIPatchStack) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APIPatchStack")
+}
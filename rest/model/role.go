@@ -0,0 +1,81 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/pkg/errors"
+)
+
+// APIRole is a named set of permissions.
+type APIRole struct {
+	Id          APIString   `json:"id"`
+	Name        APIString   `json:"name"`
+	Permissions []APIString `json:"permissions"`
+}
+
+// BuildFromService converts from a service level role to an APIRole.
+func (r *APIRole) BuildFromService(h interface{}) error {
+	v, ok := h.(role.Role)
+	if !ok {
+		return errors.Errorf("incorrect type when converting role type")
+	}
+
+	r.Id = ToAPIString(v.Id)
+	r.Name = ToAPIString(v.Name)
+	r.Permissions = make([]APIString, 0, len(v.Permissions))
+	for _, p := range v.Permissions {
+		r.Permissions = append(r.Permissions, ToAPIString(p))
+	}
+	return nil
+}
+
+// ToService returns a service layer role using the data from the APIRole.
+func (r *APIRole) ToService() (interface{}, error) {
+	permissions := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		permissions = append(permissions, FromAPIString(p))
+	}
+	return role.Role{
+		Id:          FromAPIString(r.Id),
+		Name:        FromAPIString(r.Name),
+		Permissions: permissions,
+	}, nil
+}
+
+// APIRoleAssignment grants the permissions of a role to a user, or to a
+// group prefixed with "group:", on a project or distro. A resource id of
+// "*" grants the role on every project or distro.
+type APIRoleAssignment struct {
+	Id           APIString `json:"id"`
+	Role         APIString `json:"role"`
+	Subject      APIString `json:"subject"`
+	ResourceType APIString `json:"resource_type"`
+	ResourceId   APIString `json:"resource_id"`
+}
+
+// BuildFromService converts from a service level role assignment to an
+// APIRoleAssignment.
+func (a *APIRoleAssignment) BuildFromService(h interface{}) error {
+	v, ok := h.(role.Assignment)
+	if !ok {
+		return errors.Errorf("incorrect type when converting role assignment type")
+	}
+
+	a.Id = ToAPIString(v.Id)
+	a.Role = ToAPIString(v.Role)
+	a.Subject = ToAPIString(v.Subject)
+	a.ResourceType = ToAPIString(v.ResourceType)
+	a.ResourceId = ToAPIString(v.ResourceId)
+	return nil
+}
+
+// ToService returns a service layer role assignment using the data from the
+// APIRoleAssignment.
+func (a *APIRoleAssignment) ToService() (interface{}, error) {
+	return role.Assignment{
+		Id:           FromAPIString(a.Id),
+		Role:         FromAPIString(a.Role),
+		Subject:      FromAPIString(a.Subject),
+		ResourceType: FromAPIString(a.ResourceType),
+		ResourceId:   FromAPIString(a.ResourceId),
+	}, nil
+}
@@ -57,6 +57,8 @@ type apiTaskEndDetail struct {
 	Type        APIString `json:"type"`
 	Description APIString `json:"desc"`
 	TimedOut    bool      `json:"timed_out"`
+
+	ResourceLimitExceeded APIString `json:"resource_limit"`
 }
 
 func (at *APITask) BuildPreviousExecutions(tasks []task.Task) error {
@@ -101,6 +103,8 @@ func (at *APITask) BuildFromService(t interface{}) error {
 				Type:        ToAPIString(v.Details.Type),
 				Description: ToAPIString(v.Details.Description),
 				TimedOut:    v.Details.TimedOut,
+
+				ResourceLimitExceeded: ToAPIString(v.Details.ResourceLimitExceeded),
 			},
 			Status:           ToAPIString(v.Status),
 			TimeTaken:        NewAPIDuration(v.TimeTaken),
@@ -158,6 +162,8 @@ func (ad *APITask) ToService() (interface{}, error) {
 			Type:        FromAPIString(ad.Details.Type),
 			Description: FromAPIString(ad.Details.Description),
 			TimedOut:    ad.Details.TimedOut,
+
+			ResourceLimitExceeded: FromAPIString(ad.Details.ResourceLimitExceeded),
 		},
 		Status:           FromAPIString(ad.Status),
 		TimeTaken:        ad.TimeTaken.ToDuration(),
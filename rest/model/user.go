@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/pkg/errors"
 )
@@ -26,3 +28,47 @@ func (apiPubKey *APIPubKey) BuildFromService(h interface{}) error {
 func (apiPubKey *APIPubKey) ToService() (interface{}, error) {
 	return nil, errors.Errorf("ToService() is not impelemented for APIPubKey")
 }
+
+// APIUserToken is an API token of a user. The token itself is only returned
+// when it is created. When creating a token, ExpiresInDays sets its expiry.
+type APIUserToken struct {
+	Name          APIString `json:"name"`
+	Scope         APIString `json:"scope"`
+	Projects      []string  `json:"projects"`
+	Token         APIString `json:"token,omitempty"`
+	ExpiresInDays int       `json:"expires_in_days,omitempty"`
+	CreatedAt     APITime   `json:"created_at"`
+	ExpiresAt     APITime   `json:"expires_at"`
+	LastUsedAt    APITime   `json:"last_used_at"`
+}
+
+// BuildFromService converts from a service level API token to an
+// APIUserToken.
+func (t *APIUserToken) BuildFromService(h interface{}) error {
+	v, ok := h.(user.APIToken)
+	if !ok {
+		return errors.Errorf("incorrect type when converting API token type")
+	}
+
+	t.Name = ToAPIString(v.Name)
+	t.Scope = ToAPIString(v.Scope)
+	t.Projects = v.Projects
+	if t.Projects == nil {
+		t.Projects = []string{}
+	}
+	t.CreatedAt = NewTime(v.CreatedAt)
+	t.ExpiresAt = NewTime(v.ExpiresAt)
+	t.LastUsedAt = NewTime(v.LastUsedAt)
+	return nil
+}
+
+// ToService returns a service layer API token using the data from the
+// APIUserToken. The token expires ExpiresInDays from now.
+func (t *APIUserToken) ToService() (interface{}, error) {
+	return user.APIToken{
+		Name:      FromAPIString(t.Name),
+		Scope:     FromAPIString(t.Scope),
+		Projects:  t.Projects,
+		ExpiresAt: time.Now().Add(time.Duration(t.ExpiresInDays) * 24 * time.Hour),
+	}, nil
+}
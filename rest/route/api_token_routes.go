@@ -0,0 +1,223 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultAPITokenExpiryDays = 90
+	maxAPITokenExpiryDays     = 365
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handlers for the API tokens of the current user
+//
+//    /api_tokens
+//    /api_tokens/{token_name}
+
+func getAPITokensRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &apiTokensGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &apiTokenPostHandler{},
+				MethodType:        http.MethodPost,
+			},
+		},
+		Version: version,
+	}
+}
+
+func getAPITokenDeleteRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &apiTokenDeleteHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+		Version: version,
+	}
+}
+
+type apiTokensGetHandler struct{}
+
+func (h *apiTokensGetHandler) Handler() RequestHandler {
+	return &apiTokensGetHandler{}
+}
+
+func (h *apiTokensGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *apiTokensGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	tokens := make([]model.Model, 0, len(u.APITokens))
+	for _, token := range u.APITokens {
+		apiToken := &model.APIUserToken{}
+		if err := apiToken.BuildFromService(token); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		tokens = append(tokens, apiToken)
+	}
+
+	return ResponseData{
+		Result: tokens,
+	}, nil
+}
+
+type apiTokenPostHandler struct {
+	token user.APIToken
+}
+
+func (h *apiTokenPostHandler) Handler() RequestHandler {
+	return &apiTokenPostHandler{}
+}
+
+func (h *apiTokenPostHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	apiToken := model.APIUserToken{}
+	if err := util.ReadJSONInto(body, &apiToken); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal API token: %s", err),
+		}
+	}
+	if apiToken.ExpiresInDays == 0 {
+		apiToken.ExpiresInDays = defaultAPITokenExpiryDays
+	}
+	if apiToken.ExpiresInDays < 0 || apiToken.ExpiresInDays > maxAPITokenExpiryDays {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("API tokens must expire within %d days", maxAPITokenExpiryDays),
+		}
+	}
+	if model.FromAPIString(apiToken.Scope) == "" {
+		apiToken.Scope = model.ToAPIString(user.APITokenScopeReadOnly)
+	}
+
+	i, err := apiToken.ToService()
+	if err != nil {
+		return errors.Wrap(err, "API model error")
+	}
+	h.token = i.(user.APIToken)
+
+	if strings.TrimSpace(h.token.Name) == "" || strings.Contains(h.token.Name, "/") {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "invalid API token name",
+		}
+	}
+	if !user.ValidAPITokenScope(h.token.Scope) {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("invalid API token scope '%s'", h.token.Scope),
+		}
+	}
+
+	return nil
+}
+
+func (h *apiTokenPostHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	// a token can't be used to create a token with more rights than it has
+	if token := GetAPIToken(ctx); token != nil && token.Scope != user.APITokenScopeFull {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusForbidden,
+			Message:    "API tokens can only be created with a full scope token",
+		}
+	}
+	if token := GetAPIToken(ctx); token != nil && !token.AllowsProjects(h.token.Projects) {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusForbidden,
+			Message:    fmt.Sprintf("API token '%s' can only create tokens limited to its projects", token.Name),
+		}
+	}
+	if u.GetAPIToken(h.token.Name) != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("an API token named '%s' already exists", h.token.Name),
+		}
+	}
+
+	secret, err := sc.AddAPIToken(u, h.token)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "failed to add API token")
+	}
+
+	added := u.GetAPIToken(h.token.Name)
+	if added == nil {
+		added = &h.token
+	}
+	apiToken := &model.APIUserToken{}
+	if err = apiToken.BuildFromService(*added); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+	apiToken.Token = model.ToAPIString(secret)
+
+	return ResponseData{
+		Result: []model.Model{apiToken},
+	}, nil
+}
+
+type apiTokenDeleteHandler struct {
+	name string
+}
+
+func (h *apiTokenDeleteHandler) Handler() RequestHandler {
+	return &apiTokenDeleteHandler{}
+}
+
+func (h *apiTokenDeleteHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.name = mux.Vars(r)["token_name"]
+	if strings.TrimSpace(h.name) == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "empty API token name",
+		}
+	}
+	return nil
+}
+
+func (h *apiTokenDeleteHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	if u.GetAPIToken(h.name) == nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("API token '%s' does not exist", h.name),
+		}
+	}
+	if err := sc.DeleteAPIToken(u, h.name); err != nil {
+		return ResponseData{}, errors.Wrap(err, "failed to delete API token")
+	}
+
+	return ResponseData{}, nil
+}
@@ -0,0 +1,160 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type APITokenSuite struct {
+	sc   *data.MockConnector
+	user *user.DBUser
+	ctx  context.Context
+	suite.Suite
+}
+
+func TestAPITokenSuite(t *testing.T) {
+	suite.Run(t, new(APITokenSuite))
+}
+
+func (s *APITokenSuite) SetupTest() {
+	s.user = &user.DBUser{Id: "octocat", APIKey: "legacy"}
+	s.sc = &data.MockConnector{}
+	s.sc.MockUserConnector.CachedUsers = map[string]*user.DBUser{s.user.Id: s.user}
+	s.ctx = context.WithValue(context.Background(), evergreen.RequestUser, s.user)
+}
+
+func (s *APITokenSuite) createToken(body string) (*model.APIUserToken, error) {
+	req, err := http.NewRequest(http.MethodPost, "/api_tokens", bytes.NewBufferString(body))
+	s.Require().NoError(err)
+	h := &apiTokenPostHandler{}
+	if err = h.ParseAndValidate(s.ctx, req); err != nil {
+		return nil, err
+	}
+	response, err := h.Execute(s.ctx, s.sc)
+	if err != nil {
+		return nil, err
+	}
+	s.Require().Len(response.Result, 1)
+	token, ok := response.Result[0].(*model.APIUserToken)
+	s.Require().True(ok)
+	return token, nil
+}
+
+func (s *APITokenSuite) TestCreateListAndDelete() {
+	token, err := s.createToken(`{"name": "ci", "projects": ["mci"], "expires_in_days": 7}`)
+	s.Require().NoError(err)
+	s.Equal(user.APITokenScopeReadOnly, model.FromAPIString(token.Scope))
+	s.NotEmpty(model.FromAPIString(token.Token))
+	s.Require().Len(s.user.APITokens, 1)
+	s.True(s.user.APITokens[0].ExpiresAt.After(time.Now().Add(6 * 24 * time.Hour)))
+
+	_, err = s.createToken(`{"name": "ci"}`)
+	s.Error(err)
+	_, err = s.createToken(`{"name": "bad", "scope": "admin"}`)
+	s.Error(err)
+	_, err = s.createToken(`{"name": "long", "expires_in_days": 1000}`)
+	s.Error(err)
+	_, err = s.createToken(`{"name": "a/b"}`)
+	s.Error(err)
+
+	response, err := (&apiTokensGetHandler{}).Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	listed, ok := response.Result[0].(*model.APIUserToken)
+	s.Require().True(ok)
+	s.Equal("ci", model.FromAPIString(listed.Name))
+	s.Empty(model.FromAPIString(listed.Token))
+	s.Equal([]string{"mci"}, listed.Projects)
+
+	del := &apiTokenDeleteHandler{name: "ci"}
+	_, err = del.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.user.APITokens, 0)
+	_, err = del.Execute(s.ctx, s.sc)
+	s.Error(err)
+}
+
+func (s *APITokenSuite) TestPrefetchUserWithToken() {
+	token, err := s.createToken(`{"name": "ci", "projects": ["mci"]}`)
+	s.Require().NoError(err)
+	secret := model.FromAPIString(token.Token)
+
+	req, err := http.NewRequest(http.MethodGet, "/rest/v2/tasks/t1", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Api-User", s.user.Id)
+	req.Header.Set("Api-Key", secret)
+	ctx, err := PrefetchUser(context.Background(), s.sc, req)
+	s.Require().NoError(err)
+	s.NotNil(GetUser(ctx))
+	s.Require().NotNil(GetAPIToken(ctx))
+	s.Equal("ci", GetAPIToken(ctx).Name)
+
+	// read only tokens can't make changes
+	req, err = http.NewRequest(http.MethodPost, "/rest/v2/tasks/t1/restart", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Api-User", s.user.Id)
+	req.Header.Set("Api-Key", secret)
+	_, err = PrefetchUser(context.Background(), s.sc, req)
+	s.Error(err)
+
+	// and are limited to their projects
+	s.sc.MockContextConnector.CachedContext = serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "other"},
+	}
+	_, err = PrefetchProjectContext(ctx, s.sc, req)
+	s.Error(err)
+	s.sc.MockContextConnector.CachedContext = serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "mci"},
+	}
+	_, err = PrefetchProjectContext(ctx, s.sc, req)
+	s.NoError(err)
+
+	// and can't be used on routes that don't resolve one of their projects
+	limitedCtx, err := PrefetchAPITokenProject(ctx, s.sc, req)
+	s.NoError(err)
+	s.Require().NotNil(GetProjectContext(limitedCtx))
+	s.Equal("mci", GetProjectContext(limitedCtx).ProjectRef.Identifier)
+	s.sc.MockContextConnector.CachedContext = serviceModel.Context{}
+	_, err = PrefetchAPITokenProject(ctx, s.sc, req)
+	s.Error(err)
+
+	// tokens other than full scope tokens can't create tokens
+	s.ctx = ctx
+	_, err = s.createToken(`{"name": "escalated", "scope": "full"}`)
+	s.Error(err)
+
+	// the legacy API key still works
+	req.Header.Set("Api-Key", "legacy")
+	ctx, err = PrefetchUser(context.Background(), s.sc, req)
+	s.NoError(err)
+	s.Nil(GetAPIToken(ctx))
+}
+
+func (s *APITokenSuite) TestProjectLimitedTokenCreatesLimitedTokens() {
+	token, err := s.createToken(`{"name": "ci", "scope": "full", "projects": ["mci", "docs"]}`)
+	s.Require().NoError(err)
+
+	req, err := http.NewRequest(http.MethodPost, "/rest/v2/api_tokens", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Api-User", s.user.Id)
+	req.Header.Set("Api-Key", model.FromAPIString(token.Token))
+	s.ctx, err = PrefetchUser(context.Background(), s.sc, req)
+	s.Require().NoError(err)
+
+	_, err = s.createToken(`{"name": "unlimited", "scope": "full"}`)
+	s.Error(err)
+	_, err = s.createToken(`{"name": "other", "scope": "full", "projects": ["mci", "other"]}`)
+	s.Error(err)
+	_, err = s.createToken(`{"name": "subset", "scope": "full", "projects": ["docs"]}`)
+	s.NoError(err)
+}
@@ -0,0 +1,137 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+	maxAuditExport    = 10000
+
+	auditFormatJSONLines = "jsonl"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the audit log of changes made by users
+//
+//    /audit
+
+func getAuditRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &auditEventsGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+		},
+		Version: version,
+	}
+}
+
+// auditEventsGetHandler returns the audit events matching the filters in
+// the query, most recent first. Older events are fetched by passing the
+// timestamp of the last event as "before". With "format=jsonl" the events
+// are exported as one JSON document per line.
+type auditEventsGetHandler struct {
+	filter event.AuditFilter
+	export bool
+}
+
+func (h *auditEventsGetHandler) Handler() RequestHandler {
+	return &auditEventsGetHandler{}
+}
+
+func (h *auditEventsGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	vals := r.URL.Query()
+	h.filter = event.AuditFilter{
+		User:         vals.Get("user"),
+		Method:       vals.Get("method"),
+		ResourceType: vals.Get("resource_type"),
+		ResourceId:   vals.Get("resource_id"),
+	}
+
+	var err error
+	if after := vals.Get("after"); after != "" {
+		if h.filter.After, err = time.Parse(time.RFC3339Nano, after); err != nil {
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("'after' must be in RFC-3339 format: %s", err),
+			}
+		}
+	}
+	if before := vals.Get("before"); before != "" {
+		if h.filter.Before, err = time.Parse(time.RFC3339Nano, before); err != nil {
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("'before' must be in RFC-3339 format: %s", err),
+			}
+		}
+	}
+
+	switch format := vals.Get("format"); format {
+	case "", "json":
+	case auditFormatJSONLines:
+		h.export = true
+	default:
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("unknown format '%s'", format),
+		}
+	}
+
+	maxLimit := maxAuditLimit
+	h.filter.Limit = defaultAuditLimit
+	if h.export {
+		maxLimit = maxAuditExport
+		h.filter.Limit = maxAuditExport
+	}
+	if limit := vals.Get("limit"); limit != "" {
+		if h.filter.Limit, err = strconv.Atoi(limit); err != nil || h.filter.Limit <= 0 || h.filter.Limit > maxLimit {
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("'limit' must be between 1 and %d", maxLimit),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *auditEventsGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	events, err := sc.FindAuditEvents(h.filter)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	result := make([]model.Model, 0, len(events))
+	for _, e := range events {
+		apiEvent := &model.APIAuditEvent{}
+		if err = apiEvent.BuildFromService(e); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, apiEvent)
+	}
+
+	response := ResponseData{
+		Result: result,
+	}
+	if h.export {
+		response.Metadata = &JSONLinesMetadata{}
+	}
+	return response, nil
+}
@@ -0,0 +1,90 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditSuite struct {
+	sc *data.MockConnector
+	suite.Suite
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(AuditSuite))
+}
+
+func (s *AuditSuite) SetupTest() {
+	now := time.Now()
+	s.sc = &data.MockConnector{}
+	s.sc.MockAdminConnector.AuditEvents = []event.EventLogEntry{
+		{
+			Timestamp:  now,
+			ResourceId: "t1",
+			Data:       &event.AuditEventData{User: "octocat", Method: http.MethodPatch, ResourceType: "task", Status: http.StatusOK},
+		},
+		{
+			Timestamp:  now.Add(-time.Hour),
+			ResourceId: "mci",
+			Data:       &event.AuditEventData{User: "hubot", Method: http.MethodPost, ResourceType: "project", Status: http.StatusOK},
+		},
+	}
+}
+
+func (s *AuditSuite) get(query string) (ResponseData, error) {
+	req, err := http.NewRequest(http.MethodGet, "/audit?"+query, nil)
+	s.Require().NoError(err)
+	h := &auditEventsGetHandler{}
+	if err = h.ParseAndValidate(context.Background(), req); err != nil {
+		return ResponseData{}, err
+	}
+	return h.Execute(context.Background(), s.sc)
+}
+
+func (s *AuditSuite) TestFilters() {
+	response, err := s.get("")
+	s.NoError(err)
+	s.Len(response.Result, 2)
+	s.Nil(response.Metadata)
+
+	response, err = s.get("user=hubot")
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	apiEvent, ok := response.Result[0].(*model.APIAuditEvent)
+	s.Require().True(ok)
+	s.Equal("mci", apiEvent.ResourceId)
+	s.Equal("project", apiEvent.ResourceType)
+
+	response, err = s.get("resource_type=task&resource_id=t1")
+	s.NoError(err)
+	s.Len(response.Result, 1)
+
+	response, err = s.get("before=" + time.Now().Add(-time.Minute).Format(time.RFC3339))
+	s.NoError(err)
+	s.Len(response.Result, 1)
+
+	response, err = s.get("limit=1")
+	s.NoError(err)
+	s.Len(response.Result, 1)
+}
+
+func (s *AuditSuite) TestExport() {
+	response, err := s.get("format=jsonl")
+	s.NoError(err)
+	s.Len(response.Result, 2)
+	s.IsType(&JSONLinesMetadata{}, response.Metadata)
+}
+
+func (s *AuditSuite) TestInvalidQuery() {
+	for _, query := range []string{"format=xml", "limit=0", "limit=5000", "after=yesterday"} {
+		_, err := s.get(query)
+		s.Error(err, query)
+	}
+}
@@ -6,9 +6,12 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
 )
 
 // Authenticator is an interface which defines how requests can authenticate
@@ -57,10 +60,16 @@ type ProjectAdminAuthenticator struct{}
 func (p *ProjectAdminAuthenticator) Authenticate(ctx context.Context, sc data.Connector) error {
 	projCtx := MustHaveProjectContext(ctx)
 	u := GetUser(ctx)
+	if projCtx.ProjectRef == nil {
+		return rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Not found",
+		}
+	}
 
 	// If either a superuser or admin, request is allowed to proceed.
 	if auth.IsSuperUser(sc.GetSuperUsers(), u) ||
-		util.StringSliceContains(projCtx.ProjectRef.Admins, u.Username()) {
+		auth.IsProjectAdmin(projCtx.ProjectRef.Admins, u) {
 		return nil
 	}
 
@@ -70,6 +79,52 @@ func (p *ProjectAdminAuthenticator) Authenticate(ctx context.Context, sc data.Co
 	}
 }
 
+// RequirePermissionAuthenticator only allows users who have the permission
+// on the project of the request's project context. Super users and the
+// project's admins have every permission, every user has the public
+// permissions on projects that are not private, and other permissions come
+// from the roles assigned to the user or their groups. It requires that the
+// project context be available.
+type RequirePermissionAuthenticator struct {
+	Permission string
+}
+
+// Authenticate checks that the user has the permission on the project.
+func (p *RequirePermissionAuthenticator) Authenticate(ctx context.Context, sc data.Connector) error {
+	projCtx := MustHaveProjectContext(ctx)
+	u := GetUser(ctx)
+	if u == nil || projCtx.ProjectRef == nil {
+		return rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Not found",
+		}
+	}
+
+	allowed, err := hasProjectPermission(sc, u, projCtx.ProjectRef, p.Permission)
+	if err != nil {
+		return errors.Wrap(err, "problem checking permissions")
+	}
+	if !allowed {
+		return rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Not found",
+		}
+	}
+	return nil
+}
+
+// hasProjectPermission returns whether the user has the permission on the
+// project.
+func hasProjectPermission(sc data.Connector, u auth.User, ref *model.ProjectRef, permission string) (bool, error) {
+	if auth.IsSuperUser(sc.GetSuperUsers(), u) || auth.IsProjectAdmin(ref.Admins, u) {
+		return true, nil
+	}
+	if !ref.Private && util.StringSliceContains(role.PublicPermissions, permission) {
+		return true, nil
+	}
+	return sc.UserHasPermission(u, role.ProjectResource, ref.Identifier, permission)
+}
+
 // RequireUserAuthenticator requires that a user be attached to a request.
 type RequireUserAuthenticator struct{}
 
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -35,8 +36,8 @@ func getBuildByIdRouteManager(route string, version int) *RouteManager {
 				MethodType:     http.MethodGet,
 			},
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
-				Authenticator:     &RequireUserAuthenticator{},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    &buildChangeStatusHandler{},
 				MethodType:        http.MethodPatch,
 			},
@@ -169,8 +170,8 @@ func getBuildAbortRouteManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
-				Authenticator:     &RequireUserAuthenticator{},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    (&buildAbortHandler{}).Handler(),
 				MethodType:        http.MethodPost,
 			},
@@ -234,8 +235,8 @@ func getBuildRestartManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
-				Authenticator:     &RequireUserAuthenticator{},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    (&buildRestartHandler{}).Handler(),
 				MethodType:        http.MethodPost,
 			},
@@ -0,0 +1,207 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handlers for the commit queue of a project
+//
+//    /commit_queue/{project_id}
+
+func getCommitQueueRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectView},
+				RequestHandler:    &commitQueueGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectEdit},
+				RequestHandler:    &commitQueueEnqueueHandler{},
+				MethodType:        http.MethodPut,
+			},
+		},
+		Version: version,
+	}
+}
+
+type commitQueueGetHandler struct {
+	project string
+}
+
+func (cq *commitQueueGetHandler) Handler() RequestHandler {
+	return &commitQueueGetHandler{}
+}
+
+func (cq *commitQueueGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	cq.project = mux.Vars(r)["project_id"]
+	return nil
+}
+
+func (cq *commitQueueGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	queue, err := sc.GetCommitQueue(cq.project)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "Database error")
+		}
+		return ResponseData{}, err
+	}
+
+	apiQueue := &model.APICommitQueue{}
+	if err = apiQueue.BuildFromService(queue); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{apiQueue},
+	}, nil
+}
+
+type commitQueueEnqueueHandler struct {
+	project string
+	item    string
+}
+
+func (cq *commitQueueEnqueueHandler) Handler() RequestHandler {
+	return &commitQueueEnqueueHandler{}
+}
+
+func (cq *commitQueueEnqueueHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	cq.project = mux.Vars(r)["project_id"]
+
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	item := model.APICommitQueueItem{}
+	if err := util.ReadJSONInto(body, &item); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal commit queue item: %s", err),
+		}
+	}
+	cq.item = model.FromAPIString(item.Issue)
+	if cq.item == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "commit queue item must have an issue",
+		}
+	}
+
+	return nil
+}
+
+func (cq *commitQueueEnqueueHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	position, err := sc.EnqueueItem(ctx, cq.project, cq.item, u.Id)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	return ResponseData{
+		Result: []model.Model{&model.APICommitQueuePosition{Position: position}},
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for removing an item from the commit queue of a project
+//
+//    /commit_queue/{project_id}/{item}
+
+func getCommitQueueDeleteItemRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				// users who can't edit the project may only remove the
+				// items that they queued, which the handler checks.
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &commitQueueDeleteItemHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+		Version: version,
+	}
+}
+
+type commitQueueDeleteItemHandler struct {
+	project string
+	item    string
+}
+
+func (cq *commitQueueDeleteItemHandler) Handler() RequestHandler {
+	return &commitQueueDeleteItemHandler{}
+}
+
+func (cq *commitQueueDeleteItemHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	vars := mux.Vars(r)
+	cq.project = vars["project_id"]
+	cq.item = vars["item"]
+	return nil
+}
+
+func (cq *commitQueueDeleteItemHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+	notFound := &rest.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("item '%s' not found in the commit queue of project '%s'", cq.item, cq.project),
+	}
+
+	projCtx := MustHaveProjectContext(ctx)
+	if projCtx.ProjectRef == nil {
+		return ResponseData{}, notFound
+	}
+	canEdit, err := hasProjectPermission(sc, u, projCtx.ProjectRef, role.ProjectEdit)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "problem checking permissions")
+	}
+	if !canEdit {
+		queue, err := sc.GetCommitQueue(cq.project)
+		if err != nil {
+			return ResponseData{}, err
+		}
+		i := queue.FindItem(cq.item)
+		if i < 0 || !isCommitQueueItemAuthor(u, queue.Queue[i]) {
+			return ResponseData{}, notFound
+		}
+	}
+
+	removed, err := sc.CommitQueueRemoveItem(cq.project, cq.item, u.Id)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !removed {
+		return ResponseData{}, notFound
+	}
+
+	return ResponseData{}, nil
+}
+
+// isCommitQueueItemAuthor returns whether the user queued the item, either
+// through the API or by commenting on the pull request on GitHub.
+func isCommitQueueItemAuthor(u *user.DBUser, item commitqueue.CommitQueueItem) bool {
+	if item.Author == "" {
+		return false
+	}
+	return item.Author == u.Id || item.Author == u.Settings.GithubUser.LastKnownAs
+}
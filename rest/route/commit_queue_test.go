@@ -0,0 +1,129 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommitQueueSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestCommitQueueSuite(t *testing.T) {
+	suite.Run(t, new(CommitQueueSuite))
+}
+
+func (s *CommitQueueSuite) SetupTest() {
+	s.sc = &data.MockConnector{}
+	s.sc.SetSuperUsers([]string{"admin"})
+	s.ctx = context.WithValue(context.Background(), evergreen.RequestUser, &user.DBUser{Id: "octocat"})
+}
+
+func (s *CommitQueueSuite) TestEnqueueItem() {
+	route := &commitQueueEnqueueHandler{}
+	req, err := http.NewRequest(http.MethodPut, "/commit_queue/mci", bytes.NewBufferString(`{"issue": "1234"}`))
+	s.Require().NoError(err)
+	s.NoError(route.ParseAndValidate(s.ctx, req))
+	route.project = "mci"
+	s.Equal("1234", route.item)
+
+	response, err := route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	s.Equal(&model.APICommitQueuePosition{Position: 0}, response.Result[0])
+
+	_, err = route.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	cq, err := s.sc.GetCommitQueue("mci")
+	s.NoError(err)
+	s.Require().Len(cq.Queue, 1)
+	s.Equal("octocat", cq.Queue[0].Author)
+}
+
+func (s *CommitQueueSuite) TestEnqueueItemWithoutIssueFails() {
+	route := &commitQueueEnqueueHandler{}
+	req, err := http.NewRequest(http.MethodPut, "/commit_queue/mci", bytes.NewBufferString(`{}`))
+	s.Require().NoError(err)
+	s.Error(route.ParseAndValidate(s.ctx, req))
+}
+
+func (s *CommitQueueSuite) TestGetCommitQueue() {
+	s.sc.MockCommitQueueConnector.Queue = map[string][]commitqueue.CommitQueueItem{
+		"mci": {{Issue: "1"}, {Issue: "2"}},
+	}
+	route := &commitQueueGetHandler{project: "mci"}
+	response, err := route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	apiQueue, ok := response.Result[0].(*model.APICommitQueue)
+	s.Require().True(ok)
+	s.Equal("mci", model.FromAPIString(apiQueue.ProjectID))
+	s.Require().Len(apiQueue.Queue, 2)
+	s.Equal("2", model.FromAPIString(apiQueue.Queue[1].Issue))
+}
+
+func (s *CommitQueueSuite) TestDeleteItem() {
+	s.sc.MockCommitQueueConnector.Queue = map[string][]commitqueue.CommitQueueItem{
+		"mci": {{Issue: "1"}, {Issue: "2"}},
+	}
+	ctx := context.WithValue(s.ctx, RequestContext, &serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "mci", Admins: []string{"octocat"}},
+	})
+	route := &commitQueueDeleteItemHandler{project: "mci", item: "1"}
+	_, err := route.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockCommitQueueConnector.Queue["mci"], 1)
+
+	_, err = route.Execute(ctx, s.sc)
+	s.Error(err)
+}
+
+func (s *CommitQueueSuite) TestDeleteItemRequiresEditOrAuthor() {
+	s.sc.MockCommitQueueConnector.Queue = map[string][]commitqueue.CommitQueueItem{
+		"mci": {{Issue: "1", Author: "someone-else"}, {Issue: "2", Author: "octocat"}, {Issue: "3", Author: "octo-gh"}},
+	}
+	u := &user.DBUser{Id: "octocat"}
+	u.Settings.GithubUser.LastKnownAs = "octo-gh"
+	ctx := context.WithValue(context.Background(), evergreen.RequestUser, u)
+	ctx = context.WithValue(ctx, RequestContext, &serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "mci"},
+	})
+
+	// users can't remove the items that others queued
+	_, err := (&commitQueueDeleteItemHandler{project: "mci", item: "1"}).Execute(ctx, s.sc)
+	s.Error(err)
+	s.Len(s.sc.MockCommitQueueConnector.Queue["mci"], 3)
+
+	// but they can remove their own, queued through the API or GitHub
+	for _, item := range []string{"2", "3"} {
+		_, err = (&commitQueueDeleteItemHandler{project: "mci", item: item}).Execute(ctx, s.sc)
+		s.NoError(err)
+	}
+	s.Len(s.sc.MockCommitQueueConnector.Queue["mci"], 1)
+}
+
+func (s *CommitQueueSuite) TestEditPermissionRequired() {
+	edit := getCommitQueueRouteManager("", 2).Methods[1].Authenticator
+	ctx := context.WithValue(s.ctx, RequestContext, &serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "mci"},
+	})
+	s.Error(edit.Authenticate(ctx, s.sc))
+
+	ctx = context.WithValue(s.ctx, RequestContext, &serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "mci", Admins: []string{"octocat"}},
+	})
+	s.NoError(edit.Authenticate(ctx, s.sc))
+}
@@ -0,0 +1,237 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the flaky tests of a project
+//
+//    /projects/{project_id}/flaky_tests
+
+func getFlakyTestsRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &flakyTestsGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+		},
+		Version: version,
+	}
+}
+
+type flakyTestsGetHandler struct {
+	project string
+}
+
+func (h *flakyTestsGetHandler) Handler() RequestHandler {
+	return &flakyTestsGetHandler{}
+}
+
+func (h *flakyTestsGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+	return nil
+}
+
+func (h *flakyTestsGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	tests, err := sc.FindFlakyTests(h.project)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "Database error")
+		}
+		return ResponseData{}, err
+	}
+
+	result := make([]restModel.Model, 0, len(tests))
+	for _, test := range tests {
+		apiTest := &restModel.APIFlakyTest{}
+		if err = apiTest.BuildFromService(test); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, apiTest)
+	}
+
+	return ResponseData{
+		Result: result,
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handlers for the quarantined tests of a project
+//
+//    /projects/{project_id}/quarantined_tests
+
+func getQuarantinedTestsRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &quarantinedTestsGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectEdit},
+				RequestHandler:    &quarantinedTestsAddHandler{},
+				MethodType:        http.MethodPost,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectEdit},
+				RequestHandler:    &quarantinedTestsDeleteHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+		Version: version,
+	}
+}
+
+type quarantinedTestsGetHandler struct {
+	project string
+}
+
+func (h *quarantinedTestsGetHandler) Handler() RequestHandler {
+	return &quarantinedTestsGetHandler{}
+}
+
+func (h *quarantinedTestsGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+	return nil
+}
+
+func (h *quarantinedTestsGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	tests, err := sc.FindQuarantinedTests(h.project)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "Database error")
+		}
+		return ResponseData{}, err
+	}
+
+	result := make([]restModel.Model, 0, len(tests))
+	for _, test := range tests {
+		apiTest := &restModel.APIQuarantinedTest{}
+		if err = apiTest.BuildFromService(test); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, apiTest)
+	}
+
+	return ResponseData{
+		Result: result,
+	}, nil
+}
+
+type quarantinedTestsAddHandler struct {
+	project string
+	test    model.QuarantinedTest
+}
+
+func (h *quarantinedTestsAddHandler) Handler() RequestHandler {
+	return &quarantinedTestsAddHandler{}
+}
+
+func (h *quarantinedTestsAddHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	apiTest := restModel.APIQuarantinedTest{}
+	if err := util.ReadJSONInto(body, &apiTest); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal quarantined test: %s", err),
+		}
+	}
+	h.test = model.QuarantinedTest{
+		TestFile:     restModel.FromAPIString(apiTest.TestFile),
+		BuildVariant: restModel.FromAPIString(apiTest.BuildVariant),
+		Reason:       restModel.FromAPIString(apiTest.Reason),
+	}
+	if h.test.TestFile == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "quarantined test must have a test file",
+		}
+	}
+
+	return nil
+}
+
+func (h *quarantinedTestsAddHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+	h.test.User = u.Id
+	h.test.Time = time.Now()
+
+	if err := sc.AddQuarantinedTest(h.project, h.test); err != nil {
+		return ResponseData{}, err
+	}
+
+	apiTest := &restModel.APIQuarantinedTest{}
+	if err := apiTest.BuildFromService(h.test); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []restModel.Model{apiTest},
+	}, nil
+}
+
+type quarantinedTestsDeleteHandler struct {
+	project      string
+	testFile     string
+	buildVariant string
+}
+
+func (h *quarantinedTestsDeleteHandler) Handler() RequestHandler {
+	return &quarantinedTestsDeleteHandler{}
+}
+
+func (h *quarantinedTestsDeleteHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+	h.testFile = r.URL.Query().Get("test_file")
+	h.buildVariant = r.URL.Query().Get("build_variant")
+	if h.testFile == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify the test_file of the quarantined test",
+		}
+	}
+	return nil
+}
+
+func (h *quarantinedTestsDeleteHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	removed, err := sc.RemoveQuarantinedTest(h.project, h.testFile, h.buildVariant)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !removed {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("test '%s' is not quarantined in project '%s'", h.testFile, h.project),
+		}
+	}
+
+	return ResponseData{}, nil
+}
@@ -0,0 +1,125 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type FlakyTestSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestFlakyTestSuite(t *testing.T) {
+	suite.Run(t, new(FlakyTestSuite))
+}
+
+func (s *FlakyTestSuite) SetupTest() {
+	s.sc = &data.MockConnector{}
+	s.ctx = context.WithValue(context.Background(), evergreen.RequestUser, &user.DBUser{Id: "octocat"})
+}
+
+func (s *FlakyTestSuite) TestGetFlakyTests() {
+	s.sc.MockFlakyTestConnector.FlakyTests = map[string][]flakytest.FlakyTest{
+		"mci": {
+			{Project: "mci", BuildVariant: "linux", TestFile: "a", Revisions: 2, FlakyRevisions: 1, Score: 0.5},
+		},
+	}
+	route := &flakyTestsGetHandler{project: "mci"}
+	response, err := route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	apiTest, ok := response.Result[0].(*model.APIFlakyTest)
+	s.Require().True(ok)
+	s.Equal("linux", model.FromAPIString(apiTest.BuildVariant))
+	s.Equal("a", model.FromAPIString(apiTest.TestFile))
+	s.Equal(0.5, apiTest.Score)
+}
+
+func (s *FlakyTestSuite) TestQuarantineTest() {
+	route := &quarantinedTestsAddHandler{}
+	req, err := http.NewRequest(http.MethodPost, "/projects/mci/quarantined_tests",
+		bytes.NewBufferString(`{"test_file": "a", "build_variant": "linux", "reason": "flaky"}`))
+	s.Require().NoError(err)
+	s.NoError(route.ParseAndValidate(s.ctx, req))
+	route.project = "mci"
+	s.Equal("a", route.test.TestFile)
+	s.Equal("linux", route.test.BuildVariant)
+
+	response, err := route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+
+	_, err = route.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	tests := s.sc.MockFlakyTestConnector.QuarantinedTests["mci"]
+	s.Require().Len(tests, 1)
+	s.Equal("octocat", tests[0].User)
+	s.Equal("flaky", tests[0].Reason)
+	s.False(tests[0].Time.IsZero())
+}
+
+func (s *FlakyTestSuite) TestQuarantineTestWithoutTestFileFails() {
+	route := &quarantinedTestsAddHandler{}
+	req, err := http.NewRequest(http.MethodPost, "/projects/mci/quarantined_tests", bytes.NewBufferString(`{}`))
+	s.Require().NoError(err)
+	s.Error(route.ParseAndValidate(s.ctx, req))
+}
+
+func (s *FlakyTestSuite) TestRemoveQuarantinedTest() {
+	s.sc.MockFlakyTestConnector.QuarantinedTests = map[string][]serviceModel.QuarantinedTest{
+		"mci": {{TestFile: "a"}, {TestFile: "a", BuildVariant: "linux"}},
+	}
+
+	route := &quarantinedTestsDeleteHandler{}
+	req, err := http.NewRequest(http.MethodDelete, "/projects/mci/quarantined_tests?test_file=a&build_variant=linux", nil)
+	s.Require().NoError(err)
+	s.NoError(route.ParseAndValidate(s.ctx, req))
+	route.project = "mci"
+	_, err = route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Equal([]serviceModel.QuarantinedTest{{TestFile: "a"}}, s.sc.MockFlakyTestConnector.QuarantinedTests["mci"])
+
+	_, err = route.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	req, err = http.NewRequest(http.MethodDelete, "/projects/mci/quarantined_tests", nil)
+	s.Require().NoError(err)
+	s.Error(route.ParseAndValidate(s.ctx, req))
+}
+
+func (s *FlakyTestSuite) TestPrivateProjectsRequirePermission() {
+	for _, manager := range []*RouteManager{
+		getFlakyTestsRouteManager("", 2),
+		getQuarantinedTestsRouteManager("", 2),
+	} {
+		for _, method := range manager.Methods {
+			s.Len(method.PrefetchFunctions, 2, method.MethodType)
+		}
+	}
+
+	s.sc.MockContextConnector.CachedContext = serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "mci", Private: true},
+	}
+	s.sc.SetSuperUsers([]string{"root"})
+	r, err := http.NewRequest(http.MethodGet, "/projects/mci/flaky_tests", nil)
+	s.Require().NoError(err)
+	_, err = PrefetchProjectContext(s.ctx, s.sc, r)
+	s.Error(err)
+
+	s.sc.MockContextConnector.CachedContext.ProjectRef.Admins = []string{"octocat"}
+	_, err = PrefetchProjectContext(s.ctx, s.sc, r)
+	s.NoError(err)
+}
@@ -2,11 +2,16 @@ package route
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/units"
 	"github.com/google/go-github/github"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/grip"
@@ -18,26 +23,35 @@ const (
 	githubActionOpened      = "opened"
 	githubActionSynchronize = "synchronize"
 	githubActionReopened    = "reopened"
+
+	githubActionRequestedAction = "requested_action"
+	githubActionRerequested     = "rerequested"
+
+	githubEventCheckRun = "check_run"
 )
 
 type githubHookApi struct {
 	queue  amboy.Queue
 	secret []byte
+	// appSecret validates the check_run events of the GitHub App's
+	// webhook, if it has its own secret.
+	appSecret []byte
 
 	event     interface{}
 	eventType string
 	msgID     string
 }
 
-func getGithubHooksRouteManager(queue amboy.Queue, secret []byte) routeManagerFactory {
+func getGithubHooksRouteManager(queue amboy.Queue, secret, appSecret []byte) routeManagerFactory {
 	return func(route string, version int) *RouteManager {
 		methods := []MethodHandler{}
 		if len(secret) > 0 {
 			methods = append(methods, MethodHandler{
 				Authenticator: &NoAuthAuthenticator{},
 				RequestHandler: &githubHookApi{
-					queue:  queue,
-					secret: secret,
+					queue:     queue,
+					secret:    secret,
+					appSecret: appSecret,
 				},
 				MethodType: http.MethodPost,
 			})
@@ -56,8 +70,9 @@ func getGithubHooksRouteManager(queue amboy.Queue, secret []byte) routeManagerFa
 
 func (gh *githubHookApi) Handler() RequestHandler {
 	return &githubHookApi{
-		queue:  gh.queue,
-		secret: gh.secret,
+		queue:     gh.queue,
+		secret:    gh.secret,
+		appSecret: gh.appSecret,
 	}
 }
 
@@ -71,7 +86,11 @@ func (gh *githubHookApi) ParseAndValidate(ctx context.Context, r *http.Request)
 		}
 	}
 
-	body, err := github.ValidatePayload(r, gh.secret)
+	secret := gh.secret
+	if gh.eventType == githubEventCheckRun && len(gh.appSecret) > 0 {
+		secret = gh.appSecret
+	}
+	body, err := github.ValidatePayload(r, secret)
 	if err != nil {
 		grip.Error(message.WrapError(err, message.Fields{
 			"source":  "github hook",
@@ -85,6 +104,24 @@ func (gh *githubHookApi) ParseAndValidate(ctx context.Context, r *http.Request)
 		}
 	}
 
+	if gh.eventType == githubEventCheckRun {
+		event := &githubCheckRunEvent{}
+		if err = json.Unmarshal(body, event); err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"source":  "github hook",
+				"msg_id":  gh.msgID,
+				"event":   gh.eventType,
+				"message": "rejecting github webhook",
+			}))
+			return rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    err.Error(),
+			}
+		}
+		gh.event = event
+		return nil
+	}
+
 	gh.event, err = github.ParseWebHook(gh.eventType, body)
 	if err != nil {
 		grip.Error(message.WrapError(err, message.Fields{
@@ -192,7 +229,97 @@ func (gh *githubHookApi) Execute(ctx context.Context, sc data.Connector) (Respon
 
 	case *github.PushEvent:
 		return ResponseData{}, sc.TriggerRepotracker(gh.queue, gh.msgID, event)
+
+	case *github.IssueCommentEvent:
+		err := sc.EnqueueFromComment(ctx, event)
+		grip.ErrorWhen(err != nil, message.WrapError(err, message.Fields{
+			"source":  "github hook",
+			"msg_id":  gh.msgID,
+			"event":   gh.eventType,
+			"action":  event.GetAction(),
+			"message": "failed to enqueue pull request in commit queue",
+		}))
+
+		return ResponseData{}, err
+
+	case *githubCheckRunEvent:
+		err := gh.rerunCheckRun(sc, event)
+		grip.ErrorWhen(err != nil, message.WrapError(err, message.Fields{
+			"source":  "github hook",
+			"msg_id":  gh.msgID,
+			"event":   gh.eventType,
+			"action":  event.Action,
+			"message": "failed to re-run check run",
+		}))
+
+		return ResponseData{}, err
 	}
 
 	return ResponseData{}, nil
 }
+
+// githubCheckRunEvent is the part of a check_run webhook that Evergreen
+// uses. The vendored go-github doesn't support the Checks API yet.
+type githubCheckRunEvent struct {
+	Action   string `json:"action"`
+	CheckRun struct {
+		HeadSHA    string `json:"head_sha"`
+		ExternalID string `json:"external_id"`
+	} `json:"check_run"`
+	RequestedAction *struct {
+		Identifier string `json:"identifier"`
+	} `json:"requested_action"`
+	Repo   *github.Repository `json:"repository"`
+	Sender *github.User       `json:"sender"`
+}
+
+// rerunCheckRun restarts the build of a check run that Evergreen created,
+// when the re-run button of the check run is clicked. The build must belong
+// to a pull request patch of the webhook's repository.
+func (gh *githubHookApi) rerunCheckRun(sc data.Connector, event *githubCheckRunEvent) error {
+	switch event.Action {
+	case githubActionRerequested:
+	case githubActionRequestedAction:
+		if event.RequestedAction == nil || event.RequestedAction.Identifier != units.GithubCheckRunRerunAction {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if event.CheckRun.ExternalID == "" || event.Repo == nil || event.Repo.GetOwner().GetLogin() == "" || event.Repo.GetName() == "" {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "malformed check run event",
+		}
+	}
+
+	b, err := sc.FindBuildById(event.CheckRun.ExternalID)
+	if err != nil {
+		return err
+	}
+	p, err := sc.FindPatchById(b.Version)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(p.GithubPatchData.BaseOwner, event.Repo.GetOwner().GetLogin()) ||
+		!strings.EqualFold(p.GithubPatchData.BaseRepo, event.Repo.GetName()) ||
+		p.GithubPatchData.HeadHash != event.CheckRun.HeadSHA {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("build '%s' does not belong to a pull request of %s", b.Id, event.Repo.GetFullName()),
+		}
+	}
+
+	grip.Info(message.Fields{
+		"source":   "github hook",
+		"msg_id":   gh.msgID,
+		"event":    gh.eventType,
+		"action":   event.Action,
+		"message":  "restarting build of check run",
+		"build_id": b.Id,
+		"patch_id": b.Version,
+		"sender":   event.Sender.GetLogin(),
+	})
+
+	return sc.RestartBuild(b.Id, evergreen.GithubPatchUser)
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
@@ -14,14 +15,20 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/units"
 	"github.com/google/go-github/github"
 	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/queue"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
 )
 
 type GithubWebhookRouteSuite struct {
@@ -60,7 +67,7 @@ func (s *GithubWebhookRouteSuite) SetupTest() {
 	s.NoError(db.Clear(model.ProjectRefCollection))
 
 	s.queue = evergreen.GetEnvironment().LocalQueue()
-	s.rm = getGithubHooksRouteManager(s.queue, []byte(s.conf.Api.GithubWebhookSecret))("", 2)
+	s.rm = getGithubHooksRouteManager(s.queue, []byte(s.conf.Api.GithubWebhookSecret), nil)("", 2)
 	s.sc = &data.MockConnector{MockPatchIntentConnector: data.MockPatchIntentConnector{
 		CachedIntents: map[data.MockPatchIntentKey]patch.Intent{},
 	}}
@@ -193,3 +200,118 @@ func (s *GithubWebhookRouteSuite) TestPushEventTriggersRepoTracker() {
 	s.NoError(err)
 	s.Empty(resp.Result)
 }
+
+func TestGithubCheckRunRerun(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	patchID := bson.NewObjectId()
+	sc := &data.MockConnector{
+		MockBuildConnector: data.MockBuildConnector{
+			CachedBuilds:    []build.Build{{Id: "b1", Version: patchID.Hex()}},
+			CachedRestarted: map[string]string{},
+		},
+		MockPatchConnector: data.MockPatchConnector{
+			CachedPatches: []patch.Patch{{
+				Id: patchID,
+				GithubPatchData: patch.GithubPatch{
+					BaseOwner: "evergreen-ci",
+					BaseRepo:  "evergreen",
+					HeadHash:  "abcdef",
+				},
+			}},
+		},
+	}
+
+	secret := []byte("secret")
+	h := &githubHookApi{
+		queue:  queue.NewLocalUnordered(1),
+		secret: secret,
+	}
+	makeCheckRunEvent := func(action, identifier, buildID, owner, sha string) *githubCheckRunEvent {
+		body := fmt.Sprintf(`{
+			"action": %q,
+			"check_run": {"head_sha": %q, "external_id": %q},
+			"requested_action": {"identifier": %q},
+			"repository": {"name": "evergreen", "full_name": "%s/evergreen", "owner": {"login": %q}},
+			"sender": {"login": "octocat"}
+		}`, action, sha, buildID, identifier, owner, owner)
+		req, err := makeRequest("1", []byte(body), secret)
+		require.NoError(err)
+		req.Header.Set("X-Github-Event", "check_run")
+
+		handler := h.Handler().(*githubHookApi)
+		require.NoError(handler.ParseAndValidate(context.Background(), req))
+		assert.Equal("check_run", handler.eventType)
+		event, ok := handler.event.(*githubCheckRunEvent)
+		require.True(ok)
+		return event
+	}
+
+	event := makeCheckRunEvent("requested_action", units.GithubCheckRunRerunAction, "b1", "evergreen-ci", "abcdef")
+	assert.Equal("b1", event.CheckRun.ExternalID)
+	assert.Equal("evergreen-ci", event.Repo.GetOwner().GetLogin())
+	h.event = event
+	_, err := h.Execute(context.Background(), sc)
+	assert.NoError(err)
+	assert.Equal(evergreen.GithubPatchUser, sc.CachedRestarted["b1"])
+
+	// GitHub's own re-run link also restarts the build
+	delete(sc.CachedRestarted, "b1")
+	h.event = makeCheckRunEvent("rerequested", "", "b1", "evergreen-ci", "abcdef")
+	_, err = h.Execute(context.Background(), sc)
+	assert.NoError(err)
+	assert.Contains(sc.CachedRestarted, "b1")
+
+	// other actions are ignored
+	delete(sc.CachedRestarted, "b1")
+	h.event = makeCheckRunEvent("requested_action", "something-else", "b1", "evergreen-ci", "abcdef")
+	_, err = h.Execute(context.Background(), sc)
+	assert.NoError(err)
+	h.event = makeCheckRunEvent("created", "", "b1", "evergreen-ci", "abcdef")
+	_, err = h.Execute(context.Background(), sc)
+	assert.NoError(err)
+	assert.Empty(sc.CachedRestarted)
+
+	// builds must belong to a pull request of the repository
+	h.event = makeCheckRunEvent("rerequested", "", "b1", "someone-else", "abcdef")
+	_, err = h.Execute(context.Background(), sc)
+	assert.Error(err)
+	h.event = makeCheckRunEvent("rerequested", "", "b1", "evergreen-ci", "123456")
+	_, err = h.Execute(context.Background(), sc)
+	assert.Error(err)
+	h.event = makeCheckRunEvent("rerequested", "", "b2", "evergreen-ci", "abcdef")
+	_, err = h.Execute(context.Background(), sc)
+	assert.Error(err)
+	assert.Empty(sc.CachedRestarted)
+}
+
+func TestGithubCheckRunAppSecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	h := &githubHookApi{
+		queue:     queue.NewLocalUnordered(1),
+		secret:    []byte("repo-secret"),
+		appSecret: []byte("app-secret"),
+	}
+	parse := func(event string, body, secret []byte) error {
+		req, err := makeRequest("1", body, secret)
+		require.NoError(err)
+		req.Header.Set("X-Github-Event", event)
+		return h.Handler().(*githubHookApi).ParseAndValidate(context.Background(), req)
+	}
+	checkRun := []byte(`{"action": "rerequested", "check_run": {"external_id": "b1"}}`)
+
+	// the app's check_run events are signed with the app's secret
+	assert.NoError(parse("check_run", checkRun, []byte("app-secret")))
+	assert.Error(parse("check_run", checkRun, []byte("repo-secret")))
+
+	// repository events still use the repository webhook secret
+	assert.NoError(parse("ping", []byte(`{"zen": "hi"}`), []byte("repo-secret")))
+	assert.Error(parse("ping", []byte(`{"zen": "hi"}`), []byte("app-secret")))
+
+	// without an app secret, the app's webhook uses the repository's
+	h.appSecret = nil
+	assert.NoError(parse("check_run", checkRun, []byte("repo-secret")))
+}
@@ -2,6 +2,7 @@ package route
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen/rest"
@@ -76,6 +77,10 @@ func makeHandler(methodHandler MethodHandler, sc data.Connector) http.HandlerFun
 				return
 			}
 		}
+		if ctx, err = PrefetchAPITokenProject(ctx, sc, r); err != nil {
+			handleAPIError(err, w, r)
+			return
+		}
 
 		if err = methodHandler.Authenticate(ctx, sc); err != nil {
 			handleAPIError(err, w, r)
@@ -105,6 +110,8 @@ func makeHandler(methodHandler MethodHandler, sc data.Connector) http.HandlerFun
 				return
 			}
 			util.WriteJSON(w, http.StatusOK, result.Result)
+		case *JSONLinesMetadata:
+			writeJSONLines(w, result.Result)
 		default:
 			if len(result.Result) == 1 {
 				util.WriteJSON(w, http.StatusOK, result.Result[0])
@@ -142,3 +149,21 @@ func handleAPIError(e error, w http.ResponseWriter, r *http.Request) {
 
 	util.WriteJSON(w, apiErr.StatusCode, apiErr)
 }
+
+// JSONLinesMetadata is the metadata of a result that is written as one JSON
+// document per line, for exports.
+type JSONLinesMetadata struct{}
+
+func writeJSONLines(w http.ResponseWriter, results []model.Model) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			grip.Warning(message.WrapError(err, message.Fields{
+				"message": "problem writing JSON lines result",
+			}))
+			return
+		}
+	}
+}
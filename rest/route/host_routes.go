@@ -10,7 +10,9 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -369,6 +371,11 @@ func (h *hostTerminateHandler) Execute(ctx context.Context, sc data.Connector) (
 	u := MustHaveUser(ctx)
 
 	host, err := sc.FindHostByIdWithOwner(h.hostID, u)
+	if apiErr, ok := err.(*rest.APIError); ok && apiErr.StatusCode == http.StatusUnauthorized {
+		// users who may terminate the hosts of the host's distro need not
+		// own the host
+		host, err = findHostWithDistroPermission(sc, h.hostID, u, role.HostTerminate)
+	}
 	if err != nil {
 		return ResponseData{}, err
 	}
@@ -399,6 +406,33 @@ func (h *hostTerminateHandler) Execute(ctx context.Context, sc data.Connector) (
 	return ResponseData{}, nil
 }
 
+// findHostWithDistroPermission returns the host if the user has the
+// permission on the host's distro.
+func findHostWithDistroPermission(sc data.Connector, hostID string, u *user.DBUser, permission string) (*host.Host, error) {
+	h, err := sc.FindHostById(hostID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error fetching host information")
+	}
+	if h == nil {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "host does not exist",
+		}
+	}
+
+	allowed, err := sc.UserHasPermission(u, role.DistroResource, h.Distro.Id, permission)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem checking permissions")
+	}
+	if !allowed {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "not authorized to modify host",
+		}
+	}
+	return h, nil
+}
+
 func getHostChangeRDPPasswordRouteManager(route string, version int) *RouteManager {
 	return &RouteManager{
 		Route:   route,
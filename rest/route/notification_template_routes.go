@@ -0,0 +1,262 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handlers for the notification templates of a project
+//
+//    /projects/{project_id}/notification_templates
+
+func getNotificationTemplatesRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &notificationTemplatesGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectEdit},
+				RequestHandler:    &notificationTemplateSetHandler{},
+				MethodType:        http.MethodPost,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectEdit},
+				RequestHandler:    &notificationTemplateDeleteHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+		Version: version,
+	}
+}
+
+type notificationTemplatesGetHandler struct {
+	project string
+}
+
+func (h *notificationTemplatesGetHandler) Handler() RequestHandler {
+	return &notificationTemplatesGetHandler{}
+}
+
+func (h *notificationTemplatesGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+	return nil
+}
+
+func (h *notificationTemplatesGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	templates, err := sc.FindNotificationTemplates(h.project)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "Database error")
+		}
+		return ResponseData{}, err
+	}
+
+	result := make([]restModel.Model, 0, len(templates))
+	for _, t := range templates {
+		apiTemplate := &restModel.APINotificationTemplate{}
+		if err = apiTemplate.BuildFromService(t); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, apiTemplate)
+	}
+
+	return ResponseData{
+		Result: result,
+	}, nil
+}
+
+// notificationTemplateSetHandler saves a notification template, replacing
+// the project's template for the same trigger and subscriber type.
+type notificationTemplateSetHandler struct {
+	project  string
+	template model.NotificationTemplate
+}
+
+func (h *notificationTemplateSetHandler) Handler() RequestHandler {
+	return &notificationTemplateSetHandler{}
+}
+
+func (h *notificationTemplateSetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	apiTemplate := restModel.APINotificationTemplate{}
+	if err := util.ReadJSONInto(body, &apiTemplate); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal notification template: %s", err),
+		}
+	}
+	i, err := apiTemplate.ToService()
+	if err != nil {
+		return errors.Wrap(err, "API model error")
+	}
+	h.template = i.(model.NotificationTemplate)
+
+	if err = h.template.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return nil
+}
+
+func (h *notificationTemplateSetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	if err := sc.SetNotificationTemplate(h.project, h.template); err != nil {
+		return ResponseData{}, err
+	}
+
+	apiTemplate := &restModel.APINotificationTemplate{}
+	if err := apiTemplate.BuildFromService(h.template); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []restModel.Model{apiTemplate},
+	}, nil
+}
+
+type notificationTemplateDeleteHandler struct {
+	project        string
+	trigger        string
+	subscriberType string
+}
+
+func (h *notificationTemplateDeleteHandler) Handler() RequestHandler {
+	return &notificationTemplateDeleteHandler{}
+}
+
+func (h *notificationTemplateDeleteHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+	h.trigger = r.URL.Query().Get("trigger")
+	h.subscriberType = r.URL.Query().Get("subscriber_type")
+	if h.trigger == "" || h.subscriberType == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify the trigger and subscriber_type of the notification template",
+		}
+	}
+	return nil
+}
+
+func (h *notificationTemplateDeleteHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	removed, err := sc.RemoveNotificationTemplate(h.project, h.trigger, h.subscriberType)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !removed {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' has no notification template for '%s' to %s", h.project, h.trigger, h.subscriberType),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for previewing a notification template of a project
+//
+//    /projects/{project_id}/notification_templates/preview
+
+func getNotificationTemplatePreviewRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.ProjectEdit},
+				RequestHandler:    &notificationTemplatePreviewHandler{},
+				MethodType:        http.MethodPost,
+			},
+		},
+		Version: version,
+	}
+}
+
+// notificationTemplatePreviewHandler renders a notification template for a
+// task, build or version event of the project. If the template in the
+// request has no body, the project's saved template for its trigger and
+// subscriber type is rendered.
+type notificationTemplatePreviewHandler struct {
+	project  string
+	eventID  string
+	template model.NotificationTemplate
+}
+
+func (h *notificationTemplatePreviewHandler) Handler() RequestHandler {
+	return &notificationTemplatePreviewHandler{}
+}
+
+func (h *notificationTemplatePreviewHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.project = mux.Vars(r)["project_id"]
+
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	preview := struct {
+		restModel.APINotificationTemplate
+		EventID restModel.APIString `json:"event_id"`
+	}{}
+	if err := util.ReadJSONInto(body, &preview); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal notification template preview: %s", err),
+		}
+	}
+	h.eventID = restModel.FromAPIString(preview.EventID)
+	if h.eventID == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify the event_id to preview the notification template for",
+		}
+	}
+	i, err := preview.APINotificationTemplate.ToService()
+	if err != nil {
+		return errors.Wrap(err, "API model error")
+	}
+	h.template = i.(model.NotificationTemplate)
+
+	return nil
+}
+
+func (h *notificationTemplatePreviewHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	subject, body, err := sc.PreviewNotificationTemplate(h.project, h.eventID, h.template)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "problem rendering notification template")
+		}
+		return ResponseData{}, err
+	}
+
+	return ResponseData{
+		Result: []restModel.Model{&restModel.APINotificationPreview{
+			EventID: restModel.ToAPIString(h.eventID),
+			Subject: restModel.ToAPIString(subject),
+			Body:    restModel.ToAPIString(body),
+		}},
+	}, nil
+}
@@ -0,0 +1,146 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type NotificationTemplateSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestNotificationTemplateSuite(t *testing.T) {
+	suite.Run(t, new(NotificationTemplateSuite))
+}
+
+func (s *NotificationTemplateSuite) SetupTest() {
+	s.sc = &data.MockConnector{}
+	s.ctx = context.WithValue(context.Background(), evergreen.RequestUser, &user.DBUser{Id: "octocat"})
+}
+
+func (s *NotificationTemplateSuite) TestSetAndGetTemplates() {
+	route := &notificationTemplateSetHandler{}
+	req, err := http.NewRequest(http.MethodPost, "/projects/mci/notification_templates",
+		bytes.NewBufferString(`{"trigger": "task_failed", "subscriber_type": "slack", "subject": "{{.Task.DisplayName}} failed", "body": "on {{.Build.DisplayName}}"}`))
+	s.Require().NoError(err)
+	s.NoError(route.ParseAndValidate(s.ctx, req))
+	route.project = "mci"
+	_, err = route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+
+	// saving a template for the same trigger and subscriber replaces it
+	route.template.Body = "on {{.Build.BuildVariant}}"
+	_, err = route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+
+	get := &notificationTemplatesGetHandler{project: "mci"}
+	response, err := get.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	apiTemplate, ok := response.Result[0].(*model.APINotificationTemplate)
+	s.Require().True(ok)
+	s.Equal(alertrecord.TaskFailedId, model.FromAPIString(apiTemplate.Trigger))
+	s.Equal("on {{.Build.BuildVariant}}", model.FromAPIString(apiTemplate.Body))
+}
+
+func (s *NotificationTemplateSuite) TestInvalidTemplatesAreRejected() {
+	for _, body := range []string{
+		`{"trigger": "task_failed", "subscriber_type": "slack", "body": "{{.Task.DisplayName"}`,
+		`{"trigger": "task_failed", "subscriber_type": "slack", "body": "{{.Task.NoSuchField}}"}`,
+		`{"trigger": "task_failed", "subscriber_type": "pigeon", "body": "failed"}`,
+		`{"trigger": "sometimes", "subscriber_type": "email", "body": "failed"}`,
+		`{"trigger": "task_failed", "subscriber_type": "email"}`,
+	} {
+		route := &notificationTemplateSetHandler{}
+		req, err := http.NewRequest(http.MethodPost, "/projects/mci/notification_templates", bytes.NewBufferString(body))
+		s.Require().NoError(err)
+		s.Error(route.ParseAndValidate(s.ctx, req), body)
+	}
+}
+
+func (s *NotificationTemplateSuite) TestRemoveTemplate() {
+	s.sc.MockNotificationTemplateConnector.NotificationTemplates = map[string][]serviceModel.NotificationTemplate{
+		"mci": {
+			{Trigger: alertrecord.TaskFailedId, SubscriberType: "email", Body: "a"},
+			{Trigger: alertrecord.TaskFailedId, SubscriberType: "slack", Body: "b"},
+		},
+	}
+
+	route := &notificationTemplateDeleteHandler{}
+	req, err := http.NewRequest(http.MethodDelete, "/projects/mci/notification_templates?trigger=task_failed&subscriber_type=email", nil)
+	s.Require().NoError(err)
+	s.NoError(route.ParseAndValidate(s.ctx, req))
+	route.project = "mci"
+	_, err = route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockNotificationTemplateConnector.NotificationTemplates["mci"], 1)
+
+	_, err = route.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	req, err = http.NewRequest(http.MethodDelete, "/projects/mci/notification_templates?trigger=task_failed", nil)
+	s.Require().NoError(err)
+	s.Error(route.ParseAndValidate(s.ctx, req))
+}
+
+func (s *NotificationTemplateSuite) TestPreview() {
+	s.sc.MockNotificationTemplateConnector.TemplateData = map[string]serviceModel.NotificationTemplateData{
+		"event1": {
+			Project: &serviceModel.ProjectRef{Identifier: "mci", DisplayName: "Evergreen"},
+			Task:    &task.Task{DisplayName: "compile"},
+		},
+	}
+	s.sc.MockNotificationTemplateConnector.NotificationTemplates = map[string][]serviceModel.NotificationTemplate{
+		"mci": {{Trigger: alertrecord.TaskFailedId, SubscriberType: "email", Body: "saved {{.Task.DisplayName}}"}},
+	}
+
+	route := &notificationTemplatePreviewHandler{}
+	req, err := http.NewRequest(http.MethodPost, "/projects/mci/notification_templates/preview",
+		bytes.NewBufferString(`{"event_id": "event1", "trigger": "task_failed", "subscriber_type": "slack", "subject": "{{.Project.DisplayName}}: {{.Trigger}}", "body": "{{.Task.DisplayName}}{{with .Patch}} patch{{end}}"}`))
+	s.Require().NoError(err)
+	s.NoError(route.ParseAndValidate(s.ctx, req))
+	route.project = "mci"
+	s.Equal("event1", route.eventID)
+
+	response, err := route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	preview, ok := response.Result[0].(*model.APINotificationPreview)
+	s.Require().True(ok)
+	s.Equal("Evergreen: task_failed", model.FromAPIString(preview.Subject))
+	s.Equal("compile", model.FromAPIString(preview.Body))
+
+	// without a body, the saved template is previewed
+	route.template = serviceModel.NotificationTemplate{Trigger: alertrecord.TaskFailedId, SubscriberType: "email"}
+	response, err = route.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	s.Equal("saved compile", model.FromAPIString(response.Result[0].(*model.APINotificationPreview).Body))
+
+	route.template.SubscriberType = "slack"
+	_, err = route.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	// events of other projects can't be previewed
+	route.project = "other"
+	route.template.SubscriberType = "email"
+	_, err = route.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	req, err = http.NewRequest(http.MethodPost, "/projects/mci/notification_templates/preview", bytes.NewBufferString(`{"trigger": "task_failed"}`))
+	s.Require().NoError(err)
+	s.Error(route.ParseAndValidate(s.ctx, req))
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -33,9 +34,9 @@ func getPatchByIdManager(route string, version int) *RouteManager {
 				RequestHandler: &patchByIdHandler{},
 			},
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
 				MethodType:        http.MethodPatch,
-				Authenticator:     &RequireUserAuthenticator{},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    &patchChangeStatusHandler{},
 			},
 		},
@@ -146,6 +147,58 @@ func (p *patchByIdHandler) Execute(ctx context.Context, sc data.Connector) (Resp
 	}, nil
 }
 
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for fetching the stack of a patch
+//
+//    /patches/{patch_id}/stack
+
+func getPatchStackManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				MethodType:     http.MethodGet,
+				Authenticator:  &NoAuthAuthenticator{},
+				RequestHandler: &patchStackHandler{},
+			},
+		},
+	}
+}
+
+type patchStackHandler struct {
+	patchId string
+}
+
+func (p *patchStackHandler) Handler() RequestHandler {
+	return &patchStackHandler{}
+}
+
+func (p *patchStackHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	p.patchId = mux.Vars(r)["patch_id"]
+	return nil
+}
+
+func (p *patchStackHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	summary, err := sc.FindPatchStack(p.patchId)
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "Database error")
+		}
+		return ResponseData{}, err
+	}
+
+	stackModel := &model.APIPatchStack{}
+	if err = stackModel.BuildFromService(summary); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{stackModel},
+	}, nil
+}
+
 ////////////////////////////////////////////////////////////////////////
 //
 // Handler for fetching current users patches
@@ -402,9 +455,9 @@ func getPatchAbortManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
 				MethodType:        http.MethodPost,
-				Authenticator:     &RequireUserAuthenticator{},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    p.Handler(),
 			},
 		},
@@ -470,9 +523,9 @@ func getPatchRestartManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
 				MethodType:        http.MethodPost,
-				Authenticator:     &RequireUserAuthenticator{},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    p.Handler(),
 			},
 		},
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
@@ -70,6 +71,72 @@ func (s *PatchByIdSuite) TestFindByIdFail() {
 	s.Len(res.Result, 0)
 }
 
+////////////////////////////////////////////////////////////////////////
+//
+// Tests for fetch patch stack route
+
+type PatchStackSuite struct {
+	sc     *data.MockConnector
+	objIds []bson.ObjectId
+
+	suite.Suite
+}
+
+func TestPatchStackSuite(t *testing.T) {
+	suite.Run(t, new(PatchStackSuite))
+}
+
+func (s *PatchStackSuite) SetupSuite() {
+	s.objIds = []bson.ObjectId{bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()}
+	stackID := s.objIds[0].Hex()
+
+	s.sc = &data.MockConnector{
+		MockPatchConnector: data.MockPatchConnector{
+			CachedPatches: []patch.Patch{
+				{Id: s.objIds[0], Stack: &patch.PatchStack{ID: stackID, Position: 1, Size: 2}},
+				{Id: s.objIds[1], Stack: &patch.PatchStack{ID: stackID, Position: 2, Size: 2}},
+				{Id: s.objIds[2]},
+			},
+			CachedPatchStacks: map[string]serviceModel.PatchStackSummary{
+				stackID: {
+					StackID: stackID,
+					Patches: []serviceModel.PatchStackEntry{
+						{Position: 1, PatchID: s.objIds[0].Hex(), Commit: "a"},
+						{Position: 2, PatchID: s.objIds[1].Hex(), Commit: "b"},
+					},
+					Breaks: []serviceModel.PatchStackBreak{
+						{BuildVariant: "linux", TaskName: "test", Position: 2, PatchID: s.objIds[1].Hex(), Commit: "b"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *PatchStackSuite) TestFindStack() {
+	rm := getPatchStackManager("", 2)
+	(rm.Methods[0].RequestHandler).(*patchStackHandler).patchId = s.objIds[1].Hex()
+	res, err := rm.Methods[0].Execute(context.TODO(), s.sc)
+	s.NoError(err)
+	s.Require().Len(res.Result, 1)
+
+	stack, ok := (res.Result[0]).(*model.APIPatchStack)
+	s.Require().True(ok)
+	s.Equal(model.ToAPIString(s.objIds[0].Hex()), stack.StackId)
+	s.Len(stack.Patches, 2)
+	s.Require().Len(stack.Breaks, 1)
+	s.Equal(2, stack.Breaks[0].Position)
+	s.Equal(model.ToAPIString("b"), stack.Breaks[0].Commit)
+}
+
+func (s *PatchStackSuite) TestFindStackOfPatchWithoutStackFails() {
+	rm := getPatchStackManager("", 2)
+	(rm.Methods[0].RequestHandler).(*patchStackHandler).patchId = s.objIds[2].Hex()
+	res, err := rm.Methods[0].Execute(context.TODO(), s.sc)
+	s.Error(err)
+	s.Len(res.Result, 0)
+}
+
 ////////////////////////////////////////////////////////////////////////
 //
 // Tests for fetch patch by project route
@@ -2,14 +2,19 @@ package route
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/gorilla/mux"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
 )
 
 type (
@@ -46,13 +51,28 @@ func PrefetchUser(ctx context.Context, sc data.Connector, r *http.Request) (cont
 
 	if len(authDataAPIKey) > 0 {
 		apiUser, err := sc.FindUserById(authDataName)
-		if apiUser.(*user.DBUser) != nil && err == nil {
-			if apiUser.GetAPIKey() != authDataAPIKey {
+		if dbUser := apiUser.(*user.DBUser); dbUser != nil && err == nil {
+			token, err := dbUser.CheckAPIKey(authDataAPIKey)
+			if err != nil {
 				return ctx, rest.APIError{
 					StatusCode: http.StatusUnauthorized,
 					Message:    "Invalid API key",
 				}
 			}
+			if token != nil {
+				if !token.AllowsRequest(r.Method, r.URL.Path) {
+					return ctx, rest.APIError{
+						StatusCode: http.StatusForbidden,
+						Message:    fmt.Sprintf("API token '%s' does not allow this request", token.Name),
+					}
+				}
+				grip.Warning(message.WrapError(sc.MarkAPITokenUsed(dbUser, token.Name), message.Fields{
+					"message": "could not record use of API token",
+					"user":    dbUser.Id,
+					"token":   token.Name,
+				}))
+				ctx = context.WithValue(ctx, evergreen.RequestAPIToken, token)
+			}
 
 			ctx = context.WithValue(ctx, evergreen.RequestUser, apiUser)
 		}
@@ -86,6 +106,26 @@ func PrefetchProjectContext(ctx context.Context, sc data.Connector, r *http.Requ
 		}
 	}
 
+	if opCtx.ProjectRef != nil && opCtx.ProjectRef.Private && user != nil {
+		canView, err := hasProjectPermission(sc, user, opCtx.ProjectRef, role.ProjectView)
+		if err != nil {
+			return ctx, errors.Wrap(err, "problem checking permissions")
+		}
+		if !canView {
+			return ctx, rest.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Project not found",
+			}
+		}
+	}
+
+	if token := GetAPIToken(ctx); token != nil && opCtx.ProjectRef != nil && !token.AllowsProject(opCtx.ProjectRef.Identifier) {
+		return ctx, rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Project not found",
+		}
+	}
+
 	if opCtx.Patch != nil && user == nil {
 		return ctx, rest.APIError{
 			StatusCode: http.StatusNotFound,
@@ -104,6 +144,39 @@ func GetUser(ctx context.Context) *user.DBUser {
 	return u
 }
 
+// PrefetchAPITokenProject limits requests authenticated with a project
+// limited API token to routes that resolve one of the token's projects. It
+// resolves the project from the route's variables if no earlier prefetch
+// function did, and refuses requests on routes that don't name a project.
+func PrefetchAPITokenProject(ctx context.Context, sc data.Connector, r *http.Request) (context.Context, error) {
+	token := GetAPIToken(ctx)
+	if token == nil || !token.ProjectLimited() {
+		return ctx, nil
+	}
+
+	if GetProjectContext(ctx) == nil {
+		var err error
+		if ctx, err = PrefetchProjectContext(ctx, sc, r); err != nil {
+			return ctx, err
+		}
+	}
+	opCtx := GetProjectContext(ctx)
+	if opCtx == nil || opCtx.ProjectRef == nil {
+		return ctx, rest.APIError{
+			StatusCode: http.StatusForbidden,
+			Message:    fmt.Sprintf("API token '%s' is limited to projects and can't be used on this route", token.Name),
+		}
+	}
+	return ctx, nil
+}
+
+// GetAPIToken returns the API token that authenticated a given request, or
+// nil if the request was not authenticated with one.
+func GetAPIToken(ctx context.Context) *user.APIToken {
+	t, _ := ctx.Value(evergreen.RequestAPIToken).(*user.APIToken)
+	return t
+}
+
 // GetProjectContext returns the project context associated with a
 // given request.
 func GetProjectContext(ctx context.Context) *model.Context {
@@ -0,0 +1,308 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handlers for roles
+//
+//    /roles
+//    /roles/{role_id}
+
+func getRolesRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &rolesGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &rolePostHandler{},
+				MethodType:        http.MethodPost,
+			},
+		},
+		Version: version,
+	}
+}
+
+func getRoleDeleteRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &roleDeleteHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+		Version: version,
+	}
+}
+
+type rolesGetHandler struct{}
+
+func (h *rolesGetHandler) Handler() RequestHandler {
+	return &rolesGetHandler{}
+}
+
+func (h *rolesGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *rolesGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	roles, err := sc.FindRoles()
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	result := make([]restModel.Model, 0, len(roles))
+	for _, r := range roles {
+		apiRole := &restModel.APIRole{}
+		if err = apiRole.BuildFromService(r); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, apiRole)
+	}
+
+	return ResponseData{
+		Result: result,
+	}, nil
+}
+
+type rolePostHandler struct {
+	role role.Role
+}
+
+func (h *rolePostHandler) Handler() RequestHandler {
+	return &rolePostHandler{}
+}
+
+func (h *rolePostHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	apiRole := restModel.APIRole{}
+	if err := util.ReadJSONInto(body, &apiRole); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal role: %s", err),
+		}
+	}
+	i, err := apiRole.ToService()
+	if err != nil {
+		return errors.Wrap(err, "API model error")
+	}
+	h.role = i.(role.Role)
+
+	return nil
+}
+
+func (h *rolePostHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	if err := sc.UpsertRole(h.role); err != nil {
+		return ResponseData{}, err
+	}
+
+	apiRole := &restModel.APIRole{}
+	if err := apiRole.BuildFromService(h.role); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []restModel.Model{apiRole},
+	}, nil
+}
+
+type roleDeleteHandler struct {
+	id string
+}
+
+func (h *roleDeleteHandler) Handler() RequestHandler {
+	return &roleDeleteHandler{}
+}
+
+func (h *roleDeleteHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.id = mux.Vars(r)["role_id"]
+	return nil
+}
+
+func (h *roleDeleteHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	removed, err := sc.DeleteRole(h.id)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !removed {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("role '%s' does not exist", h.id),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handlers for role assignments
+//
+//    /role_assignments
+//    /role_assignments/{assignment_id}
+
+func getRoleAssignmentsRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &roleAssignmentsGetHandler{},
+				MethodType:        http.MethodGet,
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &roleAssignmentPostHandler{},
+				MethodType:        http.MethodPost,
+			},
+		},
+		Version: version,
+	}
+}
+
+func getRoleAssignmentDeleteRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &SuperUserAuthenticator{},
+				RequestHandler:    &roleAssignmentDeleteHandler{},
+				MethodType:        http.MethodDelete,
+			},
+		},
+		Version: version,
+	}
+}
+
+type roleAssignmentsGetHandler struct {
+	subject string
+}
+
+func (h *roleAssignmentsGetHandler) Handler() RequestHandler {
+	return &roleAssignmentsGetHandler{}
+}
+
+func (h *roleAssignmentsGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.subject = r.URL.Query().Get("subject")
+	return nil
+}
+
+func (h *roleAssignmentsGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	assignments, err := sc.FindRoleAssignments(h.subject)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	result := make([]restModel.Model, 0, len(assignments))
+	for _, a := range assignments {
+		apiAssignment := &restModel.APIRoleAssignment{}
+		if err = apiAssignment.BuildFromService(a); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, apiAssignment)
+	}
+
+	return ResponseData{
+		Result: result,
+	}, nil
+}
+
+type roleAssignmentPostHandler struct {
+	assignment role.Assignment
+}
+
+func (h *roleAssignmentPostHandler) Handler() RequestHandler {
+	return &roleAssignmentPostHandler{}
+}
+
+func (h *roleAssignmentPostHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	apiAssignment := restModel.APIRoleAssignment{}
+	if err := util.ReadJSONInto(body, &apiAssignment); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("failed to unmarshal role assignment: %s", err),
+		}
+	}
+	i, err := apiAssignment.ToService()
+	if err != nil {
+		return errors.Wrap(err, "API model error")
+	}
+	h.assignment = i.(role.Assignment)
+	// assignments are always given a new id
+	h.assignment.Id = ""
+
+	return nil
+}
+
+func (h *roleAssignmentPostHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	if err := sc.AddRoleAssignment(&h.assignment); err != nil {
+		return ResponseData{}, err
+	}
+
+	apiAssignment := &restModel.APIRoleAssignment{}
+	if err := apiAssignment.BuildFromService(h.assignment); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []restModel.Model{apiAssignment},
+	}, nil
+}
+
+type roleAssignmentDeleteHandler struct {
+	id string
+}
+
+func (h *roleAssignmentDeleteHandler) Handler() RequestHandler {
+	return &roleAssignmentDeleteHandler{}
+}
+
+func (h *roleAssignmentDeleteHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.id = mux.Vars(r)["assignment_id"]
+	return nil
+}
+
+func (h *roleAssignmentDeleteHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	removed, err := sc.DeleteRoleAssignment(h.id)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !removed {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("role assignment '%s' does not exist", h.id),
+		}
+	}
+
+	return ResponseData{}, nil
+}
@@ -0,0 +1,180 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestRoleSuite(t *testing.T) {
+	suite.Run(t, new(RoleSuite))
+}
+
+func (s *RoleSuite) SetupTest() {
+	s.sc = &data.MockConnector{}
+	s.sc.SetSuperUsers([]string{"admin"})
+	s.sc.MockRoleConnector.Roles = []role.Role{
+		{Id: "contractor", Permissions: []string{role.ProjectView, role.TaskRestart}},
+	}
+	s.ctx = context.WithValue(context.Background(), evergreen.RequestUser, &user.DBUser{Id: "contractor1"})
+}
+
+func (s *RoleSuite) withProject(ref *serviceModel.ProjectRef) context.Context {
+	return context.WithValue(s.ctx, RequestContext, &serviceModel.Context{ProjectRef: ref})
+}
+
+func (s *RoleSuite) TestPermissionAuthenticator() {
+	restart := &RequirePermissionAuthenticator{Permission: role.TaskRestart}
+	edit := &RequirePermissionAuthenticator{Permission: role.ProjectEdit}
+	public := &serviceModel.ProjectRef{Identifier: "public"}
+	private := &serviceModel.ProjectRef{Identifier: "private", Private: true, Admins: []string{"project_admin"}}
+
+	// everyone may restart tasks on public projects, but not edit them
+	s.NoError(restart.Authenticate(s.withProject(public), s.sc))
+	s.Error(edit.Authenticate(s.withProject(public), s.sc))
+
+	// private projects require a role
+	s.Error(restart.Authenticate(s.withProject(private), s.sc))
+	s.sc.MockRoleConnector.Assignments = []role.Assignment{
+		{Role: "contractor", Subject: "contractor1", ResourceType: role.ProjectResource, ResourceId: "private"},
+	}
+	s.NoError(restart.Authenticate(s.withProject(private), s.sc))
+	s.Error(edit.Authenticate(s.withProject(private), s.sc))
+
+	// project admins and super users have every permission
+	for _, name := range []string{"project_admin", "admin"} {
+		ctx := context.WithValue(s.withProject(private), evergreen.RequestUser, &user.DBUser{Id: name})
+		s.NoError(edit.Authenticate(ctx, s.sc))
+	}
+
+	noUser := context.WithValue(context.Background(), RequestContext, &serviceModel.Context{ProjectRef: public})
+	s.Error(restart.Authenticate(noUser, s.sc))
+}
+
+func (s *RoleSuite) TestPrefetchPrivateProject() {
+	s.sc.MockContextConnector.CachedContext = serviceModel.Context{
+		ProjectRef: &serviceModel.ProjectRef{Identifier: "private", Private: true},
+	}
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	s.Require().NoError(err)
+
+	_, err = PrefetchProjectContext(s.ctx, s.sc, req)
+	s.Error(err)
+
+	s.sc.MockRoleConnector.Assignments = []role.Assignment{
+		{Role: "contractor", Subject: "contractor1", ResourceType: role.ProjectResource, ResourceId: role.AllResources},
+	}
+	ctx, err := PrefetchProjectContext(s.ctx, s.sc, req)
+	s.NoError(err)
+	s.NotNil(GetProjectContext(ctx))
+}
+
+func (s *RoleSuite) TestRoles() {
+	post := &rolePostHandler{}
+	req, err := http.NewRequest(http.MethodPost, "/roles",
+		bytes.NewBufferString(`{"id": "operator", "name": "Operator", "permissions": ["host:terminate", "distro:edit"]}`))
+	s.Require().NoError(err)
+	s.NoError(post.ParseAndValidate(s.ctx, req))
+	_, err = post.Execute(s.ctx, s.sc)
+	s.NoError(err)
+
+	req, err = http.NewRequest(http.MethodPost, "/roles",
+		bytes.NewBufferString(`{"id": "bad", "permissions": ["host:reboot"]}`))
+	s.Require().NoError(err)
+	post = &rolePostHandler{}
+	s.NoError(post.ParseAndValidate(s.ctx, req))
+	_, err = post.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	response, err := (&rolesGetHandler{}).Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 2)
+	apiRole, ok := response.Result[1].(*model.APIRole)
+	s.Require().True(ok)
+	s.Equal("operator", model.FromAPIString(apiRole.Id))
+	s.Len(apiRole.Permissions, 2)
+
+	del := &roleDeleteHandler{id: "operator"}
+	_, err = del.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	_, err = del.Execute(s.ctx, s.sc)
+	s.Error(err)
+}
+
+func (s *RoleSuite) TestRoleAssignments() {
+	post := &roleAssignmentPostHandler{}
+	req, err := http.NewRequest(http.MethodPost, "/role_assignments",
+		bytes.NewBufferString(`{"role": "contractor", "subject": "group:contractors", "resource_type": "project", "resource_id": "mci"}`))
+	s.Require().NoError(err)
+	s.NoError(post.ParseAndValidate(s.ctx, req))
+	response, err := post.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Require().Len(response.Result, 1)
+	apiAssignment, ok := response.Result[0].(*model.APIRoleAssignment)
+	s.Require().True(ok)
+	id := model.FromAPIString(apiAssignment.Id)
+	s.NotEmpty(id)
+
+	req, err = http.NewRequest(http.MethodPost, "/role_assignments",
+		bytes.NewBufferString(`{"role": "missing", "subject": "octocat", "resource_type": "project", "resource_id": "mci"}`))
+	s.Require().NoError(err)
+	post = &roleAssignmentPostHandler{}
+	s.NoError(post.ParseAndValidate(s.ctx, req))
+	_, err = post.Execute(s.ctx, s.sc)
+	s.Error(err)
+
+	get := &roleAssignmentsGetHandler{}
+	req, err = http.NewRequest(http.MethodGet, "/role_assignments?subject=group:contractors", nil)
+	s.Require().NoError(err)
+	s.NoError(get.ParseAndValidate(s.ctx, req))
+	response, err = get.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Len(response.Result, 1)
+
+	del := &roleAssignmentDeleteHandler{id: id}
+	_, err = del.Execute(s.ctx, s.sc)
+	s.NoError(err)
+	s.Empty(s.sc.MockRoleConnector.Assignments)
+}
+
+func (s *RoleSuite) TestTaskChangingRoutesRequireRestartPermission() {
+	managers := map[string]*RouteManager{
+		"task":            getTaskRouteManager("", 2),
+		"task abort":      getTaskAbortManager("", 2),
+		"task restart":    getTaskRestartRouteManager("", 2),
+		"build":           getBuildByIdRouteManager("", 2),
+		"build abort":     getBuildAbortRouteManager("", 2),
+		"build restart":   getBuildRestartManager("", 2),
+		"version abort":   getAbortVersionRouteManager("", 2),
+		"version restart": getRestartVersionRouteManager("", 2),
+		"patch":           getPatchByIdManager("", 2),
+		"patch abort":     getPatchAbortManager("", 2),
+		"patch restart":   getPatchRestartManager("", 2),
+	}
+	for name, manager := range managers {
+		for _, method := range manager.Methods {
+			if method.MethodType == http.MethodGet {
+				continue
+			}
+			authenticator, ok := method.Authenticator.(*RequirePermissionAuthenticator)
+			s.Require().True(ok, name)
+			s.Equal(role.TaskRestart, authenticator.Permission, name)
+			s.Len(method.PrefetchFunctions, 2, name)
+		}
+	}
+}
@@ -11,19 +11,19 @@ import (
 // AttachHandler attaches the api's request handlers to the given mux router.
 // It builds a Connector then attaches each of the main functions for
 // the api to the router.
-func AttachHandler(root *mux.Router, queue amboy.Queue, URL, prefix string, superUsers []string, githubSecret []byte) http.Handler {
+func AttachHandler(root *mux.Router, queue amboy.Queue, URL, prefix string, superUsers []string, githubSecret, githubAppSecret []byte) http.Handler {
 	sc := &data.DBConnector{}
 
 	sc.SetURL(URL)
 	sc.SetPrefix(prefix)
 	sc.SetSuperUsers(superUsers)
-	return GetHandler(root, sc, queue, githubSecret)
+	return GetHandler(root, sc, queue, githubSecret, githubAppSecret)
 }
 
 // GetHandler builds each of the functions that this api implements and then
 // registers them on the given router. It then returns the given router as an
 // http handler which can be given more functions.
-func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecret []byte) http.Handler {
+func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecret, githubAppSecret []byte) http.Handler {
 	routes := map[string]routeManagerFactory{
 		"/":                                  getPlaceHolderManger,
 		"/admin":                             getLegacyAdminSettingsManager,
@@ -34,15 +34,21 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/admin/service_flags":               getServiceFlagsRouteManager,
 		"/admin/settings":                    getAdminSettingsManager,
 		"/alias/{name}":                      getAliasRouteManager,
+		"/api_tokens":                        getAPITokensRouteManager,
+		"/api_tokens/{token_name}":           getAPITokenDeleteRouteManager,
+		"/audit":                             getAuditRouteManager,
 		"/builds/{build_id}":                 getBuildByIdRouteManager,
 		"/builds/{build_id}/abort":           getBuildAbortRouteManager,
 		"/builds/{build_id}/restart":         getBuildRestartManager,
 		"/builds/{build_id}/tasks":           getTasksByBuildRouteManager,
+		"/commit_queue/{project_id}":         getCommitQueueRouteManager,
+		"/commit_queue/{project_id}/{item}":  getCommitQueueDeleteItemRouteManager,
 		"/cost/distro/{distro_id}":           getCostByDistroIdRouteManager,
 		"/cost/project/{project_id}/tasks":   getCostTaskByProjectRouteManager,
 		"/cost/version/{version_id}":         getCostByVersionIdRouteManager,
 		"/distros":                           getDistroRouteManager,
-		"/hooks/github":                      getGithubHooksRouteManager(queue, githubSecret),
+		"/hooks/github":                      getGithubHooksRouteManager(queue, githubSecret, githubAppSecret),
+		"/hooks/slack":                       getSlackHooksRouteManager(queue),
 		"/hosts":                             getHostRouteManager,
 		"/hosts/{host_id}":                   getHostIDRouteManager,
 		"/hosts/{host_id}/change_password":   getHostChangeRDPPasswordRouteManager,
@@ -53,10 +59,19 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/patches/{patch_id}":                                  getPatchByIdManager,
 		"/patches/{patch_id}/abort":                            getPatchAbortManager,
 		"/patches/{patch_id}/restart":                          getPatchRestartManager,
+		"/patches/{patch_id}/stack":                            getPatchStackManager,
 		"/projects":                                            getProjectRouteManager,
+		"/projects/{project_id}/flaky_tests":                   getFlakyTestsRouteManager,
+		"/projects/{project_id}/notification_templates":        getNotificationTemplatesRouteManager,
+		"/projects/{project_id}/notification_templates/preview": getNotificationTemplatePreviewRouteManager,
 		"/projects/{project_id}/patches":                       getPatchesByProjectManager,
+		"/projects/{project_id}/quarantined_tests":             getQuarantinedTestsRouteManager,
 		"/projects/{project_id}/recent_versions":               getRecentVersionsManager,
 		"/projects/{project_id}/revisions/{commit_hash}/tasks": getTasksByProjectAndCommitRouteManager,
+		"/role_assignments":                                    getRoleAssignmentsRouteManager,
+		"/role_assignments/{assignment_id}":                    getRoleAssignmentDeleteRouteManager,
+		"/roles":                                               getRolesRouteManager,
+		"/roles/{role_id}":                                     getRoleDeleteRouteManager,
 		"/status/cli_version":                                  getCLIVersionRouteManager,
 		"/status/hosts/distros":                                getHostStatsByDistroManager,
 		"/status/recent_tasks":                                 getRecentTasksRouteManager,
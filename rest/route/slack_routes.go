@@ -0,0 +1,129 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/hooks/slack
+
+// slackHookApi handles the interaction payloads that slack sends when a
+// button on a task alert is clicked. Slack requires an answer within three
+// seconds, so the actions are taken by a job, which reports their results
+// back to the user in slack.
+type slackHookApi struct {
+	queue amboy.Queue
+
+	body      []byte
+	timestamp string
+	signature string
+
+	interaction thirdparty.SlackInteraction
+}
+
+func getSlackHooksRouteManager(queue amboy.Queue) routeManagerFactory {
+	return func(route string, version int) *RouteManager {
+		return &RouteManager{
+			Route: route,
+			Methods: []MethodHandler{
+				{
+					Authenticator:  &NoAuthAuthenticator{},
+					RequestHandler: &slackHookApi{queue: queue},
+					MethodType:     http.MethodPost,
+				},
+			},
+			Version: version,
+		}
+	}
+}
+
+func (sh *slackHookApi) Handler() RequestHandler {
+	return &slackHookApi{queue: sh.queue}
+}
+
+func (sh *slackHookApi) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	sh.timestamp = r.Header.Get("X-Slack-Request-Timestamp")
+	sh.signature = r.Header.Get("X-Slack-Signature")
+
+	var err error
+	sh.body, err = ioutil.ReadAll(r.Body)
+	if err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "failed to read request body",
+		}
+	}
+	form, err := url.ParseQuery(string(sh.body))
+	if err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "request body must be form encoded",
+		}
+	}
+	if err = json.Unmarshal([]byte(form.Get("payload")), &sh.interaction); err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("invalid slack payload: %s", err.Error()),
+		}
+	}
+	if sh.interaction.Type != thirdparty.SlackInteractionBlockActions {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("unsupported slack interaction '%s'", sh.interaction.Type),
+		}
+	}
+
+	return nil
+}
+
+func (sh *slackHookApi) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	settings, err := sc.GetEvergreenSettings()
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "problem retrieving settings")
+	}
+	if settings == nil || settings.Slack.SigningSecret == "" || sh.queue == nil {
+		grip.Warning("Slack signing secret is empty! Slack interactions have been disabled!")
+		return ResponseData{}, rest.APIError{
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    "slack interactions are not configured",
+		}
+	}
+	if err = thirdparty.VerifySlackSignature([]byte(settings.Slack.SigningSecret), sh.timestamp, sh.signature, sh.body, time.Now()); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"source":     "slack hook",
+			"message":    "rejecting slack interaction",
+			"slack_user": sh.interaction.User.ID,
+		}))
+		return ResponseData{}, rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "invalid slack signature",
+		}
+	}
+
+	j := units.NewSlackInteractionJob(sh.interaction)
+	if _, ok := sh.queue.Get(j.ID()); ok {
+		// slack sent the interaction again because the first answer was late
+		return ResponseData{}, nil
+	}
+	if err = sh.queue.Put(j); err != nil {
+		return ResponseData{}, errors.Wrap(err, "problem queueing slack interaction")
+	}
+
+	return ResponseData{}, nil
+}
@@ -0,0 +1,88 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/alerts"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/mongodb/amboy/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackInteraction(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	settings := &evergreen.Settings{}
+	settings.Slack.SigningSecret = "secret"
+	sc := &data.MockConnector{
+		MockAdminConnector: data.MockAdminConnector{MockSettings: settings},
+	}
+	// the queue isn't started, so the queued jobs don't run
+	q := queue.NewLocalOrdered(1)
+
+	interact := func(triggerID string, sign func(timestamp string, body []byte) string) error {
+		payload, err := json.Marshal(map[string]interface{}{
+			"type":         "block_actions",
+			"user":         map[string]string{"id": "U123", "username": "slack.admin"},
+			"actions":      []map[string]string{{"action_id": alerts.SlackActionRestartTask, "block_id": "t1", "value": "t1"}},
+			"response_url": "https://hooks.slack.com/actions/1",
+			"trigger_id":   triggerID,
+		})
+		require.NoError(err)
+		body := []byte(url.Values{"payload": []string{string(payload)}}.Encode())
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req, err := http.NewRequest(http.MethodPost, "/hooks/slack", bytes.NewReader(body))
+		require.NoError(err)
+		req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		req.Header.Set("X-Slack-Signature", sign(timestamp, body))
+
+		h := (&slackHookApi{queue: q}).Handler()
+		require.NoError(h.ParseAndValidate(context.Background(), req))
+		_, err = h.Execute(context.Background(), sc)
+		return err
+	}
+	sign := func(timestamp string, body []byte) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write([]byte(fmt.Sprintf("v0:%s:", timestamp)))
+		_, _ = mac.Write(body)
+		return "v0=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	err := interact("1", func(string, []byte) string { return "v0=bad" })
+	require.Error(err)
+	assert.Equal(http.StatusUnauthorized, err.(rest.APIError).StatusCode)
+	assert.Equal(0, q.Stats().Total)
+
+	require.NoError(interact("1", sign))
+	assert.Equal(1, q.Stats().Total)
+	_, ok := q.Get("slack-interaction:U123:1")
+	assert.True(ok)
+
+	// slack sending the interaction again doesn't queue it again
+	require.NoError(interact("1", sign))
+	assert.Equal(1, q.Stats().Total)
+
+	require.NoError(interact("2", sign))
+	assert.Equal(2, q.Stats().Total)
+
+	settings.Slack.SigningSecret = ""
+	err = interact("3", sign)
+	require.Error(err)
+	assert.Equal(http.StatusServiceUnavailable, err.(rest.APIError).StatusCode)
+}
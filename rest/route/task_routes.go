@@ -9,6 +9,8 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
@@ -26,7 +28,7 @@ func getTaskRestartRouteManager(route string, version int) *RouteManager {
 	trh := &taskRestartHandler{}
 	taskRestart := MethodHandler{
 		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
-		Authenticator:     &RequireUserAuthenticator{},
+		Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 		RequestHandler:    trh.Handler(),
 		MethodType:        http.MethodPost,
 	}
@@ -59,8 +61,8 @@ func getTasksByBuildRouteManager(route string, version int) *RouteManager {
 func getTaskRouteManager(route string, version int) *RouteManager {
 	tep := &TaskExecutionPatchHandler{}
 	taskExecutionPatch := MethodHandler{
-		PrefetchFunctions: []PrefetchFunc{PrefetchProjectContext, PrefetchUser},
-		Authenticator:     &NoAuthAuthenticator{},
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 		RequestHandler:    tep.Handler(),
 		MethodType:        http.MethodPatch,
 	}
@@ -105,9 +107,9 @@ func getTaskAbortManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
 				MethodType:        http.MethodPost,
-				Authenticator:     &RequireUserAuthenticator{},
+				Authenticator:     &RequirePermissionAuthenticator{Permission: role.TaskRestart},
 				RequestHandler:    t.Handler(),
 			},
 		},
@@ -536,6 +538,7 @@ func (tep *TaskExecutionPatchHandler) ParseAndValidate(ctx context.Context, r *h
 // Execute sets the Activated and Priority field of the given task and returns
 // an updated version of the task.
 func (tep *TaskExecutionPatchHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	before := map[string]interface{}{"priority": tep.task.Priority, "activated": tep.task.Activated}
 	if tep.Priority != nil {
 		priority := *tep.Priority
 		if priority > evergreen.MaxTaskPriority &&
@@ -560,6 +563,7 @@ func (tep *TaskExecutionPatchHandler) Execute(ctx context.Context, sc data.Conne
 	if err != nil {
 		return ResponseData{}, errors.Wrap(err, "Database error")
 	}
+	event.AuditChanges(ctx, before, map[string]interface{}{"priority": refreshedTask.Priority, "activated": refreshedTask.Activated})
 
 	taskModel := &model.APITask{}
 	err = taskModel.BuildFromService(refreshedTask)
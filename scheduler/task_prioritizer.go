@@ -235,7 +235,9 @@ func (self *CmpBasedTaskComparator) splitTasksByRequester(
 		switch {
 		case task.Priority > evergreen.MaxTaskPriority:
 			priorityTasks = append(priorityTasks, task)
-		case task.Requester == evergreen.RepotrackerVersionRequester:
+		case task.Requester == evergreen.RepotrackerVersionRequester,
+			task.Requester == evergreen.PeriodicBuildRequester,
+			task.Requester == evergreen.TriggerRequester:
 			repoTrackerTasks = append(repoTrackerTasks, task)
 		case evergreen.IsPatchRequester(task.Requester):
 			patchTasks = append(patchTasks, task)
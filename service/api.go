@@ -151,6 +151,10 @@ func (as *APIServer) checkProject(next http.HandlerFunc) http.HandlerFunc {
 			as.LoggedError(w, r, http.StatusNotFound, errors.New("project not found"))
 			return
 		}
+		if token := GetAPIToken(r); token != nil && !token.AllowsProject(projectRef.Identifier) {
+			as.LoggedError(w, r, http.StatusNotFound, errors.New("project not found"))
+			return
+		}
 
 		p, err := model.FindProject("", projectRef)
 		if err != nil {
@@ -205,6 +209,36 @@ func (as *APIServer) GetVersion(w http.ResponseWriter, r *http.Request) {
 	as.WriteJSON(w, http.StatusOK, v)
 }
 
+// FetchArtifacts returns the links of the artifacts of other tasks that the
+// task fetches before its commands run.
+func (as *APIServer) FetchArtifacts(w http.ResponseWriter, r *http.Request) {
+	t := MustHaveTask(r)
+
+	v, err := version.FindOne(version.ById(t.Version))
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if v == nil {
+		http.Error(w, "version not found", http.StatusNotFound)
+		return
+	}
+
+	project := &model.Project{}
+	if err = model.LoadProjectInto([]byte(v.Config), v.Identifier, project); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "problem loading project config"))
+		return
+	}
+
+	artifacts, err := model.FindArtifactsToFetch(t, project)
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	as.WriteJSON(w, http.StatusOK, artifacts)
+}
+
 func (as *APIServer) GetProjectRef(w http.ResponseWriter, r *http.Request) {
 	t := MustHaveTask(r)
 
@@ -279,7 +313,10 @@ func (as *APIServer) FetchProjectVars(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	as.WriteJSON(w, http.StatusOK, projectVars.Vars)
+	as.WriteJSON(w, http.StatusOK, apimodels.ExpansionVars{
+		Vars:        projectVars.Vars,
+		PrivateVars: projectVars.PrivateVars,
+	})
 }
 
 // AttachFiles updates file mappings for a task or build
@@ -624,7 +661,7 @@ func (as *APIServer) AttachRoutes(root *mux.Router) {
 	AttachRESTHandler(root, as)
 	// attaches /rest/v2 routes
 	APIV2Prefix := evergreen.APIRoutePrefix + "/" + evergreen.RestRoutePrefix
-	route.AttachHandler(root, as.queue, as.Settings.ApiUrl, APIV2Prefix, as.Settings.SuperUsers, []byte(as.Settings.Api.GithubWebhookSecret))
+	route.AttachHandler(root, as.queue, as.Settings.ApiUrl, APIV2Prefix, as.Settings.SuperUsers, []byte(as.Settings.Api.GithubWebhookSecret), []byte(as.Settings.GithubApp.WebhookSecret))
 
 	r := root.PathPrefix("/api/2/").Subrouter()
 	r.HandleFunc("/", home)
@@ -661,8 +698,8 @@ func (as *APIServer) AttachRoutes(root *mux.Router) {
 	// Routes for operating on existing spawn hosts - get info, terminate, etc.
 	spawn := apiRootOld.PathPrefix("/spawn/").Subrouter()
 	spawn.HandleFunc("/{instance_id:[\\w_\\-\\@]+}/", requireUser(as.hostInfo, nil)).Methods("GET")
-	spawn.HandleFunc("/{instance_id:[\\w_\\-\\@]+}/", requireUser(as.modifyHost, nil)).Methods("POST")
-	spawn.HandleFunc("/ready/{instance_id:[\\w_\\-\\@]+}/{status}", requireUser(as.spawnHostReady, nil)).Methods("POST")
+	spawn.HandleFunc("/{instance_id:[\\w_\\-\\@]+}/", requireUser(requireUnlimitedToken(as.modifyHost), nil)).Methods("POST")
+	spawn.HandleFunc("/ready/{instance_id:[\\w_\\-\\@]+}/{status}", requireUser(requireUnlimitedToken(as.spawnHostReady), nil)).Methods("POST")
 
 	runtimes := apiRootOld.PathPrefix("/runtimes/").Subrouter()
 	runtimes.HandleFunc("/", as.listRuntimes).Methods("GET")
@@ -681,7 +718,7 @@ func (as *APIServer) AttachRoutes(root *mux.Router) {
 
 	// Spawnhost routes - creating new hosts, listing existing hosts, listing distros
 	spawns := apiRootOld.PathPrefix("/spawns/").Subrouter()
-	spawns.HandleFunc("/", requireUser(as.requestHost, nil)).Methods("PUT")
+	spawns.HandleFunc("/", requireUser(requireUnlimitedToken(as.requestHost), nil)).Methods("PUT")
 	spawns.HandleFunc("/{user}/", requireUser(as.hostsInfoForUser, nil)).Methods("GET")
 	spawns.HandleFunc("/distros/list/", requireUser(as.listDistros, nil)).Methods("GET")
 
@@ -697,6 +734,7 @@ func (as *APIServer) AttachRoutes(root *mux.Router) {
 	taskRouter.HandleFunc("/log", as.checkTask(true, as.checkHost(as.AppendTaskLog))).Methods("POST")
 	taskRouter.HandleFunc("/heartbeat", as.checkTask(true, as.checkHost(as.Heartbeat))).Methods("POST")
 	taskRouter.HandleFunc("/results", as.checkTask(true, as.checkHost(as.AttachResults))).Methods("POST")
+	taskRouter.HandleFunc("/perf_results", as.checkTask(true, as.checkHost(as.AttachPerfResults))).Methods("POST")
 	taskRouter.HandleFunc("/test_logs", as.checkTask(true, as.checkHost(as.AttachTestLog))).Methods("POST")
 	taskRouter.HandleFunc("/files", as.checkTask(false, as.checkHost(as.AttachFiles))).Methods("POST")
 	taskRouter.HandleFunc("/system_info", as.checkTask(true, as.checkHost(as.TaskSystemInfo))).Methods("POST")
@@ -706,6 +744,7 @@ func (as *APIServer) AttachRoutes(root *mux.Router) {
 	taskRouter.HandleFunc("/version", as.checkTask(false, as.GetVersion)).Methods("GET")
 	taskRouter.HandleFunc("/project_ref", as.checkTask(false, as.GetProjectRef)).Methods("GET")
 	taskRouter.HandleFunc("/fetch_vars", as.checkTask(true, as.FetchProjectVars)).Methods("GET")
+	taskRouter.HandleFunc("/fetch_artifacts", as.checkTask(true, as.FetchArtifacts)).Methods("GET")
 
 	// plugins
 	taskRouter.HandleFunc("/git/patchfile/{patchfile_id}", as.checkTask(false, as.gitServePatchFile)).Methods("GET")
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/spawn"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/pkg/errors"
+)
+
+// getDebugHold returns the user that the host of a failed task should
+// be held for, and for how many minutes. The duration is the larger of
+// the task's debug_on_failure setting and the patch's.
+func getDebugHold(t *task.Task) (string, int, error) {
+	mins := t.DebugOnFailureMins
+
+	if evergreen.IsPatchRequester(t.Requester) {
+		p, err := patch.FindOne(patch.ByVersion(t.Version))
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "problem finding patch for version %s", t.Version)
+		}
+		if p == nil {
+			return "", 0, errors.Errorf("no patch found for version %s", t.Version)
+		}
+		if p.DebugOnFailureMins > mins {
+			mins = p.DebugOnFailureMins
+		}
+		return p.Author, mins, nil
+	}
+
+	if mins <= 0 {
+		return "", 0, nil
+	}
+
+	v, err := version.FindOne(version.ById(t.Version))
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "problem finding version %s", t.Version)
+	}
+	if v == nil {
+		return "", 0, errors.Errorf("no version found with id %s", t.Version)
+	}
+	return v.AuthorID, mins, nil
+}
+
+// holdHostForDebugging takes the host of a failed task out of rotation
+// and gives it to the author of the task's patch or commit, if the task
+// or patch set debug_on_failure. It returns true if the host was held.
+func (as *APIServer) holdHostForDebugging(t *task.Task, h *host.Host) (bool, error) {
+	if !h.IsEphemeral() || h.StartedBy != evergreen.User {
+		return false, nil
+	}
+
+	ownerID, mins, err := getDebugHold(t)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if mins <= 0 {
+		return false, nil
+	}
+	if ownerID == "" {
+		return false, errors.Errorf("task %s has no author to hold host %s for", t.Id, h.Id)
+	}
+
+	owner, err := user.FindOne(user.ById(ownerID))
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding user %s", ownerID)
+	}
+	if owner == nil {
+		return false, errors.Errorf("user %s not found", ownerID)
+	}
+
+	if err = spawn.HoldHostForDebugging(h, owner, t.Id, time.Duration(mins)*time.Minute); err != nil {
+		return false, errors.Wrapf(err, "problem holding host %s for user %s", h.Id, ownerID)
+	}
+
+	job := units.NewHostDebugHoldJob(evergreen.GetEnvironment(), h.Id, t.Id)
+	return true, errors.Wrap(as.queue.Put(job), "couldn't queue job to authorize keys on held host")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/spawn"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/units"
 	"github.com/evergreen-ci/evergreen/util"
@@ -44,6 +45,17 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		Tasks       []string `json:"tasks"`
 		Finalize    bool     `json:"finalize"`
 		Alias       string   `json:"alias"`
+
+		// DebugOnFailure is the number of minutes to hold the hosts
+		// of failed tasks for debugging.
+		DebugOnFailure int `json:"debug_on_failure"`
+
+		// Stack places the patch in a stack of patches, one for each
+		// commit of a local branch, and Commit and CommitMessage
+		// identify the patch's commit.
+		Stack         *patch.PatchStack `json:"stack,omitempty"`
+		Commit        string            `json:"commit,omitempty"`
+		CommitMessage string            `json:"commit_message,omitempty"`
 	}{}
 	if err := util.ReadJSONInto(util.NewRequestReaderWithSize(r, patch.SizeLimit), &data); err != nil {
 		as.LoggedError(w, r, http.StatusBadRequest, err)
@@ -53,6 +65,32 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		as.LoggedError(w, r, http.StatusBadRequest, errors.New("Patch is too large."))
 		return
 	}
+	if token := GetAPIToken(r); token != nil && !token.AllowsProject(data.Project) {
+		as.LoggedError(w, r, http.StatusForbidden, errors.Errorf("API token '%s' does not allow project '%s'", token.Name, data.Project))
+		return
+	}
+	if data.DebugOnFailure < 0 || time.Duration(data.DebugOnFailure)*time.Minute > spawn.MaxDebugHoldDuration {
+		as.LoggedError(w, r, http.StatusBadRequest, errors.Errorf("debug_on_failure must be between 0 and %.0f minutes",
+			spawn.MaxDebugHoldDuration.Minutes()))
+		return
+	}
+	if data.Stack != nil {
+		if err := data.Stack.Validate(); err != nil {
+			as.LoggedError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		if data.Stack.Position > 1 {
+			first, err := patch.FindOne(patch.ById(patch.NewId(data.Stack.ID)))
+			if err != nil {
+				as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "can't fetch first patch of the stack"))
+				return
+			}
+			if first == nil || first.Author != dbUser.Id || first.Stack == nil || first.Stack.Size != data.Stack.Size {
+				as.LoggedError(w, r, http.StatusBadRequest, errors.Errorf("'%s' is not the first patch of a stack", data.Stack.ID))
+				return
+			}
+		}
+	}
 	variants := strings.Split(data.Variants, ",")
 
 	intent, err := patch.NewCliIntent(dbUser.Id, data.Project, data.Githash, r.FormValue("module"), data.Patch, data.Description, data.Finalize, variants, data.Tasks, data.Alias)
@@ -88,6 +126,18 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		as.LoggedError(w, r, http.StatusInternalServerError, errors.New("patch couldn't be found"))
 		return
 	}
+	if data.DebugOnFailure > 0 {
+		if err = patchDoc.SetDebugOnFailure(data.DebugOnFailure); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "can't set debug_on_failure for patch"))
+			return
+		}
+	}
+	if data.Stack != nil {
+		if err = patchDoc.SetStack(*data.Stack, data.Commit, data.CommitMessage); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "can't set stack for patch"))
+			return
+		}
+	}
 
 	as.WriteJSON(w, http.StatusCreated, PatchAPIResponse{Patch: patchDoc})
 }
@@ -112,6 +162,9 @@ func getPatchFromRequest(r *http.Request) (*patch.Patch, error) {
 	if existingPatch == nil {
 		return nil, errors.Errorf("no existing request with id: %v", patchIdStr)
 	}
+	if token := GetAPIToken(r); token != nil && !token.AllowsProject(existingPatch.Project) {
+		return nil, errors.Errorf("no existing request with id: %v", patchIdStr)
+	}
 
 	return existingPatch, nil
 }
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/perfresult"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AttachPerfResults stores the performance results reported by the
+// perf.send command, and queues a job to detect regressions in them.
+func (as *APIServer) AttachPerfResults(w http.ResponseWriter, r *http.Request) {
+	t := MustHaveTask(r)
+
+	results := []perfresult.PerfResult{}
+	if err := util.ReadJSONInto(util.NewRequestReader(r), &results); err != nil {
+		as.LoggedError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if len(results) == 0 {
+		as.WriteJSON(w, http.StatusOK, "no perf results to attach")
+		return
+	}
+
+	catcher := grip.NewBasicCatcher()
+	now := time.Now()
+	for i := range results {
+		catcher.Add(results[i].Validate())
+
+		results[i].TaskID = t.Id
+		results[i].Execution = t.Execution
+		results[i].TaskName = t.DisplayName
+		results[i].Project = t.Project
+		results[i].BuildVariant = t.BuildVariant
+		results[i].Version = t.Version
+		results[i].Revision = t.Revision
+		results[i].Order = t.RevisionOrderNumber
+		results[i].Requester = t.Requester
+		results[i].CreateTime = now
+		results[i].ChangePoint = nil
+		results[i].ID = bson.NewObjectId()
+	}
+	if catcher.HasErrors() {
+		as.LoggedError(w, r, http.StatusBadRequest, errors.Wrap(catcher.Resolve(), "invalid perf results"))
+		return
+	}
+
+	if err := perfresult.InsertMany(results); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := as.queue.Put(units.NewPerfChangePointJob(t.Id, t.Execution, results[0].ID.Hex())); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "problem queueing change point detection for task %s", t.Id))
+		return
+	}
+
+	as.WriteJSON(w, http.StatusOK, "perf results successfully attached")
+}
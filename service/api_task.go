@@ -164,10 +164,19 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 	}
 	if t.Requester == evergreen.GithubPRRequester {
 		if updates.BuildNewStatus == evergreen.BuildFailed || updates.BuildNewStatus == evergreen.BuildSucceeded {
-			job := units.NewGithubStatusUpdateJobForBuild(t.BuildId)
-			if err = as.queue.Put(job); err != nil {
-				as.LoggedError(w, r, http.StatusInternalServerError, errors.New("couldn't queue job to update github status"))
-				return
+			// builds are reported as check runs in place of commit
+			// statuses when the GitHub App is configured
+			if as.Settings.GithubApp.Enabled() {
+				if err = as.queue.Put(units.NewGithubCheckRunJob(t.BuildId)); err != nil {
+					as.LoggedError(w, r, http.StatusInternalServerError, errors.New("couldn't queue job to create github check run"))
+					return
+				}
+			} else {
+				job := units.NewGithubStatusUpdateJobForBuild(t.BuildId)
+				if err = as.queue.Put(job); err != nil {
+					as.LoggedError(w, r, http.StatusInternalServerError, errors.New("couldn't queue job to update github status"))
+					return
+				}
 			}
 		}
 
@@ -179,6 +188,15 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	if t.Requester == evergreen.MergeTestRequester {
+		if updates.PatchNewStatus == evergreen.PatchFailed || updates.PatchNewStatus == evergreen.PatchSucceeded {
+			job := units.NewCommitQueueJob(t.Project, t.Version)
+			if err = as.queue.Put(job); err != nil {
+				as.LoggedError(w, r, http.StatusInternalServerError, errors.New("couldn't queue job to update commit queue"))
+				return
+			}
+		}
+	}
 	// the task was aborted if it is still in undispatched.
 	// the active state should be inactive.
 	if details.Status == evergreen.TaskUndispatched {
@@ -208,7 +226,9 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !evergreen.IsPatchRequester(t.Requester) {
+	// tasks that are restarted automatically haven't really failed, so
+	// they don't alert
+	if !evergreen.IsPatchRequester(t.Requester) && !updates.TaskAutoRestarted {
 		if t.IsPartOfDisplay() {
 			parent := t.DisplayTask
 			if task.IsFinished(*parent) {
@@ -230,6 +250,28 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 		grip.Errorln("Error updating expected duration:", err)
 	}
 
+	// hosts of failed tasks that set debug_on_failure are given to the
+	// author instead of running more tasks.
+	if details.Status == evergreen.TaskFailed && !updates.TaskAutoRestarted {
+		held, err := as.holdHostForDebugging(t, currentHost)
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "problem holding host for debugging",
+			"host_id": currentHost.Id,
+			"task_id": t.Id,
+		}))
+		if held {
+			grip.Info(message.Fields{
+				"message": "holding host for debugging",
+				"host_id": currentHost.Id,
+				"task_id": t.Id,
+				"user":    currentHost.StartedBy,
+			})
+			endTaskResp.ShouldExit = true
+			as.WriteJSON(w, http.StatusOK, endTaskResp)
+			return
+		}
+	}
+
 	if checkHostHealth(currentHost) {
 		// set the needs new agent flag on the host
 		if err := currentHost.SetNeedsNewAgent(true); err != nil {
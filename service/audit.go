@@ -0,0 +1,148 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/gorilla/mux"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/urfave/negroni"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// auditedResources are the route variables that identify the resource a
+// request changes, most specific first.
+var auditedResources = []struct {
+	variable     string
+	resourceType string
+}{
+	{"task_id", "task"},
+	{"taskId", "task"},
+	{"host_id", "host"},
+	{"hostId", "host"},
+	{"patch_id", "patch"},
+	{"build_id", "build"},
+	{"version_id", "version"},
+	{"distro_id", "distro"},
+	{"project_id", "project"},
+	{"projectId", "project"},
+	{"user_id", "user"},
+	{"role_id", "role"},
+	{"assignment_id", "role_assignment"},
+	{"key_name", "key"},
+	{"token_name", "api_token"},
+}
+
+// AuditLogger is a middleware handler that records an audit event for each
+// request by a user that may change something. Handlers can add the state
+// of the resource before and after the change with event.AuditChanges.
+type AuditLogger struct {
+	router *mux.Router
+}
+
+// NewAuditLogger returns negroni middleware that audits the requests to the
+// router. It must run after the UserMiddleware.
+func NewAuditLogger(router *mux.Router) *AuditLogger {
+	return &AuditLogger{router: router}
+}
+
+func (l *AuditLogger) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	u := GetUser(r)
+	if u == nil || !isMutatingMethod(r.Method) {
+		next(rw, r)
+		return
+	}
+
+	data := &event.AuditEventData{
+		User:   u.Username(),
+		Method: r.Method,
+		Path:   r.URL.Path,
+	}
+	if token := GetAPIToken(r); token != nil {
+		data.APIToken = token.Name
+	}
+	var resourceId string
+	match := &mux.RouteMatch{}
+	if l.router.Match(r, match) {
+		data.ResourceType, resourceId = auditedResource(match.Vars)
+	}
+
+	next(rw, r.WithContext(event.WithAuditEvent(r.Context(), data)))
+
+	if res, ok := rw.(negroni.ResponseWriter); ok {
+		data.Status = res.Status()
+	}
+	grip.Error(message.WrapError(event.LogAuditEvent(resourceId, *data), message.Fields{
+		"message": "problem recording audit event",
+		"user":    data.User,
+		"method":  data.Method,
+		"path":    data.Path,
+	}))
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// auditedResource returns the type and id of the resource identified by the
+// route variables, if any.
+func auditedResource(vars map[string]string) (string, string) {
+	for _, resource := range auditedResources {
+		if id := vars[resource.variable]; id != "" {
+			return resource.resourceType, id
+		}
+	}
+	return "", ""
+}
+
+// auditSnapshot copies the state of a resource, so that later changes to the
+// resource don't change the state recorded from before a change.
+func auditSnapshot(in interface{}) interface{} {
+	raw, err := bson.Marshal(in)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "problem copying state for the audit log",
+		}))
+		return nil
+	}
+	out := bson.M{}
+	if err = bson.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// auditedProject is the state of a project recorded in the audit log.
+type auditedProject struct {
+	Ref         model.ProjectRef  `bson:"ref" json:"ref"`
+	Vars        map[string]string `bson:"vars,omitempty" json:"vars,omitempty"`
+	PrivateVars map[string]bool   `bson:"private_vars,omitempty" json:"private_vars,omitempty"`
+}
+
+// newAuditedProject copies the state of a project, without the values of its
+// private variables.
+func newAuditedProject(ref model.ProjectRef, vars *model.ProjectVars) auditedProject {
+	state := auditedProject{Ref: ref}
+	if vars == nil {
+		return state
+	}
+	state.Vars = map[string]string{}
+	state.PrivateVars = map[string]bool{}
+	for k, v := range vars.Vars {
+		if vars.PrivateVars[k] {
+			v = ""
+		}
+		state.Vars[k] = v
+	}
+	for k, v := range vars.PrivateVars {
+		state.PrivateVars[k] = v
+	}
+	return state
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditedResource(t *testing.T) {
+	assert := assert.New(t)
+
+	resourceType, id := auditedResource(map[string]string{"project_id": "mci", "task_id": "t1"})
+	assert.Equal("task", resourceType)
+	assert.Equal("t1", id)
+
+	resourceType, id = auditedResource(map[string]string{"projectId": "mci"})
+	assert.Equal("project", resourceType)
+	assert.Equal("mci", id)
+
+	resourceType, id = auditedResource(map[string]string{"name": "alias"})
+	assert.Empty(resourceType)
+	assert.Empty(id)
+
+	assert.True(isMutatingMethod("POST"))
+	assert.True(isMutatingMethod("DELETE"))
+	assert.False(isMutatingMethod("GET"))
+}
+
+func TestNewAuditedProjectRedactsPrivateVars(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := &model.ProjectVars{
+		Id:          "mci",
+		Vars:        map[string]string{"public": "a", "secret": "b"},
+		PrivateVars: map[string]bool{"secret": true},
+	}
+	state := newAuditedProject(model.ProjectRef{Identifier: "mci"}, vars)
+	assert.Equal("a", state.Vars["public"])
+	assert.Equal("", state.Vars["secret"])
+	assert.True(state.PrivateVars["secret"])
+
+	// the project's own variables are left alone
+	assert.Equal("b", vars.Vars["secret"])
+
+	state = newAuditedProject(model.ProjectRef{Identifier: "mci"}, nil)
+	assert.Nil(state.Vars)
+}
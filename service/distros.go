@@ -60,6 +60,7 @@ func (uis *UIServer) modifyDistro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	before := auditSnapshot(oldDistro)
 	newDistro := oldDistro
 
 	// attempt to unmarshal data into distros field for type validation
@@ -105,6 +106,7 @@ func (uis *UIServer) modifyDistro(w http.ResponseWriter, r *http.Request) {
 	}
 
 	event.LogDistroModified(id, u.Username(), newDistro)
+	event.AuditChanges(r.Context(), before, newDistro)
 
 	message := fmt.Sprintf("Distro %v successfully updated.", id)
 	if shouldDeco {
@@ -135,6 +137,7 @@ func (uis *UIServer) removeDistro(w http.ResponseWriter, r *http.Request) {
 	}
 
 	event.LogDistroRemoved(id, u.Username(), d)
+	event.AuditChanges(r.Context(), d, nil)
 
 	PushFlash(uis.CookieStore, r, w, NewSuccessFlash(fmt.Sprintf("Distro %v successfully removed.", id)))
 	uis.WriteJSON(w, http.StatusOK, "distro successfully removed")
@@ -207,6 +210,7 @@ func (uis *UIServer) addDistro(w http.ResponseWriter, r *http.Request) {
 	}
 
 	event.LogDistroAdded(d.Id, u.Username(), d)
+	event.AuditChanges(r.Context(), nil, d)
 
 	PushFlash(uis.CookieStore, r, w, NewSuccessFlash(fmt.Sprintf("Distro %v successfully added.", d.Id)))
 	uis.WriteJSON(w, http.StatusOK, "distro successfully added")
@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/plugin"
 	"github.com/evergreen-ci/evergreen/util"
@@ -136,6 +137,19 @@ func requireUser(onSuccess, onFail http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// requireUnlimitedToken takes a request handler and returns a wrapped version
+// which refuses requests authenticated with an API token that is limited to
+// projects, for routes that don't belong to a project.
+func requireUnlimitedToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token := GetAPIToken(r); token != nil && token.ProjectLimited() {
+			http.Error(w, fmt.Sprintf("Forbidden - API token '%s' is limited to projects", token.Name), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
 // requireSuperUser takes a request handler and returns a wrapped version which verifies that
 // the requester is authenticated as a superuser. For a requester who isn't a super user, the
 // request will be redirected to the login page instead.
@@ -154,6 +168,66 @@ func (uis *UIServer) requireSuperUser(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// requirePermission takes a request handler and returns a wrapped version which verifies that
+// the user has the permission: on the project context's project for project permissions, or on
+// the distro in the URL for distro permissions. For a requester without the permission, the
+// request will be redirected to the login page instead.
+func (uis *UIServer) requirePermission(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dbUser := GetUser(r)
+		if dbUser == nil {
+			uis.RedirectToLogin(w, r)
+			return
+		}
+
+		var allowed bool
+		var err error
+		switch role.ResourceType(permission) {
+		case role.ProjectResource:
+			allowed, err = uis.hasProjectPermission(dbUser, MustHaveProjectContext(r).ProjectRef, permission)
+		case role.DistroResource:
+			allowed, err = uis.hasDistroPermission(dbUser, mux.Vars(r)["distro_id"], permission)
+		}
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "Error checking permissions"))
+			return
+		}
+		if !allowed {
+			uis.RedirectToLogin(w, r)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// hasProjectPermission returns whether the user has the permission on the project. Super users
+// and the project's admins have every permission, every user has the public permissions on
+// projects that are not private, and other permissions come from the roles assigned to the user.
+func (uis *UIServer) hasProjectPermission(u *user.DBUser, project *model.ProjectRef, permission string) (bool, error) {
+	if u == nil || project == nil {
+		return false, nil
+	}
+	if uis.isSuperUser(u) || isAdmin(u, project) {
+		return true, nil
+	}
+	if !project.Private && util.StringSliceContains(role.PublicPermissions, permission) {
+		return true, nil
+	}
+	return role.UserHasPermission(u, role.ProjectResource, project.Identifier, permission)
+}
+
+// hasDistroPermission returns whether the user has the permission on the distro. Super users
+// have every permission, and other permissions come from the roles assigned to the user.
+func (uis *UIServer) hasDistroPermission(u *user.DBUser, distroId string, permission string) (bool, error) {
+	if u == nil {
+		return false, nil
+	}
+	if uis.isSuperUser(u) {
+		return true, nil
+	}
+	return role.UserHasPermission(u, role.DistroResource, distroId, permission)
+}
+
 // isSuperUser verifies that a given user has super user permissions.
 // A user has these permission if they are in the super users list or if the list is empty,
 // in which case all users are super users.
@@ -161,22 +235,16 @@ func (uis *UIServer) isSuperUser(u *user.DBUser) bool {
 	if u == nil {
 		return false
 	}
-	if util.StringSliceContains(uis.Settings.SuperUsers, u.Id) ||
-		len(uis.Settings.SuperUsers) == 0 {
-		return true
-	}
-
-	return false
-
+	return auth.IsSuperUser(uis.Settings.SuperUsers, u)
 }
 
-// isAdmin returns false if the user is nil or if its id is not
-// located in ProjectRef's Admins field.
+// isAdmin returns false if the user is nil or if neither its id nor one of
+// its groups is located in ProjectRef's Admins field.
 func isAdmin(u *user.DBUser, project *model.ProjectRef) bool {
 	if u == nil {
 		return false
 	}
-	return util.StringSliceContains(project.Admins, u.Id)
+	return auth.IsProjectAdmin(project.Admins, u)
 }
 
 // RedirectToLogin forces a redirect to the login page. The redirect param is set on the query
@@ -214,6 +282,26 @@ func (uis *UIServer) loadCtx(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// project limited tokens can only be used on pages of their projects
+		if token := GetAPIToken(r); token != nil && token.ProjectLimited() &&
+			(projCtx.ProjectRef == nil || !requestNamesProject(r) || !token.AllowsProject(projCtx.ProjectRef.Identifier)) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+
+		// a private project named by the request can only be viewed with permission
+		if projCtx.ProjectRef != nil && projCtx.ProjectRef.Private && requestNamesProject(r) {
+			canView, err := uis.hasProjectPermission(GetUser(r), projCtx.ProjectRef, role.ProjectView)
+			if err != nil {
+				uis.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "Error checking permissions"))
+				return
+			}
+			if !canView {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+		}
+
 		if projCtx.Patch != nil && GetUser(r) == nil {
 			uis.RedirectToLogin(w, r)
 			return
@@ -256,6 +344,18 @@ func (pc *projectContext) populateProjectRefs(includePrivate, isSuperUser bool,
 	return nil
 }
 
+// requestNamesProject returns whether the project of the request is named by its URL, rather
+// than remembered from a previous request or defaulted.
+func requestNamesProject(r *http.Request) bool {
+	vars := mux.Vars(r)
+	for _, key := range []string{"task_id", "build_id", "version_id", "patch_id", "project_id"} {
+		if vars[key] != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // getRequestProjectId determines the projectId to associate with the request context,
 // in cases where it could not be inferred from a task/build/version/patch etc.
 // The projectId is determined using the following criteria in order of priority:
@@ -378,10 +478,23 @@ func UserMiddleware(um auth.UserManager) func(rw http.ResponseWriter, r *http.Re
 		} else if len(authDataAPIKey) > 0 {
 			dbUser, err := user.FindOne(user.ById(authDataName))
 			if dbUser != nil && err == nil {
-				if dbUser.APIKey != authDataAPIKey {
+				token, err := dbUser.CheckAPIKey(authDataAPIKey)
+				if err != nil {
 					http.Error(rw, "Unauthorized - invalid API key", http.StatusUnauthorized)
 					return
 				}
+				if token != nil {
+					if !token.AllowsRequest(r.Method, r.URL.Path) {
+						http.Error(rw, fmt.Sprintf("Forbidden - API token '%s' does not allow this request", token.Name), http.StatusForbidden)
+						return
+					}
+					grip.Warning(message.WrapError(dbUser.MarkAPITokenUsed(token.Name), message.Fields{
+						"message": "could not record use of API token",
+						"user":    dbUser.Id,
+						"token":   token.Name,
+					}))
+					r = setRequestAPIToken(r, token)
+				}
 				r = setRequestUser(r, dbUser)
 			} else {
 				grip.Errorln("Error getting user:", err)
@@ -35,6 +35,10 @@ func setRequestUser(r *http.Request, u auth.User) *http.Request {
 	return r.WithContext(context.WithValue(r.Context(), evergreen.RequestUser, u))
 }
 
+func setRequestAPIToken(r *http.Request, t *user.APIToken) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), evergreen.RequestAPIToken, t))
+}
+
 func setRequestID(r *http.Request, id int) *http.Request {
 	return r.WithContext(context.WithValue(r.Context(), requestID, id))
 }
@@ -92,6 +96,13 @@ func GetUser(r *http.Request) *user.DBUser {
 	return nil
 }
 
+// GetAPIToken returns the API token that authenticated the request, or nil if
+// the request was not authenticated with one.
+func GetAPIToken(r *http.Request) *user.APIToken {
+	t, _ := r.Context().Value(evergreen.RequestAPIToken).(*user.APIToken)
+	return t
+}
+
 // GetProjectContext fetches the projectContext associated with the request. Returns an error
 // if no projectContext has been loaded and attached to the request.
 func GetProjectContext(r *http.Request) (projectContext, error) {
@@ -9,8 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/alerts"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/user"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/units"
@@ -169,25 +171,30 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Project not found", http.StatusNotFound)
 		return
 	}
+	origProjectRef := *projectRef
 
 	responseRef := struct {
-		Identifier         string               `json:"id"`
-		DisplayName        string               `json:"display_name"`
-		RemotePath         string               `json:"remote_path"`
-		BatchTime          int                  `json:"batch_time"`
-		DeactivatePrevious bool                 `json:"deactivate_previous"`
-		Branch             string               `json:"branch_name"`
-		ProjVarsMap        map[string]string    `json:"project_vars"`
-		ProjectAliases     []model.ProjectAlias `json:"project_aliases"`
-		DeleteAliases      []string             `json:"delete_aliases"`
-		PrivateVars        map[string]bool      `json:"private_vars"`
-		Enabled            bool                 `json:"enabled"`
-		Private            bool                 `json:"private"`
-		Owner              string               `json:"owner_name"`
-		Repo               string               `json:"repo_name"`
-		Admins             []string             `json:"admins"`
-		TracksPushEvents   bool                 `json:"tracks_push_events"`
-		PRTestingEnabled   bool                 `json:"pr_testing_enabled"`
+		Identifier         string                          `json:"id"`
+		DisplayName        string                          `json:"display_name"`
+		RemotePath         string                          `json:"remote_path"`
+		BatchTime          int                             `json:"batch_time"`
+		DeactivatePrevious bool                            `json:"deactivate_previous"`
+		Branch             string                          `json:"branch_name"`
+		ProjVarsMap        map[string]string               `json:"project_vars"`
+		ProjectAliases     []model.ProjectAlias            `json:"project_aliases"`
+		DeleteAliases      []string                        `json:"delete_aliases"`
+		PrivateVars        map[string]bool                 `json:"private_vars"`
+		Enabled            bool                            `json:"enabled"`
+		Private            bool                            `json:"private"`
+		Owner              string                          `json:"owner_name"`
+		Repo               string                          `json:"repo_name"`
+		Admins             []string                        `json:"admins"`
+		TracksPushEvents   bool                            `json:"tracks_push_events"`
+		PRTestingEnabled   bool                            `json:"pr_testing_enabled"`
+		CommitQueue        model.CommitQueueParams         `json:"commit_queue"`
+		PeriodicBuilds     []model.PeriodicBuildDefinition `json:"periodic_builds"`
+		Triggers           []model.TriggerDefinition       `json:"triggers"`
+		TaskRetry          model.TaskRetryParams           `json:"task_retry"`
 		AlertConfig        map[string][]struct {
 			Provider string                 `json:"provider"`
 			Settings map[string]interface{} `json:"settings"`
@@ -225,6 +232,39 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 			errs = append(errs, fmt.Sprintf("task regex #%d is invalid", i+1))
 		}
 	}
+	if responseRef.CommitQueue.Enabled {
+		switch responseRef.CommitQueue.MergeMethod {
+		case "", "merge", "squash", "rebase":
+		default:
+			errs = append(errs, fmt.Sprintf("commit queue merge method '%s' must be one of merge, squash, or rebase", responseRef.CommitQueue.MergeMethod))
+		}
+	}
+	if responseRef.TaskRetry.MaxRetries < 0 || responseRef.TaskRetry.MaxRetries > evergreen.MaxTaskExecution {
+		errs = append(errs, fmt.Sprintf("task retries must be between 0 and %d", evergreen.MaxTaskExecution))
+	}
+	periodicBuildIds := map[string]bool{}
+	for _, definition := range responseRef.PeriodicBuilds {
+		if err := definition.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if periodicBuildIds[definition.ID] {
+			errs = append(errs, fmt.Sprintf("periodic build id '%s' is used more than once", definition.ID))
+		}
+		periodicBuildIds[definition.ID] = true
+	}
+	triggerIds := map[string]bool{}
+	for _, definition := range responseRef.Triggers {
+		if err := definition.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if definition.Project == id {
+			errs = append(errs, fmt.Sprintf("trigger '%s' cannot have the project itself as its upstream project", definition.ID))
+		}
+		if triggerIds[definition.ID] {
+			errs = append(errs, fmt.Sprintf("trigger id '%s' is used more than once", definition.ID))
+		}
+		triggerIds[definition.ID] = true
+	}
 	if len(errs) > 0 {
 		errMsg := ""
 		for _, err := range errs {
@@ -250,6 +290,12 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// users who can edit the project but aren't its admins can't change who its admins are
+	if !uis.isSuperUser(dbUser) && !isAdmin(dbUser, projectRef) && !sameAdmins(projectRef.Admins, responseRef.Admins) {
+		http.Error(w, "Only project admins can change the project's admins", http.StatusUnauthorized)
+		return
+	}
+
 	projectRef.DisplayName = responseRef.DisplayName
 	projectRef.RemotePath = responseRef.RemotePath
 	projectRef.BatchTime = responseRef.BatchTime
@@ -263,6 +309,15 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	projectRef.Identifier = id
 	projectRef.TracksPushEvents = responseRef.TracksPushEvents
 	projectRef.PRTestingEnabled = responseRef.PRTestingEnabled
+	projectRef.CommitQueue = responseRef.CommitQueue
+	for i := range responseRef.PeriodicBuilds {
+		if old := projectRef.GetPeriodicBuild(responseRef.PeriodicBuilds[i].ID); old != nil {
+			responseRef.PeriodicBuilds[i].LastScheduled = old.LastScheduled
+		}
+	}
+	projectRef.PeriodicBuilds = responseRef.PeriodicBuilds
+	projectRef.Triggers = responseRef.Triggers
+	projectRef.TaskRetry = responseRef.TaskRetry
 
 	projectRef.Alerts = map[string][]model.AlertConfig{}
 	for triggerId, alerts := range responseRef.AlertConfig {
@@ -277,6 +332,7 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 
 	if !projectRef.Enabled {
 		projectRef.PRTestingEnabled = false
+		projectRef.CommitQueue.Enabled = false
 	}
 
 	projectVars, err := model.FindOneProjectVars(id)
@@ -284,6 +340,7 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	before := newAuditedProject(origProjectRef, projectVars)
 
 	if responseRef.SetupGithubHook {
 		var hook *model.GithubHook
@@ -366,6 +423,7 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	event.AuditChanges(r.Context(), before, newAuditedProject(*projectRef, projectVars))
 
 	catcher := grip.NewSimpleCatcher()
 	for i := range responseRef.ProjectAliases {
@@ -539,3 +597,18 @@ func (uis *UIServer) setupGithubHook(projectRef *model.ProjectRef) (int, error)
 
 	return *hook.ID, nil
 }
+
+// sameAdmins returns whether the two lists name the same admins, in any order.
+func sameAdmins(a, b []string) bool {
+	for _, admin := range a {
+		if !util.StringSliceContains(b, admin) {
+			return false
+		}
+	}
+	for _, admin := range b {
+		if !util.StringSliceContains(a, admin) {
+			return false
+		}
+	}
+	return true
+}
@@ -44,6 +44,7 @@ type uiTaskData struct {
 	TaskWaiting      string                  `json:"task_waiting"`
 	Activated        bool                    `json:"activated"`
 	Restarts         int                     `json:"restarts"`
+	AutoRestarts     int                     `json:"auto_restarts"`
 	Execution        int                     `json:"execution"`
 	TotalExecutions  int                     `json:"total_executions"`
 	StartTime        int64                   `json:"start_time"`
@@ -64,6 +65,8 @@ type uiTaskData struct {
 	HostDNS string `json:"host_dns,omitempty"`
 	// from the host doc (the host id)
 	HostId string `json:"host_id,omitempty"`
+	// set when the host is held for debugging this task
+	DebugHold *uiDebugHold `json:"debug_hold,omitempty"`
 
 	// for breadcrumb
 	BuildVariantDisplay string `json:"build_variant_display"`
@@ -95,6 +98,13 @@ type uiTaskData struct {
 	PartOfDisplay  bool         `json:"in_display"`
 }
 
+// uiDebugHold describes a host that is held for debugging a failed task.
+type uiDebugHold struct {
+	Owner          string    `json:"owner"`
+	SSHCommand     string    `json:"ssh_command"`
+	ExpirationTime time.Time `json:"expiration_time"`
+}
+
 type uiDep struct {
 	Id             string                  `json:"id"`
 	Name           string                  `json:"display_name"`
@@ -207,6 +217,7 @@ func (uis *UIServer) taskPage(w http.ResponseWriter, r *http.Request) {
 		BuildId:             projCtx.Task.BuildId,
 		Activated:           projCtx.Task.Activated,
 		Restarts:            projCtx.Task.Restarts,
+		AutoRestarts:        projCtx.Task.AutoRestarts,
 		Execution:           projCtx.Task.Execution,
 		Requester:           projCtx.Task.Requester,
 		StartTime:           projCtx.Task.StartTime.UnixNano(),
@@ -261,6 +272,13 @@ func (uis *UIServer) taskPage(w http.ResponseWriter, r *http.Request) {
 		}
 		if taskHost != nil {
 			uiTask.HostDNS = taskHost.Host
+			if taskHost.DebugTask == projCtx.Task.Id && taskHost.Status == evergreen.HostRunning {
+				uiTask.DebugHold = &uiDebugHold{
+					Owner:          taskHost.StartedBy,
+					SSHCommand:     fmt.Sprintf("ssh %s@%s", taskHost.User, taskHost.Host),
+					ExpirationTime: taskHost.ExpirationTime,
+				}
+			}
 		}
 	}
 
@@ -651,6 +669,7 @@ func (uis *UIServer) taskModify(w http.ResponseWriter, r *http.Request) {
 		return
 	case "set_active":
 		active := putParams.Active
+		before := map[string]interface{}{"priority": projCtx.Task.Priority, "activated": projCtx.Task.Activated}
 		if err = model.SetActiveState(projCtx.Task.Id, authUser.Username(), active); err != nil {
 			http.Error(w, fmt.Sprintf("Error activating task %v: %v", projCtx.Task.Id, err),
 				http.StatusInternalServerError)
@@ -661,7 +680,9 @@ func (uis *UIServer) taskModify(w http.ResponseWriter, r *http.Request) {
 		projCtx.Task, err = task.FindOne(task.ById(projCtx.Task.Id))
 		if err != nil {
 			uis.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
 		}
+		event.AuditChanges(r.Context(), before, map[string]interface{}{"priority": projCtx.Task.Priority, "activated": projCtx.Task.Activated})
 		uis.WriteJSON(w, http.StatusOK, projCtx.Task)
 		return
 	case "set_priority":
@@ -680,6 +701,7 @@ func (uis *UIServer) taskModify(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Cannot set a negative priority. If this task should not run, it should be unscheduled.", http.StatusBadRequest)
 			return
 		}
+		before := map[string]interface{}{"priority": projCtx.Task.Priority, "activated": projCtx.Task.Activated}
 		if err = projCtx.Task.SetPriority(priority, authUser.Username()); err != nil {
 			http.Error(w, fmt.Sprintf("Error setting task priority %v: %v", projCtx.Task.Id, err), http.StatusInternalServerError)
 			return
@@ -689,7 +711,9 @@ func (uis *UIServer) taskModify(w http.ResponseWriter, r *http.Request) {
 		projCtx.Task, err = task.FindOne(task.ById(projCtx.Task.Id))
 		if err != nil {
 			uis.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
 		}
+		event.AuditChanges(r.Context(), before, map[string]interface{}{"priority": projCtx.Task.Priority, "activated": projCtx.Task.Activated})
 		uis.WriteJSON(w, http.StatusOK, projCtx.Task)
 		return
 	default:
@@ -8,8 +8,8 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model/user"
-	"github.com/evergreen-ci/evergreen/util"
 	"github.com/gorilla/mux"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
@@ -49,7 +49,7 @@ func MakeTemplateFuncs(fo FuncOptions, superUsers []string) (map[string]interfac
 	r := map[string]interface{}{
 		// IsSuperUser returns true if the given user Id has super user privileges.
 		"IsSuperUser": func(userName string) bool {
-			return len(superUsers) == 0 || util.StringSliceContains(superUsers, userName)
+			return len(superUsers) == 0 || auth.IsNamedIn(superUsers, userName)
 		},
 		// Gravatar returns a Gravatar URL for the given email string.
 		"Gravatar": func(email string) string {
@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/plugin"
 	"github.com/evergreen-ci/evergreen/rest/route"
@@ -146,7 +147,7 @@ func (uis *UIServer) AttachRoutes(r *mux.Router) error {
 	// Task page (and related routes)
 	r.HandleFunc("/task/{task_id}", uis.loadCtx(uis.taskPage)).Methods("GET")
 	r.HandleFunc("/task/{task_id}/{execution}", uis.loadCtx(uis.taskPage)).Methods("GET")
-	r.HandleFunc("/tasks/{task_id}", requireLogin(uis.loadCtx(uis.taskModify))).Methods("PUT")
+	r.HandleFunc("/tasks/{task_id}", uis.loadCtx(uis.requirePermission(role.TaskRestart, uis.taskModify))).Methods("PUT")
 	r.HandleFunc("/json/task_log/{task_id}", uis.loadCtx(uis.taskLog))
 	r.HandleFunc("/json/task_log/{task_id}/{execution}", uis.loadCtx(uis.taskLog))
 	r.HandleFunc("/task_log_raw/{task_id}/{execution}", uis.loadCtx(uis.taskLogRaw))
@@ -161,12 +162,12 @@ func (uis *UIServer) AttachRoutes(r *mux.Router) error {
 
 	// Build page
 	r.HandleFunc("/build/{build_id}", uis.loadCtx(uis.buildPage)).Methods("GET")
-	r.HandleFunc("/builds/{build_id}", requireLogin(uis.loadCtx(uis.modifyBuild))).Methods("PUT")
+	r.HandleFunc("/builds/{build_id}", uis.loadCtx(uis.requirePermission(role.TaskRestart, uis.modifyBuild))).Methods("PUT")
 	r.HandleFunc("/json/build_history/{build_id}", uis.loadCtx(uis.buildHistory)).Methods("GET")
 
 	// Version page
 	r.HandleFunc("/version/{version_id}", uis.loadCtx(uis.versionPage)).Methods("GET")
-	r.HandleFunc("/version/{version_id}", requireLogin(uis.loadCtx(uis.modifyVersion))).Methods("PUT")
+	r.HandleFunc("/version/{version_id}", uis.loadCtx(uis.requirePermission(role.TaskRestart, uis.modifyVersion))).Methods("PUT")
 	r.HandleFunc("/json/version_history/{version_id}", uis.loadCtx(uis.versionHistory))
 	r.HandleFunc("/version/{project_id}/{revision}", uis.loadCtx(uis.versionFind)).Methods("GET")
 
@@ -181,8 +182,8 @@ func (uis *UIServer) AttachRoutes(r *mux.Router) error {
 	r.HandleFunc("/distros", uis.requireSuperUser(uis.loadCtx(uis.addDistro))).Methods("PUT")
 	r.HandleFunc("/distros/{distro_id}", requireLogin(uis.loadCtx(uis.getDistro))).Methods("GET")
 	r.HandleFunc("/distros/{distro_id}", uis.requireSuperUser(uis.loadCtx(uis.addDistro))).Methods("PUT")
-	r.HandleFunc("/distros/{distro_id}", uis.requireSuperUser(uis.loadCtx(uis.modifyDistro))).Methods("POST")
-	r.HandleFunc("/distros/{distro_id}", uis.requireSuperUser(uis.loadCtx(uis.removeDistro))).Methods("DELETE")
+	r.HandleFunc("/distros/{distro_id}", uis.requirePermission(role.DistroEdit, uis.loadCtx(uis.modifyDistro))).Methods("POST")
+	r.HandleFunc("/distros/{distro_id}", uis.requirePermission(role.DistroEdit, uis.loadCtx(uis.removeDistro))).Methods("DELETE")
 
 	// Event Logs
 	r.HandleFunc("/event_log/{resource_type}/{resource_id:[\\w_\\-\\:\\.\\@]+}", uis.loadCtx(uis.fullEventLogs))
@@ -233,6 +234,7 @@ func (uis *UIServer) AttachRoutes(r *mux.Router) error {
 	r.HandleFunc("/settings", requireLogin(uis.loadCtx(uis.userSettingsPage))).Methods("GET")
 	r.HandleFunc("/settings", requireLogin(uis.loadCtx(uis.userSettingsModify))).Methods("PUT")
 	r.HandleFunc("/settings/newkey", requireLogin(uis.loadCtx(uis.newAPIKey))).Methods("POST")
+	r.HandleFunc("/settings/slack", requireLogin(uis.loadCtx(uis.linkSlackAccount))).Methods("POST")
 
 	// Task stats
 	r.HandleFunc("/task_timing", requireLogin(uis.loadCtx(uis.taskTimingPage))).Methods("GET")
@@ -242,10 +244,10 @@ func (uis *UIServer) AttachRoutes(r *mux.Router) error {
 
 	// Project routes
 	r.HandleFunc("/projects", requireLogin(uis.loadCtx(uis.projectsPage))).Methods("GET")
-	r.HandleFunc("/project/{project_id}", uis.loadCtx(uis.requireAdmin(uis.projectPage))).Methods("GET")
-	r.HandleFunc("/project/{project_id}", uis.loadCtx(uis.requireAdmin(uis.modifyProject))).Methods("POST")
+	r.HandleFunc("/project/{project_id}", uis.loadCtx(uis.requirePermission(role.ProjectEdit, uis.projectPage))).Methods("GET")
+	r.HandleFunc("/project/{project_id}", uis.loadCtx(uis.requirePermission(role.ProjectEdit, uis.modifyProject))).Methods("POST")
 	r.HandleFunc("/project/{project_id}", uis.loadCtx(uis.requireAdmin(uis.addProject))).Methods("PUT")
-	r.HandleFunc("/project/{project_id}/repo_revision", uis.loadCtx(uis.requireAdmin(uis.setRevision))).Methods("PUT")
+	r.HandleFunc("/project/{project_id}/repo_revision", uis.loadCtx(uis.requirePermission(role.ProjectEdit, uis.setRevision))).Methods("PUT")
 
 	// Admin routes
 	r.HandleFunc("/admin", requireLogin(uis.loadCtx(uis.adminSettings))).Methods("GET")
@@ -255,7 +257,7 @@ func (uis *UIServer) AttachRoutes(r *mux.Router) error {
 	AttachRESTHandler(r, uis)
 
 	// attaches /rest/v2 routes
-	route.AttachHandler(r, uis.queue, uis.Settings.Ui.Url, evergreen.RestRoutePrefix, uis.Settings.SuperUsers, []byte(uis.Settings.Api.GithubWebhookSecret))
+	route.AttachHandler(r, uis.queue, uis.Settings.Ui.Url, evergreen.RestRoutePrefix, uis.Settings.SuperUsers, []byte(uis.Settings.Api.GithubWebhookSecret), []byte(uis.Settings.GithubApp.WebhookSecret))
 
 	// Static Path handlers
 	r.PathPrefix("/clients").Handler(http.StripPrefix("/clients", http.FileServer(http.Dir(filepath.Join(uis.Home, evergreen.ClientDirectory)))))
@@ -85,6 +85,32 @@ func (uis *UIServer) newAPIKey(w http.ResponseWriter, r *http.Request) {
 	}{newKey})
 }
 
+// linkSlackAccount links the Slack account that has the user's email address
+// to the user, so that the user can act on Slack alerts.
+func (uis *UIServer) linkSlackAccount(w http.ResponseWriter, r *http.Request) {
+	currentUser := MustHaveUser(r)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	slackUser, err := thirdparty.FindSlackUserByEmail(ctx, uis.Settings.Slack.Token, currentUser.Email())
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "Error fetching user from Slack"))
+		return
+	}
+	if slackUser == nil {
+		uis.LoggedError(w, r, http.StatusNotFound, errors.Errorf("No Slack account has the email address '%s'", currentUser.Email()))
+		return
+	}
+
+	member := user.SlackMember{ID: slackUser.ID, Name: slackUser.Name}
+	if err = currentUser.LinkSlackMember(member); err != nil {
+		uis.LoggedError(w, r, http.StatusBadRequest, errors.Wrap(err, "Error linking Slack account"))
+		return
+	}
+	uis.WriteJSON(w, http.StatusOK, member)
+}
+
 func (uis *UIServer) userSettingsPage(w http.ResponseWriter, r *http.Request) {
 	currentUser := MustHaveUser(r)
 	settingsData := currentUser.Settings
@@ -146,6 +172,9 @@ func (uis *UIServer) userSettingsModify(w http.ResponseWriter, r *http.Request)
 		userSettings.GithubUser.UID = currentUser.Settings.GithubUser.UID
 	}
 
+	// the slack account can only be changed by linking it
+	userSettings.SlackMember = currentUser.Settings.SlackMember
+
 	if err := model.SaveUserSettings(currentUser.Username(), userSettings); err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError,
 			errors.Wrap(err, "Error saving user settings"))
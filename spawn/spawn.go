@@ -35,6 +35,10 @@ const (
 	MaxPerUser                 = 3
 	DefaultExpiration          = 24 * time.Hour
 	MaxExpirationDurationHours = 24 * time.Hour * 7 // 7 days
+
+	// MaxDebugHoldDuration is the longest that a host can be held
+	// for debugging after a task fails.
+	MaxDebugHoldDuration = DefaultExpiration
 )
 
 // Options holds the required parameters for spawning a host.
@@ -140,14 +144,87 @@ func CreateHost(so Options) (*host.Host, error) {
 	return intentHost, errors.WithStack(err)
 }
 
+// HoldHostForDebugging gives the build host that ran a failed task to
+// the owner, in the same way as a spawn host, so that the failure can be
+// debugged. The host is terminated once the duration has passed.
+func HoldHostForDebugging(h *host.Host, owner *user.DBUser, taskID string, duration time.Duration) error {
+	if owner == nil {
+		return errors.New("cannot hold host for nil user")
+	}
+	if !h.IsEphemeral() {
+		return errors.Errorf("cannot hold host '%s' with provider '%s'", h.Id, h.Provider)
+	}
+	if duration <= 0 {
+		return errors.New("debug hold duration must be positive")
+	}
+	if duration > MaxDebugHoldDuration {
+		duration = MaxDebugHoldDuration
+	}
+
+	activeSpawnedHosts, err := host.Find(host.ByUserWithRunningStatus(owner.Id))
+	if err != nil {
+		return errors.Wrap(err, "Error occurred finding user's current hosts")
+	}
+	if len(activeSpawnedHosts) >= MaxPerUser {
+		return errors.Errorf("User is already running the max allowed number of spawn hosts (%d of %d)",
+			len(activeSpawnedHosts), MaxPerUser)
+	}
+
+	return errors.WithStack(h.HoldForDebugging(owner.Id, taskID, time.Now().Add(duration)))
+}
+
+// AuthorizeKeys adds the public keys to the authorized keys of the
+// host's user, so that the owner of a host that is held for debugging
+// can log in to it.
+func AuthorizeKeys(ctx context.Context, settings *evergreen.Settings, h *host.Host, keys []string) error {
+	if len(keys) == 0 {
+		return errors.New("no keys to authorize")
+	}
+
+	lines := []string{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if strings.ContainsAny(key, "'\n") {
+			return errors.New("public key contains invalid characters")
+		}
+		lines = append(lines, fmt.Sprintf("echo '%s' >> ~/.ssh/authorized_keys", key))
+	}
+	cmd := "mkdir -p ~/.ssh && " + strings.Join(lines, " && ")
+
+	remoteCmd, err := constructRemoteCommand(ctx, settings, h, cmd)
+	if err != nil {
+		return errors.Wrap(err, "Error constructing command to authorize keys")
+	}
+
+	stderr := &util.CappedWriter{
+		Buffer:   &bytes.Buffer{},
+		MaxBytes: 1024 * 1024, // 1MB
+	}
+	if err = remoteCmd.SetOutput(subprocess.OutputOptions{Error: stderr, SuppressOutput: true}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = remoteCmd.Run(ctx); err != nil {
+		return errors.Wrapf(err, "Error authorizing keys on host '%s': %s", h.Id, stderr.Buffer.String())
+	}
+
+	return nil
+}
+
 func SetHostRDPPassword(ctx context.Context, host *host.Host, password string) error {
 	pwdUpdateCmd, err := constructPwdUpdateCommand(ctx, evergreen.GetEnvironment().Settings(), host, password)
 	if err != nil {
 		return errors.Wrap(err, "Error constructing host RDP password")
 	}
 
-	stdout := &util.CappedWriter{&bytes.Buffer{}, 1024 * 1024}
-	stderr := &util.CappedWriter{&bytes.Buffer{}, 1024 * 1024}
+	stdout := &util.CappedWriter{
+		Buffer:   &bytes.Buffer{},
+		MaxBytes: 1024 * 1024, // 1MB
+	}
+	stderr := &util.CappedWriter{
+		Buffer:   &bytes.Buffer{},
+		MaxBytes: 1024 * 1024, // 1MB
+	}
 
 	opts := subprocess.OutputOptions{Error: stderr, Output: stdout}
 	if err = pwdUpdateCmd.SetOutput(opts); err != nil {
@@ -178,6 +255,16 @@ func SetHostRDPPassword(ctx context.Context, host *host.Host, password string) e
 // constructPwdUpdateCommand returns a RemoteCommand struct used to
 // set the RDP password on a remote windows machine.
 func constructPwdUpdateCommand(ctx context.Context, settings *evergreen.Settings, hostObj *host.Host, password string) (subprocess.Command, error) {
+	escapedPassword := strings.Replace(password, `\`, `\\`, -1)
+	updatePwdCmd := fmt.Sprintf(`net user %s "%s" && sc config sshd obj= '.\%s' password= "%s"`,
+		hostObj.User, escapedPassword, hostObj.User, escapedPassword)
+
+	return constructRemoteCommand(ctx, settings, hostObj, updatePwdCmd)
+}
+
+// constructRemoteCommand returns a RemoteCommand struct used to run a
+// command on the host as its user.
+func constructRemoteCommand(ctx context.Context, settings *evergreen.Settings, hostObj *host.Host, cmd string) (subprocess.Command, error) {
 	cloudHost, err := cloud.GetCloudHost(ctx, hostObj, settings)
 	if err != nil {
 		return nil, err
@@ -193,13 +280,8 @@ func constructPwdUpdateCommand(ctx context.Context, settings *evergreen.Settings
 		return nil, err
 	}
 
-	escapedPassword := strings.Replace(password, `\`, `\\`, -1)
-	updatePwdCmd := fmt.Sprintf(`net user %s "%s" && sc config sshd obj= '.\%s' password= "%s"`,
-		hostObj.User, escapedPassword, hostObj.User, escapedPassword)
-
-	// construct the required termination command
 	remoteCommand := subprocess.NewRemoteCommand(
-		updatePwdCmd,
+		cmd,
 		hostInfo.Hostname,
 		hostObj.User,
 		nil,   // env
@@ -21,6 +21,7 @@ type localCmd struct {
 	Stdout           io.Writer `json:"-"`
 	Stderr           io.Writer `json:"-"`
 	cmd              *exec.Cmd
+	resourceGroup    *ResourceGroup
 	mutex            sync.RWMutex
 }
 
@@ -86,12 +87,17 @@ func (lc *localCmd) Start(ctx context.Context) error {
 		lc.Shell = "sh"
 	}
 
-	var cmd *exec.Cmd
+	binary, args := lc.Shell, []string{"-c", lc.CmdString}
+	if lc.ScriptMode {
+		args = nil
+	}
+	if lc.resourceGroup != nil {
+		binary, args = lc.resourceGroup.Wrap(binary, args)
+	}
+
+	cmd := exec.CommandContext(ctx, binary, args...)
 	if lc.ScriptMode {
-		cmd = exec.CommandContext(ctx, lc.Shell)
 		cmd.Stdin = strings.NewReader(lc.CmdString)
-	} else {
-		cmd = exec.CommandContext(ctx, lc.Shell, "-c", lc.CmdString)
 	}
 
 	// create the command, set the options
@@ -112,6 +118,13 @@ func (lc *localCmd) Start(ctx context.Context) error {
 	return cmd.Start()
 }
 
+func (lc *localCmd) SetResourceGroup(group *ResourceGroup) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.resourceGroup = group
+}
+
 func (lc *localCmd) Stop() error {
 	lc.mutex.RLock()
 	defer lc.mutex.RUnlock()
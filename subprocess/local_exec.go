@@ -24,6 +24,7 @@ type localExec struct {
 	env              []string
 	output           OutputOptions
 	cmd              *exec.Cmd
+	resourceGroup    *ResourceGroup
 	mutex            sync.RWMutex
 }
 
@@ -83,7 +84,12 @@ func (c *localExec) Start(ctx context.Context) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.cmd = exec.CommandContext(ctx, c.binary, c.args...) // nolint
+	binary, args := c.binary, c.args
+	if c.resourceGroup != nil {
+		binary, args = c.resourceGroup.Wrap(binary, args)
+	}
+
+	c.cmd = exec.CommandContext(ctx, binary, args...) // nolint
 	c.cmd.Dir = c.workingDirectory
 	c.cmd.Env = c.env
 
@@ -92,6 +98,13 @@ func (c *localExec) Start(ctx context.Context) error {
 
 	return c.cmd.Start()
 }
+func (c *localExec) SetResourceGroup(group *ResourceGroup) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.resourceGroup = group
+}
+
 func (c *localExec) Stop() error {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
@@ -0,0 +1,120 @@
+package subprocess
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// The names of the resource limits, as they appear in project
+// configuration files and in task end details.
+const (
+	ResourceLimitMemory    = "max_memory_mb"
+	ResourceLimitCPUShares = "cpu_shares"
+	ResourceLimitPIDs      = "max_pids"
+
+	minCPUShares = 2
+	maxCPUShares = 262144
+)
+
+// ErrResourceLimitsUnsupported is returned when resource limits
+// cannot be enforced on the current system.
+var ErrResourceLimitsUnsupported = errors.New("resource limits are not supported on this system")
+
+// ResourceLimits describe the resources that a process and all of its
+// children may consume. Zero values are unlimited.
+type ResourceLimits struct {
+	MaxMemoryMB int `mapstructure:"max_memory_mb" yaml:"max_memory_mb,omitempty" bson:"max_memory_mb,omitempty" json:"max_memory_mb,omitempty"`
+	CPUShares   int `mapstructure:"cpu_shares" yaml:"cpu_shares,omitempty" bson:"cpu_shares,omitempty" json:"cpu_shares,omitempty"`
+	MaxPIDs     int `mapstructure:"max_pids" yaml:"max_pids,omitempty" bson:"max_pids,omitempty" json:"max_pids,omitempty"`
+}
+
+// IsZero returns true when no limits are set.
+func (l ResourceLimits) IsZero() bool {
+	return l.MaxMemoryMB == 0 && l.CPUShares == 0 && l.MaxPIDs == 0
+}
+
+// Validate checks that the limits are within the ranges that can be
+// enforced.
+func (l ResourceLimits) Validate() error {
+	catcher := grip.NewBasicCatcher()
+
+	if l.MaxMemoryMB < 0 {
+		catcher.Add(errors.Errorf("%s cannot be negative", ResourceLimitMemory))
+	}
+	if l.CPUShares != 0 && (l.CPUShares < minCPUShares || l.CPUShares > maxCPUShares) {
+		catcher.Add(errors.Errorf("%s must be between %d and %d", ResourceLimitCPUShares, minCPUShares, maxCPUShares))
+	}
+	if l.MaxPIDs < 0 {
+		catcher.Add(errors.Errorf("%s cannot be negative", ResourceLimitPIDs))
+	}
+
+	return catcher.Resolve()
+}
+
+// Merge returns the limits with each limit set in override replacing
+// the corresponding limit in l.
+func (l ResourceLimits) Merge(override ResourceLimits) ResourceLimits {
+	if override.MaxMemoryMB != 0 {
+		l.MaxMemoryMB = override.MaxMemoryMB
+	}
+	if override.CPUShares != 0 {
+		l.CPUShares = override.CPUShares
+	}
+	if override.MaxPIDs != 0 {
+		l.MaxPIDs = override.MaxPIDs
+	}
+	return l
+}
+
+// ResourceGroupCommand is a command that can join a resource group before it
+// runs, so that the limits of the group apply to the command's process from
+// its start and to all of the children that it creates.
+type ResourceGroupCommand interface {
+	Command
+	// SetResourceGroup makes the command's process join the group when
+	// the command starts.
+	SetResourceGroup(*ResourceGroup)
+}
+
+// ResourceLimitError reports that a process was terminated, or
+// prevented from making progress, because it reached one of its
+// resource limits.
+type ResourceLimitError struct {
+	// Limit is the name of the limit that was reached.
+	Limit string
+	Err   error
+}
+
+func (e *ResourceLimitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("process exceeded resource limit '%s'", e.Limit)
+	}
+	return fmt.Sprintf("process exceeded resource limit '%s': %s", e.Limit, e.Err.Error())
+}
+
+// GetResourceLimitExceeded returns the name of the resource limit
+// that caused the error, or an empty string if the error was not
+// caused by a resource limit.
+func GetResourceLimitExceeded(err error) string {
+	if limitErr, ok := errors.Cause(err).(*ResourceLimitError); ok {
+		return limitErr.Limit
+	}
+	return ""
+}
+
+// resourceGroupPrefix returns the prefix of the names of all resource
+// groups created for the given key, so that they can be found during
+// cleanup.
+func resourceGroupPrefix(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, key) + "."
+}
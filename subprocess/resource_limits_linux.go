@@ -0,0 +1,187 @@
+package subprocess
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	cgroupRoot       = "/sys/fs/cgroup"
+	cgroupParentName = "evergreen"
+)
+
+// ResourceGroup enforces resource limits on a set of processes using a
+// cgroups v2 control group.
+type ResourceGroup struct {
+	path   string
+	limits ResourceLimits
+}
+
+// NewResourceGroup creates a control group that enforces the given
+// limits on every process added to it. The key identifies the owner
+// of the group (e.g. the task ID), so that KillSpawnedProcs can remove
+// any groups that are left behind. If the system does not use cgroups
+// v2, NewResourceGroup returns ErrResourceLimitsUnsupported.
+func NewResourceGroup(key string, limits ResourceLimits) (*ResourceGroup, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if limits.IsZero() {
+		return nil, errors.New("no resource limits specified")
+	}
+
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, ErrResourceLimitsUnsupported
+	}
+
+	controllers := []string{}
+	if limits.MaxMemoryMB > 0 {
+		controllers = append(controllers, "+memory")
+	}
+	if limits.CPUShares > 0 {
+		controllers = append(controllers, "+cpu")
+	}
+	if limits.MaxPIDs > 0 {
+		controllers = append(controllers, "+pids")
+	}
+
+	parent := filepath.Join(cgroupRoot, cgroupParentName)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, errors.Wrap(err, "problem creating parent control group")
+	}
+	for _, dir := range []string{cgroupRoot, parent} {
+		if err := writeCgroupFile(dir, "cgroup.subtree_control", strings.Join(controllers, " ")); err != nil {
+			return nil, errors.Wrapf(err, "problem enabling controllers in '%s'", dir)
+		}
+	}
+
+	g := &ResourceGroup{
+		path:   filepath.Join(parent, resourceGroupPrefix(key)+util.RandomString()),
+		limits: limits,
+	}
+	if err := os.Mkdir(g.path, 0755); err != nil {
+		return nil, errors.Wrap(err, "problem creating control group")
+	}
+
+	catcher := grip.NewBasicCatcher()
+	if limits.MaxMemoryMB > 0 {
+		catcher.Add(writeCgroupFile(g.path, "memory.max", strconv.Itoa(limits.MaxMemoryMB*1024*1024)))
+		// without this, processes would swap instead of being
+		// killed when they reach the limit. Not all kernels
+		// support swap accounting, so this is best effort.
+		grip.Debug(writeCgroupFile(g.path, "memory.swap.max", "0"))
+	}
+	if limits.CPUShares > 0 {
+		catcher.Add(writeCgroupFile(g.path, "cpu.weight", strconv.Itoa(cpuSharesToWeight(limits.CPUShares))))
+	}
+	if limits.MaxPIDs > 0 {
+		catcher.Add(writeCgroupFile(g.path, "pids.max", strconv.Itoa(limits.MaxPIDs)))
+	}
+	if catcher.HasErrors() {
+		grip.Warning(os.Remove(g.path))
+		return nil, errors.Wrap(catcher.Resolve(), "problem setting resource limits")
+	}
+
+	return g, nil
+}
+
+// Wrap returns the binary and arguments of a command that moves its own
+// process into the group and then replaces itself with the given command,
+// so that the command's process and all of its children are in the group
+// from the start. If the process can't join the group, the command runs
+// unconstrained.
+func (g *ResourceGroup) Wrap(binary string, args []string) (string, []string) {
+	script := `echo $$ > "$0" || echo "not enforcing resource limits: could not join control group" >&2; exec "$@"`
+	return "sh", append([]string{"-c", script, filepath.Join(g.path, "cgroup.procs"), binary}, args...)
+}
+
+// Add moves a process, and all children it creates afterwards, into the
+// group.
+func (g *ResourceGroup) Add(pid int) error {
+	return errors.Wrapf(writeCgroupFile(g.path, "cgroup.procs", strconv.Itoa(pid)),
+		"problem adding process %d to control group", pid)
+}
+
+// Exceeded returns the name of the first limit that processes in the
+// group have reached, or an empty string if no limits were reached.
+func (g *ResourceGroup) Exceeded() string {
+	if g.limits.MaxMemoryMB > 0 && readCgroupEvent(g.path, "memory.events", "oom_kill") > 0 {
+		return ResourceLimitMemory
+	}
+	if g.limits.MaxPIDs > 0 && readCgroupEvent(g.path, "pids.events", "max") > 0 {
+		return ResourceLimitPIDs
+	}
+	return ""
+}
+
+// Close kills any processes remaining in the group and removes it.
+func (g *ResourceGroup) Close() error {
+	return removeCgroup(g.path)
+}
+
+// cleanupResourceGroups removes all of the control groups created for
+// the given key.
+func cleanupResourceGroups(key string, logger grip.Journaler) {
+	groups, err := filepath.Glob(filepath.Join(cgroupRoot, cgroupParentName, resourceGroupPrefix(key)+"*"))
+	if err != nil {
+		return
+	}
+
+	for _, path := range groups {
+		if err := removeCgroup(path); err != nil {
+			logger.Infof("removing control group %s failed: %v", path, err)
+		}
+	}
+}
+
+func removeCgroup(path string) error {
+	// cgroup.kill is only available on newer kernels; otherwise the
+	// processes are killed by KillSpawnedProcs.
+	_ = writeCgroupFile(path, "cgroup.kill", "1")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "problem removing control group '%s'", path)
+	}
+	return nil
+}
+
+// cpuSharesToWeight converts cgroups v1 CPU shares to the equivalent
+// cgroups v2 CPU weight.
+func cpuSharesToWeight(shares int) int {
+	return 1 + ((shares-minCPUShares)*9999)/(maxCPUShares-minCPUShares)
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	return errors.WithStack(ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644))
+}
+
+// readCgroupEvent returns the count of an event in a flat-keyed
+// cgroup events file, or 0 if the event is not recorded.
+func readCgroupEvent(dir, name, event string) int {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[0] != event {
+			continue
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0
+		}
+		return count
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package subprocess
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceGroupWrap(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "resource-group")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	// a directory stands in for the control group, so the process's id is
+	// written to its cgroup.procs file
+	group := &ResourceGroup{path: dir}
+
+	for name, proc := range map[string]ResourceGroupCommand{
+		"shell": NewLocalCommand("echo $$", "", "sh", nil, true).(ResourceGroupCommand),
+		"exec": func() ResourceGroupCommand {
+			cmd, err := NewLocalExec("sh", []string{"-c", "echo $$"}, nil, "")
+			require.NoError(err)
+			return cmd.(ResourceGroupCommand)
+		}(),
+	} {
+		out := &bytes.Buffer{}
+		require.NoError(proc.SetOutput(OutputOptions{Output: out, SendErrorToOutput: true}), name)
+		proc.SetResourceGroup(group)
+		require.NoError(proc.Run(ctx), name)
+
+		// the process joined the group before it replaced itself with
+		// the command, so it kept its id
+		procs, err := ioutil.ReadFile(filepath.Join(dir, "cgroup.procs"))
+		require.NoError(err, name)
+		pid := strings.TrimSpace(string(procs))
+		assert.Equal(strconv.Itoa(proc.GetPid()), pid, name)
+		assert.Equal(pid, strings.TrimSpace(out.String()), name)
+	}
+}
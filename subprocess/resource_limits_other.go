@@ -0,0 +1,29 @@
+// +build !linux
+
+package subprocess
+
+import "github.com/mongodb/grip"
+
+// ResourceGroup enforces resource limits on a set of processes. Resource
+// limits are only supported on Linux.
+type ResourceGroup struct{}
+
+// NewResourceGroup always returns ErrResourceLimitsUnsupported, because
+// resource limits are only supported on Linux.
+func NewResourceGroup(key string, limits ResourceLimits) (*ResourceGroup, error) {
+	return nil, ErrResourceLimitsUnsupported
+}
+
+// Wrap returns the command unchanged on this platform.
+func (g *ResourceGroup) Wrap(binary string, args []string) (string, []string) { return binary, args }
+
+// Add is a noop on this platform.
+func (g *ResourceGroup) Add(pid int) error { return ErrResourceLimitsUnsupported }
+
+// Exceeded is a noop on this platform.
+func (g *ResourceGroup) Exceeded() string { return "" }
+
+// Close is a noop on this platform.
+func (g *ResourceGroup) Close() error { return nil }
+
+func cleanupResourceGroups(key string, logger grip.Journaler) {}
@@ -0,0 +1,50 @@
+package subprocess
+
+import (
+	"errors"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceLimitsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ResourceLimits{}.Validate())
+	assert.NoError(ResourceLimits{MaxMemoryMB: 512, CPUShares: 1024, MaxPIDs: 10}.Validate())
+	assert.Error(ResourceLimits{MaxMemoryMB: -1}.Validate())
+	assert.Error(ResourceLimits{MaxPIDs: -1}.Validate())
+	assert.Error(ResourceLimits{CPUShares: 1}.Validate())
+	assert.Error(ResourceLimits{CPUShares: maxCPUShares + 1}.Validate())
+}
+
+func TestResourceLimitsMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	task := ResourceLimits{MaxMemoryMB: 512, CPUShares: 1024}
+	assert.Equal(task, task.Merge(ResourceLimits{}))
+	assert.Equal(ResourceLimits{MaxMemoryMB: 256, CPUShares: 1024, MaxPIDs: 10},
+		task.Merge(ResourceLimits{MaxMemoryMB: 256, MaxPIDs: 10}))
+	assert.True(ResourceLimits{}.IsZero())
+	assert.False(task.IsZero())
+}
+
+func TestGetResourceLimitExceeded(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", GetResourceLimitExceeded(nil))
+	assert.Equal("", GetResourceLimitExceeded(errors.New("exit status 1")))
+
+	err := &ResourceLimitError{Limit: ResourceLimitMemory, Err: errors.New("signal: killed")}
+	assert.Equal(ResourceLimitMemory, GetResourceLimitExceeded(err))
+	assert.Equal(ResourceLimitMemory, GetResourceLimitExceeded(pkgerrors.Wrap(err, "command failed")))
+	assert.Contains(err.Error(), ResourceLimitMemory)
+}
+
+func TestResourceGroupPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("task_1.", resourceGroupPrefix("task_1"))
+	assert.Equal("a_b_c.", resourceGroupPrefix("a/b c"))
+}
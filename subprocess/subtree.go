@@ -43,5 +43,7 @@ func envHasMarkers(key string, env []string) bool {
 func KillSpawnedProcs(key string, logger grip.Journaler) error {
 	// Clean up all shell processes spawned during the execution of this task by this agent,
 	// by calling the platform-specific "cleanup" function
-	return cleanup(key, logger)
+	err := cleanup(key, logger)
+	cleanupResourceGroups(key, logger)
+	return err
 }
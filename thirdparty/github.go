@@ -633,3 +633,69 @@ func GetPullRequestMergeBase(ctx context.Context, token string, data patch.Githu
 
 	return *commit.Parents[0].SHA, nil
 }
+
+// GetGithubPullRequest fetches the pull request with the given number.
+func GetGithubPullRequest(ctx context.Context, token, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem fetching pull request %d from %s/%s", prNumber, owner, repo)
+	}
+	if pr == nil || pr.Base == nil || pr.Base.Ref == nil || pr.Head == nil || pr.Head.SHA == nil ||
+		pr.User == nil || pr.User.Login == nil {
+		return nil, errors.New("pull request document is malformed/missing data")
+	}
+
+	return pr, nil
+}
+
+// MergePullRequest merges the pull request with the given merge method,
+// which is one of merge, squash, or rebase. The merge fails if the head
+// of the pull request is no longer sha.
+func MergePullRequest(ctx context.Context, token, owner, repo string, prNumber int, sha, mergeMethod, commitMessage string) error {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	res, _, err := client.PullRequests.Merge(ctx, owner, repo, prNumber, commitMessage, &github.PullRequestOptions{
+		SHA:         sha,
+		MergeMethod: mergeMethod,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "problem merging pull request %d into %s/%s", prNumber, owner, repo)
+	}
+	if res == nil || res.Merged == nil || !*res.Merged {
+		msg := ""
+		if res != nil && res.Message != nil {
+			msg = *res.Message
+		}
+		return errors.Errorf("pull request %d was not merged: %s", prNumber, msg)
+	}
+
+	return nil
+}
+
+// PostCommentToPullRequest adds a comment to the pull request with the
+// given number.
+func PostCommentToPullRequest(ctx context.Context, token, owner, repo string, prNumber int, comment string) error {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{
+		Body: github.String(comment),
+	})
+	return errors.Wrapf(err, "problem commenting on pull request %d in %s/%s", prNumber, owner, repo)
+}
@@ -0,0 +1,270 @@
+package thirdparty
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	// GithubCheckRunStatus* are the statuses of a check run.
+	GithubCheckRunStatusQueued     = "queued"
+	GithubCheckRunStatusInProgress = "in_progress"
+	GithubCheckRunStatusCompleted  = "completed"
+
+	// GithubCheckRunConclusion* are the conclusions of a completed check run.
+	GithubCheckRunConclusionSuccess   = "success"
+	GithubCheckRunConclusionFailure   = "failure"
+	GithubCheckRunConclusionCancelled = "cancelled"
+	GithubCheckRunConclusionTimedOut  = "timed_out"
+
+	// GithubAnnotationLevel* are the levels of a check run annotation.
+	GithubAnnotationLevelNotice  = "notice"
+	GithubAnnotationLevelWarning = "warning"
+	GithubAnnotationLevelFailure = "failure"
+
+	// GithubMaxCheckRunAnnotations is the most annotations that a single
+	// check run request may include.
+	GithubMaxCheckRunAnnotations = 50
+
+	githubChecksPreview       = "application/vnd.github.antiope-preview+json"
+	githubIntegrationsPreview = "application/vnd.github.machine-man-preview+json"
+
+	// GitHub rejects app tokens that expire more than 10 minutes after
+	// they were issued, or that were issued in the future, so leave some
+	// room for clock drift.
+	githubAppJWTLifetime = 9 * time.Minute
+	githubAppJWTDrift    = time.Minute
+)
+
+// GithubCheckRun is a check run, as it is created or updated through the
+// GitHub Checks API.
+type GithubCheckRun struct {
+	Name        string                 `json:"name,omitempty"`
+	HeadSHA     string                 `json:"head_sha,omitempty"`
+	DetailsURL  string                 `json:"details_url,omitempty"`
+	ExternalID  string                 `json:"external_id,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Conclusion  string                 `json:"conclusion,omitempty"`
+	StartedAt   *time.Time             `json:"started_at,omitempty"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	Output      *GithubCheckRunOutput  `json:"output,omitempty"`
+	Actions     []GithubCheckRunAction `json:"actions,omitempty"`
+}
+
+// GithubCheckRunOutput is the summary shown on a check run's page. The
+// summary and text are Markdown.
+type GithubCheckRunOutput struct {
+	Title       string                     `json:"title"`
+	Summary     string                     `json:"summary"`
+	Text        string                     `json:"text,omitempty"`
+	Annotations []GithubCheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+// GithubCheckRunAnnotation attaches a message to lines of a file in the
+// pull request.
+type GithubCheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+	RawDetails      string `json:"raw_details,omitempty"`
+}
+
+// GithubCheckRunAction is a button on a check run's page. When it is
+// clicked, GitHub sends a check_run webhook with the "requested_action"
+// action and the button's identifier.
+type GithubCheckRunAction struct {
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Identifier  string `json:"identifier"`
+}
+
+type githubCheckRunResponse struct {
+	ID int64 `json:"id"`
+}
+
+// NewGithubAppJWT returns the token that authenticates requests as the
+// GitHub App with the id, signed with the app's private key.
+func NewGithubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	if key == nil {
+		return "", errors.New("github app private key is missing")
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "problem encoding token header")
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-githubAppJWTDrift).Unix(),
+		"exp": now.Add(githubAppJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "problem encoding token claims")
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "problem signing github app token")
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// GetGithubAppInstallationToken returns a token that authenticates requests
+// as the GitHub App's installation on the repository.
+func GetGithubAppInstallationToken(ctx context.Context, appID int64, key *rsa.PrivateKey, owner, repo string) (string, error) {
+	jwt, err := NewGithubAppJWT(appID, key, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	httpClient := util.GetHTTPClient()
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/installation", owner, repo), nil)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	req.Header.Set("Accept", githubIntegrationsPreview)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	installation := &github.Installation{}
+	if _, err = client.Do(ctx, req, installation); err != nil {
+		return "", errors.Wrapf(err, "problem finding github app installation for %s/%s", owner, repo)
+	}
+	if installation.ID == nil {
+		return "", errors.Errorf("github app is not installed on %s/%s", owner, repo)
+	}
+
+	req, err = client.NewRequest(http.MethodPost, fmt.Sprintf("installations/%d/access_tokens", *installation.ID), nil)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	req.Header.Set("Accept", githubIntegrationsPreview)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	token := &github.InstallationToken{}
+	if _, err = client.Do(ctx, req, token); err != nil {
+		return "", errors.Wrapf(err, "problem creating github app installation token for %s/%s", owner, repo)
+	}
+	if token.Token == nil {
+		return "", errors.Errorf("github returned no installation token for %s/%s", owner, repo)
+	}
+
+	return *token.Token, nil
+}
+
+// CreateGithubCheckRun creates the check run on the repository and returns
+// its id. The token must be a GitHub App installation token. Annotations
+// beyond the most that one request may include are added to the check run
+// in later requests.
+func CreateGithubCheckRun(ctx context.Context, token, owner, repo string, run GithubCheckRun) (int64, error) {
+	requests := splitGithubCheckRun(run)
+
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/check-runs", owner, repo), requests[0])
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	req.Header.Set("Accept", githubChecksPreview)
+
+	created := &githubCheckRunResponse{}
+	if _, err = client.Do(ctx, req, created); err != nil {
+		return 0, errors.Wrapf(err, "problem creating check run '%s' on %s/%s", run.Name, owner, repo)
+	}
+
+	for _, update := range requests[1:] {
+		if err = updateGithubCheckRun(ctx, client, owner, repo, created.ID, update); err != nil {
+			return created.ID, err
+		}
+	}
+
+	return created.ID, nil
+}
+
+// UpdateGithubCheckRun updates the check run with the id on the repository.
+// The token must be a GitHub App installation token.
+func UpdateGithubCheckRun(ctx context.Context, token, owner, repo string, id int64, run GithubCheckRun) error {
+	httpClient, err := getGithubClient(token)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	for _, update := range splitGithubCheckRun(run) {
+		if err = updateGithubCheckRun(ctx, client, owner, repo, id, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateGithubCheckRun(ctx context.Context, client *github.Client, owner, repo string, id int64, run GithubCheckRun) error {
+	req, err := client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%s/%s/check-runs/%d", owner, repo, id), run)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Accept", githubChecksPreview)
+
+	_, err = client.Do(ctx, req, nil)
+	return errors.Wrapf(err, "problem updating check run %d on %s/%s", id, owner, repo)
+}
+
+// splitGithubCheckRun splits the check run into the requests that create
+// or update it, so that no request has more annotations than GitHub
+// allows. GitHub appends the annotations of each update to the check run.
+func splitGithubCheckRun(run GithubCheckRun) []GithubCheckRun {
+	if run.Output == nil || len(run.Output.Annotations) <= GithubMaxCheckRunAnnotations {
+		return []GithubCheckRun{run}
+	}
+
+	annotations := run.Output.Annotations
+	requests := []GithubCheckRun{}
+	for len(annotations) > 0 {
+		n := GithubMaxCheckRunAnnotations
+		if len(annotations) < n {
+			n = len(annotations)
+		}
+
+		output := *run.Output
+		output.Annotations = annotations[:n]
+		annotations = annotations[n:]
+
+		request := run
+		if len(requests) > 0 {
+			request = GithubCheckRun{}
+		}
+		request.Output = &output
+		requests = append(requests, request)
+	}
+	return requests
+}
@@ -0,0 +1,96 @@
+package thirdparty
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGithubAppJWT(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+
+	now := time.Now()
+	jwt, err := NewGithubAppJWT(1234, key, now)
+	require.NoError(err)
+	parts := strings.Split(jwt, ".")
+	require.Len(parts, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(err)
+	assert.JSONEq(`{"alg":"RS256","typ":"JWT"}`, string(header))
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(err)
+	claims := struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}{}
+	require.NoError(json.Unmarshal(claimsJSON, &claims))
+	assert.Equal("1234", claims.Issuer)
+	assert.True(claims.IssuedAt < now.Unix())
+	assert.True(claims.ExpiresAt > now.Unix())
+	assert.True(claims.ExpiresAt-claims.IssuedAt <= int64((10 * time.Minute).Seconds()))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	_, err = NewGithubAppJWT(1234, nil, now)
+	assert.Error(err)
+}
+
+func TestSplitGithubCheckRun(t *testing.T) {
+	assert := assert.New(t)
+
+	run := GithubCheckRun{
+		Name:    "evergreen/ubuntu",
+		HeadSHA: "abcdef",
+		Status:  GithubCheckRunStatusCompleted,
+		Output: &GithubCheckRunOutput{
+			Title:   "2 failed",
+			Summary: "summary",
+		},
+	}
+	requests := splitGithubCheckRun(run)
+	assert.Len(requests, 1)
+	assert.Equal(run, requests[0])
+
+	for i := 0; i < GithubMaxCheckRunAnnotations*2+1; i++ {
+		run.Output.Annotations = append(run.Output.Annotations, GithubCheckRunAnnotation{
+			Path:    fmt.Sprintf("test_%d.go", i),
+			Message: "failed",
+		})
+	}
+	requests = splitGithubCheckRun(run)
+	assert.Len(requests, 3)
+	assert.Equal("evergreen/ubuntu", requests[0].Name)
+	assert.Equal(GithubCheckRunStatusCompleted, requests[0].Status)
+	assert.Len(requests[0].Output.Annotations, GithubMaxCheckRunAnnotations)
+	assert.Equal("test_0.go", requests[0].Output.Annotations[0].Path)
+	for _, request := range requests[1:] {
+		assert.Empty(request.Name)
+		assert.Empty(request.Status)
+		assert.Equal("2 failed", request.Output.Title)
+		assert.Equal("summary", request.Output.Summary)
+	}
+	assert.Len(requests[1].Output.Annotations, GithubMaxCheckRunAnnotations)
+	assert.Equal("test_50.go", requests[1].Output.Annotations[0].Path)
+	assert.Len(requests[2].Output.Annotations, 1)
+	assert.Len(run.Output.Annotations, GithubMaxCheckRunAnnotations*2+1)
+}
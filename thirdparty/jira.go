@@ -146,6 +146,27 @@ func (jiraHandler *JiraHandler) UpdateTicket(key string, fields map[string]inter
 	return nil
 }
 
+// AddComment adds a comment with the given body to the ticket with the given key.
+func (jiraHandler *JiraHandler) AddComment(key, body string) error {
+	apiEndpoint := fmt.Sprintf("https://%v/rest/api/2/issue/%v/comment", jiraHandler.JiraServer, url.QueryEscape(key))
+	postArgs := struct {
+		Body string `json:"body"`
+	}{body}
+	res, err := jiraHandler.MyHttp.doPost(apiEndpoint, jiraHandler.UserName, jiraHandler.Password, postArgs)
+	if res != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if res.StatusCode >= 300 || res.StatusCode < 200 {
+		msg, _ := ioutil.ReadAll(res.Body)
+		return errors.Errorf("HTTP request returned unexpected status `%v`: %v", res.Status, string(msg))
+	}
+
+	return nil
+}
+
 // GetJIRATicket returns the ticket with the given key.
 func (jiraHandler *JiraHandler) GetJIRATicket(key string) (*JiraTicket, error) {
 	apiEndpoint := fmt.Sprintf("https://%v/rest/api/latest/issue/%v", jiraHandler.JiraServer, url.QueryEscape(key))
@@ -0,0 +1,261 @@
+package thirdparty
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	slackPostMessageURL   = "https://slack.com/api/chat.postMessage"
+	slackLookupByEmailURL = "https://slack.com/api/users.lookupByEmail"
+
+	// SlackBlock* are the types of the blocks in a Slack message.
+	SlackBlockSection = "section"
+	SlackBlockContext = "context"
+	SlackBlockActions = "actions"
+
+	// SlackText* are the types of the text in a Slack message.
+	SlackTextPlain    = "plain_text"
+	SlackTextMarkdown = "mrkdwn"
+
+	// SlackButtonStyle* are the styles of a Slack button.
+	SlackButtonStylePrimary = "primary"
+	SlackButtonStyleDanger  = "danger"
+
+	// SlackInteractionBlockActions is the type of the interaction payload
+	// that Slack sends when a button in a message is clicked.
+	SlackInteractionBlockActions = "block_actions"
+
+	// slackMaxRequestAge is the oldest that a request from Slack may be,
+	// to prevent replaying requests.
+	slackMaxRequestAge = 5 * time.Minute
+)
+
+// SlackMessage is a message posted through the Slack API. Text is shown in
+// notifications, and in place of the blocks by clients that can't show
+// them.
+type SlackMessage struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackBlock is a block of a Slack message. The elements of a context block
+// are SlackTexts, and those of an actions block are SlackButtons.
+type SlackBlock struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Text     *SlackText    `json:"text,omitempty"`
+	Fields   []SlackText   `json:"fields,omitempty"`
+	Elements []interface{} `json:"elements,omitempty"`
+}
+
+// SlackText is text in a Slack message.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackButton is a button in a Slack message. Buttons with a URL open it.
+// Clicking any button sends an interaction payload with its action id and
+// value to the app's interactivity request URL.
+type SlackButton struct {
+	Type     string    `json:"type"`
+	Text     SlackText `json:"text"`
+	ActionID string    `json:"action_id"`
+	Value    string    `json:"value,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Style    string    `json:"style,omitempty"`
+}
+
+// NewSlackButton returns a button with the label.
+func NewSlackButton(label, actionID, value string) SlackButton {
+	return SlackButton{
+		Type:     "button",
+		Text:     SlackText{Type: SlackTextPlain, Text: label},
+		ActionID: actionID,
+		Value:    value,
+	}
+}
+
+// SlackInteraction is the part of the interaction payload that Slack sends
+// when a button in a message is clicked that Evergreen uses.
+type SlackInteraction struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+	Actions     []SlackAction `json:"actions"`
+	ResponseURL string        `json:"response_url"`
+	TriggerID   string        `json:"trigger_id"`
+}
+
+// SlackAction is a clicked button of a SlackInteraction.
+type SlackAction struct {
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id"`
+	Value    string `json:"value"`
+}
+
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// SlackUser is a member of the Slack workspace.
+type SlackUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FindSlackUserByEmail returns the member of the workspace whose account
+// has the email address, or nil if there is none. The bot token must have
+// the users:read.email scope.
+func FindSlackUserByEmail(ctx context.Context, token, email string) (*SlackUser, error) {
+	return findSlackUserByEmail(ctx, slackLookupByEmailURL, token, email)
+}
+
+func findSlackUserByEmail(ctx context.Context, lookupURL, token, email string) (*SlackUser, error) {
+	if token == "" {
+		return nil, errors.New("slack token is missing")
+	}
+	if email == "" {
+		return nil, errors.New("email address is empty")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, lookupURL+"?"+url.Values{"email": []string{email}}.Encode(), nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem looking up slack user")
+	}
+	defer resp.Body.Close()
+
+	result := struct {
+		slackAPIResponse
+		User SlackUser `json:"user"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Wrapf(err, "problem reading slack response with status %s", resp.Status)
+	}
+	if result.Error == "users_not_found" {
+		return nil, nil
+	}
+	if !result.OK {
+		return nil, errors.Errorf("slack rejected user lookup: %s", result.Error)
+	}
+	return &result.User, nil
+}
+
+// PostSlackMessage posts the message to its channel with the bot token.
+func PostSlackMessage(ctx context.Context, token string, msg *SlackMessage) error {
+	if token == "" {
+		return errors.New("slack token is missing")
+	}
+	if msg.Channel == "" {
+		return errors.New("slack message must have a channel")
+	}
+
+	resp, err := postSlackJSON(ctx, slackPostMessageURL, token, msg)
+	if err != nil {
+		return errors.Wrapf(err, "problem posting slack message to %s", msg.Channel)
+	}
+	defer resp.Body.Close()
+
+	result := slackAPIResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Wrapf(err, "problem reading slack response with status %s", resp.Status)
+	}
+	if !result.OK {
+		return errors.Errorf("slack rejected message to %s: %s", msg.Channel, result.Error)
+	}
+	return nil
+}
+
+// RespondToSlackInteraction posts the text to the response URL of an
+// interaction, so that only the user who clicked the button sees it.
+func RespondToSlackInteraction(ctx context.Context, responseURL, text string) error {
+	resp, err := postSlackJSON(ctx, responseURL, "", map[string]interface{}{
+		"response_type":    "ephemeral",
+		"replace_original": false,
+		"text":             text,
+	})
+	if err != nil {
+		return errors.Wrap(err, "problem responding to slack interaction")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("slack rejected interaction response with status %s", resp.Status)
+	}
+	return nil
+}
+
+func postSlackJSON(ctx context.Context, url, token string, data interface{}) (*http.Response, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem encoding request")
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	return client.Do(req)
+}
+
+// VerifySlackSignature checks that a request was sent by Slack, given the
+// app's signing secret, the request's X-Slack-Request-Timestamp and
+// X-Slack-Signature headers, and its body. Requests sent long before now
+// are rejected.
+func VerifySlackSignature(secret []byte, timestamp, signature string, body []byte, now time.Time) error {
+	if len(secret) == 0 {
+		return errors.New("slack signing secret is missing")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid slack request timestamp '%s'", timestamp)
+	}
+	sent := time.Unix(seconds, 0)
+	if now.Sub(sent) > slackMaxRequestAge || sent.Sub(now) > slackMaxRequestAge {
+		return errors.Errorf("slack request timestamp %s is too far from now", sent)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = fmt.Fprintf(mac, "v0:%s:", timestamp)
+	_, _ = mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("slack request signature does not match")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package thirdparty
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signSlackRequest(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte("v0:" + timestamp + ":"))
+	_, _ = mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte("payload=%7B%7D")
+	signature := signSlackRequest("secret", timestamp, body)
+
+	assert.NoError(VerifySlackSignature([]byte("secret"), timestamp, signature, body, now))
+	assert.Error(VerifySlackSignature([]byte("other"), timestamp, signature, body, now))
+	assert.Error(VerifySlackSignature([]byte("secret"), timestamp, signature, []byte("payload=x"), now))
+	assert.Error(VerifySlackSignature(nil, timestamp, signature, body, now))
+	assert.Error(VerifySlackSignature([]byte("secret"), "yesterday", signature, body, now))
+
+	old := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+	assert.Error(VerifySlackSignature([]byte("secret"), old, signSlackRequest("secret", old, body), body, now))
+}
+
+func TestRespondToSlackInteraction(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	require.NoError(RespondToSlackInteraction(context.Background(), server.URL, "done"))
+	assert.Equal("ephemeral", received["response_type"])
+	assert.Equal(false, received["replace_original"])
+	assert.Equal("done", received["text"])
+}
+
+func TestFindSlackUserByEmail(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("email") {
+		case "octocat@example.com":
+			_, _ = w.Write([]byte(`{"ok": true, "user": {"id": "U123", "name": "octocat"}}`))
+		case "nobody@example.com":
+			_, _ = w.Write([]byte(`{"ok": false, "error": "users_not_found"}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok": false, "error": "missing_scope"}`))
+		}
+	}))
+	defer server.Close()
+
+	slackUser, err := findSlackUserByEmail(context.Background(), server.URL, "token", "octocat@example.com")
+	require.NoError(err)
+	require.NotNil(slackUser)
+	assert.Equal("U123", slackUser.ID)
+	assert.Equal("octocat", slackUser.Name)
+
+	slackUser, err = findSlackUserByEmail(context.Background(), server.URL, "token", "nobody@example.com")
+	assert.NoError(err)
+	assert.Nil(slackUser)
+
+	_, err = findSlackUserByEmail(context.Background(), server.URL, "token", "other@example.com")
+	assert.Error(err)
+
+	_, err = findSlackUserByEmail(context.Background(), server.URL, "", "octocat@example.com")
+	assert.Error(err)
+}
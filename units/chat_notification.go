@@ -0,0 +1,133 @@
+package units
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	chatNotificationJobName = "chat-notification"
+
+	chatNotificationTimeout = 30 * time.Second
+)
+
+func init() {
+	registry.AddJobType(chatNotificationJobName, func() amboy.Job { return makeChatNotificationJob() })
+}
+
+// chatNotificationJob sends a notification to a Microsoft Teams or generic
+// chat webhook subscriber.
+type chatNotificationJob struct {
+	NotificationID string `bson:"notification_id" json:"notification_id" yaml:"notification_id"`
+	job.Base       `bson:"job_base" json:"job_base" yaml:"job_base"`
+}
+
+func makeChatNotificationJob() *chatNotificationJob {
+	j := &chatNotificationJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    chatNotificationJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewChatNotificationJob creates a job to send the notification with the id,
+// which must be for a Teams or chat webhook subscriber.
+func NewChatNotificationJob(id bson.ObjectId) amboy.Job {
+	j := makeChatNotificationJob()
+	j.NotificationID = id.Hex()
+
+	j.SetID(fmt.Sprintf("%s:%s", chatNotificationJobName, id.Hex()))
+	return j
+}
+
+func (j *chatNotificationJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if !bson.IsObjectIdHex(j.NotificationID) {
+		j.AddError(errors.Errorf("invalid notification id '%s'", j.NotificationID))
+		return
+	}
+	n, err := notification.Find(bson.ObjectIdHex(j.NotificationID))
+	if err != nil {
+		j.AddError(errors.Wrap(err, "can't find notification"))
+		return
+	}
+	if n == nil {
+		j.AddError(errors.Errorf("notification '%s' does not exist", j.NotificationID))
+		return
+	}
+	if !n.SentAt.IsZero() || n.Digest.Batched() {
+		// batched notifications are sent in a digest by the
+		// notification digest job
+		return
+	}
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	sendErr := sendChatNotification(ctx, client, n)
+	if sendErr != nil {
+		grip.Error(message.WrapError(sendErr, message.Fields{
+			"message":         "can't send chat notification",
+			"job":             j.ID(),
+			"notification_id": j.NotificationID,
+			"subscriber_type": n.Subscriber.Type,
+		}))
+		j.AddError(sendErr)
+		j.AddError(n.MarkError(sendErr))
+		return
+	}
+	j.AddError(n.MarkSent())
+}
+
+// sendChatNotification posts the notification to its subscriber's webhook.
+func sendChatNotification(ctx context.Context, client *http.Client, n *notification.Notification) error {
+	url, headers, body, err := n.ChatRequest()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "can't create chat webhook request")
+	}
+	ctx, cancel := context.WithTimeout(ctx, chatNotificationTimeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "can't reach chat webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("chat webhook responded with %s: %s", resp.Status, string(msg))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package units
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendChatNotification(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		body    []byte
+		headers http.Header
+		status  = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		require.NoError(err)
+		headers = r.Header
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	payload := &notification.ChatPayload{
+		Title:  "Task failed",
+		Text:   `compile "linux" failed`,
+		URL:    "https://evergreen.example.com/task/t1",
+		Status: evergreen.TaskFailed,
+	}
+
+	// Teams subscribers are sent a message card
+	url := server.URL
+	n := &notification.Notification{
+		Subscriber: event.Subscriber{Type: event.TeamsSubscriberType, Target: &url},
+		Payload:    payload,
+	}
+	require.NoError(sendChatNotification(context.Background(), http.DefaultClient, n))
+	card := map[string]interface{}{}
+	require.NoError(json.Unmarshal(body, &card))
+	assert.Equal("MessageCard", card["@type"])
+	assert.Equal("Task failed", card["title"])
+	assert.Equal("E01E5A", card["themeColor"])
+	assert.Equal("application/json", headers.Get("Content-Type"))
+
+	// chat webhook subscribers are sent their rendered template
+	n = &notification.Notification{
+		Subscriber: event.Subscriber{
+			Type: event.ChatWebhookSubscriberType,
+			Target: &event.ChatWebhookSubscriber{
+				URL:      server.URL,
+				Template: `{"content": {{json .Text}}, "link": {{json .URL}}}`,
+				Headers:  map[string]string{"X-Token": "secret"},
+			},
+		},
+		Payload: payload,
+	}
+	require.NoError(sendChatNotification(context.Background(), http.DefaultClient, n))
+	rendered := map[string]string{}
+	require.NoError(json.Unmarshal(body, &rendered))
+	assert.Equal(payload.Text, rendered["content"])
+	assert.Equal(payload.URL, rendered["link"])
+	assert.Equal("secret", headers.Get("X-Token"))
+
+	// errors from the webhook are returned
+	status = http.StatusBadRequest
+	assert.Error(sendChatNotification(context.Background(), http.DefaultClient, n))
+
+	// templates that don't produce JSON are rejected
+	n.Subscriber.Target = &event.ChatWebhookSubscriber{URL: server.URL, Template: `{"content": {{.Text}}}`}
+	assert.Error(sendChatNotification(context.Background(), http.DefaultClient, n))
+}
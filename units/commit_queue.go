@@ -0,0 +1,443 @@
+package units
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/evergreen/validator"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	commitQueueJobName = "commit-queue"
+
+	// commitQueueClaimTimeout is how long a job may take to create the
+	// patch of an item that it claimed before another job releases it.
+	commitQueueClaimTimeout = 30 * time.Minute
+)
+
+func init() {
+	registry.AddJobType(commitQueueJobName, func() amboy.Job { return makeCommitQueueJob() })
+}
+
+type commitQueueJob struct {
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	job.Base  `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	env evergreen.Environment
+}
+
+func makeCommitQueueJob() *commitQueueJob {
+	j := &commitQueueJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    commitQueueJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewCommitQueueJob creates a job that advances the commit queue of a
+// project: it merges the pull request at the front of the queue once its
+// patch passes, removes pull requests whose patches fail, and creates
+// patches for the pull requests that are not being tested yet.
+func NewCommitQueueJob(projectID, id string) amboy.Job {
+	j := makeCommitQueueJob()
+	j.ProjectID = projectID
+	j.SetID(fmt.Sprintf("%s.%s.%s", commitQueueJobName, projectID, id))
+	return j
+}
+
+func (j *commitQueueJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "problem retrieving service flags"))
+		return
+	}
+	if flags.CommitQueueDisabled {
+		grip.Info(message.Fields{
+			"job":     j.ID(),
+			"project": j.ProjectID,
+			"message": "commit queue is disabled",
+		})
+		return
+	}
+
+	projectRef, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "problem finding project ref '%s'", j.ProjectID))
+		return
+	}
+	if projectRef == nil || !projectRef.Enabled || !projectRef.CommitQueue.Enabled {
+		return
+	}
+
+	cq, err := commitqueue.FindOneId(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if cq == nil || len(cq.Queue) == 0 {
+		return
+	}
+
+	githubToken, err := j.env.Settings().GetGithubOauthToken()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	// check the patches of the items under test in queue order, since
+	// an item can only be merged after the items ahead of it.
+	for i := 0; i < len(cq.Queue); {
+		item := cq.Queue[i]
+		if item.Version == "" {
+			i++
+			continue
+		}
+
+		p, err := patch.FindOne(patch.ById(patch.NewId(item.Version)))
+		if err != nil {
+			j.AddError(errors.Wrapf(err, "problem finding patch '%s'", item.Version))
+			return
+		}
+		if p == nil && item.HeadHash == "" {
+			// another job claimed the item and is creating its patch,
+			// unless it died before finishing.
+			if bson.IsObjectIdHex(item.Version) && time.Since(bson.ObjectIdHex(item.Version).Time()) < commitQueueClaimTimeout {
+				i++
+				continue
+			}
+			if err = cq.SetVersion(item.Issue, "", ""); err != nil {
+				j.AddError(err)
+				return
+			}
+			i++
+			continue
+		}
+		if p == nil {
+			j.AddError(j.dequeue(ctx, githubToken, projectRef, cq, i, "its patch was removed"))
+			continue
+		}
+
+		switch {
+		case p.Status == evergreen.PatchFailed:
+			j.AddError(j.dequeue(ctx, githubToken, projectRef, cq, i,
+				fmt.Sprintf("its patch failed (%s)", j.patchURL(item.Version))))
+			continue
+
+		case p.Status == evergreen.PatchSucceeded && i == 0:
+			if err = j.merge(ctx, githubToken, projectRef, item); err != nil {
+				j.AddError(j.dequeue(ctx, githubToken, projectRef, cq, i,
+					fmt.Sprintf("it could not be merged: %s", err.Error())))
+				continue
+			}
+			if _, err = cq.Remove(item.Issue); err != nil {
+				j.AddError(err)
+				return
+			}
+			continue
+		}
+		i++
+	}
+
+	// test each item that is not being tested with the changes of
+	// every item ahead of it.
+	for i := 0; i < len(cq.Queue); {
+		if cq.Queue[i].Version != "" {
+			i++
+			continue
+		}
+
+		// claim the item before creating its patch, so that another job
+		// advancing the queue at the same time doesn't test it too.
+		patchID := bson.NewObjectId()
+		claimed, err := cq.ClaimItem(cq.Queue[i].Issue, patchID.Hex())
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		if !claimed {
+			grip.Info(message.Fields{
+				"job":       j.ID(),
+				"project":   j.ProjectID,
+				"pr_number": cq.Queue[i].Issue,
+				"message":   "commit queue item was claimed by another job",
+			})
+			return
+		}
+
+		headHash, err := j.createPatch(ctx, githubToken, projectRef, patchID, cq.Queue[:i+1])
+		if err != nil {
+			j.AddError(cq.SetVersion(cq.Queue[i].Issue, "", ""))
+			j.AddError(j.dequeue(ctx, githubToken, projectRef, cq, i,
+				fmt.Sprintf("its patch could not be created: %s", err.Error())))
+			continue
+		}
+		if err = cq.SetVersion(cq.Queue[i].Issue, patchID.Hex(), headHash); err != nil {
+			j.AddError(err)
+			return
+		}
+		i++
+	}
+}
+
+func (j *commitQueueJob) patchURL(version string) string {
+	return fmt.Sprintf("%s/version/%s", j.env.Settings().Ui.Url, version)
+}
+
+// merge merges the pull request of the item through the GitHub API.
+func (j *commitQueueJob) merge(ctx context.Context, githubToken string, projectRef *model.ProjectRef, item commitqueue.CommitQueueItem) error {
+	prNum, err := item.PRNumber()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	msg := fmt.Sprintf("Merged by the Evergreen commit queue (%s)", j.patchURL(item.Version))
+	err = thirdparty.MergePullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum,
+		item.HeadHash, projectRef.CommitQueue.MergeMethod, msg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	grip.Info(message.Fields{
+		"job":       j.ID(),
+		"project":   j.ProjectID,
+		"pr_number": prNum,
+		"version":   item.Version,
+		"message":   "merged pull request from commit queue",
+	})
+	return nil
+}
+
+// dequeue removes the item at index i from the queue and tells the author
+// of the pull request why. The items behind it were tested with its
+// changes, so their patches are aborted and they are tested again.
+func (j *commitQueueJob) dequeue(ctx context.Context, githubToken string, projectRef *model.ProjectRef,
+	cq *commitqueue.CommitQueue, i int, reason string) error {
+
+	item := cq.Queue[i]
+	if _, err := cq.Remove(item.Issue); err != nil {
+		return errors.WithStack(err)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, later := range cq.Queue[i:] {
+		if later.Version == "" {
+			continue
+		}
+		p, err := patch.FindOne(patch.ById(patch.NewId(later.Version)))
+		if err != nil {
+			catcher.Add(errors.Wrapf(err, "problem finding patch '%s'", later.Version))
+			continue
+		}
+		if p != nil {
+			catcher.Add(model.CancelPatch(p, evergreen.User))
+		}
+		catcher.Add(cq.SetVersion(later.Issue, "", ""))
+	}
+
+	grip.Info(message.Fields{
+		"job":       j.ID(),
+		"project":   j.ProjectID,
+		"pr_number": item.Issue,
+		"version":   item.Version,
+		"reason":    reason,
+		"message":   "removed pull request from commit queue",
+	})
+
+	prNum, err := item.PRNumber()
+	if err != nil {
+		catcher.Add(err)
+		return catcher.Resolve()
+	}
+	mention := ""
+	pr, err := thirdparty.GetGithubPullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum)
+	if err == nil {
+		mention = fmt.Sprintf("@%s ", *pr.User.Login)
+	}
+	comment := fmt.Sprintf("%sEvergreen removed this pull request from the commit queue because %s.", mention, reason)
+	catcher.Add(thirdparty.PostCommentToPullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum, comment))
+
+	return catcher.Resolve()
+}
+
+// createPatch creates and finalizes a patch with the id that tests the
+// last of the items against the head of the project's branch, with the
+// changes of all of the items applied in order. It returns the head of the
+// last item's pull request.
+func (j *commitQueueJob) createPatch(ctx context.Context, githubToken string, projectRef *model.ProjectRef,
+	patchID bson.ObjectId, items []commitqueue.CommitQueueItem) (string, error) {
+
+	item := items[len(items)-1]
+	prNum, err := item.PRNumber()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	pr, err := thirdparty.GetGithubPullRequest(ctx, githubToken, projectRef.Owner, projectRef.Repo, prNum)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if pr.GetState() != "open" {
+		return "", errors.New("the pull request is not open")
+	}
+	if *pr.Base.Ref != projectRef.Branch {
+		return "", errors.Errorf("the pull request is not against branch '%s'", projectRef.Branch)
+	}
+
+	branch, err := thirdparty.GetBranchEvent(ctx, githubToken, projectRef.Owner, projectRef.Repo, projectRef.Branch)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if branch.Commit == nil || branch.Commit.SHA == nil {
+		return "", errors.Errorf("branch '%s' has no head commit", projectRef.Branch)
+	}
+	baseHash := *branch.Commit.SHA
+
+	alias := projectRef.CommitQueue.PatchAlias
+	if alias == "" {
+		alias = patch.CommitQueueAlias
+	}
+
+	patchDoc := &patch.Patch{
+		Id:      patchID,
+		Project: projectRef.Identifier,
+		Githash: baseHash,
+		Status:  evergreen.PatchCreated,
+		Alias:   alias,
+		Description: fmt.Sprintf("Commit queue merge test for '%s/%s' pull request #%d: %s",
+			projectRef.Owner, projectRef.Repo, prNum, pr.GetTitle()),
+		CommitQueueItem: item.Issue,
+	}
+
+	// the changes of all of the items are applied together as one diff,
+	// since each part of a patch is applied to a clean checkout.
+	diffs := make([]string, 0, len(items))
+	for _, it := range items {
+		var num int
+		num, err = it.PRNumber()
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		var diff string
+		diff, _, err = fetchDiffFromGithub(&patch.GithubPatch{
+			BaseOwner: projectRef.Owner,
+			BaseRepo:  projectRef.Repo,
+			PRNumber:  num,
+		}, githubToken)
+		if err != nil {
+			return "", errors.Wrapf(err, "problem fetching diff of pull request %d", num)
+		}
+		diffs = append(diffs, diff)
+	}
+	diff := combineDiffs(diffs...)
+	summaries, err := thirdparty.GetPatchSummaries(diff)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get patch summary")
+	}
+
+	patchFileID := fmt.Sprintf("%s_%s", patchDoc.Id.Hex(), baseHash)
+	if err = db.WriteGridFile(patch.GridFSPrefix, patchFileID, strings.NewReader(diff)); err != nil {
+		return "", errors.Wrap(err, "failed to write patch file to db")
+	}
+	patchDoc.Patches = append(patchDoc.Patches, patch.ModulePatch{
+		ModuleName: "",
+		Githash:    baseHash,
+		PatchSet: patch.PatchSet{
+			PatchFileId: patchFileID,
+			Summary:     summaries,
+		},
+	})
+
+	if pr.User.ID == nil {
+		return "", errors.New("pull request author has no uid")
+	}
+	u, err := findEvergreenUserForPR(*pr.User.ID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to fetch user")
+	}
+	patchDoc.Author = u.Id
+
+	project, err := validator.GetPatchedProject(ctx, patchDoc, githubToken)
+	if err != nil {
+		return "", errors.Wrap(err, errInvalidPatchedConfig)
+	}
+	projectYamlBytes, err := yaml.Marshal(project)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling patched config")
+	}
+	patchDoc.PatchedConfig = string(projectYamlBytes)
+
+	project.BuildProjectTVPairs(patchDoc, alias)
+	if len(patchDoc.Tasks) == 0 && len(patchDoc.BuildVariants) == 0 {
+		return "", errors.Errorf("alias '%s' does not match any tasks", alias)
+	}
+
+	patchDoc.PatchNumber, err = u.IncPatchNumber()
+	if err != nil {
+		return "", errors.Wrap(err, "error computing patch num")
+	}
+	patchDoc.CreateTime = time.Now()
+
+	if err = patchDoc.Insert(); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if _, err = model.FinalizePatch(ctx, patchDoc, evergreen.MergeTestRequester, githubToken); err != nil {
+		return "", errors.Wrap(err, "problem finalizing patch")
+	}
+
+	grip.Info(message.Fields{
+		"job":       j.ID(),
+		"project":   j.ProjectID,
+		"pr_number": prNum,
+		"patch_id":  patchDoc.Id.Hex(),
+		"items":     len(items),
+		"message":   "created commit queue patch",
+	})
+
+	return *pr.Head.SHA, nil
+}
+
+// combineDiffs joins the diffs into one diff that applies all of their
+// changes in order.
+func combineDiffs(diffs ...string) string {
+	combined := bytes.Buffer{}
+	for _, diff := range diffs {
+		if diff == "" {
+			continue
+		}
+		combined.WriteString(diff)
+		if !strings.HasSuffix(diff, "\n") {
+			combined.WriteString("\n")
+		}
+	}
+	return combined.String()
+}
@@ -0,0 +1,57 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineDiffs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := `diff --git a/a.yml b/a.yml
+index 1111111..2222222 100644
+--- a/a.yml
++++ b/a.yml
+@@ -1 +1 @@
+-a: 1
++a: 2`
+	second := `diff --git a/b.yml b/b.yml
+index 3333333..4444444 100644
+--- a/b.yml
++++ b/b.yml
+@@ -1 +1 @@
+-b: 1
++b: 2
+`
+	diff := combineDiffs(first, "", second)
+	summaries, err := thirdparty.GetPatchSummaries(diff)
+	require.NoError(err)
+	require.Len(summaries, 2)
+
+	p := &patch.Patch{
+		Patches: []patch.ModulePatch{{
+			PatchSet: patch.PatchSet{Patch: diff, Summary: summaries},
+		}},
+	}
+	base := map[string]string{"a.yml": "a: 1\n", "b.yml": "b: 1\n"}
+	load := model.PatchedAxisValuesLoader(ctx, p, func(path string) ([]byte, error) {
+		return []byte(base[path]), nil
+	})
+
+	// both pull requests' changes are in the patched tree
+	data, err := load("a.yml")
+	require.NoError(err)
+	assert.Equal("a: 2\n", string(data))
+	data, err = load("b.yml")
+	require.NoError(err)
+	assert.Equal("b: 2\n", string(data))
+}
@@ -146,6 +146,140 @@ func PopulateActivationJobs(part int) amboy.QueueOperation {
 	}
 }
 
+func PopulateCommitQueueJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.CommitQueueDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "commit queue is disabled",
+				"mode":    "degraded",
+				"impact":  "pull requests are not tested or merged",
+			})
+			return nil
+		}
+
+		projects, err := model.FindProjectRefsWithCommitQueueEnabled()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(part).Format(tsFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			catcher.Add(queue.Put(NewCommitQueueJob(proj.Identifier, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateProjectTriggerEventJobs enqueues a job to process the events
+// that can trigger downstream projects.
+func PopulateProjectTriggerEventJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.RepotrackerDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "repotracker is disabled",
+				"mode":    "degraded",
+				"impact":  "downstream projects are not triggered",
+			})
+			return nil
+		}
+
+		ts := util.RoundPartOfHour(part).Format(tsFormat)
+
+		return queue.Put(NewProjectTriggerEventsJob(ts))
+	}
+}
+
+// PopulatePeriodicBuildJobs enqueues a job for each periodic build
+// definition whose cron schedule has run, in UTC, since the last time in
+// its schedule that a build was queued for. Schedule points that were
+// missed while builds weren't being queued, up to periodicBuildCatchupWindow
+// ago, are caught up with one build for the latest of them, since every
+// build is of the head of the branch.
+func PopulatePeriodicBuildJobs() amboy.QueueOperation {
+	const periodicBuildCatchupWindow = 24 * time.Hour
+
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.RepotrackerDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "repotracker is disabled",
+				"mode":    "degraded",
+				"impact":  "periodic builds are not created",
+			})
+			return nil
+		}
+
+		projects, err := model.FindProjectRefsWithPeriodicBuilds()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		now := time.Now().UTC().Truncate(time.Minute)
+
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			for _, definition := range proj.PeriodicBuilds {
+				schedule, err := util.ParseCron(definition.Cron)
+				if err != nil {
+					catcher.Add(errors.Wrapf(err, "invalid periodic build '%s' for project %s",
+						definition.ID, proj.Identifier))
+					continue
+				}
+
+				// new definitions start with the current minute
+				since := definition.LastScheduled
+				if since.IsZero() {
+					since = now.Add(-time.Minute)
+				}
+				if earliest := now.Add(-periodicBuildCatchupWindow); since.Before(earliest) {
+					since = earliest
+				}
+				scheduled, ok := schedule.Latest(since, now)
+				if !ok {
+					continue
+				}
+				if scheduled.Before(now) {
+					grip.Info(message.Fields{
+						"message":        "catching up missed periodic build",
+						"project":        proj.Identifier,
+						"definition":     definition.ID,
+						"scheduled":      scheduled,
+						"last_scheduled": definition.LastScheduled,
+					})
+				}
+
+				j := NewPeriodicBuildJob(proj.Identifier, definition.ID, scheduled.Format(tsFormat))
+				if _, ok = queue.Get(j.ID()); !ok {
+					if err = queue.Put(j); err != nil {
+						catcher.Add(err)
+						continue
+					}
+				}
+				catcher.Add(proj.SetPeriodicBuildLastScheduled(definition.ID, scheduled))
+			}
+		}
+
+		return catcher.Resolve()
+	}
+}
+
 func PopulateHostMonitoring(env evergreen.Environment) amboy.QueueOperation {
 	const reachabilityCheckInterval = 10 * time.Minute
 
@@ -279,3 +413,81 @@ func PopulateSchedulerJobs() amboy.QueueOperation {
 		return catcher.Resolve()
 	}
 }
+
+// PopulateFlakyTestDetectionJobs enqueues a job for each enabled project
+// that recomputes the project's flaky tests, at most once an hour.
+func PopulateFlakyTestDetectionJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		projects, err := model.FindAllTrackedProjectRefs()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(0).Format(tsFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			if !proj.Enabled {
+				continue
+			}
+			catcher.Add(queue.Put(NewFlakyTestDetectionJob(proj.Identifier, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulatePatchStackJobs enqueues a job that finalizes the next patch of
+// each sequential patch stack whose previous patch has finished.
+func PopulatePatchStackJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		ts := util.RoundPartOfHour(part).Format(tsFormat)
+		return queue.Put(NewPatchStackJob(ts))
+	}
+}
+
+// PopulateNotificationDigestJobs enqueues a job that sends the notification
+// digests that are due.
+func PopulateNotificationDigestJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.AlertsDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "alerts are disabled",
+				"mode":    "degraded",
+				"impact":  "notification digests are not sent",
+			})
+			return nil
+		}
+
+		ts := util.RoundPartOfHour(part).Format(tsFormat)
+		return queue.Put(NewNotificationDigestJob(ts))
+	}
+}
+
+// PopulatePerfRegressionNotificationJobs enqueues a job that notifies the
+// subscribers of perf regressions.
+func PopulatePerfRegressionNotificationJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.AlertsDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "alerts are disabled",
+				"mode":    "degraded",
+				"impact":  "perf regression notifications are not sent",
+			})
+			return nil
+		}
+
+		ts := util.RoundPartOfHour(part).Format(tsFormat)
+		return queue.Put(NewPerfRegressionNotificationsJob(ts))
+	}
+}
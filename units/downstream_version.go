@@ -0,0 +1,114 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/repotracker"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	downstreamVersionJobName = "downstream-version"
+)
+
+func init() {
+	registry.AddJobType(downstreamVersionJobName, func() amboy.Job { return makeDownstreamVersionJob() })
+}
+
+type downstreamVersionJob struct {
+	ProjectID string              `bson:"project_id" json:"project_id" yaml:"project_id"`
+	TriggerID string              `bson:"trigger_id" json:"trigger_id" yaml:"trigger_id"`
+	Upstream  model.UpstreamEvent `bson:"upstream" json:"upstream" yaml:"upstream"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env       evergreen.Environment
+}
+
+func makeDownstreamVersionJob() *downstreamVersionJob {
+	j := &downstreamVersionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    downstreamVersionJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewDownstreamVersionJob creates a job that creates a version of a
+// downstream project for one of its triggers and an upstream event.
+func NewDownstreamVersionJob(projectID, triggerID string, upstream model.UpstreamEvent) amboy.Job {
+	j := makeDownstreamVersionJob()
+	j.ProjectID = projectID
+	j.TriggerID = triggerID
+	j.Upstream = upstream
+	j.SetID(fmt.Sprintf("%s:%s:%s:%s", downstreamVersionJobName, upstream.EventID, projectID, triggerID))
+	return j
+}
+
+func (j *downstreamVersionJob) Run(ctx context.Context) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	settings := j.env.Settings()
+	if settings == nil {
+		j.AddError(errors.New("settings is empty"))
+		return
+	}
+	token, err := settings.GetGithubOauthToken()
+	if err != nil {
+		j.AddError(errors.New("github token is missing"))
+		return
+	}
+
+	ref, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if ref == nil {
+		j.AddError(errors.Errorf("can't find project ref for project %s", j.ProjectID))
+		return
+	}
+	definition := ref.GetTrigger(j.TriggerID)
+	if definition == nil {
+		j.AddError(errors.Errorf("project %s has no trigger '%s'", j.ProjectID, j.TriggerID))
+		return
+	}
+
+	tracker := &repotracker.RepoTracker{
+		Settings:   settings,
+		ProjectRef: ref,
+		RepoPoller: repotracker.NewGithubRepositoryPoller(ref, token),
+	}
+	v, err := tracker.CreateDownstreamVersion(ctx, definition, &j.Upstream)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"job":      downstreamVersionJobName,
+		"job_id":   j.ID(),
+		"project":  j.ProjectID,
+		"trigger":  j.TriggerID,
+		"upstream": j.Upstream.Project,
+		"version":  v.Id,
+	})
+}
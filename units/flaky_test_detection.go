@@ -0,0 +1,67 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+const (
+	flakyTestDetectionJobName = "flaky-test-detection"
+)
+
+func init() {
+	registry.AddJobType(flakyTestDetectionJobName, func() amboy.Job { return makeFlakyTestDetectionJob() })
+}
+
+type flakyTestDetectionJob struct {
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+}
+
+func makeFlakyTestDetectionJob() *flakyTestDetectionJob {
+	j := &flakyTestDetectionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    flakyTestDetectionJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewFlakyTestDetectionJob creates a job that recomputes the flaky tests
+// of a project from the test results of its recently finished tasks.
+func NewFlakyTestDetectionJob(projectID, ts string) amboy.Job {
+	j := makeFlakyTestDetectionJob()
+	j.ProjectID = projectID
+	j.SetID(fmt.Sprintf("%s:%s:%s", flakyTestDetectionJobName, projectID, ts))
+	return j
+}
+
+func (j *flakyTestDetectionJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	flaky, err := flakytest.UpdateForProject(j.ProjectID, time.Now().Add(-flakytest.DetectionWindow))
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"job":         flakyTestDetectionJobName,
+		"job_id":      j.ID(),
+		"project":     j.ProjectID,
+		"flaky_tests": len(flaky),
+	})
+}
@@ -0,0 +1,340 @@
+package units
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	githubCheckRunJobName = "github-check-run"
+
+	// GithubCheckRunRerunAction identifies the check run button that
+	// restarts the build's tasks.
+	GithubCheckRunRerunAction = "evergreen-rerun"
+
+	// maxGithubCheckRunAnnotations caps the annotations of a check run, so
+	// that a build with many failing tests doesn't flood the pull request.
+	maxGithubCheckRunAnnotations = 3 * thirdparty.GithubMaxCheckRunAnnotations
+)
+
+func init() {
+	registry.AddJobType(githubCheckRunJobName, func() amboy.Job { return makeGithubCheckRunJob() })
+}
+
+// githubCheckRunJob reports a finished build of a GitHub pull request patch
+// as a check run of the pull request's head commit, using the GitHub App
+// configured in the admin settings.
+type githubCheckRunJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env      evergreen.Environment
+
+	BuildID string `bson:"build_id" json:"build_id" yaml:"build_id"`
+}
+
+func makeGithubCheckRunJob() *githubCheckRunJob {
+	j := &githubCheckRunJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    githubCheckRunJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	j.SetPriority(1)
+	return j
+}
+
+// NewGithubCheckRunJob creates a job that reports the build as a check run
+// named 'evergreen/[build variant name]'.
+func NewGithubCheckRunJob(buildID string) amboy.Job {
+	j := makeGithubCheckRunJob()
+	j.BuildID = buildID
+
+	j.SetID(fmt.Sprintf("%s:%s-%s", githubCheckRunJobName, buildID, time.Now().String()))
+	return j
+}
+
+func (j *githubCheckRunJob) Run(ctx context.Context) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.GithubStatusAPIDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     githubCheckRunJobName,
+			"message": "github status updates are disabled, not creating check run",
+		})
+		j.AddError(errors.New("github status updates are disabled, not creating check run"))
+		return
+	}
+
+	settings := j.env.Settings()
+	if settings == nil || settings.Ui.Url == "" {
+		j.AddError(errors.New("ui not configured"))
+		return
+	}
+	if !settings.GithubApp.Enabled() {
+		j.AddError(errors.New("github app not configured"))
+		return
+	}
+	key, err := settings.GithubApp.RSAPrivateKey()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	b, p, err := j.fetch()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	failed, err := findFailedTasksWithTests(b)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	run, err := githubCheckRunForBuild(b, failed, p.GithubPatchData.HeadHash, settings.Ui.Url)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	ctx, cancel = context.WithTimeout(ctx, githubStatusAPITimeout)
+	defer cancel()
+
+	owner, repo := p.GithubPatchData.BaseOwner, p.GithubPatchData.BaseRepo
+	token, err := thirdparty.GetGithubAppInstallationToken(ctx, settings.GithubApp.AppID, key, owner, repo)
+	if err == nil {
+		_, err = thirdparty.CreateGithubCheckRun(ctx, token, owner, repo, run)
+	}
+	if err != nil {
+		grip.Alert(message.WrapError(err, message.Fields{
+			"message":  "github API failure",
+			"source":   "check runs",
+			"job":      j.ID(),
+			"build_id": j.BuildID,
+			"owner":    owner,
+			"repo":     repo,
+		}))
+		j.AddError(err)
+	}
+}
+
+// fetch returns the job's build and the pull request patch that it belongs
+// to.
+func (j *githubCheckRunJob) fetch() (*build.Build, *patch.Patch, error) {
+	b, err := build.FindOne(build.ById(j.BuildID))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "problem finding build '%s'", j.BuildID)
+	}
+	if b == nil {
+		return nil, nil, errors.Errorf("can't find build '%s'", j.BuildID)
+	}
+	if !bson.IsObjectIdHex(b.Version) {
+		return nil, nil, errors.Errorf("build '%s' is not part of a patch", b.Id)
+	}
+
+	p, err := patch.FindOne(patch.ById(bson.ObjectIdHex(b.Version)))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "problem finding patch '%s'", b.Version)
+	}
+	if p == nil {
+		return nil, nil, errors.Errorf("can't find patch '%s'", b.Version)
+	}
+	if p.GithubPatchData.BaseOwner == "" || p.GithubPatchData.BaseRepo == "" || p.GithubPatchData.HeadHash == "" {
+		return nil, nil, errors.Errorf("patch '%s' is not a pull request patch", b.Version)
+	}
+
+	return b, p, nil
+}
+
+// findFailedTasksWithTests returns the build's failed tasks, with their
+// test results.
+func findFailedTasksWithTests(b *build.Build) ([]task.Task, error) {
+	failed := []task.Task{}
+	for _, cached := range b.Tasks {
+		if cached.Status != evergreen.TaskFailed {
+			continue
+		}
+		t, err := task.FindOneId(cached.Id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding task '%s'", cached.Id)
+		}
+		if t == nil {
+			continue
+		}
+		if err = t.MergeNewTestResults(); err != nil {
+			return nil, errors.Wrapf(err, "problem finding test results of task '%s'", t.Id)
+		}
+		failed = append(failed, *t)
+	}
+	return failed, nil
+}
+
+// githubCheckRunForBuild returns the check run that reports the finished
+// build. Its summary lists the failed tasks and its text lists their failed
+// tests. Failed tests whose names are file paths are also annotated on the
+// file. The test parsers don't record where in the file a test failed, so
+// the annotations are on the file's first line. Failed builds get a button
+// that restarts the build's tasks.
+func githubCheckRunForBuild(b *build.Build, failed []task.Task, headSHA, uiRoot string) (thirdparty.GithubCheckRun, error) {
+	run := thirdparty.GithubCheckRun{
+		Name:       fmt.Sprintf("evergreen/%s", b.BuildVariant),
+		HeadSHA:    headSHA,
+		DetailsURL: fmt.Sprintf("%s/build/%s", uiRoot, b.Id),
+		ExternalID: b.Id,
+		Status:     thirdparty.GithubCheckRunStatusCompleted,
+	}
+
+	switch b.Status {
+	case evergreen.BuildSucceeded:
+		run.Conclusion = thirdparty.GithubCheckRunConclusionSuccess
+	case evergreen.BuildFailed:
+		run.Conclusion = thirdparty.GithubCheckRunConclusionFailure
+		run.Actions = []thirdparty.GithubCheckRunAction{{
+			Label:       "Re-run",
+			Description: "Restart the tasks of this build",
+			Identifier:  GithubCheckRunRerunAction,
+		}}
+	default:
+		return run, errors.New("build status is pending; refusing to create check run")
+	}
+	if !b.StartTime.IsZero() {
+		started := b.StartTime
+		run.StartedAt = &started
+	}
+	if !b.FinishTime.IsZero() {
+		finished := b.FinishTime
+		run.CompletedAt = &finished
+	}
+
+	desc := taskStatusToDesc(b)
+	summary := &bytes.Buffer{}
+	text := &bytes.Buffer{}
+	annotations := []thirdparty.GithubCheckRunAnnotation{}
+	skipped := 0
+	if len(failed) > 0 {
+		summary.WriteString("### Failed tasks\n\n")
+	}
+	for _, t := range failed {
+		taskURL := fmt.Sprintf("%s/task/%s", uiRoot, t.Id)
+		failedTests := 0
+		for _, result := range t.LocalTestResults {
+			if result.Status != evergreen.TestFailedStatus {
+				continue
+			}
+			failedTests++
+			if failedTests == 1 {
+				fmt.Fprintf(text, "### [%s](%s)\n\n", t.DisplayName, taskURL)
+			}
+			logURL := testLogURL(uiRoot, result)
+			if logURL != "" {
+				fmt.Fprintf(text, "- [%s](%s)\n", result.TestFile, logURL)
+			} else {
+				fmt.Fprintf(text, "- %s\n", result.TestFile)
+			}
+
+			file, ok := testFilePath(result.TestFile)
+			if !ok {
+				continue
+			}
+			if len(annotations) >= maxGithubCheckRunAnnotations {
+				skipped++
+				continue
+			}
+			annotation := thirdparty.GithubCheckRunAnnotation{
+				Path:            file,
+				StartLine:       1,
+				EndLine:         1,
+				AnnotationLevel: thirdparty.GithubAnnotationLevelFailure,
+				Title:           fmt.Sprintf("%s failed", result.TestFile),
+				Message:         fmt.Sprintf("%s failed in task %s", result.TestFile, t.DisplayName),
+			}
+			if logURL != "" {
+				annotation.RawDetails = logURL
+			}
+			annotations = append(annotations, annotation)
+		}
+		if failedTests > 0 {
+			text.WriteString("\n")
+		}
+
+		switch failedTests {
+		case 0:
+			fmt.Fprintf(summary, "- [%s](%s)\n", t.DisplayName, taskURL)
+		case 1:
+			fmt.Fprintf(summary, "- [%s](%s): 1 failed test\n", t.DisplayName, taskURL)
+		default:
+			fmt.Fprintf(summary, "- [%s](%s): %d failed tests\n", t.DisplayName, taskURL, failedTests)
+		}
+	}
+	if skipped > 0 {
+		fmt.Fprintf(summary, "\n%d more failed tests were not annotated.\n", skipped)
+	}
+	if summary.Len() == 0 {
+		summary.WriteString(desc)
+	}
+
+	run.Output = &thirdparty.GithubCheckRunOutput{
+		Title:       desc,
+		Summary:     summary.String(),
+		Text:        text.String(),
+		Annotations: annotations,
+	}
+	return run, nil
+}
+
+// testLogURL returns the link to the test's log, or an empty string if the
+// test has no log.
+func testLogURL(uiRoot string, result task.TestResult) string {
+	if result.URL != "" {
+		return result.URL
+	}
+	if result.LogId == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%s%s#L%d", uiRoot, task.TestLogPath, result.LogId, result.LineNum)
+}
+
+// testFilePath returns the test's name as a path relative to the root of
+// the repository, if the name looks like a path to a file.
+func testFilePath(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, " \t:\\") || path.Ext(name) == "" {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
@@ -0,0 +1,132 @@
+package units
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGithubCheckRunForBuild(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	start := time.Now().Add(-10 * time.Minute).Round(time.Second)
+	b := &build.Build{
+		Id:           "b1",
+		BuildVariant: "ubuntu",
+		Status:       evergreen.BuildFailed,
+		StartTime:    start,
+		FinishTime:   start.Add(5 * time.Minute),
+		Tasks: []build.TaskCache{
+			{Id: "t1", Status: evergreen.TaskFailed},
+			{Id: "t2", Status: evergreen.TaskFailed},
+			{Id: "t3", Status: evergreen.TaskSucceeded},
+		},
+	}
+	failed := []task.Task{
+		{
+			Id:          "t1",
+			DisplayName: "js",
+			LocalTestResults: []task.TestResult{
+				{Status: evergreen.TestFailedStatus, TestFile: "jstests/core/find.js", LogId: "log1", LineNum: 12},
+				{Status: evergreen.TestSucceededStatus, TestFile: "jstests/core/insert.js"},
+				{Status: evergreen.TestFailedStatus, TestFile: "TestUpdate", URL: "https://logs.example.com/1"},
+			},
+		},
+		{Id: "t2", DisplayName: "lint"},
+	}
+
+	run, err := githubCheckRunForBuild(b, failed, "abcdef", "https://evergreen.example.com")
+	require.NoError(err)
+	assert.Equal("evergreen/ubuntu", run.Name)
+	assert.Equal("abcdef", run.HeadSHA)
+	assert.Equal("b1", run.ExternalID)
+	assert.Equal("https://evergreen.example.com/build/b1", run.DetailsURL)
+	assert.Equal(thirdparty.GithubCheckRunStatusCompleted, run.Status)
+	assert.Equal(thirdparty.GithubCheckRunConclusionFailure, run.Conclusion)
+	require.NotNil(run.StartedAt)
+	assert.Equal(start, *run.StartedAt)
+	require.NotNil(run.CompletedAt)
+	assert.Equal(start.Add(5*time.Minute), *run.CompletedAt)
+	require.Len(run.Actions, 1)
+	assert.Equal(GithubCheckRunRerunAction, run.Actions[0].Identifier)
+
+	require.NotNil(run.Output)
+	assert.Equal(taskStatusToDesc(b), run.Output.Title)
+	assert.Contains(run.Output.Summary, "- [js](https://evergreen.example.com/task/t1): 2 failed tests")
+	assert.Contains(run.Output.Summary, "- [lint](https://evergreen.example.com/task/t2)\n")
+	assert.Contains(run.Output.Text, "- [jstests/core/find.js](https://evergreen.example.com/test_log/log1#L12)")
+	assert.Contains(run.Output.Text, "- [TestUpdate](https://logs.example.com/1)")
+	assert.NotContains(run.Output.Text, "insert.js")
+
+	require.Len(run.Output.Annotations, 1)
+	annotation := run.Output.Annotations[0]
+	assert.Equal("jstests/core/find.js", annotation.Path)
+	assert.Equal(1, annotation.StartLine)
+	assert.Equal(1, annotation.EndLine)
+	assert.Equal(thirdparty.GithubAnnotationLevelFailure, annotation.AnnotationLevel)
+	assert.Equal("https://evergreen.example.com/test_log/log1#L12", annotation.RawDetails)
+
+	b.Status = evergreen.BuildSucceeded
+	run, err = githubCheckRunForBuild(b, nil, "abcdef", "https://evergreen.example.com")
+	require.NoError(err)
+	assert.Equal(thirdparty.GithubCheckRunConclusionSuccess, run.Conclusion)
+	assert.Empty(run.Actions)
+	assert.Equal(run.Output.Title, run.Output.Summary)
+	assert.Empty(run.Output.Annotations)
+
+	b.Status = evergreen.BuildStarted
+	_, err = githubCheckRunForBuild(b, nil, "abcdef", "https://evergreen.example.com")
+	assert.Error(err)
+}
+
+func TestGithubCheckRunAnnotationLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	b := &build.Build{
+		Id:           "b1",
+		BuildVariant: "ubuntu",
+		Status:       evergreen.BuildFailed,
+	}
+	failed := []task.Task{{Id: "t1", DisplayName: "js"}}
+	for i := 0; i < maxGithubCheckRunAnnotations+5; i++ {
+		failed[0].LocalTestResults = append(failed[0].LocalTestResults, task.TestResult{
+			Status:   evergreen.TestFailedStatus,
+			TestFile: fmt.Sprintf("jstests/test_%d.js", i),
+		})
+	}
+
+	run, err := githubCheckRunForBuild(b, failed, "abcdef", "https://evergreen.example.com")
+	require.NoError(err)
+	assert.Len(run.Output.Annotations, maxGithubCheckRunAnnotations)
+	assert.Contains(run.Output.Summary, "5 more failed tests were not annotated")
+}
+
+func TestTestFilePath(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, expected := range map[string]string{
+		"jstests/core/find.js":    "jstests/core/find.js",
+		"./src/foo_test.go":       "src/foo_test.go",
+		"src/../lib/parser.py":    "lib/parser.py",
+		"TestFindOne":             "",
+		"":                        "",
+		"/abs/path/test.js":       "",
+		"../outside/test.js":      "",
+		"test suite with spaces.": "",
+		"C:\\tests\\test.js":      "",
+		"pkg.TestFoo:subtest":     "",
+	} {
+		path, ok := testFilePath(name)
+		assert.Equal(expected != "", ok, name)
+		assert.Equal(expected, path, name)
+	}
+}
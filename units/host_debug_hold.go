@@ -0,0 +1,127 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/spawn"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const hostDebugHoldJobName = "host-debug-hold"
+
+func init() {
+	registry.AddJobType(hostDebugHoldJobName, func() amboy.Job {
+		return makeHostDebugHoldJob()
+	})
+}
+
+type hostDebugHoldJob struct {
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	TaskID   string `bson:"task_id" json:"task_id" yaml:"task_id"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	env evergreen.Environment
+}
+
+func makeHostDebugHoldJob() *hostDebugHoldJob {
+	j := &hostDebugHoldJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    hostDebugHoldJobName,
+				Version: 0,
+			},
+		},
+	}
+
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewHostDebugHoldJob creates a job that authorizes the public keys of
+// the user that a host is held for, once the host has been taken out of
+// rotation after a task failed on it.
+func NewHostDebugHoldJob(env evergreen.Environment, hostID, taskID string) amboy.Job {
+	j := makeHostDebugHoldJob()
+	j.HostID = hostID
+	j.TaskID = taskID
+	j.env = env
+	j.SetPriority(2)
+	j.SetID(fmt.Sprintf("%s.%s.%s", hostDebugHoldJobName, hostID, taskID))
+
+	return j
+}
+
+func (j *hostDebugHoldJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	h, err := host.FindOneId(j.HostID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if h == nil {
+		j.AddError(errors.Errorf("could not find host %s", j.HostID))
+		return
+	}
+	if h.DebugTask != j.TaskID || h.Status != evergreen.HostRunning {
+		grip.Info(message.Fields{
+			"job":     j.ID(),
+			"host":    j.HostID,
+			"task":    j.TaskID,
+			"status":  h.Status,
+			"message": "host is no longer held for debugging the task",
+		})
+		return
+	}
+
+	owner, err := user.FindOne(user.ById(h.StartedBy))
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if owner == nil {
+		j.AddError(errors.Errorf("could not find user %s", h.StartedBy))
+		return
+	}
+
+	keys := make([]string, 0, len(owner.PubKeys))
+	for _, key := range owner.PubKeys {
+		keys = append(keys, key.Key)
+	}
+	if len(keys) == 0 {
+		grip.Notice(message.Fields{
+			"job":     j.ID(),
+			"host":    j.HostID,
+			"user":    owner.Id,
+			"message": "user has no public keys to authorize on held host",
+		})
+		return
+	}
+
+	if err = spawn.AuthorizeKeys(ctx, j.env.Settings(), h, keys); err != nil {
+		j.AddError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"job":     j.ID(),
+		"host":    j.HostID,
+		"task":    j.TaskID,
+		"user":    owner.Id,
+		"message": "authorized user keys on host held for debugging",
+	})
+}
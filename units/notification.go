@@ -0,0 +1,169 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/alerts"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	notificationJobName = "notification"
+)
+
+func init() {
+	registry.AddJobType(notificationJobName, func() amboy.Job { return makeNotificationJob() })
+}
+
+// notificationJob sends a notification to an email, slack, or JIRA comment
+// subscriber.
+type notificationJob struct {
+	NotificationID string `bson:"notification_id" json:"notification_id" yaml:"notification_id"`
+	job.Base       `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+func makeNotificationJob() *notificationJob {
+	j := &notificationJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    notificationJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewNotificationJob creates a job to send the notification with the id,
+// which must be for an email, slack, or JIRA comment subscriber.
+func NewNotificationJob(id bson.ObjectId) amboy.Job {
+	j := makeNotificationJob()
+	j.NotificationID = id.Hex()
+
+	j.SetID(fmt.Sprintf("%s:%s", notificationJobName, id.Hex()))
+	return j
+}
+
+// newSendNotificationJob returns the job that sends the notification to its
+// subscriber.
+func newSendNotificationJob(n *notification.Notification) (amboy.Job, error) {
+	switch n.Subscriber.Type {
+	case event.TeamsSubscriberType, event.ChatWebhookSubscriberType:
+		return NewChatNotificationJob(n.ID), nil
+	case event.EmailSubscriberType, event.SlackSubscriberType, event.JIRACommentSubscriberType:
+		return NewNotificationJob(n.ID), nil
+	default:
+		return nil, errors.Errorf("notifications to %s subscribers can't be sent by a job", n.Subscriber.Type)
+	}
+}
+
+func (j *notificationJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	if !bson.IsObjectIdHex(j.NotificationID) {
+		j.AddError(errors.Errorf("invalid notification id '%s'", j.NotificationID))
+		return
+	}
+	n, err := notification.Find(bson.ObjectIdHex(j.NotificationID))
+	if err != nil {
+		j.AddError(errors.Wrap(err, "can't find notification"))
+		return
+	}
+	if n == nil {
+		j.AddError(errors.Errorf("notification '%s' does not exist", j.NotificationID))
+		return
+	}
+	if !n.SentAt.IsZero() || n.Digest.Batched() {
+		// batched notifications are sent in a digest by the
+		// notification digest job
+		return
+	}
+
+	sendErr := sendNotification(ctx, j.env.Settings(), n)
+	if sendErr != nil {
+		grip.Error(message.WrapError(sendErr, message.Fields{
+			"message":         "can't send notification",
+			"job":             j.ID(),
+			"notification_id": j.NotificationID,
+			"subscriber_type": n.Subscriber.Type,
+		}))
+		j.AddError(sendErr)
+		j.AddError(n.MarkError(sendErr))
+		return
+	}
+	j.AddError(n.MarkSent())
+}
+
+// sendNotification sends the notification with the email, slack, or JIRA
+// settings.
+func sendNotification(ctx context.Context, settings *evergreen.Settings, n *notification.Notification) error {
+	target, ok := n.Subscriber.Target.(*string)
+	if !ok || target == nil || *target == "" {
+		return errors.Errorf("%s subscriber is invalid", n.Subscriber.Type)
+	}
+
+	switch n.Subscriber.Type {
+	case event.EmailSubscriberType:
+		payload, ok := n.Payload.(*notification.EmailPayload)
+		if !ok || payload == nil {
+			return errors.New("email payload is invalid")
+		}
+		smtp := settings.Alerts.SMTP
+		if smtp == nil || smtp.Server == "" {
+			return errors.New("smtp settings are not configured")
+		}
+		sender := alerts.SMTPSettings{
+			Server:   smtp.Server,
+			Port:     smtp.Port,
+			UseSSL:   smtp.UseSSL,
+			Username: smtp.Username,
+			Password: smtp.Password,
+			From:     smtp.From,
+		}
+		return errors.Wrap(sender.SendEmail(*target, payload.Subject, string(payload.Body), payload.Headers),
+			"problem sending email")
+
+	case event.SlackSubscriberType:
+		payload, ok := n.Payload.(*string)
+		if !ok || payload == nil {
+			return errors.New("slack payload is invalid")
+		}
+		return errors.Wrap(thirdparty.PostSlackMessage(ctx, settings.Slack.Token, &thirdparty.SlackMessage{
+			Channel: *target,
+			Text:    *payload,
+		}), "problem posting slack message")
+
+	case event.JIRACommentSubscriberType:
+		payload, ok := n.Payload.(*string)
+		if !ok || payload == nil {
+			return errors.New("jira-comment payload is invalid")
+		}
+		if settings.Jira.Host == "" {
+			return errors.New("jira settings are not configured")
+		}
+		jira := thirdparty.NewJiraHandler(settings.Jira.Host, settings.Jira.Username, settings.Jira.Password)
+		return errors.Wrapf(jira.AddComment(*target, *payload), "problem commenting on '%s'", *target)
+
+	default:
+		return errors.Errorf("notifications to %s subscribers can't be sent", n.Subscriber.Type)
+	}
+}
@@ -0,0 +1,208 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	notificationDigestJobName = "notification-digest"
+
+	// digestRequeueDelay is how long a digest may wait to be sent before
+	// the job queues it again, in case queueing it failed.
+	digestRequeueDelay = 15 * time.Minute
+)
+
+func init() {
+	registry.AddJobType(notificationDigestJobName, func() amboy.Job { return makeNotificationDigestJob() })
+}
+
+// notificationDigestJob summarizes the batched notifications of each
+// subscriber whose digest is due in one digest notification.
+type notificationDigestJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+func makeNotificationDigestJob() *notificationDigestJob {
+	j := &notificationDigestJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    notificationDigestJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewNotificationDigestJob creates a job that sends the notification
+// digests that are due.
+func NewNotificationDigestJob(ts string) amboy.Job {
+	j := makeNotificationDigestJob()
+	j.SetID(fmt.Sprintf("%s:%s", notificationDigestJobName, ts))
+	return j
+}
+
+func (j *notificationDigestJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	j.requeueUnsentDigests()
+
+	pending, err := notification.FindUndigested()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	batches, err := notification.BatchDigests(pending)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	now := time.Now()
+	finished := map[string]bool{}
+	sent := 0
+	for i := range batches {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			return
+		}
+
+		batch := &batches[i]
+		versionFinished := false
+		if batch.Digest.Mode == event.DigestVersion {
+			if versionFinished, err = j.versionFinished(finished, batch.Version); err != nil {
+				j.AddError(err)
+				continue
+			}
+		}
+		if !batch.Ready(now, versionFinished) {
+			continue
+		}
+
+		if err = j.sendDigest(batch); err != nil {
+			j.AddError(errors.Wrapf(err, "problem sending digest to %s subscriber", batch.Subscriber.Type))
+			continue
+		}
+		sent++
+	}
+
+	grip.Info(message.Fields{
+		"job":           notificationDigestJobName,
+		"job_id":        j.ID(),
+		"notifications": len(pending),
+		"batches":       len(batches),
+		"digests_sent":  sent,
+	})
+}
+
+// versionFinished returns whether the version has finished, caching the
+// result. Versions that no longer exist count as finished.
+func (j *notificationDigestJob) versionFinished(cache map[string]bool, versionID string) (bool, error) {
+	if versionID == "" {
+		return true, nil
+	}
+	if done, ok := cache[versionID]; ok {
+		return done, nil
+	}
+	v, err := version.FindOne(version.ById(versionID).WithFields(version.StatusKey))
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding version '%s'", versionID)
+	}
+	done := v == nil || util.StringSliceContains([]string{evergreen.VersionFailed, evergreen.VersionSucceeded}, v.Status)
+	cache[versionID] = done
+	return done, nil
+}
+
+// sendDigest claims the batch's notifications for the batch's digest
+// notification, saves the digest, and queues it to be sent. A batch that
+// another job claimed first is skipped.
+func (j *notificationDigestJob) sendDigest(batch *notification.DigestBatch) error {
+	digest, err := batch.Compose()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]bson.ObjectId, 0, len(batch.Notifications))
+	for _, n := range batch.Notifications {
+		ids = append(ids, n.ID)
+	}
+	claimed, err := notification.MarkDigested(ids, digest.ID)
+	if err != nil {
+		return err
+	}
+	if claimed < len(ids) {
+		// the notifications that this job claimed are batched again
+		// by the next run
+		grip.Info(message.Fields{
+			"job":           notificationDigestJobName,
+			"job_id":        j.ID(),
+			"message":       "digest batch was claimed by another job",
+			"notifications": len(ids),
+			"claimed":       claimed,
+		})
+		if claimed > 0 {
+			return notification.UnmarkDigested(digest.ID)
+		}
+		return nil
+	}
+
+	if err = notification.InsertMany(*digest); err != nil {
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(errors.Wrap(err, "problem saving digest notification"))
+		catcher.Add(notification.UnmarkDigested(digest.ID))
+		return catcher.Resolve()
+	}
+
+	// the digest is saved, so if it can't be queued now a later run
+	// queues it again.
+	return j.queueDigest(digest)
+}
+
+// requeueUnsentDigests queues the saved digests that should have been sent
+// by now.
+func (j *notificationDigestJob) requeueUnsentDigests() {
+	digests, err := notification.FindUnsentDigests(time.Now().Add(-digestRequeueDelay))
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	for i := range digests {
+		grip.Warning(message.WrapError(j.queueDigest(&digests[i]), message.Fields{
+			"job":       notificationDigestJobName,
+			"job_id":    j.ID(),
+			"message":   "problem queueing unsent digest, it may be queued already",
+			"digest_id": digests[i].ID.Hex(),
+		}))
+	}
+}
+
+func (j *notificationDigestJob) queueDigest(digest *notification.Notification) error {
+	sender, err := newSendNotificationJob(digest)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(j.env.RemoteQueue().Put(sender), "problem queueing digest %s", digest.ID.Hex())
+}
@@ -0,0 +1,71 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/amboy/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestNotificationDigestSendDigest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(notification.NotificationsCollection))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &mock.Environment{}
+	require.NoError(env.Configure(ctx, "", nil))
+	// the queue isn't started, so the queued jobs don't run
+	env.Remote = queue.NewLocalOrdered(1)
+
+	channel := "#evg"
+	created := time.Now().Add(-2 * time.Hour)
+	for _, summary := range []string{"task failed", "build failed"} {
+		payload := summary
+		require.NoError(notification.InsertMany(notification.Notification{
+			ID:         bson.NewObjectIdWithTime(created),
+			Subscriber: event.Subscriber{Type: event.SlackSubscriberType, Target: &channel},
+			Payload:    &payload,
+			Digest:     event.Digest{Mode: event.DigestInterval, IntervalMinutes: 60},
+		}))
+	}
+	pending, err := notification.FindUndigested()
+	require.NoError(err)
+	batches, err := notification.BatchDigests(pending)
+	require.NoError(err)
+	require.Len(batches, 1)
+
+	// two overlapping runs that built the same batch send one digest
+	for i := 0; i < 2; i++ {
+		j := makeNotificationDigestJob()
+		j.env = env
+		assert.NoError(j.sendDigest(&batches[0]))
+	}
+	assert.Equal(1, env.Remote.Stats().Total)
+	digests, err := notification.FindUnsentDigests(time.Now().Add(time.Minute))
+	require.NoError(err)
+	require.Len(digests, 1)
+	pending, err = notification.FindUndigested()
+	require.NoError(err)
+	assert.Empty(pending)
+
+	// a digest that was saved but never sent is queued again
+	stale := digests[0]
+	stale.ID = bson.NewObjectIdWithTime(time.Now().Add(-time.Hour))
+	require.NoError(notification.InsertMany(stale))
+	j := makeNotificationDigestJob()
+	j.env = env
+	j.requeueUnsentDigests()
+	assert.Equal(2, env.Remote.Stats().Total)
+}
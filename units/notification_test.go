@@ -0,0 +1,68 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestNewSendNotificationJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	for subscriberType, jobType := range map[string]string{
+		event.EmailSubscriberType:       notificationJobName,
+		event.SlackSubscriberType:       notificationJobName,
+		event.JIRACommentSubscriberType: notificationJobName,
+		event.TeamsSubscriberType:       chatNotificationJobName,
+		event.ChatWebhookSubscriberType: chatNotificationJobName,
+	} {
+		n := &notification.Notification{ID: bson.NewObjectId(), Subscriber: event.Subscriber{Type: subscriberType}}
+		j, err := newSendNotificationJob(n)
+		require.NoError(err, subscriberType)
+		assert.Equal(jobType, j.Type().Name, subscriberType)
+		assert.Contains(j.ID(), n.ID.Hex())
+	}
+
+	_, err := newSendNotificationJob(&notification.Notification{
+		ID:         bson.NewObjectId(),
+		Subscriber: event.Subscriber{Type: event.GithubPullRequestSubscriberType},
+	})
+	assert.Error(err)
+}
+
+func TestSendNotificationRequiresSettings(t *testing.T) {
+	assert := assert.New(t)
+
+	target := "someone"
+	body := "3 notifications"
+	settings := &evergreen.Settings{}
+	for _, n := range []notification.Notification{
+		{
+			Subscriber: event.Subscriber{Type: event.EmailSubscriberType, Target: &target},
+			Payload:    &notification.EmailPayload{Subject: body},
+		},
+		{
+			Subscriber: event.Subscriber{Type: event.SlackSubscriberType, Target: &target},
+			Payload:    &body,
+		},
+		{
+			Subscriber: event.Subscriber{Type: event.JIRACommentSubscriberType, Target: &target},
+			Payload:    &body,
+		},
+	} {
+		assert.Error(sendNotification(context.Background(), settings, &n), n.Subscriber.Type)
+	}
+
+	empty := ""
+	assert.Error(sendNotification(context.Background(), settings, &notification.Notification{
+		Subscriber: event.Subscriber{Type: event.SlackSubscriberType, Target: &empty},
+		Payload:    &body,
+	}))
+}
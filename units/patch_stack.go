@@ -0,0 +1,117 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const patchStackJobName = "patch-stack"
+
+func init() {
+	registry.AddJobType(patchStackJobName, func() amboy.Job { return makePatchStackJob() })
+}
+
+type patchStackJob struct {
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	env evergreen.Environment
+}
+
+func makePatchStackJob() *patchStackJob {
+	j := &patchStackJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    patchStackJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewPatchStackJob creates a job that advances sequential patch stacks: it
+// finalizes each waiting patch once the patch before it in its stack has
+// finished.
+func NewPatchStackJob(id string) amboy.Job {
+	j := makePatchStackJob()
+	j.SetID(fmt.Sprintf("%s.%s", patchStackJobName, id))
+	return j
+}
+
+func (j *patchStackJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "problem retrieving service flags"))
+		return
+	}
+	if flags.SchedulerDisabled {
+		grip.Info(message.Fields{
+			"job":     j.ID(),
+			"message": "scheduler is disabled",
+		})
+		return
+	}
+
+	waiting, err := patch.Find(patch.ByUnfinalizedSequentialStack())
+	if err != nil {
+		j.AddError(errors.Wrap(err, "problem finding patches of sequential stacks"))
+		return
+	}
+	if len(waiting) == 0 {
+		return
+	}
+
+	githubToken, err := j.env.Settings().GetGithubOauthToken()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	for i := range waiting {
+		p := &waiting[i]
+		previous, err := patch.FindOne(patch.ByStackPosition(p.Stack.ID, p.Stack.Position-1))
+		if err != nil {
+			j.AddError(errors.Wrapf(err, "problem finding the patch before patch %s", p.Id.Hex()))
+			continue
+		}
+		if previous == nil {
+			j.AddError(errors.Errorf("patch %s has no patch before it in stack %s", p.Id.Hex(), p.Stack.ID))
+			continue
+		}
+		if !previous.IsFinished() {
+			continue
+		}
+
+		if _, err = model.FinalizePatch(ctx, p, evergreen.PatchVersionRequester, githubToken); err != nil {
+			j.AddError(errors.Wrapf(err, "problem finalizing patch %s", p.Id.Hex()))
+			continue
+		}
+
+		grip.Info(message.Fields{
+			"job":      j.ID(),
+			"message":  "finalized next patch of sequential stack",
+			"patch":    p.Id.Hex(),
+			"stack":    p.Stack.ID,
+			"position": p.Stack.Position,
+			"previous": previous.Status,
+		})
+	}
+}
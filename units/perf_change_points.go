@@ -0,0 +1,140 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/perfresult"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const perfChangePointJobName = "perf-change-point-detection"
+
+func init() {
+	registry.AddJobType(perfChangePointJobName, func() amboy.Job { return makePerfChangePointJob() })
+}
+
+type perfChangePointJob struct {
+	TaskID    string `bson:"task_id" json:"task_id" yaml:"task_id"`
+	Execution int    `bson:"execution" json:"execution" yaml:"execution"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	logger grip.Journaler
+}
+
+func makePerfChangePointJob() *perfChangePointJob {
+	j := &perfChangePointJob{
+		logger: logging.MakeGrip(grip.GetSender()),
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    perfChangePointJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewPerfChangePointJob creates a job that compares the performance
+// results of a task execution against the results of previous
+// mainline versions, and raises an event for each regression. A task can
+// send perf results more than once, so the batch identifies the results
+// that the job is queued for.
+func NewPerfChangePointJob(taskID string, execution int, batch string) amboy.Job {
+	j := makePerfChangePointJob()
+	j.TaskID = taskID
+	j.Execution = execution
+	j.SetID(fmt.Sprintf("%s.%s.%d.%s", perfChangePointJobName, taskID, execution, batch))
+	return j
+}
+
+func (j *perfChangePointJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	results, err := perfresult.Find(perfresult.ByTaskIDAndExecution(j.TaskID, j.Execution))
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "problem finding perf results for task %s", j.TaskID))
+		return
+	}
+
+	for i := range results {
+		r := &results[i]
+		if r.ChangePoint != nil {
+			continue
+		}
+
+		reported, err := r.HasEarlierChangePoint()
+		if err != nil {
+			j.AddError(err)
+			continue
+		}
+		if reported {
+			continue
+		}
+
+		history, err := r.FindHistory(perfresult.HistorySize)
+		if err != nil {
+			j.AddError(errors.Wrapf(err, "problem finding history for test '%s'", r.TestName))
+			continue
+		}
+
+		cp := perfresult.DetectChangePoint(r, history)
+		if cp == nil {
+			continue
+		}
+		set, err := r.SetChangePoint(cp)
+		if err != nil {
+			j.AddError(err)
+			continue
+		}
+		if !set {
+			// another job detected it first
+			continue
+		}
+
+		j.logger.Info(message.Fields{
+			"message":        "detected performance change point",
+			"task_id":        r.TaskID,
+			"test":           r.TestName,
+			"metric":         r.Metric,
+			"thread_level":   r.ThreadLevel,
+			"value":          r.Value,
+			"baseline_mean":  cp.BaselineMean,
+			"window_mean":    cp.WindowMean,
+			"start_version":  cp.StartVersion,
+			"percent_change": cp.PercentChange,
+			"regression":     cp.Regression,
+		})
+
+		// patches are compared against mainline, but only
+		// regressions in mainline versions are reported.
+		if !cp.Regression || r.Requester != evergreen.RepotrackerVersionRequester {
+			continue
+		}
+		event.LogPerfRegression(r.TaskID, event.PerfEventData{
+			Project:        r.Project,
+			BuildVariant:   r.BuildVariant,
+			Version:        r.Version,
+			StartVersion:   cp.StartVersion,
+			TaskName:       r.TaskName,
+			TestName:       r.TestName,
+			Metric:         r.Metric,
+			ThreadLevel:    r.ThreadLevel,
+			Value:          r.Value,
+			BaselineMean:   cp.BaselineMean,
+			BaselineStdDev: cp.BaselineStdDev,
+			WindowMean:     cp.WindowMean,
+			PercentChange:  cp.PercentChange,
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	perfRegressionNotificationsJobName = "perf-regression-notifications"
+
+	// perfRegressionNotificationsBatchSize is the most events that one
+	// job processes
+	perfRegressionNotificationsBatchSize = 1000
+)
+
+func init() {
+	registry.AddJobType(perfRegressionNotificationsJobName, func() amboy.Job { return makePerfRegressionNotificationsJob() })
+}
+
+type perfRegressionNotificationsJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+func makePerfRegressionNotificationsJob() *perfRegressionNotificationsJob {
+	j := &perfRegressionNotificationsJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    perfRegressionNotificationsJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewPerfRegressionNotificationsJob creates a job that notifies the
+// subscribers of the perf regressions that have not been processed yet.
+func NewPerfRegressionNotificationsJob(ts string) amboy.Job {
+	j := makePerfRegressionNotificationsJob()
+	j.SetID(fmt.Sprintf("%s:%s", perfRegressionNotificationsJobName, ts))
+	return j
+}
+
+func (j *perfRegressionNotificationsJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	query := db.Query(event.UnprocessedPerfEvents()).
+		Sort([]string{event.TimestampKey}).
+		Limit(perfRegressionNotificationsBatchSize)
+	events, err := event.Find(event.AllLogCollection, query)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "problem finding perf events"))
+		return
+	}
+
+	logger := event.NewDBEventLogger(event.AllLogCollection)
+	for i := range events {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			return
+		}
+
+		e := &events[i]
+		notifications, err := perfRegressionNotifications(e, j.env.Settings().Ui.Url)
+		if err != nil {
+			j.AddError(err)
+			continue
+		}
+		if err = notification.InsertMany(notifications...); err != nil {
+			j.AddError(errors.Wrapf(err, "problem saving notifications for event %s", e.ID.Hex()))
+			continue
+		}
+		if err = logger.MarkProcessed(e); err != nil {
+			j.AddError(err)
+			continue
+		}
+
+		for k := range notifications {
+			n := &notifications[k]
+			if n.Digest.Batched() {
+				// the notification digest job sends it
+				continue
+			}
+			sender, err := newSendNotificationJob(n)
+			if err != nil {
+				j.AddError(err)
+				continue
+			}
+			j.AddError(j.env.RemoteQueue().Put(sender))
+		}
+	}
+}
+
+// perfRegressionNotifications returns a notification for each subscriber to
+// the perf regression event.
+func perfRegressionNotifications(e *event.EventLogEntry, uiRoot string) ([]notification.Notification, error) {
+	if e.EventType != event.EventPerfRegression {
+		return nil, nil
+	}
+	data, ok := e.Data.(*event.PerfEventData)
+	if !ok {
+		return nil, errors.Errorf("event %s has invalid perf data", e.ID.Hex())
+	}
+
+	groups, err := event.FindSubscribers(event.ResourceTypePerf, event.TriggerPerfRegression, data.Selectors(e.ResourceId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding subscribers to event %s", e.ID.Hex())
+	}
+
+	notifications := []notification.Notification{}
+	for _, group := range groups {
+		for _, sub := range group.Subscribers {
+			payload, err := notification.PerfRegressionPayload(sub.Subscriber.Type, data, uiRoot)
+			if err != nil {
+				grip.Warning(message.WrapError(err, message.Fields{
+					"job":             perfRegressionNotificationsJobName,
+					"message":         "skipping perf regression subscriber",
+					"event_id":        e.ID.Hex(),
+					"subscriber_type": sub.Subscriber.Type,
+				}))
+				continue
+			}
+			notifications = append(notifications, notification.Notification{
+				ID:           bson.NewObjectId(),
+				Subscriber:   sub.Subscriber,
+				Payload:      payload,
+				Digest:       sub.Digest,
+				Version:      data.Version,
+				BuildVariant: data.BuildVariant,
+			})
+		}
+	}
+	return notifications, nil
+}
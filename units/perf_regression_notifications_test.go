@@ -0,0 +1,111 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/amboy/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestPerfRegressionNotificationsJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(event.AllLogCollection, event.SubscriptionsCollection, notification.NotificationsCollection))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &mock.Environment{}
+	require.NoError(env.Configure(ctx, "", nil))
+	// the queue isn't started, so the queued jobs don't run
+	env.Remote = queue.NewLocalOrdered(1)
+
+	email := "perf@example.com"
+	channel := "#perf"
+	webhook := "https://example.com/hook"
+	for _, sub := range []event.Subscription{
+		{
+			Type:       event.ResourceTypePerf,
+			Trigger:    event.TriggerPerfRegression,
+			Selectors:  []event.Selector{{Type: event.SelectorProject, Data: "mci"}},
+			Subscriber: event.Subscriber{Type: event.EmailSubscriberType, Target: &email},
+		},
+		{
+			Type:       event.ResourceTypePerf,
+			Trigger:    event.TriggerPerfRegression,
+			Selectors:  []event.Selector{{Type: event.SelectorProject, Data: "mci"}, {Type: event.SelectorTestName, Data: "insert"}},
+			Subscriber: event.Subscriber{Type: event.SlackSubscriberType, Target: &channel},
+			Digest:     event.Digest{Mode: event.DigestVersion},
+		},
+		{
+			Type:       event.ResourceTypePerf,
+			Trigger:    event.TriggerPerfRegression,
+			Selectors:  []event.Selector{{Type: event.SelectorProject, Data: "mci"}},
+			Subscriber: event.Subscriber{Type: event.EvergreenWebhookSubscriberType, Target: &event.WebhookSubscriber{URL: webhook}},
+		},
+		{
+			Type:       event.ResourceTypePerf,
+			Trigger:    event.TriggerPerfRegression,
+			Selectors:  []event.Selector{{Type: event.SelectorProject, Data: "other"}},
+			Subscriber: event.Subscriber{Type: event.EmailSubscriberType, Target: &email},
+		},
+	} {
+		require.NoError(sub.Upsert())
+	}
+
+	event.LogPerfRegression("t1", event.PerfEventData{
+		Project:       "mci",
+		BuildVariant:  "ubuntu",
+		Version:       "v1",
+		TaskName:      "perf",
+		TestName:      "insert",
+		Metric:        "ops_per_sec",
+		Value:         50,
+		BaselineMean:  100,
+		PercentChange: -50,
+	})
+
+	j := makePerfRegressionNotificationsJob()
+	j.env = env
+	j.Run(ctx)
+	require.NoError(j.Error())
+
+	notifications := []notification.Notification{}
+	require.NoError(db.FindAllQ(notification.NotificationsCollection, db.Query(bson.M{}), &notifications))
+	require.Len(notifications, 2)
+	types := map[string]notification.Notification{}
+	for _, n := range notifications {
+		types[n.Subscriber.Type] = n
+		assert.Equal("v1", n.Version)
+		assert.Equal("ubuntu", n.BuildVariant)
+	}
+	require.Contains(types, event.EmailSubscriberType)
+	require.Contains(types, event.SlackSubscriberType)
+	assert.Contains(types[event.EmailSubscriberType].Payload.(*notification.EmailPayload).Subject, "insert ops_per_sec")
+
+	// only the immediate notification is queued, the digest job sends
+	// the other
+	assert.Equal(1, env.Remote.Stats().Total)
+	_, ok := env.Remote.Get(NewNotificationJob(types[event.EmailSubscriberType].ID).ID())
+	assert.True(ok)
+
+	// the event is only processed once
+	events, err := event.Find(event.AllLogCollection, db.Query(event.UnprocessedPerfEvents()))
+	require.NoError(err)
+	assert.Empty(events)
+	j = makePerfRegressionNotificationsJob()
+	j.env = env
+	j.Run(ctx)
+	require.NoError(j.Error())
+	count, err := db.Count(notification.NotificationsCollection, bson.M{})
+	require.NoError(err)
+	assert.Equal(2, count)
+}
@@ -0,0 +1,127 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/repotracker"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+)
+
+const (
+	periodicBuildJobName = "periodic-build"
+)
+
+func init() {
+	registry.AddJobType(periodicBuildJobName, func() amboy.Job { return makePeriodicBuildJob() })
+}
+
+type periodicBuildJob struct {
+	ProjectID    string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	DefinitionID string `bson:"definition_id" json:"definition_id" yaml:"definition_id"`
+	job.Base     `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env          evergreen.Environment
+}
+
+func makePeriodicBuildJob() *periodicBuildJob {
+	j := &periodicBuildJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    periodicBuildJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewPeriodicBuildJob creates a job that creates a version for a periodic
+// build definition of a project. The timestamp is the scheduled time of the
+// build, so that each scheduled build only runs once.
+func NewPeriodicBuildJob(projectID, definitionID, ts string) amboy.Job {
+	j := makePeriodicBuildJob()
+	j.ProjectID = projectID
+	j.DefinitionID = definitionID
+	j.SetID(fmt.Sprintf("%s:%s:%s:%s", periodicBuildJobName, projectID, definitionID, ts))
+	return j
+}
+
+func (j *periodicBuildJob) Run(ctx context.Context) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.RepotrackerDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     periodicBuildJobName,
+			"id":      j.ID(),
+			"message": "repotracker is disabled",
+		})
+		return
+	}
+
+	settings := j.env.Settings()
+	if settings == nil {
+		j.AddError(errors.New("settings is empty"))
+		return
+	}
+	token, err := settings.GetGithubOauthToken()
+	if err != nil {
+		j.AddError(errors.New("github token is missing"))
+		return
+	}
+
+	ref, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if ref == nil {
+		j.AddError(errors.Errorf("can't find project ref for project %s", j.ProjectID))
+		return
+	}
+	definition := ref.GetPeriodicBuild(j.DefinitionID)
+	if definition == nil {
+		j.AddError(errors.Errorf("project %s has no periodic build '%s'", j.ProjectID, j.DefinitionID))
+		return
+	}
+
+	tracker := &repotracker.RepoTracker{
+		Settings:   settings,
+		ProjectRef: ref,
+		RepoPoller: repotracker.NewGithubRepositoryPoller(ref, token),
+	}
+	v, err := tracker.CreatePeriodicVersion(ctx, definition)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"job":        periodicBuildJobName,
+		"job_id":     j.ID(),
+		"project":    j.ProjectID,
+		"definition": j.DefinitionID,
+		"version":    v.Id,
+	})
+}
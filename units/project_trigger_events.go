@@ -0,0 +1,155 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+)
+
+const (
+	projectTriggerEventsJobName = "project-trigger-events"
+
+	// projectTriggerEventsBatchSize is the most events that one job
+	// processes
+	projectTriggerEventsBatchSize = 1000
+
+	// projectTriggerEventsMaxAttempts is how many times the triggers for
+	// an event are evaluated before giving up on it
+	projectTriggerEventsMaxAttempts = 3
+
+	// projectTriggerEventsWindow is how far back the job looks for events
+	// that triggers have not been evaluated for
+	projectTriggerEventsWindow = 24 * time.Hour
+)
+
+func init() {
+	registry.AddJobType(projectTriggerEventsJobName, func() amboy.Job { return makeProjectTriggerEventsJob() })
+}
+
+type projectTriggerEventsJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env      evergreen.Environment
+}
+
+func makeProjectTriggerEventsJob() *projectTriggerEventsJob {
+	j := &projectTriggerEventsJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    projectTriggerEventsJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewProjectTriggerEventsJob creates a job that evaluates project triggers
+// for the recent task, build, and version events that they have not been
+// evaluated for yet, and queues a job to create a downstream version for
+// each project trigger that an event matches. Progress is tracked apart
+// from the processed time that notifications use.
+func NewProjectTriggerEventsJob(ts string) amboy.Job {
+	j := makeProjectTriggerEventsJob()
+	j.SetID(fmt.Sprintf("%s:%s", projectTriggerEventsJobName, ts))
+	return j
+}
+
+func (j *projectTriggerEventsJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.RepotrackerDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     projectTriggerEventsJobName,
+			"id":      j.ID(),
+			"message": "repotracker is disabled",
+		})
+		return
+	}
+
+	query := db.Query(event.UntriggeredEvents(time.Now().Add(-projectTriggerEventsWindow), projectTriggerEventsMaxAttempts,
+		event.ResourceTypeTask, event.ResourceTypeBuild, event.ResourceTypeVersion)).
+		Sort([]string{event.TimestampKey}).
+		Limit(projectTriggerEventsBatchSize)
+	events, err := event.Find(event.AllLogCollection, query)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "problem finding events to trigger on"))
+		return
+	}
+
+	logger := event.NewDBEventLogger(event.AllLogCollection)
+	queue := j.env.RemoteQueue()
+	for i := range events {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			return
+		}
+
+		// events that fail to process are retried by later jobs
+		// until they run out of attempts, so that one bad event
+		// cannot hold up the rest
+		if err = j.processEvent(queue, &events[i]); err != nil {
+			j.AddError(err)
+			j.AddError(logger.MarkTriggersFailed(&events[i]))
+			continue
+		}
+		j.AddError(logger.MarkTriggersProcessed(&events[i]))
+	}
+}
+
+func (j *projectTriggerEventsJob) processEvent(queue amboy.Queue, e *event.EventLogEntry) error {
+	upstream, err := model.NewUpstreamEvent(e)
+	if err != nil {
+		return errors.Wrapf(err, "problem reading event %s", e.ID.Hex())
+	}
+	if upstream == nil {
+		return nil
+	}
+
+	triggers, err := model.FindDownstreamTriggers(upstream)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, t := range triggers {
+		downstream := NewDownstreamVersionJob(t.ProjectID, t.Definition.ID, *upstream)
+		if _, ok := queue.Get(downstream.ID()); ok {
+			// queued by an earlier attempt at this event
+			continue
+		}
+		grip.Info(message.Fields{
+			"job":        projectTriggerEventsJobName,
+			"job_id":     j.ID(),
+			"message":    "triggering downstream project",
+			"upstream":   upstream.Project,
+			"downstream": t.ProjectID,
+			"trigger":    t.Definition.ID,
+			"event":      upstream.EventID,
+		})
+		catcher.Add(queue.Put(downstream))
+	}
+	return catcher.Resolve()
+}
@@ -0,0 +1,206 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/alerts"
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/plugin/builtin/buildbaron"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const slackInteractionJobName = "slack-interaction"
+
+func init() {
+	registry.AddJobType(slackInteractionJobName, func() amboy.Job { return makeSlackInteractionJob() })
+}
+
+// slackInteractionJob takes the actions of the buttons that a user clicked
+// on a slack task alert, and reports their results back to the user in
+// slack. Each action is taken as the evergreen user whose settings have the
+// slack account of the user who clicked it linked.
+type slackInteractionJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env      evergreen.Environment
+
+	Interaction thirdparty.SlackInteraction `bson:"interaction" json:"interaction" yaml:"interaction"`
+}
+
+func makeSlackInteractionJob() *slackInteractionJob {
+	j := &slackInteractionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    slackInteractionJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	j.SetPriority(1)
+	return j
+}
+
+// NewSlackInteractionJob creates a job that takes the actions of a slack
+// interaction, which must already have been verified to come from slack.
+// Slack sends an interaction again if it isn't acknowledged in time, so the
+// job id is unique to the interaction.
+func NewSlackInteractionJob(interaction thirdparty.SlackInteraction) amboy.Job {
+	j := makeSlackInteractionJob()
+	j.Interaction = interaction
+
+	j.SetID(fmt.Sprintf("%s:%s:%s", slackInteractionJobName, interaction.User.ID, interaction.TriggerID))
+	return j
+}
+
+func (j *slackInteractionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	settings := j.env.Settings()
+
+	for _, action := range j.Interaction.Actions {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			return
+		}
+
+		text := j.takeAction(settings, action)
+		if text == "" {
+			continue
+		}
+		j.AddError(errors.Wrapf(thirdparty.RespondToSlackInteraction(ctx, j.Interaction.ResponseURL, text),
+			"problem responding to slack action '%s' on task '%s'", action.ActionID, action.Value))
+	}
+}
+
+// takeAction takes the action on the task, and returns the text to show
+// the user in slack.
+func (j *slackInteractionJob) takeAction(settings *evergreen.Settings, action thirdparty.SlackAction) string {
+	var permission string
+	switch action.ActionID {
+	case alerts.SlackActionViewLogs:
+		// the button opens the logs in the browser
+		return ""
+	case alerts.SlackActionRestartTask:
+		permission = role.TaskRestart
+	case alerts.SlackActionFileTicket:
+		permission = role.ProjectView
+	case alerts.SlackActionMuteAlerts:
+		permission = role.ProjectEdit
+	default:
+		return fmt.Sprintf("Unknown action '%s'.", action.ActionID)
+	}
+
+	u, err := user.FindBySlackMemberID(j.Interaction.User.ID)
+	if err != nil {
+		return j.actionError(action, err)
+	}
+	if u == nil {
+		return "Your Slack account isn't linked to an Evergreen user. Link it on your Evergreen settings page to act on alerts."
+	}
+
+	t, err := task.FindOneId(action.Value)
+	if err != nil {
+		return j.actionError(action, err)
+	}
+	if t == nil {
+		return fmt.Sprintf("Task '%s' was not found.", action.Value)
+	}
+	ref, err := model.FindOneProjectRef(t.Project)
+	if err != nil {
+		return j.actionError(action, err)
+	}
+	if ref == nil {
+		return fmt.Sprintf("Task '%s' was not found.", action.Value)
+	}
+	allowed, err := slackUserHasPermission(settings, u, ref, permission)
+	if err != nil {
+		return j.actionError(action, err)
+	}
+	if !allowed {
+		return fmt.Sprintf("You are not authorized to do that in project '%s'.", ref.Identifier)
+	}
+
+	grip.Info(message.Fields{
+		"job":     slackInteractionJobName,
+		"job_id":  j.ID(),
+		"message": "taking slack action",
+		"user":    u.Id,
+		"action":  action.ActionID,
+		"task_id": t.Id,
+		"project": t.Project,
+	})
+
+	switch action.ActionID {
+	case alerts.SlackActionRestartTask:
+		if err = model.TryResetTask(t.Id, u.Id, evergreen.RESTV2Package, nil); err != nil {
+			return j.actionError(action, err)
+		}
+		return fmt.Sprintf("Restarted task '%s' on '%s'.", t.DisplayName, t.BuildVariant)
+	case alerts.SlackActionFileTicket:
+		var bbp *buildbaron.BuildBaronPlugin
+		bbp, err = buildbaron.New(settings)
+		if err != nil {
+			return j.actionError(action, errors.Wrap(err, "build baron is not configured"))
+		}
+		if !bbp.ProjectEnabled(t.Project) {
+			return fmt.Sprintf("Build baron is not configured for project '%s'.", t.Project)
+		}
+		var result *thirdparty.JiraCreateTicketResponse
+		result, err = bbp.FileTicket(t, nil, u.Id)
+		if err != nil {
+			return j.actionError(action, err)
+		}
+		return fmt.Sprintf("Filed %s for task '%s' on '%s'.", result.Key, t.DisplayName, t.BuildVariant)
+	default:
+		until := time.Now().Add(alertrecord.DefaultMuteDuration)
+		if err = alertrecord.MuteTask(t.Project, t.BuildVariant, t.DisplayName, u.Id, until); err != nil {
+			return j.actionError(action, err)
+		}
+		return fmt.Sprintf("Muted alerts for task '%s' on '%s' until %s.", t.DisplayName, t.BuildVariant, until.UTC().Format(time.RFC1123))
+	}
+}
+
+func (j *slackInteractionJob) actionError(action thirdparty.SlackAction, err error) string {
+	grip.Error(message.WrapError(err, message.Fields{
+		"job":        slackInteractionJobName,
+		"job_id":     j.ID(),
+		"message":    "problem taking slack action",
+		"slack_user": j.Interaction.User.ID,
+		"action":     action.ActionID,
+		"task_id":    action.Value,
+	}))
+	return fmt.Sprintf("Could not complete the action: %s", err.Error())
+}
+
+// slackUserHasPermission returns whether the user has the permission on the
+// project. Super users and the project's admins have every permission,
+// every user has the public permissions on projects that are not private,
+// and other permissions come from the roles assigned to the user.
+func slackUserHasPermission(settings *evergreen.Settings, u *user.DBUser, ref *model.ProjectRef, permission string) (bool, error) {
+	if auth.IsSuperUser(settings.SuperUsers, u) || auth.IsProjectAdmin(ref.Admins, u) {
+		return true, nil
+	}
+	if !ref.Private && util.StringSliceContains(role.PublicPermissions, permission) {
+		return true, nil
+	}
+	return role.UserHasPermission(u, role.ProjectResource, ref.Identifier, permission)
+}
@@ -0,0 +1,44 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackInteractionJobID(t *testing.T) {
+	assert := assert.New(t)
+
+	interaction := thirdparty.SlackInteraction{TriggerID: "1"}
+	interaction.User.ID = "U123"
+	jOne := NewSlackInteractionJob(interaction)
+	jTwo := NewSlackInteractionJob(interaction)
+	interaction.TriggerID = "2"
+	jThree := NewSlackInteractionJob(interaction)
+
+	assert.Equal(jOne.ID(), jTwo.ID())
+	assert.NotEqual(jOne.ID(), jThree.ID())
+}
+
+func TestSlackUserHasPermission(t *testing.T) {
+	assert := assert.New(t)
+
+	settings := &evergreen.Settings{SuperUsers: []string{"root"}}
+	public := &model.ProjectRef{Identifier: "public", Admins: []string{"admin"}}
+
+	for _, u := range []string{"root", "admin", "other"} {
+		allowed, err := slackUserHasPermission(settings, &user.DBUser{Id: u}, public, role.TaskRestart)
+		assert.NoError(err)
+		assert.True(allowed, u)
+	}
+	for _, u := range []string{"root", "admin"} {
+		allowed, err := slackUserHasPermission(settings, &user.DBUser{Id: u}, public, role.ProjectEdit)
+		assert.NoError(err)
+		assert.True(allowed, u)
+	}
+}
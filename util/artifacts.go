@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/mongodb/grip"
@@ -190,6 +191,94 @@ func BuildArchive(ctx context.Context, tarWriter *tar.Writer, rootPath string, i
 	}
 }
 
+// BuildArtifactFileList returns the paths, relative to rootPath and
+// separated by slashes, of the files that match the include patterns
+// and do not match any of the exclude patterns. Patterns are
+// interpreted in the same way as in BuildArchive: each include pattern
+// is a directory followed by a file name glob, where "**" matches all
+// files under the directory and "**<suffix>" matches all files under
+// the directory with names ending in the suffix. Exclude patterns are
+// globs matched against file names.
+func BuildArtifactFileList(rootPath string, includes, excludes []string) ([]string, error) {
+	seen := map[string]bool{}
+	files := []string{}
+
+	add := func(path string, info os.FileInfo) error {
+		if info == nil || info.IsDir() {
+			return nil
+		}
+		for _, ignore := range excludes {
+			match, err := filepath.Match(ignore, info.Name())
+			if err != nil {
+				return errors.Wrapf(err, "invalid exclude pattern '%s'", ignore)
+			}
+			if match {
+				return nil
+			}
+		}
+
+		rel, err := filepath.Rel(rootPath, path)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rel = filepath.ToSlash(rel)
+		if !seen[rel] {
+			seen[rel] = true
+			files = append(files, rel)
+		}
+		return nil
+	}
+
+	for _, includePattern := range includes {
+		dir, filematch := filepath.Split(includePattern)
+		dir = filepath.Join(rootPath, dir)
+		exists, err := FileExists(dir)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !exists {
+			continue
+		}
+
+		var walk filepath.WalkFunc
+		if strings.HasPrefix(filematch, "**") {
+			suffix := filematch[2:]
+			walk = func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return nil
+				}
+				if strings.HasSuffix(filepath.Base(path), suffix) {
+					return add(path, info)
+				}
+				return nil
+			}
+		} else {
+			if _, err = filepath.Match(filematch, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid include pattern '%s'", includePattern)
+			}
+			walk = func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return nil
+				}
+				if info.IsDir() && filepath.Clean(path) != filepath.Clean(dir) {
+					return filepath.SkipDir
+				}
+				if match, _ := filepath.Match(filematch, filepath.Base(path)); match {
+					return add(path, info)
+				}
+				return nil
+			}
+		}
+
+		if err = filepath.Walk(dir, walk); err != nil {
+			return nil, errors.Wrapf(err, "problem finding files matching '%s'", includePattern)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
 // Extract unpacks the tar.Reader into rootPath.
 func Extract(ctx context.Context, tarReader *tar.Reader, rootPath string) error {
 	for {
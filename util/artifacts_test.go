@@ -11,6 +11,7 @@ import (
 	"github.com/mongodb/grip/logging"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/smartystreets/goconvey/convey/reporting"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,3 +110,23 @@ func TestArchiveRoundTrip(t *testing.T) {
 		So(strings.Trim(string(contents), "\r\n\t "), ShouldEqual, "Hello, World")
 	})
 }
+
+func TestBuildArtifactFileList(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root := filepath.Join(getDirectoryOfFile(), "testdata", "artifacts_in")
+
+	files, err := BuildArtifactFileList(root, []string{"dir1/**"}, []string{"*.pdb"})
+	require.NoError(err)
+	assert.Equal([]string{"dir1/dir2/my_symlink.txt", "dir1/dir2/testfile.txt"}, files)
+
+	files, err = BuildArtifactFileList(root, []string{"dir1/**.pdb", "dir1/dir2/test*"}, nil)
+	require.NoError(err)
+	assert.Equal([]string{"dir1/dir2/test.pdb", "dir1/dir2/testfile.txt"}, files)
+
+	// non-recursive patterns only match files directly in the directory
+	files, err = BuildArtifactFileList(root, []string{"dir1/*", "missing/*"}, nil)
+	require.NoError(err)
+	assert.Empty(files)
+}
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CronSchedule is a parsed five field cron expression: minute, hour, day
+// of month, month, and day of week. Each field accepts "*", single
+// values, ranges such as "1-5", lists such as "1,15", and steps such as
+// "*/10" or "0-30/5". The descriptors @hourly, @daily, @weekly, and
+// @monthly are also accepted.
+type CronSchedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+
+	// a day matches if either day field matches when both are
+	// restricted
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+var cronDescriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseCron parses a five field cron expression.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[expr]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("cron expression '%s' must have 5 fields", expr)
+	}
+
+	// a day field that starts with "*", such as "*/2", does not restrict
+	// the day, as in standard cron
+	s := &CronSchedule{
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, errors.Wrap(err, "invalid minute")
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, errors.Wrap(err, "invalid hour")
+	}
+	if s.daysOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, errors.Wrap(err, "invalid day of month")
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, errors.Wrap(err, "invalid month")
+	}
+	if s.daysOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, errors.Wrap(err, "invalid day of week")
+	}
+	// both 0 and 7 are Sunday
+	if s.daysOfWeek[7] {
+		s.daysOfWeek[0] = true
+	}
+
+	return s, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return nil, errors.Errorf("invalid step in '%s'", part)
+			}
+			part = part[:i]
+		}
+
+		low, high := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, errors.Errorf("invalid range '%s'", part)
+			}
+			if high, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, errors.Errorf("invalid range '%s'", part)
+			}
+		default:
+			val, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, errors.Errorf("invalid value '%s'", part)
+			}
+			low = val
+			if step == 1 {
+				high = val
+			}
+		}
+		if low < min || high > max || low > high {
+			return nil, errors.Errorf("'%s' is out of the range %d-%d", part, min, max)
+		}
+
+		for i := low; i <= high; i += step {
+			values[i] = true
+		}
+	}
+	return values, nil
+}
+
+// Matches returns true if the schedule runs in the minute of the time.
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[int(t.Month())] {
+		return false
+	}
+
+	dom := s.daysOfMonth[t.Day()]
+	dow := s.daysOfWeek[int(t.Weekday())]
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Latest returns the latest minute that the schedule runs in that is after
+// the first time and no later than the second, and false if there is none.
+func (s *CronSchedule) Latest(after, until time.Time) (time.Time, bool) {
+	for t := until.Truncate(time.Minute); t.After(after); t = t.Add(-time.Minute) {
+		if s.Matches(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, expr := range []string{"* * * * *", "0 2 * * 1-5", "*/15 0-6/2 1,15 * *", "@daily", " 30 4 * * 7 "} {
+		_, err := ParseCron(expr)
+		assert.NoError(err, expr)
+	}
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@yearly"} {
+		_, err := ParseCron(expr)
+		assert.Error(err, expr)
+	}
+}
+
+func TestCronScheduleMatches(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Monday, January 15, 2018
+	monday := time.Date(2018, time.January, 15, 2, 0, 0, 0, time.UTC)
+
+	s, err := ParseCron("0 2 * * 1-5")
+	require.NoError(err)
+	assert.True(s.Matches(monday))
+	assert.False(s.Matches(monday.Add(time.Minute)))
+	assert.False(s.Matches(monday.AddDate(0, 0, -1)))
+
+	s, err = ParseCron("@daily")
+	require.NoError(err)
+	assert.True(s.Matches(monday.Add(-2 * time.Hour)))
+	assert.False(s.Matches(monday))
+
+	s, err = ParseCron("*/20 * * * *")
+	require.NoError(err)
+	assert.True(s.Matches(monday.Add(40 * time.Minute)))
+	assert.False(s.Matches(monday.Add(50 * time.Minute)))
+
+	// Sunday can be 0 or 7
+	s, err = ParseCron("0 2 * * 7")
+	require.NoError(err)
+	assert.True(s.Matches(monday.AddDate(0, 0, -1)))
+
+	// when both day fields are restricted, either one matching is enough
+	s, err = ParseCron("0 2 1 * 1")
+	require.NoError(err)
+	assert.True(s.Matches(monday))
+	assert.True(s.Matches(time.Date(2018, time.February, 1, 2, 0, 0, 0, time.UTC)))
+	assert.False(s.Matches(monday.AddDate(0, 0, 1)))
+}
+
+func TestCronScheduleStepDays(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// a stepped day of month doesn't restrict the day, so only the day
+	// of week matters
+	s, err := ParseCron("0 2 */2 * 1")
+	require.NoError(err)
+	// Monday, January 15, 2018 is an odd day
+	assert.True(s.Matches(time.Date(2018, time.January, 15, 2, 0, 0, 0, time.UTC)))
+	assert.False(s.Matches(time.Date(2018, time.January, 17, 2, 0, 0, 0, time.UTC)))
+
+	s, err = ParseCron("0 2 1 * */2")
+	require.NoError(err)
+	assert.True(s.Matches(time.Date(2018, time.February, 1, 2, 0, 0, 0, time.UTC)))
+	assert.False(s.Matches(time.Date(2018, time.January, 16, 2, 0, 0, 0, time.UTC)))
+}
+
+func TestCronScheduleLatest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := ParseCron("0 */6 * * *")
+	require.NoError(err)
+	start := time.Date(2018, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	latest, ok := s.Latest(start, start.Add(13*time.Hour+30*time.Second))
+	assert.True(ok)
+	assert.Equal(start.Add(12*time.Hour), latest)
+
+	// the first time is excluded and the second is included
+	_, ok = s.Latest(start, start.Add(5*time.Hour))
+	assert.False(ok)
+	latest, ok = s.Latest(start.Add(-time.Minute), start)
+	assert.True(ok)
+	assert.Equal(start, latest)
+}
@@ -20,6 +20,7 @@ var distroSyntaxValidators = []distroValidator{
 	ensureValidSSHOptions,
 	ensureValidExpansions,
 	ensureStaticHostsAreNotSpawnable,
+	ensureValidBlobStore,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -171,3 +172,15 @@ func ensureHasNonZeroID(ctx context.Context, d *distro.Distro, s *evergreen.Sett
 
 	return nil
 }
+
+// ensureValidBlobStore checks that the distro's blob store, if any, is
+// fully configured.
+func ensureValidBlobStore(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if d.BlobStore == nil {
+		return nil
+	}
+	if err := d.BlobStore.Validate(); err != nil {
+		return []ValidationError{{Error, fmt.Sprintf("distro has invalid blob store: %s", err.Error())}}
+	}
+	return nil
+}
@@ -55,6 +55,13 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 	}
 
 	project := &model.Project{}
+	// axis values files are read at the same revision as the project file,
+	// with the patch applied. The head of a pull request already has the
+	// changes of the patch.
+	loadAxisValues := model.GithubAxisValuesLoader(ctx, githubOauthToken, projectRef.Owner, projectRef.Repo, hash)
+	if !p.IsGithubPRPatch() {
+		loadAxisValues = model.PatchedAxisValuesLoader(ctx, p, loadAxisValues)
+	}
 
 	// if the patched config exists, use that as the project file bytes.
 	if p.PatchedConfig != "" {
@@ -63,7 +70,7 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 
 	// apply remote configuration patch if needed
 	if !p.IsGithubPRPatch() && p.ConfigChanged(projectRef.RemotePath) && p.PatchedConfig == "" {
-		project, err = model.MakePatchedConfig(ctx, p, projectRef.RemotePath, string(projectFileBytes))
+		project, err = model.MakePatchedConfig(ctx, p, projectRef.RemotePath, string(projectFileBytes), loadAxisValues)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not patch remote configuration file")
 		}
@@ -83,7 +90,7 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 		}
 	} else {
 		// configuration is not patched
-		if err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, project); err != nil {
+		if err = model.LoadProjectIntoWithAxisValues(projectFileBytes, projectRef.Identifier, project, loadAxisValues); err != nil {
 			return nil, errors.WithStack(err)
 		}
 	}
@@ -10,8 +10,11 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/command"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/spawn"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/goamz/goamz/s3"
 	"github.com/pkg/errors"
 )
 
@@ -56,6 +59,10 @@ var projectSyntaxValidators = []projectValidator{
 	validateProjectTaskIdsAndTags,
 	validateTaskGroups,
 	validateGenerateTasks,
+	validateTaskResourceLimits,
+	validateBlobStore,
+	validateTaskDebugOnFailure,
+	validateFetchArtifacts,
 }
 
 // Functions used to validate the semantics of a project configuration file.
@@ -155,11 +162,35 @@ func checkAllDependenciesSpec(project *model.Project) []ValidationError {
 func checkDependencyGraph(project *model.Project) []ValidationError {
 	errs := []ValidationError{}
 
-	// map of task name and variant -> BuildVariantTaskUnit
-	tasksByNameAndVariant := map[model.TVPair]model.BuildVariantTaskUnit{}
-
 	// generate task nodes for every task and variant combination
+	tasksByNameAndVariant, allNodes := getTaskUnitsByNameAndVariant(project)
 	visited := map[model.TVPair]bool{}
+	for _, node := range allNodes {
+		visited[node] = false
+	}
+
+	// run through the task nodes, checking their dependency graphs for cycles
+	for _, node := range allNodes {
+		// the visited nodes
+		if err := dependencyCycleExists(node, visited, tasksByNameAndVariant); err != nil {
+			errs = append(errs,
+				ValidationError{
+					Message: fmt.Sprintf(
+						"dependency error for '%v' task: %v", node.TaskName, err),
+				},
+			)
+		}
+	}
+
+	return errs
+}
+
+// getTaskUnitsByNameAndVariant returns the populated task of every task and
+// variant combination in the project, with task groups expanded into their
+// tasks, and the combinations in the order that they appear in the project.
+func getTaskUnitsByNameAndVariant(project *model.Project) (map[model.TVPair]model.BuildVariantTaskUnit, []model.TVPair) {
+	// map of task name and variant -> BuildVariantTaskUnit
+	tasksByNameAndVariant := map[model.TVPair]model.BuildVariantTaskUnit{}
 	allNodes := []model.TVPair{}
 
 	taskGroups := map[string]struct{}{}
@@ -180,25 +211,11 @@ func checkDependencyGraph(project *model.Project) []ValidationError {
 			node := model.TVPair{bv.Name, t.Name}
 
 			tasksByNameAndVariant[node] = t
-			visited[node] = false
 			allNodes = append(allNodes, node)
 		}
 	}
 
-	// run through the task nodes, checking their dependency graphs for cycles
-	for _, node := range allNodes {
-		// the visited nodes
-		if err := dependencyCycleExists(node, visited, tasksByNameAndVariant); err != nil {
-			errs = append(errs,
-				ValidationError{
-					Message: fmt.Sprintf(
-						"dependency error for '%v' task: %v", node.TaskName, err),
-				},
-			)
-		}
-	}
-
-	return errs
+	return tasksByNameAndVariant, allNodes
 }
 
 // Helper for checking the dependency graph for cycles.
@@ -217,7 +234,25 @@ func dependencyCycleExists(node model.TVPair, visited map[model.TVPair]bool,
 
 	visited[node] = true
 
-	task := tasksByNameAndVariant[node]
+	depNodes := getDependencyNodes(node, tasksByNameAndVariant[node], visited)
+
+	// for each of the task's dependencies, make a recursive call
+	for _, dn := range depNodes {
+		if err := dependencyCycleExists(dn, visited, tasksByNameAndVariant); err != nil {
+			return err
+		}
+	}
+
+	// remove the task from the visited map so that higher-level calls do not see it
+	visited[node] = false
+
+	// no cycle found
+	return nil
+}
+
+// getDependencyNodes returns the task/variant pairs, out of all of the
+// project's nodes, that the task at node depends on directly.
+func getDependencyNodes(node model.TVPair, task model.BuildVariantTaskUnit, nodes map[model.TVPair]bool) []model.TVPair {
 	depNodes := []model.TVPair{}
 	// build a list of all possible dependency nodes for the task
 	for _, dep := range task.DependsOn {
@@ -232,7 +267,7 @@ func dependencyCycleExists(node model.TVPair, visited map[model.TVPair]bool,
 			}
 			// handle * case by grabbing all the variant's tasks that aren't the current one
 			if dn.TaskName == model.AllDependencies {
-				for n := range visited {
+				for n := range nodes {
 					if n.TaskName != node.TaskName && n.Variant == dn.Variant {
 						depNodes = append(depNodes, n)
 					}
@@ -245,14 +280,14 @@ func dependencyCycleExists(node model.TVPair, visited map[model.TVPair]bool,
 			// handle the all-variants case by adding all nodes that are
 			// of the same task (but not the current node)
 			if dep.Name != model.AllDependencies {
-				for n := range visited {
+				for n := range nodes {
 					if n.TaskName == dep.Name && (n != node) {
 						depNodes = append(depNodes, n)
 					}
 				}
 			} else {
 				// edge case where variant and task name are both *
-				for n := range visited {
+				for n := range nodes {
 					if n != node {
 						depNodes = append(depNodes, n)
 					}
@@ -260,19 +295,7 @@ func dependencyCycleExists(node model.TVPair, visited map[model.TVPair]bool,
 			}
 		}
 	}
-
-	// for each of the task's dependencies, make a recursive call
-	for _, dn := range depNodes {
-		if err := dependencyCycleExists(dn, visited, tasksByNameAndVariant); err != nil {
-			return err
-		}
-	}
-
-	// remove the task from the visited map so that higher-level calls do not see it
-	visited[node] = false
-
-	// no cycle found
-	return nil
+	return depNodes
 }
 
 // Ensures that the project has at least one buildvariant and also that all the
@@ -893,3 +916,154 @@ func validateGenerateTasks(p *model.Project) []ValidationError {
 	}
 	return errs
 }
+
+// validateTaskResourceLimits ensures that the resource limits of each task
+// can be enforced.
+func validateTaskResourceLimits(p *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	for _, t := range p.Tasks {
+		if err := t.ResourceLimits.Validate(); err != nil {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("task '%s' has invalid resource limits: %s", t.Name, err.Error()),
+			})
+		}
+	}
+	return errs
+}
+
+func validateBlobStore(p *model.Project) []ValidationError {
+	if p.BlobStore == nil {
+		return nil
+	}
+	if err := p.BlobStore.Validate(); err != nil {
+		return []ValidationError{{
+			Message: fmt.Sprintf("project has invalid blob store: %s", err.Error()),
+		}}
+	}
+	return nil
+}
+
+// validateTaskDebugOnFailure ensures that the number of minutes each task
+// holds its host for debugging is not negative or too long.
+func validateTaskDebugOnFailure(p *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	maxMins := int(spawn.MaxDebugHoldDuration.Minutes())
+	for _, t := range p.Tasks {
+		if t.DebugOnFailure < 0 || t.DebugOnFailure > maxMins {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("task '%s' has invalid debug_on_failure %d: must be between 0 and %d minutes",
+					t.Name, t.DebugOnFailure, maxMins),
+			})
+		}
+	}
+	return errs
+}
+
+// validateFetchArtifacts ensures that every artifact that a task fetches is
+// declared by a task that it depends on, directly or indirectly, so that the
+// artifact is attached before the task runs.
+func validateFetchArtifacts(p *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	tasksByNameAndVariant, allNodes := getTaskUnitsByNameAndVariant(p)
+	nodes := map[model.TVPair]bool{}
+	for _, node := range allNodes {
+		nodes[node] = true
+	}
+
+	for _, node := range allNodes {
+		pt := p.FindProjectTask(node.TaskName)
+		if pt == nil {
+			continue
+		}
+		for _, dep := range pt.FetchArtifacts {
+			if dep.Task == "" || dep.Name == "" {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%s' must specify the task and name of each artifact that it fetches", node.TaskName),
+				})
+				continue
+			}
+
+			producer := model.TVPair{Variant: dep.Variant, TaskName: dep.Task}
+			if producer.Variant == "" {
+				producer.Variant = node.Variant
+			}
+			if _, ok := tasksByNameAndVariant[producer]; !ok {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%s' on variant '%s' fetches artifact '%s' from task '%s' on variant '%s', which does not exist",
+						node.TaskName, node.Variant, dep.Name, producer.TaskName, producer.Variant),
+				})
+				continue
+			}
+			if producerTask := p.FindProjectTask(producer.TaskName); producerTask == nil ||
+				!util.StringSliceContains(producerTask.Artifacts, dep.Name) {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%s' fetches artifact '%s' from task '%s', which does not declare it in its artifacts",
+						node.TaskName, dep.Name, producer.TaskName),
+				})
+			} else if setting := privateArtifactSetting(p, producerTask, dep.Name); setting != "" {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%s' fetches artifact '%s' from task '%s', which uploads it with %s, but only public artifacts can be fetched",
+						node.TaskName, dep.Name, producer.TaskName, setting),
+				})
+			}
+			if !dependsOn(node, producer, tasksByNameAndVariant, nodes, map[model.TVPair]bool{}) {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%s' on variant '%s' fetches artifact '%s' from task '%s' on variant '%s', which it does not depend on",
+						node.TaskName, node.Variant, dep.Name, producer.TaskName, producer.Variant),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// privateArtifactSetting returns the setting of the task's s3.put command that
+// makes the artifact with the given name private, or the empty string if the
+// artifact is public. The agent downloads artifacts without credentials, so
+// it can only fetch artifacts that anyone can read.
+func privateArtifactSetting(p *model.Project, pt *model.ProjectTask, name string) string {
+	cmds := []model.PluginCommandConf{}
+	for _, c := range pt.Commands {
+		if c.Function == "" {
+			cmds = append(cmds, c)
+			continue
+		}
+		if f, ok := p.Functions[c.Function]; ok && f != nil {
+			cmds = append(cmds, f.List()...)
+		}
+	}
+
+	for _, c := range cmds {
+		if c.Command != "s3.put" {
+			continue
+		}
+		if displayName, _ := c.Params["display_name"].(string); displayName != name {
+			continue
+		}
+		permissions, _ := c.Params["permissions"].(string)
+		if permissions != string(s3.PublicRead) && permissions != string(s3.PublicReadWrite) {
+			return fmt.Sprintf("'%s' permissions", permissions)
+		}
+		if visibility, _ := c.Params["visibility"].(string); visibility == artifact.Private {
+			return fmt.Sprintf("'%s' visibility", visibility)
+		}
+	}
+	return ""
+}
+
+// dependsOn returns true if the task at node depends on the task at target,
+// directly or through other dependencies.
+func dependsOn(node, target model.TVPair, tasksByNameAndVariant map[model.TVPair]model.BuildVariantTaskUnit,
+	nodes map[model.TVPair]bool, seen map[model.TVPair]bool) bool {
+	if seen[node] {
+		return false
+	}
+	seen[node] = true
+
+	for _, dn := range getDependencyNodes(node, tasksByNameAndVariant[node], nodes) {
+		if dn == target || dependsOn(dn, target, tasksByNameAndVariant, nodes, seen) {
+			return true
+		}
+	}
+	return false
+}
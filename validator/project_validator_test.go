@@ -1747,3 +1747,130 @@ buildvariants:
 	assert.Len(semanticErrs, 0)
 	assert.NoError(err)
 }
+
+func TestValidateTaskDebugOnFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &model.Project{
+		Tasks: []model.ProjectTask{
+			{Name: "ok", DebugOnFailure: 60},
+			{Name: "unset"},
+			{Name: "negative", DebugOnFailure: -1},
+			{Name: "too_long", DebugOnFailure: 60 * 48},
+		},
+	}
+	errs := validateTaskDebugOnFailure(p)
+	assert.Len(errs, 2)
+	assert.Contains(errs[0].Message, "negative")
+	assert.Contains(errs[1].Message, "too_long")
+}
+
+func TestValidateFetchArtifacts(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &model.Project{
+		Tasks: []model.ProjectTask{
+			{Name: "compile", Artifacts: []string{"binaries"}},
+			{Name: "lint"},
+			{
+				Name:           "test",
+				DependsOn:      []model.TaskUnitDependency{{Name: "compile", Variant: "linux"}},
+				FetchArtifacts: []model.ArtifactDependency{{Task: "compile", Variant: "linux", Name: "binaries"}},
+			},
+			{
+				Name:           "integration",
+				DependsOn:      []model.TaskUnitDependency{{Name: "test"}},
+				FetchArtifacts: []model.ArtifactDependency{{Task: "compile", Variant: "linux", Name: "binaries"}},
+			},
+			{
+				Name:           "undeclared",
+				DependsOn:      []model.TaskUnitDependency{{Name: "lint"}},
+				FetchArtifacts: []model.ArtifactDependency{{Task: "lint", Name: "binaries"}},
+			},
+			{
+				Name:           "no_dependency",
+				FetchArtifacts: []model.ArtifactDependency{{Task: "compile", Variant: "linux", Name: "binaries"}},
+			},
+			{
+				Name:           "missing",
+				FetchArtifacts: []model.ArtifactDependency{{Task: "compile", Variant: "windows", Name: "binaries"}},
+			},
+		},
+		BuildVariants: []model.BuildVariant{
+			{
+				Name: "linux",
+				Tasks: []model.BuildVariantTaskUnit{
+					{Name: "compile"}, {Name: "lint"}, {Name: "test"}, {Name: "integration"},
+				},
+			},
+			{
+				Name: "windows",
+				Tasks: []model.BuildVariantTaskUnit{
+					{Name: "test"}, {Name: "lint"}, {Name: "undeclared"}, {Name: "no_dependency"}, {Name: "missing"},
+				},
+			},
+		},
+	}
+	errs := validateFetchArtifacts(p)
+	if assert.Len(errs, 3) {
+		assert.Contains(errs[0].Message, "does not declare it")
+		assert.Contains(errs[1].Message, "does not depend on")
+		assert.Contains(errs[2].Message, "does not exist")
+	}
+}
+
+func TestValidateFetchPrivateArtifacts(t *testing.T) {
+	assert := assert.New(t)
+
+	upload := func(name, permissions, visibility string) model.PluginCommandConf {
+		return model.PluginCommandConf{
+			Command: "s3.put",
+			Params: map[string]interface{}{
+				"display_name": name,
+				"permissions":  permissions,
+				"visibility":   visibility,
+			},
+		}
+	}
+	p := &model.Project{
+		Functions: map[string]*model.YAMLCommandSet{
+			"upload docs": {SingleCommand: &model.PluginCommandConf{
+				Command: "s3.put",
+				Params:  map[string]interface{}{"display_name": "docs", "permissions": "private"},
+			}},
+		},
+		Tasks: []model.ProjectTask{
+			{
+				Name:      "compile",
+				Artifacts: []string{"binaries", "symbols", "report", "docs"},
+				Commands: []model.PluginCommandConf{
+					upload("binaries", "public-read", ""),
+					upload("symbols", "private", ""),
+					upload("report", "public-read", "private"),
+					{Function: "upload docs"},
+				},
+			},
+			{
+				Name:      "test",
+				DependsOn: []model.TaskUnitDependency{{Name: "compile"}},
+				FetchArtifacts: []model.ArtifactDependency{
+					{Task: "compile", Name: "binaries"},
+					{Task: "compile", Name: "symbols"},
+					{Task: "compile", Name: "report"},
+					{Task: "compile", Name: "docs"},
+				},
+			},
+		},
+		BuildVariants: []model.BuildVariant{
+			{Name: "linux", Tasks: []model.BuildVariantTaskUnit{{Name: "compile"}, {Name: "test"}}},
+		},
+	}
+	errs := validateFetchArtifacts(p)
+	if assert.Len(errs, 3) {
+		assert.Contains(errs[0].Message, "'symbols'")
+		assert.Contains(errs[0].Message, "'private' permissions")
+		assert.Contains(errs[1].Message, "'report'")
+		assert.Contains(errs[1].Message, "'private' visibility")
+		assert.Contains(errs[2].Message, "'docs'")
+	}
+}